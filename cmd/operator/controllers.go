@@ -0,0 +1,423 @@
+// Copyright 2021 The prometheus-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"sync"
+	"time"
+
+	alertmanagercontroller "github.com/prometheus-operator/prometheus-operator/pkg/alertmanager"
+	"github.com/prometheus-operator/prometheus-operator/pkg/operator"
+	probercontroller "github.com/prometheus-operator/prometheus-operator/pkg/prober"
+	prometheuscontroller "github.com/prometheus-operator/prometheus-operator/pkg/prometheus"
+	thanoscontroller "github.com/prometheus-operator/prometheus-operator/pkg/thanos"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"golang.org/x/sync/errgroup"
+)
+
+const configFileCheckInterval = 10 * time.Second
+
+// levelFilter is a logger filtering log entries by level. The level can be
+// changed at runtime.
+type levelFilter struct {
+	mtx    sync.RWMutex
+	next   log.Logger
+	filter log.Logger
+}
+
+func newLevelFilter(next log.Logger, lvl string) (*levelFilter, error) {
+	l := &levelFilter{next: next}
+	if err := l.SetLevel(lvl); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// SetLevel updates the level of the filter.
+func (l *levelFilter) SetLevel(lvl string) error {
+	opt, err := levelOption(lvl)
+	if err != nil {
+		return err
+	}
+
+	l.setFilter(opt)
+	return nil
+}
+
+func (l *levelFilter) setFilter(opt level.Option) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	l.filter = level.NewFilter(l.next, opt)
+}
+
+// Log implements the log.Logger interface.
+func (l *levelFilter) Log(keyvals ...interface{}) error {
+	l.mtx.RLock()
+	defer l.mtx.RUnlock()
+	return l.filter.Log(keyvals...)
+}
+
+func levelOption(lvl string) (level.Option, error) {
+	switch lvl {
+	case logLevelAll:
+		return level.AllowAll(), nil
+	case logLevelDebug:
+		return level.AllowDebug(), nil
+	case logLevelInfo:
+		return level.AllowInfo(), nil
+	case logLevelWarn:
+		return level.AllowWarn(), nil
+	case logLevelError:
+		return level.AllowError(), nil
+	case logLevelNone:
+		return level.AllowNone(), nil
+	}
+	return nil, fmt.Errorf("log level %v unknown, %v are possible values", lvl, availableLogLevels)
+}
+
+// controllers groups the Prometheus, Alertmanager, ThanosRuler and Prober
+// controllers sharing the same namespace scoping. A controller is nil when the
+// operator lacks the permissions required to run it.
+type controllers struct {
+	po *prometheuscontroller.Operator
+	ao *alertmanagercontroller.Operator
+	to *thanoscontroller.Operator
+	bo *probercontroller.Operator
+
+	// reg holds the metrics of the controllers.
+	reg    *prometheus.Registry
+	logger log.Logger
+
+	cancel context.CancelFunc
+	wg     *errgroup.Group
+	ctx    context.Context
+}
+
+func newControllers(ctx context.Context, cfg operator.Config, logger log.Logger) (*controllers, error) {
+	c := &controllers{
+		reg:    prometheus.NewRegistry(),
+		logger: logger,
+	}
+	ctx, c.cancel = context.WithCancel(ctx)
+	c.wg, c.ctx = errgroup.WithContext(ctx)
+
+	var err error
+	c.po, err = prometheuscontroller.New(c.ctx, cfg, log.With(logger, "component", "prometheusoperator"), c.reg)
+	if err != nil && !c.skip(err) {
+		c.cancel()
+		return nil, errors.Wrap(err, "instantiating prometheus controller failed")
+	}
+
+	c.ao, err = alertmanagercontroller.New(c.ctx, cfg, log.With(logger, "component", "alertmanageroperator"), c.reg)
+	if err != nil && !c.skip(err) {
+		c.cancel()
+		return nil, errors.Wrap(err, "instantiating alertmanager controller failed")
+	}
+
+	c.to, err = thanoscontroller.New(c.ctx, cfg, log.With(logger, "component", "thanosoperator"), c.reg)
+	if err != nil && !c.skip(err) {
+		c.cancel()
+		return nil, errors.Wrap(err, "instantiating thanos controller failed")
+	}
+
+	c.bo, err = probercontroller.New(c.ctx, cfg, log.With(logger, "component", "proberoperator"), c.reg)
+	if err != nil && !c.skip(err) {
+		c.cancel()
+		return nil, errors.Wrap(err, "instantiating prober controller failed")
+	}
+
+	return c, nil
+}
+
+// skip returns true if the controller can't run because the operator lacks
+// the required permissions or custom resource definitions, in which case the
+// other controllers run without it.
+func (c *controllers) skip(err error) bool {
+	var mpe *operator.MissingPermissionsError
+	if !errors.As(err, &mpe) {
+		return false
+	}
+
+	for _, err := range mpe.Errors {
+		level.Warn(c.logger).Log("msg", "controller disabled", "controller", mpe.Controller, "err", err)
+	}
+	operator.NewMetrics(mpe.Controller, c.reg).DisableFeature("controller")
+
+	return true
+}
+
+// run starts the controllers and blocks until they are stopped.
+func (c *controllers) run() error {
+	if c.po != nil {
+		c.wg.Go(func() error { return c.po.Run(c.ctx) })
+	}
+	if c.ao != nil {
+		c.wg.Go(func() error { return c.ao.Run(c.ctx) })
+	}
+	if c.to != nil {
+		c.wg.Go(func() error { return c.to.Run(c.ctx) })
+	}
+	if c.bo != nil {
+		c.wg.Go(func() error { return c.bo.Run(c.ctx) })
+	}
+	// Keep running when all the controllers are disabled so that the
+	// metrics remain available.
+	c.wg.Go(func() error {
+		<-c.ctx.Done()
+		return nil
+	})
+	return c.wg.Wait()
+}
+
+func (c *controllers) stop() {
+	c.cancel()
+}
+
+func (c *controllers) applyConfig(cfg operator.Config) error {
+	if c.po != nil {
+		if err := c.po.ApplyConfig(cfg); err != nil {
+			return err
+		}
+	}
+	if c.ao != nil {
+		if err := c.ao.ApplyConfig(cfg); err != nil {
+			return err
+		}
+	}
+	if c.to != nil {
+		if err := c.to.ApplyConfig(cfg); err != nil {
+			return err
+		}
+	}
+	if c.bo != nil {
+		return c.bo.ApplyConfig(cfg)
+	}
+	return nil
+}
+
+// configManager watches the configuration file of the operator. Settings
+// which can be updated at runtime are applied to the running controllers
+// while changes to the settings used to create the controllers (namespace
+// scoping, Kubernetes client, kubelet service, local host, cluster domain and
+// secret field selector) restart the controllers.
+type configManager struct {
+	filename string
+	// flagCfg is the configuration resulting from the command-line flags.
+	flagCfg operator.Config
+	logger  log.Logger
+	lvl     *levelFilter
+
+	mtx     sync.RWMutex
+	cfg     operator.Config
+	ctrls   *controllers
+	handler http.Handler
+
+	restart chan struct{}
+}
+
+// loadConfig returns the configuration resulting from the command-line flags
+// overridden by the configuration file if any.
+func loadConfig(flagCfg operator.Config, filename string) (operator.Config, *operator.OperatorConfiguration, error) {
+	if filename == "" {
+		return flagCfg, nil, nil
+	}
+
+	oc, err := operator.LoadConfigurationFile(filename)
+	if err != nil {
+		return operator.Config{}, nil, err
+	}
+
+	cfg := flagCfg
+	if err := oc.ApplyTo(&cfg); err != nil {
+		return operator.Config{}, nil, errors.Wrap(err, "invalid configuration")
+	}
+
+	return cfg, oc, nil
+}
+
+func newConfigManager(filename string, flagCfg, cfg operator.Config, lvl *levelFilter, logger log.Logger) *configManager {
+	return &configManager{
+		filename: filename,
+		flagCfg:  flagCfg,
+		cfg:      cfg,
+		lvl:      lvl,
+		logger:   logger,
+		restart:  make(chan struct{}, 1),
+		handler: http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			http.Error(w, "controllers not started", http.StatusServiceUnavailable)
+		}),
+	}
+}
+
+// ServeHTTP exposes the metrics of the operator and of the running
+// controllers.
+func (m *configManager) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	m.mtx.RLock()
+	h := m.handler
+	m.mtx.RUnlock()
+	h.ServeHTTP(w, req)
+}
+
+func (m *configManager) config() operator.Config {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+	return m.cfg
+}
+
+// runControllers runs the controllers until the context is canceled. The
+// controllers are recreated whenever the settings used to create them change.
+func (m *configManager) runControllers(ctx context.Context, r prometheus.Gatherer) error {
+	for {
+		cfg := m.config()
+		ctrls, err := newControllers(ctx, cfg, m.logger)
+		if err != nil {
+			return err
+		}
+
+		m.mtx.Lock()
+		m.ctrls = ctrls
+		m.handler = promhttp.HandlerFor(prometheus.Gatherers{r, ctrls.reg}, promhttp.HandlerOpts{})
+		m.mtx.Unlock()
+
+		errc := make(chan error, 1)
+		go func() { errc <- ctrls.run() }()
+
+		select {
+		case err := <-errc:
+			ctrls.stop()
+			return err
+		case <-m.restart:
+			level.Info(m.logger).Log("msg", "controller settings changed, restarting the controllers")
+			ctrls.stop()
+			if err := <-errc; err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// watchConfigFile polls the configuration file and applies its changes until
+// the context is canceled.
+func (m *configManager) watchConfigFile(ctx context.Context) error {
+	last, err := ioutil.ReadFile(m.filename)
+	if err != nil {
+		level.Warn(m.logger).Log("msg", "failed to read the configuration file", "file", m.filename, "err", err)
+	}
+
+	ticker := time.NewTicker(configFileCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		b, err := ioutil.ReadFile(m.filename)
+		if err != nil {
+			level.Warn(m.logger).Log("msg", "failed to read the configuration file", "file", m.filename, "err", err)
+			continue
+		}
+		if bytes.Equal(b, last) {
+			continue
+		}
+		last = b
+
+		if err := m.reload(); err != nil {
+			level.Error(m.logger).Log("msg", "failed to reload the configuration file", "file", m.filename, "err", err)
+			continue
+		}
+		level.Info(m.logger).Log("msg", "configuration file reloaded", "file", m.filename)
+	}
+}
+
+func (m *configManager) reload() error {
+	cfg, _, err := loadConfig(m.flagCfg, m.filename)
+	if err != nil {
+		return err
+	}
+
+	m.mtx.RLock()
+	prev := m.cfg
+	ctrls := m.ctrls
+	m.mtx.RUnlock()
+
+	// The web server and the logger are set up once when the operator
+	// starts, the previous values remain in effect until then.
+	if !reflect.DeepEqual(prev.ListenAddress, cfg.ListenAddress) || !reflect.DeepEqual(prev.ServerTLSConfig, cfg.ServerTLSConfig) {
+		level.Warn(m.logger).Log("msg", "setting changed in the configuration file but requires a restart of the operator to be applied", "setting", "web")
+		cfg.ListenAddress, cfg.ServerTLSConfig = prev.ListenAddress, prev.ServerTLSConfig
+	}
+	if prev.LogFormat != cfg.LogFormat {
+		level.Warn(m.logger).Log("msg", "setting changed in the configuration file but requires a restart of the operator to be applied", "setting", "logFormat")
+		cfg.LogFormat = prev.LogFormat
+	}
+
+	var lvl level.Option
+	if prev.LogLevel != cfg.LogLevel {
+		if lvl, err = levelOption(cfg.LogLevel); err != nil {
+			return err
+		}
+	}
+
+	// The settings used to create the controllers are applied by restarting
+	// the controllers.
+	if !reflect.DeepEqual(prev.Namespaces, cfg.Namespaces) ||
+		prev.Host != cfg.Host || prev.TLSInsecure != cfg.TLSInsecure || !reflect.DeepEqual(prev.TLSConfig, cfg.TLSConfig) ||
+		prev.KubeletObject != cfg.KubeletObject ||
+		prev.LocalHost != cfg.LocalHost ||
+		prev.ClusterDomain != cfg.ClusterDomain ||
+		prev.SecretListWatchSelector != cfg.SecretListWatchSelector {
+		m.commit(cfg, lvl)
+		select {
+		case m.restart <- struct{}{}:
+		default:
+		}
+		return nil
+	}
+
+	if ctrls != nil {
+		if err := ctrls.applyConfig(cfg); err != nil {
+			return err
+		}
+	}
+
+	m.commit(cfg, lvl)
+	return nil
+}
+
+// commit stores the configuration once it has been applied and updates the
+// log level if needed.
+func (m *configManager) commit(cfg operator.Config, lvl level.Option) {
+	if lvl != nil {
+		m.lvl.setFilter(lvl)
+	}
+
+	m.mtx.Lock()
+	m.cfg = cfg
+	m.mtx.Unlock()
+}
@@ -0,0 +1,105 @@
+// Copyright 2021 The prometheus-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-kit/log"
+
+	"github.com/prometheus-operator/prometheus-operator/pkg/operator"
+)
+
+func TestConfigManagerReload(t *testing.T) {
+	flagCfg := operator.Config{
+		ClusterDomain: "cluster.local",
+		LogFormat:     logFormatLogfmt,
+		LogLevel:      logLevelInfo,
+	}
+
+	for _, tc := range []struct {
+		name    string
+		file    string
+		restart bool
+		exp     operator.Config
+	}{
+		{
+			name: "log level",
+			file: "logLevel: debug",
+			exp: operator.Config{
+				ClusterDomain: "cluster.local",
+				LogFormat:     logFormatLogfmt,
+				LogLevel:      logLevelDebug,
+			},
+		},
+		{
+			name:    "cluster domain restarts the controllers",
+			file:    "clusterDomain: example.com",
+			restart: true,
+			exp: operator.Config{
+				ClusterDomain: "example.com",
+				LogFormat:     logFormatLogfmt,
+				LogLevel:      logLevelInfo,
+			},
+		},
+		{
+			name: "log format requires a restart of the operator",
+			file: "logFormat: json",
+			exp:  flagCfg,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "operator-config")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+
+			filename := filepath.Join(dir, "config.yaml")
+			content := "apiVersion: " + operator.OperatorConfigurationAPIVersion + "\nkind: " + operator.OperatorConfigurationKind + "\n" + tc.file + "\n"
+			if err := ioutil.WriteFile(filename, []byte(content), 0600); err != nil {
+				t.Fatal(err)
+			}
+
+			lvl, err := newLevelFilter(log.NewNopLogger(), flagCfg.LogLevel)
+			if err != nil {
+				t.Fatal(err)
+			}
+			m := newConfigManager(filename, flagCfg, flagCfg, lvl, log.NewNopLogger())
+
+			if err := m.reload(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got := m.config(); got.ClusterDomain != tc.exp.ClusterDomain || got.LogFormat != tc.exp.LogFormat || got.LogLevel != tc.exp.LogLevel {
+				t.Fatalf("expected configuration %+v, got %+v", tc.exp, got)
+			}
+
+			select {
+			case <-m.restart:
+				if !tc.restart {
+					t.Fatal("unexpected restart of the controllers")
+				}
+			default:
+				if tc.restart {
+					t.Fatal("expected a restart of the controllers")
+				}
+			}
+		})
+	}
+}
@@ -31,12 +31,9 @@ import (
 	"time"
 
 	"github.com/prometheus-operator/prometheus-operator/pkg/admission"
-	alertmanagercontroller "github.com/prometheus-operator/prometheus-operator/pkg/alertmanager"
 	"github.com/prometheus-operator/prometheus-operator/pkg/api"
 	"github.com/prometheus-operator/prometheus-operator/pkg/k8sutil"
 	"github.com/prometheus-operator/prometheus-operator/pkg/operator"
-	prometheuscontroller "github.com/prometheus-operator/prometheus-operator/pkg/prometheus"
-	thanoscontroller "github.com/prometheus-operator/prometheus-operator/pkg/thanos"
 	"github.com/prometheus-operator/prometheus-operator/pkg/versionutil"
 
 	rbacproxytls "github.com/brancz/kube-rbac-proxy/pkg/tls"
@@ -44,10 +41,8 @@ import (
 	"github.com/go-kit/log/level"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/collectors"
-	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/prometheus/common/version"
 	"golang.org/x/sync/errgroup"
-	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	klog "k8s.io/klog/v2"
 )
@@ -146,12 +141,17 @@ var (
 
 	rawTLSCipherSuites string
 	serverTLS          bool
+	configFile         string
+
+	nsSelector       string
+	deniedNsSelector string
 
 	flagset = flag.CommandLine
 )
 
 func init() {
 	// With migration to klog-gokit, calling klogv2.InitFlags(flagset) is not applicable.
+	flagset.StringVar(&configFile, "config-file", "", "Path to the OperatorConfiguration file. The values defined in the file take precedence over the command-line flags. The file is watched for changes: instance selectors, labels, config reloader settings, default base images and log level are applied without restart, the controllers are restarted when the namespaces, API server connection, kubelet service, local host, cluster domain or secret field selector change and the web server and log format settings require a restart of the operator.")
 	flagset.StringVar(&cfg.ListenAddress, "web.listen-address", ":8080", "Address on which to expose metrics and web interface.")
 	flagset.BoolVar(&serverTLS, "web.enable-tls", false, "Activate prometheus operator web server TLS.  "+
 		" This is useful for example when using the rule validation webhook.")
@@ -170,6 +170,7 @@ func init() {
 	flagset.StringVar(&cfg.TLSConfig.KeyFile, "key-file", "", "- NOT RECOMMENDED FOR PRODUCTION - Path to private TLS certificate file.")
 	flagset.StringVar(&cfg.TLSConfig.CAFile, "ca-file", "", "- NOT RECOMMENDED FOR PRODUCTION - Path to TLS CA file.")
 	flagset.StringVar(&cfg.KubeletObject, "kubelet-service", "", "Service/Endpoints object to write kubelets into in format \"namespace/name\"")
+	flagset.StringVar(&cfg.KubeletSync.NodeSelector, "kubelet-selector", "", "Label selector to filter nodes written into the kubelet Service/Endpoints object")
 	flagset.BoolVar(&cfg.TLSInsecure, "tls-insecure", false, "- NOT RECOMMENDED FOR PRODUCTION - Don't verify API server's CA certificate.")
 	// The Prometheus config reloader image is released along with the
 	// Prometheus Operator image, tagged with the same semver version. Default to
@@ -185,6 +186,8 @@ func init() {
 	flagset.StringVar(&cfg.ThanosDefaultBaseImage, "thanos-default-base-image", operator.DefaultThanosBaseImage, "Thanos default base image (path without tag/version)")
 	flagset.Var(ns, "namespaces", "Namespaces to scope the interaction of the Prometheus Operator and the apiserver (allow list). This is mutually exclusive with --deny-namespaces.")
 	flagset.Var(deniedNs, "deny-namespaces", "Namespaces not to scope the interaction of the Prometheus Operator (deny list). This is mutually exclusive with --namespaces.")
+	flagset.StringVar(&nsSelector, "namespace-selector", "", "Label selector of the namespaces to scope the interaction of the Prometheus Operator and the apiserver. Namespaces are added and removed at runtime when their labels change. This is mutually exclusive with --namespaces and --deny-namespaces.")
+	flagset.StringVar(&deniedNsSelector, "deny-namespace-selector", "", "Label selector of the namespaces not to scope the interaction of the Prometheus Operator. Namespaces are added and removed at runtime when their labels change. This is mutually exclusive with --namespaces and --deny-namespaces.")
 	flagset.Var(prometheusNs, "prometheus-instance-namespaces", "Namespaces where Prometheus custom resources and corresponding Secrets, Configmaps and StatefulSets are watched/created. If set this takes precedence over --namespaces or --deny-namespaces for Prometheus custom resources.")
 	flagset.Var(alertmanagerNs, "alertmanager-instance-namespaces", "Namespaces where Alertmanager custom resources and corresponding StatefulSets are watched/created. If set this takes precedence over --namespaces or --deny-namespaces for Alertmanager custom resources.")
 	flagset.Var(thanosRulerNs, "thanos-ruler-instance-namespaces", "Namespaces where ThanosRuler custom resources and corresponding StatefulSets are watched/created. If set this takes precedence over --namespaces or --deny-namespaces for ThanosRuler custom resources.")
@@ -209,28 +212,48 @@ func Main() int {
 		return 0
 	}
 
-	logger := log.NewLogfmtLogger(log.NewSyncWriter(os.Stdout))
+	if len(ns) > 0 && len(deniedNs) > 0 {
+		fmt.Fprint(os.Stderr, "--namespaces and --deny-namespaces are mutually exclusive. Please provide only one of them.\n")
+		return 1
+	}
+
+	var err error
+	cfg.Namespaces, err = operator.NewNamespaces(ns, deniedNs, prometheusNs, alertmanagerNs, thanosRulerNs)
+	if err != nil {
+		fmt.Fprint(os.Stderr, "invalid namespaces: ", err, "\n")
+		return 1
+	}
+
+	if err := cfg.Namespaces.SetSelectors(nsSelector, deniedNsSelector); err != nil {
+		fmt.Fprint(os.Stderr, "invalid namespace selectors: ", err, "\n")
+		return 1
+	}
+
+	if rawTLSCipherSuites != "" {
+		cfg.ServerTLSConfig.CipherSuites = strings.Split(rawTLSCipherSuites, ",")
+	}
+
+	flagCfg := cfg
+	var oc *operator.OperatorConfiguration
+	cfg, oc, err = loadConfig(flagCfg, configFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to load the configuration file %q: %v\n", configFile, err)
+		return 1
+	}
+	if oc != nil && oc.Web != nil && oc.Web.EnableTLS != nil {
+		serverTLS = *oc.Web.EnableTLS
+	}
+
+	var logger log.Logger = log.NewLogfmtLogger(log.NewSyncWriter(os.Stdout))
 	if cfg.LogFormat == logFormatJSON {
 		logger = log.NewJSONLogger(log.NewSyncWriter(os.Stdout))
 	}
-	switch cfg.LogLevel {
-	case logLevelAll:
-		logger = level.NewFilter(logger, level.AllowAll())
-	case logLevelDebug:
-		logger = level.NewFilter(logger, level.AllowDebug())
-	case logLevelInfo:
-		logger = level.NewFilter(logger, level.AllowInfo())
-	case logLevelWarn:
-		logger = level.NewFilter(logger, level.AllowWarn())
-	case logLevelError:
-		logger = level.NewFilter(logger, level.AllowError())
-	case logLevelNone:
-		logger = level.NewFilter(logger, level.AllowNone())
-	default:
-		fmt.Fprintf(os.Stderr, "log level %v unknown, %v are possible values", cfg.LogLevel, availableLogLevels)
+	lvl, err := newLevelFilter(logger, cfg.LogLevel)
+	if err != nil {
+		fmt.Fprint(os.Stderr, err)
 		return 1
 	}
-	logger = log.With(logger, "ts", log.DefaultTimestampUTC)
+	logger = log.With(lvl, "ts", log.DefaultTimestampUTC)
 	logger = log.With(logger, "caller", log.DefaultCaller)
 
 	// Check validity of reloader resource values given to flags
@@ -261,59 +284,13 @@ func Main() int {
 	level.Info(logger).Log("msg", "Starting Prometheus Operator", "version", version.Info())
 	level.Info(logger).Log("build_context", version.BuildContext())
 
-	if len(ns) > 0 && len(deniedNs) > 0 {
-		fmt.Fprint(os.Stderr, "--namespaces and --deny-namespaces are mutually exclusive. Please provide only one of them.\n")
-		return 1
-	}
-
-	cfg.Namespaces.AllowList = ns
-	if len(cfg.Namespaces.AllowList) == 0 {
-		cfg.Namespaces.AllowList[v1.NamespaceAll] = struct{}{}
-	}
-
-	cfg.Namespaces.DenyList = deniedNs
-	cfg.Namespaces.PrometheusAllowList = prometheusNs
-	cfg.Namespaces.AlertmanagerAllowList = alertmanagerNs
-	cfg.Namespaces.ThanosRulerAllowList = thanosRulerNs
-
-	if len(cfg.Namespaces.PrometheusAllowList) == 0 {
-		cfg.Namespaces.PrometheusAllowList = cfg.Namespaces.AllowList
-	}
-
-	if len(cfg.Namespaces.AlertmanagerAllowList) == 0 {
-		cfg.Namespaces.AlertmanagerAllowList = cfg.Namespaces.AllowList
-	}
-
-	if len(cfg.Namespaces.ThanosRulerAllowList) == 0 {
-		cfg.Namespaces.ThanosRulerAllowList = cfg.Namespaces.AllowList
-	}
-
 	ctx, cancel := context.WithCancel(context.Background())
 	wg, ctx := errgroup.WithContext(ctx)
 	r := prometheus.NewRegistry()
 
 	k8sutil.MustRegisterClientGoMetrics(r)
 
-	po, err := prometheuscontroller.New(ctx, cfg, log.With(logger, "component", "prometheusoperator"), r)
-	if err != nil {
-		fmt.Fprint(os.Stderr, "instantiating prometheus controller failed: ", err)
-		cancel()
-		return 1
-	}
-
-	ao, err := alertmanagercontroller.New(ctx, cfg, log.With(logger, "component", "alertmanageroperator"), r)
-	if err != nil {
-		fmt.Fprint(os.Stderr, "instantiating alertmanager controller failed: ", err)
-		cancel()
-		return 1
-	}
-
-	to, err := thanoscontroller.New(ctx, cfg, log.With(logger, "component", "thanosoperator"), r)
-	if err != nil {
-		fmt.Fprint(os.Stderr, "instantiating thanos controller failed: ", err)
-		cancel()
-		return 1
-	}
+	cm := newConfigManager(configFile, flagCfg, cfg, lvl, logger)
 
 	mux := http.NewServeMux()
 	web, err := api.New(cfg, log.With(logger, "component", "api"))
@@ -335,9 +312,6 @@ func Main() int {
 
 	var tlsConfig *tls.Config
 	if serverTLS {
-		if rawTLSCipherSuites != "" {
-			cfg.ServerTLSConfig.CipherSuites = strings.Split(rawTLSCipherSuites, ",")
-		}
 		tlsConfig, err = operator.NewTLSConfig(logger, cfg.ServerTLSConfig.CertFile, cfg.ServerTLSConfig.KeyFile,
 			cfg.ServerTLSConfig.ClientCAFile, cfg.ServerTLSConfig.MinVersion, cfg.ServerTLSConfig.CipherSuites)
 		if tlsConfig == nil || err != nil {
@@ -357,11 +331,23 @@ func Main() int {
 		Help: "Number of errors that occurred while validating a prometheusRules object",
 	})
 
+	agentValidationTriggeredCounter := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "prometheus_operator_prometheusagent_validation_triggered_total",
+		Help: "Number of times a prometheusAgent object triggered validation",
+	})
+
+	agentValidationErrorsCounter := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "prometheus_operator_prometheusagent_validation_errors_total",
+		Help: "Number of errors that occurred while validating a prometheusAgent object",
+	})
+
 	r.MustRegister(
 		collectors.NewGoCollector(),
 		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
 		validationTriggeredCounter,
 		validationErrorsCounter,
+		agentValidationTriggeredCounter,
+		agentValidationErrorsCounter,
 		version.NewCollector("prometheus_operator"),
 	)
 
@@ -369,17 +355,22 @@ func Main() int {
 		validationTriggeredCounter,
 		validationErrorsCounter,
 	)
+	admit.RegisterAgentMetrics(
+		agentValidationTriggeredCounter,
+		agentValidationErrorsCounter,
+	)
 
-	mux.Handle("/metrics", promhttp.HandlerFor(r, promhttp.HandlerOpts{}))
+	mux.Handle("/metrics", cm)
 	mux.Handle("/debug/pprof/", http.HandlerFunc(pprof.Index))
 	mux.Handle("/debug/pprof/cmdline", http.HandlerFunc(pprof.Cmdline))
 	mux.Handle("/debug/pprof/profile", http.HandlerFunc(pprof.Profile))
 	mux.Handle("/debug/pprof/symbol", http.HandlerFunc(pprof.Symbol))
 	mux.Handle("/debug/pprof/trace", http.HandlerFunc(pprof.Trace))
 
-	wg.Go(func() error { return po.Run(ctx) })
-	wg.Go(func() error { return ao.Run(ctx) })
-	wg.Go(func() error { return to.Run(ctx) })
+	wg.Go(func() error { return cm.runControllers(ctx, r) })
+	if configFile != "" {
+		wg.Go(func() error { return cm.watchConfigFile(ctx) })
+	}
 
 	if tlsConfig != nil {
 		r, err := rbacproxytls.NewCertReloader(
@@ -16,8 +16,10 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net/http"
+	"net/url"
 	"os"
 	"regexp"
 	"strings"
@@ -105,6 +107,15 @@ func main() {
 	reloadURL := app.Flag("reload-url", "reload URL to trigger Prometheus reload on").
 		Default("http://127.0.0.1:9090/-/reload").URL()
 
+	reloadUsername := app.Flag("reload-basic-auth-username", "username used to authenticate against the reload URL").
+		Envar("RELOAD_BASIC_AUTH_USERNAME").String()
+
+	reloadPassword := app.Flag("reload-basic-auth-password", "password used to authenticate against the reload URL").
+		Envar("RELOAD_BASIC_AUTH_PASSWORD").String()
+
+	reloadInsecureSkipVerify := app.Flag("reload-tls-insecure-skip-verify", "skip the verification of the certificate served by the reload URL").
+		Bool()
+
 	versionutil.RegisterIntoKingpinFlags(app)
 
 	if _, err := app.Parse(os.Args[1:]); err != nil {
@@ -154,6 +165,17 @@ func main() {
 		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
 	)
 
+	if *reloadUsername != "" {
+		// The HTTP client sends the credentials of the URL with basic
+		// authentication.
+		(*reloadURL).User = url.UserPassword(*reloadUsername, *reloadPassword)
+	}
+
+	if *reloadInsecureSkipVerify {
+		// The reloader sends the requests with the default HTTP client.
+		http.DefaultTransport.(*http.Transport).TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+
 	var g run.Group
 	{
 		ctx, cancel := context.WithCancel(context.Background())
@@ -102,6 +102,26 @@
             },
             'for': '5m',
           },
+          {
+            alert: 'PrometheusOperatorConfigSizeNearLimit',
+            expr: |||
+              (
+                max_over_time(prometheus_operator_config_compressed_size_bytes{%(prometheusOperatorSelector)s}[5m])
+              or
+                max_over_time(prometheus_operator_config_size_bytes{%(prometheusOperatorSelector)s}[5m])
+                unless
+                prometheus_operator_config_compressed_size_bytes{%(prometheusOperatorSelector)s}
+              ) > 0.8 * 1024 * 1024
+            ||| % $._config,
+            labels: {
+              severity: 'warning',
+            },
+            annotations: {
+              description: 'The configuration generated by the {{ $labels.controller }} controller for {{ $labels.key }} in {{ $labels.namespace }} namespace is {{ $value | humanize1024 }}B, close to the 1MiB limit of Kubernetes Secrets.',
+              summary: 'Generated configuration is close to the Secret size limit',
+            },
+            'for': '15m',
+          },
         ],
       },
     ],
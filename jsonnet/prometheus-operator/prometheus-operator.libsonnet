@@ -30,9 +30,12 @@ function(params) {
   '0alertmanagerCustomResourceDefinition': import 'alertmanager-crd.libsonnet',
   '0alertmanagerConfigCustomResourceDefinition': import 'alertmanagerconfig-crd.libsonnet',
   '0prometheusCustomResourceDefinition': import 'prometheus-crd.libsonnet',
+  '0prometheusagentCustomResourceDefinition': import 'prometheusagent-crd.libsonnet',
   '0servicemonitorCustomResourceDefinition': import 'servicemonitor-crd.libsonnet',
   '0podmonitorCustomResourceDefinition': import 'podmonitor-crd.libsonnet',
   '0probeCustomResourceDefinition': import 'probe-crd.libsonnet',
+  '0scrapeconfigCustomResourceDefinition': import 'scrapeconfig-crd.libsonnet',
+  '0proberCustomResourceDefinition': import 'prober-crd.libsonnet',
   '0prometheusruleCustomResourceDefinition': import 'prometheusrule-crd.libsonnet',
   '0thanosrulerCustomResourceDefinition': import 'thanosruler-crd.libsonnet',
 
@@ -71,6 +74,11 @@ function(params) {
           'alertmanagerconfigs',
           'prometheuses',
           'prometheuses/finalizers',
+          'prometheusagents',
+          'prometheusagents/finalizers',
+          'scrapeconfigs',
+          'probers',
+          'probers/finalizers',
           'thanosrulers',
           'thanosrulers/finalizers',
           'servicemonitors',
@@ -82,7 +90,7 @@ function(params) {
       },
       {
         apiGroups: ['apps'],
-        resources: ['statefulsets'],
+        resources: ['statefulsets', 'daemonsets', 'deployments'],
         verbs: ['*'],
       },
       {
@@ -119,6 +127,11 @@ function(params) {
         resources: ['ingresses'],
         verbs: ['get', 'list', 'watch'],
       },
+      {
+        apiGroups: ['gateway.networking.k8s.io'],
+        resources: ['httproutes'],
+        verbs: ['get', 'list', 'watch'],
+      },
     ],
   },
 
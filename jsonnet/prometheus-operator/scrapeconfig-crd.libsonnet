@@ -0,0 +1 @@
+{"apiVersion":"apiextensions.k8s.io/v1","kind":"CustomResourceDefinition","metadata":{"annotations":{"controller-gen.kubebuilder.io/version":"v0.4.1"},"creationTimestamp":null,"name":"scrapeconfigs.monitoring.coreos.com"},"spec":{"group":"monitoring.coreos.com","names":{"categories":["prometheus-operator"],"kind":"ScrapeConfig","listKind":"ScrapeConfigList","plural":"scrapeconfigs","singular":"scrapeconfig"},"scope":"Namespaced","versions":[{"name":"v1alpha1","schema":{"openAPIV3Schema":{"description":"ScrapeConfig defines a namespaced Prometheus scrape_config to be aggregated across multiple namespaces into the Prometheus configuration. It covers the service discovery mechanisms which aren't based on Kubernetes objects.","properties":{"apiVersion":{"description":"APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources","type":"string"},"kind":{"description":"Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds","type":"string"},"metadata":{"type":"object"},"spec":{"description":"ScrapeConfigSpec is a specification of the desired configuration for a scrape configuration.","properties":{"authorization":{"description":"Authorization header to use on every scrape request.","properties":{"credentials":{"description":"The secret's key that contains the credentials of the request","properties":{"key":{"description":"The key of the secret to select from.  Must be a valid secret key.","type":"string"},"name":{"description":"Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?","type":"string"},"optional":{"description":"Specify whether the Secret or its key must be defined","type":"boolean"}},"required":["key"],"type":"object"},"type":{"description":"Set the authentication type. Defaults to Bearer, Basic will cause an error","type":"string"}},"type":"object"},"azureSDConfigs":{"description":"AzureSDConfigs defines a list of Azure service discovery configurations.","items":{"description":"AzureSDConfig defines a Prometheus Azure service discovery configuration. See https://prometheus.io/docs/prometheus/latest/configuration/configuration/#azure_sd_config","properties":{"authenticationMethod":{"description":"The authentication method, either OAuth or ManagedIdentity. See https://docs.microsoft.com/en-us/azure/active-directory/managed-identities-azure-resources/overview","enum":["OAuth","ManagedIdentity"],"type":"string"},"clientID":{"description":"Optional client ID. Only required with the OAuth authentication method.","type":"string"},"clientSecret":{"description":"Optional client secret. Only required with the OAuth authentication method. The secret is written in the Prometheus configuration even when credentialsAsFiles is enabled.","properties":{"key":{"description":"The key of the secret to select from.  Must be a valid secret key.","type":"string"},"name":{"description":"Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?","type":"string"},"optional":{"description":"Specify whether the Secret or its key must be defined","type":"boolean"}},"required":["key"],"type":"object"},"environment":{"description":"The Azure environment.","type":"string"},"port":{"description":"The port to scrape metrics from. If using the public IP address, this must instead be specified in the relabeling rule.","type":"integer"},"refreshInterval":{"description":"RefreshInterval configures the refresh interval at which Prometheus will re-read the instance list.","type":"string"},"resourceGroup":{"description":"Optional resource group name. Limits discovery to this resource group.","type":"string"},"subscriptionID":{"description":"The subscription ID. Always required.","minLength":1,"type":"string"},"tenantID":{"description":"Optional tenant ID. Only required with the OAuth authentication method.","type":"string"}},"required":["subscriptionID"],"type":"object"},"type":"array"},"basicAuth":{"description":"BasicAuth information to use on every scrape request.","properties":{"password":{"description":"The secret in the service monitor namespace that contains the password for authentication.","properties":{"key":{"description":"The key of the secret to select from.  Must be a valid secret key.","type":"string"},"name":{"description":"Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?","type":"string"},"optional":{"description":"Specify whether the Secret or its key must be defined","type":"boolean"}},"required":["key"],"type":"object"},"username":{"description":"The secret in the service monitor namespace that contains the username for authentication.","properties":{"key":{"description":"The key of the secret to select from.  Must be a valid secret key.","type":"string"},"name":{"description":"Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?","type":"string"},"optional":{"description":"Specify whether the Secret or its key must be defined","type":"boolean"}},"required":["key"],"type":"object"}},"type":"object"},"consulSDConfigs":{"description":"ConsulSDConfigs defines a list of Consul service discovery configurations.","items":{"description":"ConsulSDConfig defines a Prometheus Consul service discovery configuration. See https://prometheus.io/docs/prometheus/latest/configuration/configuration/#consul_sd_config","properties":{"allowStale":{"description":"Allow stale Consul results (see https://www.consul.io/api/features/consistency.html). Will reduce load on Consul. If unset, Prometheus uses its default value.","type":"boolean"},"authorization":{"description":"Authorization header configuration to authenticate against the Consul Server.","properties":{"credentials":{"description":"The secret's key that contains the credentials of the request","properties":{"key":{"description":"The key of the secret to select from.  Must be a valid secret key.","type":"string"},"name":{"description":"Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?","type":"string"},"optional":{"description":"Specify whether the Secret or its key must be defined","type":"boolean"}},"required":["key"],"type":"object"},"type":{"description":"Set the authentication type. Defaults to Bearer, Basic will cause an error","type":"string"}},"type":"object"},"basicAuth":{"description":"BasicAuth information to authenticate against the Consul Server.","properties":{"password":{"description":"The secret in the service monitor namespace that contains the password for authentication.","properties":{"key":{"description":"The key of the secret to select from.  Must be a valid secret key.","type":"string"},"name":{"description":"Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?","type":"string"},"optional":{"description":"Specify whether the Secret or its key must be defined","type":"boolean"}},"required":["key"],"type":"object"},"username":{"description":"The secret in the service monitor namespace that contains the username for authentication.","properties":{"key":{"description":"The key of the secret to select from.  Must be a valid secret key.","type":"string"},"name":{"description":"Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?","type":"string"},"optional":{"description":"Specify whether the Secret or its key must be defined","type":"boolean"}},"required":["key"],"type":"object"}},"type":"object"},"datacenter":{"description":"Consul Datacenter name, if not provided it will use the local Consul Agent Datacenter.","type":"string"},"namespace":{"description":"Namespaces are only supported in Consul Enterprise.","type":"string"},"nodeMeta":{"additionalProperties":{"type":"string"},"description":"Node metadata key/value pairs to filter nodes for a given service.","type":"object"},"partition":{"description":"Admin Partitions are only supported in Consul Enterprise.","type":"string"},"refreshInterval":{"description":"The time after which the provided names are refreshed. If unset, Prometheus uses its default value.","type":"string"},"scheme":{"description":"HTTP Scheme default \"http\"","enum":["HTTP","HTTPS"],"type":"string"},"server":{"description":"A valid string consisting of a hostname or IP followed by an optional port number.","minLength":1,"type":"string"},"services":{"description":"A list of services for which targets are retrieved. If omitted, all services are scraped.","items":{"type":"string"},"type":"array"},"tagSeparator":{"description":"The string by which Consul tags are joined into the tag label. If unset, Prometheus uses its default value.","type":"string"},"tags":{"description":"An optional list of tags used to filter nodes for a given service. Services must contain all tags in the list.","items":{"type":"string"},"type":"array"},"tlsConfig":{"description":"TLS configuration to connect to the Consul API.","properties":{"ca":{"description":"Struct containing the CA cert to use for the targets.","properties":{"configMap":{"description":"ConfigMap containing data to use for the targets.","properties":{"key":{"description":"The key to select.","type":"string"},"name":{"description":"Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?","type":"string"},"optional":{"description":"Specify whether the ConfigMap or its key must be defined","type":"boolean"}},"required":["key"],"type":"object"},"secret":{"description":"Secret containing data to use for the targets.","properties":{"key":{"description":"The key of the secret to select from.  Must be a valid secret key.","type":"string"},"name":{"description":"Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?","type":"string"},"optional":{"description":"Specify whether the Secret or its key must be defined","type":"boolean"}},"required":["key"],"type":"object"}},"type":"object"},"cert":{"description":"Struct containing the client cert file for the targets.","properties":{"configMap":{"description":"ConfigMap containing data to use for the targets.","properties":{"key":{"description":"The key to select.","type":"string"},"name":{"description":"Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?","type":"string"},"optional":{"description":"Specify whether the ConfigMap or its key must be defined","type":"boolean"}},"required":["key"],"type":"object"},"secret":{"description":"Secret containing data to use for the targets.","properties":{"key":{"description":"The key of the secret to select from.  Must be a valid secret key.","type":"string"},"name":{"description":"Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?","type":"string"},"optional":{"description":"Specify whether the Secret or its key must be defined","type":"boolean"}},"required":["key"],"type":"object"}},"type":"object"},"insecureSkipVerify":{"description":"Disable target certificate validation.","type":"boolean"},"keySecret":{"description":"Secret containing the client key file for the targets.","properties":{"key":{"description":"The key of the secret to select from.  Must be a valid secret key.","type":"string"},"name":{"description":"Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?","type":"string"},"optional":{"description":"Specify whether the Secret or its key must be defined","type":"boolean"}},"required":["key"],"type":"object"},"serverName":{"description":"Used to verify the hostname for the targets.","type":"string"}},"type":"object"},"tokenRef":{"description":"Consul ACL TokenRef, if not provided it will use the ACL from the local Consul Agent. The token is written in the Prometheus configuration even when credentialsAsFiles is enabled.","properties":{"key":{"description":"The key of the secret to select from.  Must be a valid secret key.","type":"string"},"name":{"description":"Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?","type":"string"},"optional":{"description":"Specify whether the Secret or its key must be defined","type":"boolean"}},"required":["key"],"type":"object"}},"required":["server"],"type":"object"},"type":"array"},"dnsSDConfigs":{"description":"DNSSDConfigs defines a list of DNS service discovery configurations.","items":{"description":"DNSSDConfig defines a Prometheus DNS service discovery configuration. See https://prometheus.io/docs/prometheus/latest/configuration/configuration/#dns_sd_config","properties":{"names":{"description":"A list of DNS domain names to be queried.","items":{"type":"string"},"minItems":1,"type":"array"},"port":{"description":"The port number used if the query type is not SRV.","type":"integer"},"refreshInterval":{"description":"RefreshInterval configures the time after which the provided names are refreshed.","type":"string"},"type":{"description":"The type of DNS query to perform. One of SRV, A, AAAA or MX. If not set, Prometheus uses its default value (SRV).","enum":["SRV","A","AAAA","MX"],"type":"string"}},"required":["names"],"type":"object"},"type":"array"},"ec2SDConfigs":{"description":"EC2SDConfigs defines a list of EC2 service discovery configurations.","items":{"description":"EC2SDConfig defines a Prometheus EC2 service discovery configuration. See https://prometheus.io/docs/prometheus/latest/configuration/configuration/#ec2_sd_config","properties":{"accessKey":{"description":"AccessKey is the AWS API key. If not specified, the environment variable `AWS_ACCESS_KEY_ID` is used. The key is written in the Prometheus configuration even when credentialsAsFiles is enabled.","properties":{"key":{"description":"The key of the secret to select from.  Must be a valid secret key.","type":"string"},"name":{"description":"Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?","type":"string"},"optional":{"description":"Specify whether the Secret or its key must be defined","type":"boolean"}},"required":["key"],"type":"object"},"filters":{"description":"Filters can be used optionally to filter the instance list by other criteria.","items":{"description":"EC2Filter is the configuration for filtering EC2 instances.","properties":{"name":{"description":"Name of the filter.","type":"string"},"values":{"description":"Values of the filter.","items":{"type":"string"},"type":"array"}},"required":["name","values"],"type":"object"},"type":"array"},"port":{"description":"The port to scrape metrics from. If using the public IP address, this must instead be specified in the relabeling rule.","type":"integer"},"refreshInterval":{"description":"RefreshInterval configures the refresh interval at which Prometheus will re-read the instance list.","type":"string"},"region":{"description":"The AWS region. If blank, the region from the instance metadata is used.","type":"string"},"roleARN":{"description":"AWS Role ARN, an alternative to using AWS API keys.","type":"string"},"secretKey":{"description":"SecretKey is the AWS API secret. If not specified, the environment variable `AWS_SECRET_ACCESS_KEY` is used. The secret is written in the Prometheus configuration even when credentialsAsFiles is enabled.","properties":{"key":{"description":"The key of the secret to select from.  Must be a valid secret key.","type":"string"},"name":{"description":"Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?","type":"string"},"optional":{"description":"Specify whether the Secret or its key must be defined","type":"boolean"}},"required":["key"],"type":"object"}},"type":"object"},"type":"array"},"fileSDConfigs":{"description":"FileSDConfigs defines a list of file service discovery configurations.","items":{"description":"FileSDConfig defines a Prometheus file service discovery configuration. See https://prometheus.io/docs/prometheus/latest/configuration/configuration/#file_sd_config","properties":{"files":{"description":"List of files to be used for file discovery. The files need to be mounted into the Prometheus pods, for instance with the `configMaps` or `secrets` fields of the Prometheus resource. The last path segment may contain a single * that matches any character sequence.","items":{"description":"SDFile represents a file used for service discovery.","pattern":"^[^*]*(\\*[^/]*)?\\.(json|yml|yaml|JSON|YML|YAML)$","type":"string"},"minItems":1,"type":"array"},"refreshInterval":{"description":"RefreshInterval configures the refresh interval at which Prometheus will reload the content of the files.","type":"string"}},"required":["files"],"type":"object"},"type":"array"},"honorLabels":{"description":"HonorLabels chooses the metric's labels on collisions with target labels.","type":"boolean"},"honorTimestamps":{"description":"HonorTimestamps controls whether Prometheus respects the timestamps present in scraped data.","type":"boolean"},"httpSDConfigs":{"description":"HTTPSDConfigs defines a list of HTTP service discovery configurations.","items":{"description":"HTTPSDConfig defines a Prometheus HTTP service discovery configuration. See https://prometheus.io/docs/prometheus/latest/configuration/configuration/#http_sd_config","properties":{"authorization":{"description":"Authorization header configuration to authenticate against the target HTTP endpoint.","properties":{"credentials":{"description":"The secret's key that contains the credentials of the request","properties":{"key":{"description":"The key of the secret to select from.  Must be a valid secret key.","type":"string"},"name":{"description":"Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?","type":"string"},"optional":{"description":"Specify whether the Secret or its key must be defined","type":"boolean"}},"required":["key"],"type":"object"},"type":{"description":"Set the authentication type. Defaults to Bearer, Basic will cause an error","type":"string"}},"type":"object"},"basicAuth":{"description":"BasicAuth information to authenticate against the target HTTP endpoint.","properties":{"password":{"description":"The secret in the service monitor namespace that contains the password for authentication.","properties":{"key":{"description":"The key of the secret to select from.  Must be a valid secret key.","type":"string"},"name":{"description":"Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?","type":"string"},"optional":{"description":"Specify whether the Secret or its key must be defined","type":"boolean"}},"required":["key"],"type":"object"},"username":{"description":"The secret in the service monitor namespace that contains the username for authentication.","properties":{"key":{"description":"The key of the secret to select from.  Must be a valid secret key.","type":"string"},"name":{"description":"Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?","type":"string"},"optional":{"description":"Specify whether the Secret or its key must be defined","type":"boolean"}},"required":["key"],"type":"object"}},"type":"object"},"refreshInterval":{"description":"RefreshInterval configures the refresh interval at which Prometheus will re-query the endpoint to update the target list.","type":"string"},"tlsConfig":{"description":"TLS configuration applying to the target HTTP endpoint.","properties":{"ca":{"description":"Struct containing the CA cert to use for the targets.","properties":{"configMap":{"description":"ConfigMap containing data to use for the targets.","properties":{"key":{"description":"The key to select.","type":"string"},"name":{"description":"Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?","type":"string"},"optional":{"description":"Specify whether the ConfigMap or its key must be defined","type":"boolean"}},"required":["key"],"type":"object"},"secret":{"description":"Secret containing data to use for the targets.","properties":{"key":{"description":"The key of the secret to select from.  Must be a valid secret key.","type":"string"},"name":{"description":"Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?","type":"string"},"optional":{"description":"Specify whether the Secret or its key must be defined","type":"boolean"}},"required":["key"],"type":"object"}},"type":"object"},"cert":{"description":"Struct containing the client cert file for the targets.","properties":{"configMap":{"description":"ConfigMap containing data to use for the targets.","properties":{"key":{"description":"The key to select.","type":"string"},"name":{"description":"Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?","type":"string"},"optional":{"description":"Specify whether the ConfigMap or its key must be defined","type":"boolean"}},"required":["key"],"type":"object"},"secret":{"description":"Secret containing data to use for the targets.","properties":{"key":{"description":"The key of the secret to select from.  Must be a valid secret key.","type":"string"},"name":{"description":"Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?","type":"string"},"optional":{"description":"Specify whether the Secret or its key must be defined","type":"boolean"}},"required":["key"],"type":"object"}},"type":"object"},"insecureSkipVerify":{"description":"Disable target certificate validation.","type":"boolean"},"keySecret":{"description":"Secret containing the client key file for the targets.","properties":{"key":{"description":"The key of the secret to select from.  Must be a valid secret key.","type":"string"},"name":{"description":"Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?","type":"string"},"optional":{"description":"Specify whether the Secret or its key must be defined","type":"boolean"}},"required":["key"],"type":"object"},"serverName":{"description":"Used to verify the hostname for the targets.","type":"string"}},"type":"object"},"url":{"description":"URL from which the targets are fetched.","minLength":1,"pattern":"^http(s)?://.+$","type":"string"}},"required":["url"],"type":"object"},"type":"array"},"labelLimit":{"description":"Per-scrape limit on number of labels that will be accepted for a sample. Only valid in Prometheus versions 2.27.0 and newer.","format":"int64","type":"integer"},"labelNameLengthLimit":{"description":"Per-scrape limit on length of labels name that will be accepted for a sample. Only valid in Prometheus versions 2.27.0 and newer.","format":"int64","type":"integer"},"labelValueLengthLimit":{"description":"Per-scrape limit on length of labels value that will be accepted for a sample. Only valid in Prometheus versions 2.27.0 and newer.","format":"int64","type":"integer"},"metricRelabelings":{"description":"MetricRelabelConfigs to apply to the samples before ingestion.","items":{"description":"RelabelConfig allows dynamic rewriting of the label set, being applied to samples before ingestion. It defines `\u003cmetric_relabel_configs\u003e`-section of Prometheus configuration. More info: https://prometheus.io/docs/prometheus/latest/configuration/configuration/#metric_relabel_configs","properties":{"action":{"description":"Action to perform based on regex matching. Default is 'replace'","type":"string"},"modulus":{"description":"Modulus to take of the hash of the source label values.","format":"int64","type":"integer"},"regex":{"description":"Regular expression against which the extracted value is matched. Default is '(.*)'","type":"string"},"replacement":{"description":"Replacement value against which a regex replace is performed if the regular expression matches. Regex capture groups are available. Default is '$1'","type":"string"},"separator":{"description":"Separator placed between concatenated source label values. default is ';'.","type":"string"},"sourceLabels":{"description":"The source labels select values from existing labels. Their content is concatenated using the configured separator and matched against the configured regular expression for the replace, keep, and drop actions.","items":{"type":"string"},"type":"array"},"targetLabel":{"description":"Label to which the resulting value is written in a replace action. It is mandatory for replace actions. Regex capture groups are available.","type":"string"}},"type":"object"},"type":"array"},"metricsPath":{"description":"MetricsPath HTTP path to scrape for metrics. If empty, Prometheus uses the default value (e.g. /metrics).","type":"string"},"params":{"additionalProperties":{"items":{"type":"string"},"type":"array"},"description":"Optional HTTP URL parameters","type":"object"},"relabelings":{"description":"RelabelConfigs to apply to the targets before scraping. More info: https://prometheus.io/docs/prometheus/latest/configuration/configuration/#relabel_config","items":{"description":"RelabelConfig allows dynamic rewriting of the label set, being applied to samples before ingestion. It defines `\u003cmetric_relabel_configs\u003e`-section of Prometheus configuration. More info: https://prometheus.io/docs/prometheus/latest/configuration/configuration/#metric_relabel_configs","properties":{"action":{"description":"Action to perform based on regex matching. Default is 'replace'","type":"string"},"modulus":{"description":"Modulus to take of the hash of the source label values.","format":"int64","type":"integer"},"regex":{"description":"Regular expression against which the extracted value is matched. Default is '(.*)'","type":"string"},"replacement":{"description":"Replacement value against which a regex replace is performed if the regular expression matches. Regex capture groups are available. Default is '$1'","type":"string"},"separator":{"description":"Separator placed between concatenated source label values. default is ';'.","type":"string"},"sourceLabels":{"description":"The source labels select values from existing labels. Their content is concatenated using the configured separator and matched against the configured regular expression for the replace, keep, and drop actions.","items":{"type":"string"},"type":"array"},"targetLabel":{"description":"Label to which the resulting value is written in a replace action. It is mandatory for replace actions. Regex capture groups are available.","type":"string"}},"type":"object"},"type":"array"},"sampleLimit":{"description":"SampleLimit defines per-scrape limit on number of scraped samples that will be accepted.","format":"int64","type":"integer"},"scheme":{"description":"Configures the protocol scheme used for requests. If empty, Prometheus uses HTTP by default.","enum":["HTTP","HTTPS"],"type":"string"},"scrapeInterval":{"description":"Interval at which metrics should be scraped. If not specified, Prometheus' global scrape interval is used.","type":"string"},"scrapeTimeout":{"description":"Timeout after which the scrape is ended. If not specified, Prometheus' global scrape timeout is used.","type":"string"},"staticConfigs":{"description":"StaticConfigs defines a list of static targets with a common label set.","items":{"description":"StaticConfig defines a Prometheus static configuration. See https://prometheus.io/docs/prometheus/latest/configuration/configuration/#static_config","properties":{"labels":{"additionalProperties":{"type":"string"},"description":"Labels assigned to all metrics scraped from the targets.","type":"object"},"targets":{"description":"List of targets for this static configuration.","items":{"type":"string"},"type":"array"}},"type":"object"},"type":"array"},"targetLimit":{"description":"TargetLimit defines a limit on the number of scraped targets that will be accepted.","format":"int64","type":"integer"},"tlsConfig":{"description":"TLS configuration to use on every scrape request","properties":{"ca":{"description":"Struct containing the CA cert to use for the targets.","properties":{"configMap":{"description":"ConfigMap containing data to use for the targets.","properties":{"key":{"description":"The key to select.","type":"string"},"name":{"description":"Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?","type":"string"},"optional":{"description":"Specify whether the ConfigMap or its key must be defined","type":"boolean"}},"required":["key"],"type":"object"},"secret":{"description":"Secret containing data to use for the targets.","properties":{"key":{"description":"The key of the secret to select from.  Must be a valid secret key.","type":"string"},"name":{"description":"Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?","type":"string"},"optional":{"description":"Specify whether the Secret or its key must be defined","type":"boolean"}},"required":["key"],"type":"object"}},"type":"object"},"cert":{"description":"Struct containing the client cert file for the targets.","properties":{"configMap":{"description":"ConfigMap containing data to use for the targets.","properties":{"key":{"description":"The key to select.","type":"string"},"name":{"description":"Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?","type":"string"},"optional":{"description":"Specify whether the ConfigMap or its key must be defined","type":"boolean"}},"required":["key"],"type":"object"},"secret":{"description":"Secret containing data to use for the targets.","properties":{"key":{"description":"The key of the secret to select from.  Must be a valid secret key.","type":"string"},"name":{"description":"Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?","type":"string"},"optional":{"description":"Specify whether the Secret or its key must be defined","type":"boolean"}},"required":["key"],"type":"object"}},"type":"object"},"insecureSkipVerify":{"description":"Disable target certificate validation.","type":"boolean"},"keySecret":{"description":"Secret containing the client key file for the targets.","properties":{"key":{"description":"The key of the secret to select from.  Must be a valid secret key.","type":"string"},"name":{"description":"Name of the referent. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names TODO: Add other useful fields. apiVersion, kind, uid?","type":"string"},"optional":{"description":"Specify whether the Secret or its key must be defined","type":"boolean"}},"required":["key"],"type":"object"},"serverName":{"description":"Used to verify the hostname for the targets.","type":"string"}},"type":"object"}},"type":"object"}},"required":["spec"],"type":"object"}},"served":true,"storage":true}]},"status":{"acceptedNames":{"kind":"","plural":""},"conditions":[],"storedVersions":[]}}
\ No newline at end of file
@@ -24,6 +24,7 @@ import (
 	"github.com/go-kit/log"
 	"github.com/go-kit/log/level"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	monitoringv1alpha1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1alpha1"
 	promoperator "github.com/prometheus-operator/prometheus-operator/pkg/prometheus"
 	"github.com/prometheus/client_golang/prometheus"
 	v1 "k8s.io/api/admission/v1"
@@ -36,6 +37,7 @@ const (
 	addAdditionalAnnotationPatch = `{ "op": "add", "path": "/metadata/annotations/prometheus-operator-validated", "value": "true" }`
 	errUnmarshalAdmission        = "Cannot unmarshal admission request"
 	errUnmarshalRules            = "Cannot unmarshal rules from spec"
+	errUnmarshalAgent            = "Cannot unmarshal prometheusagent"
 )
 
 var (
@@ -45,14 +47,21 @@ var (
 		Version:  "v1",
 		Resource: "prometheusrules",
 	}
+	agentResource = metav1.GroupVersionResource{
+		Group:    "monitoring.coreos.com",
+		Version:  "v1alpha1",
+		Resource: "prometheusagents",
+	}
 )
 
 // Admission is a validating and mutating webhook that ensures PrometheusRules pushed into the cluster will be
 // valid when loaded by a Prometheus
 type Admission struct {
-	validationErrorsCounter    prometheus.Counter
-	validationTriggeredCounter prometheus.Counter
-	logger                     log.Logger
+	validationErrorsCounter         prometheus.Counter
+	validationTriggeredCounter      prometheus.Counter
+	agentValidationErrorsCounter    prometheus.Counter
+	agentValidationTriggeredCounter prometheus.Counter
+	logger                          log.Logger
 }
 
 func New(logger log.Logger) *Admission {
@@ -62,6 +71,7 @@ func New(logger log.Logger) *Admission {
 func (a *Admission) Register(mux *http.ServeMux) {
 	mux.HandleFunc("/admission-prometheusrules/validate", a.servePrometheusRulesValidate)
 	mux.HandleFunc("/admission-prometheusrules/mutate", a.servePrometheusRulesMutate)
+	mux.HandleFunc("/admission-prometheusagents/validate", a.servePrometheusAgentsValidate)
 }
 
 func (a *Admission) RegisterMetrics(validationTriggeredCounter, validationErrorsCounter prometheus.Counter) {
@@ -69,6 +79,12 @@ func (a *Admission) RegisterMetrics(validationTriggeredCounter, validationErrors
 	a.validationErrorsCounter = validationErrorsCounter
 }
 
+// RegisterAgentMetrics sets the counters of the PrometheusAgent validations.
+func (a *Admission) RegisterAgentMetrics(validationTriggeredCounter, validationErrorsCounter prometheus.Counter) {
+	a.agentValidationTriggeredCounter = validationTriggeredCounter
+	a.agentValidationErrorsCounter = validationErrorsCounter
+}
+
 type admitFunc func(ar v1.AdmissionReview) *v1.AdmissionResponse
 
 func (a *Admission) servePrometheusRulesMutate(w http.ResponseWriter, r *http.Request) {
@@ -79,6 +95,10 @@ func (a *Admission) servePrometheusRulesValidate(w http.ResponseWriter, r *http.
 	a.serveAdmission(w, r, a.validatePrometheusRules)
 }
 
+func (a *Admission) servePrometheusAgentsValidate(w http.ResponseWriter, r *http.Request) {
+	a.serveAdmission(w, r, a.validatePrometheusAgents)
+}
+
 func toAdmissionResponseFailure(message string, errors []error) *v1.AdmissionResponse {
 	r := &v1.AdmissionResponse{
 		Result: &metav1.Status{
@@ -215,3 +235,30 @@ func (a *Admission) validatePrometheusRules(ar v1.AdmissionReview) *v1.Admission
 
 	return &v1.AdmissionResponse{Allowed: true}
 }
+
+func (a *Admission) validatePrometheusAgents(ar v1.AdmissionReview) *v1.AdmissionResponse {
+	a.agentValidationTriggeredCounter.Inc()
+	level.Debug(a.logger).Log("msg", "Validating prometheusagents")
+
+	if ar.Request.Resource != agentResource {
+		err := fmt.Errorf("expected resource to be %v, but received %v", agentResource, ar.Request.Resource)
+		level.Warn(a.logger).Log("err", err)
+		a.agentValidationErrorsCounter.Inc()
+		return toAdmissionResponseFailure("Unexpected resource kind", []error{err})
+	}
+
+	agent := &monitoringv1alpha1.PrometheusAgent{}
+	if err := json.Unmarshal(ar.Request.Object.Raw, agent); err != nil {
+		level.Info(a.logger).Log("msg", errUnmarshalAgent, "err", err)
+		a.agentValidationErrorsCounter.Inc()
+		return toAdmissionResponseFailure(errUnmarshalAgent, []error{err})
+	}
+
+	if err := promoperator.ValidateAgent(agent); err != nil {
+		level.Info(a.logger).Log("msg", "Invalid prometheusagent", "err", err)
+		a.agentValidationErrorsCounter.Inc()
+		return toAdmissionResponseFailure("PrometheusAgent is not valid", []error{err})
+	}
+
+	return &v1.AdmissionResponse{Allowed: true}
+}
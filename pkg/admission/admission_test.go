@@ -29,6 +29,7 @@ import (
 	"github.com/go-kit/log"
 	"github.com/go-kit/log/level"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"k8s.io/api/admission/v1"
 )
 
@@ -157,6 +158,42 @@ func TestMutateNonStringsToStrings(t *testing.T) {
 	}
 }
 
+func TestAdmitGoodAgent(t *testing.T) {
+	ts := server(api().servePrometheusAgentsValidate)
+	defer ts.Close()
+
+	resp := send(t, ts, agentReview(`{"serviceMonitorSelector": {}}`))
+
+	if !resp.Response.Allowed {
+		t.Errorf("Expected admission to be allowed but it was not")
+	}
+}
+
+func TestAdmitAgentWithUnsupportedFields(t *testing.T) {
+	a := api()
+	ts := server(a.servePrometheusAgentsValidate)
+	defer ts.Close()
+
+	resp := send(t, ts, agentReview(`{"mode": "DaemonSet", "replicas": 2, "probeSelector": {}}`))
+
+	if resp.Response.Allowed {
+		t.Errorf("Expected admission to not be allowed but it was")
+	}
+
+	exp := "fields not supported in DaemonSet mode: replicas, probeSelector"
+	act := resp.Response.Result.Details.Causes[0].Message
+	if act != exp {
+		t.Errorf("Expected error %q, got %q", exp, act)
+	}
+
+	if v := testutil.ToFloat64(a.agentValidationErrorsCounter); v != 1 {
+		t.Errorf("Expected 1 agent validation error, got %v", v)
+	}
+	if v := testutil.ToFloat64(a.validationErrorsCounter); v != 0 {
+		t.Errorf("Expected no rule validation error, got %v", v)
+	}
+}
+
 func api() *Admission {
 	validationTriggered := prometheus.NewCounter(prometheus.CounterOpts{
 		Name: "prometheus_operator_rule_validation_triggered_total",
@@ -167,10 +204,21 @@ func api() *Admission {
 		Name: "prometheus_operator_rule_validation_errors_total",
 		Help: "Number of errors that occurred while validating a prometheusRules object",
 	})
+	agentValidationTriggered := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "prometheus_operator_prometheusagent_validation_triggered_total",
+		Help: "Number of times a prometheusAgent object triggered validation",
+	})
+
+	agentValidationErrors := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "prometheus_operator_prometheusagent_validation_errors_total",
+		Help: "Number of errors that occurred while validating a prometheusAgent object",
+	})
 	a := &Admission{
-		logger:                     log.NewLogfmtLogger(log.NewSyncWriter(os.Stdout)),
-		validationErrorsCounter:    validationErrors,
-		validationTriggeredCounter: validationTriggered}
+		logger:                          log.NewLogfmtLogger(log.NewSyncWriter(os.Stdout)),
+		validationErrorsCounter:         validationErrors,
+		validationTriggeredCounter:      validationTriggered,
+		agentValidationErrorsCounter:    agentValidationErrors,
+		agentValidationTriggeredCounter: agentValidationTriggered}
 	a.logger = level.NewFilter(a.logger, level.AllowNone())
 	return a
 }
@@ -534,3 +582,35 @@ var nonStringsInLabelsAnnotations = []byte(`
     "dryRun": false
   }
 }`)
+
+func agentReview(spec string) []byte {
+	return []byte(`
+{
+  "kind": "AdmissionReview",
+  "apiVersion": "admission.k8s.io/v1",
+  "request": {
+    "uid": "2a6a4d4c-0d3c-4b0c-9c39-8e4b1b3ce2a5",
+    "kind": {
+      "group": "monitoring.coreos.com",
+      "version": "v1alpha1",
+      "kind": "PrometheusAgent"
+    },
+    "resource": {
+      "group": "monitoring.coreos.com",
+      "version": "v1alpha1",
+      "resource": "prometheusagents"
+    },
+    "namespace": "monitoring",
+    "operation": "CREATE",
+    "object": {
+      "apiVersion": "monitoring.coreos.com/v1alpha1",
+      "kind": "PrometheusAgent",
+      "metadata": {
+        "name": "test",
+        "namespace": "monitoring"
+      },
+      "spec": ` + spec + `
+    }
+  }
+}`)
+}
@@ -23,6 +23,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/blang/semver/v4"
 	"github.com/go-kit/log"
 	"github.com/pkg/errors"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
@@ -30,6 +31,7 @@ import (
 	"github.com/prometheus-operator/prometheus-operator/pkg/assets"
 	"github.com/prometheus/alertmanager/config"
 	"gopkg.in/yaml.v2"
+	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 )
 
@@ -60,16 +62,57 @@ func (c alertmanagerConfig) String() string {
 type configGenerator struct {
 	logger log.Logger
 	store  *assets.Store
+
+	// When credentialsAsFiles is true, the receiver credentials are
+	// referenced as files for the fields supported by amVersion and their
+	// values are collected into credentials.
+	credentialsAsFiles bool
+	amVersion          semver.Version
+	credentials        map[string][]byte
 }
 
 func newConfigGenerator(logger log.Logger, store *assets.Store) *configGenerator {
 	cg := &configGenerator{
-		logger: logger,
-		store:  store,
+		logger:      logger,
+		store:       store,
+		credentials: map[string][]byte{},
 	}
 	return cg
 }
 
+// credentialKey returns the key of the credential in the credentials secret.
+// Namespace and secret names can't contain underscores which keeps the
+// result unique.
+func credentialKey(namespace string, sel v1.SecretKeySelector) string {
+	return fmt.Sprintf("secret_%s_%s_%s", namespace, sel.Name, sel.Key)
+}
+
+// withCredentialsAsFiles configures the generator to reference the receiver
+// credentials as files for the given Alertmanager version.
+func (cg *configGenerator) withCredentialsAsFiles(version semver.Version) *configGenerator {
+	cg.credentialsAsFiles = true
+	cg.amVersion = version
+	return cg
+}
+
+// credentialFile returns the path of the file holding the value of the secret
+// key if credentials are referenced as files and the Alertmanager version is
+// at least minVersion (if not empty). Otherwise it returns an empty string and the value
+// should be inlined.
+func (cg *configGenerator) credentialFile(namespace string, sel v1.SecretKeySelector, value string, minVersion string) string {
+	if !cg.credentialsAsFiles {
+		return ""
+	}
+	if minVersion != "" && cg.amVersion.LT(semver.MustParse(minVersion)) {
+		return ""
+	}
+
+	key := credentialKey(namespace, sel)
+	cg.credentials[key] = []byte(value)
+
+	return path.Join(credentialsDir, key)
+}
+
 func (cg *configGenerator) generateConfig(
 	ctx context.Context,
 	baseConfig alertmanagerConfig,
@@ -305,7 +348,11 @@ func (cg *configGenerator) convertWebhookConfig(ctx context.Context, in monitori
 		if err != nil {
 			return nil, errors.Errorf("failed to get key %q from secret %q", in.URLSecret.Key, in.URLSecret.Name)
 		}
-		out.URL = strings.TrimSpace(url)
+		if file := cg.credentialFile(crKey.Namespace, *in.URLSecret, strings.TrimSpace(url), "0.26.0"); file != "" {
+			out.URLFile = file
+		} else {
+			out.URL = strings.TrimSpace(url)
+		}
 	} else if in.URL != nil {
 		out.URL = *in.URL
 	}
@@ -352,7 +399,11 @@ func (cg *configGenerator) convertSlackConfig(ctx context.Context, in monitoring
 		if err != nil {
 			return nil, errors.Errorf("failed to get key %q from secret %q", in.APIURL.Key, in.APIURL.Name)
 		}
-		out.APIURL = strings.TrimSpace(url)
+		if file := cg.credentialFile(crKey.Namespace, *in.APIURL, strings.TrimSpace(url), "0.22.0"); file != "" {
+			out.APIURLFile = file
+		} else {
+			out.APIURL = strings.TrimSpace(url)
+		}
 	}
 
 	var actions []slackAction
@@ -428,7 +479,11 @@ func (cg *configGenerator) convertPagerdutyConfig(ctx context.Context, in monito
 		if err != nil {
 			return nil, errors.Errorf("failed to get routing key %q from secret %q", in.RoutingKey.Key, in.RoutingKey.Name)
 		}
-		out.RoutingKey = routingKey
+		if file := cg.credentialFile(crKey.Namespace, *in.RoutingKey, routingKey, "0.25.0"); file != "" {
+			out.RoutingKeyFile = file
+		} else {
+			out.RoutingKey = routingKey
+		}
 	}
 
 	if in.ServiceKey != nil {
@@ -436,7 +491,11 @@ func (cg *configGenerator) convertPagerdutyConfig(ctx context.Context, in monito
 		if err != nil {
 			return nil, errors.Errorf("failed to get service key %q from secret %q", in.ServiceKey.Key, in.ServiceKey.Name)
 		}
-		out.ServiceKey = serviceKey
+		if file := cg.credentialFile(crKey.Namespace, *in.ServiceKey, serviceKey, "0.25.0"); file != "" {
+			out.ServiceKeyFile = file
+		} else {
+			out.ServiceKey = serviceKey
+		}
 	}
 
 	var details map[string]string
@@ -476,7 +535,11 @@ func (cg *configGenerator) convertOpsgenieConfig(ctx context.Context, in monitor
 		if err != nil {
 			return nil, errors.Errorf("failed to get api key %q from secret %q", in.APIKey.Key, in.APIKey.Name)
 		}
-		out.APIKey = apiKey
+		if file := cg.credentialFile(crKey.Namespace, *in.APIKey, apiKey, "0.24.0"); file != "" {
+			out.APIKeyFile = file
+		} else {
+			out.APIKey = apiKey
+		}
 	}
 
 	var details map[string]string
@@ -627,7 +690,11 @@ func (cg *configGenerator) convertVictorOpsConfig(ctx context.Context, in monito
 		if err != nil {
 			return nil, errors.Errorf("failed to get secret %q", in.APIKey)
 		}
-		out.APIKey = apiKey
+		if file := cg.credentialFile(crKey.Namespace, *in.APIKey, apiKey, "0.25.0"); file != "" {
+			out.APIKeyFile = file
+		} else {
+			out.APIKey = apiKey
+		}
 	}
 	if in.RoutingKey == "" {
 		return nil, errors.New("missing Routing key in VictorOps config")
@@ -806,7 +873,12 @@ func (cg *configGenerator) convertHTTPConfig(ctx context.Context, in monitoringv
 		}
 
 		if username != "" && password != "" {
-			out.BasicAuth = &basicAuth{Username: username, Password: password}
+			out.BasicAuth = &basicAuth{Username: username}
+			if file := cg.credentialFile(crKey.Namespace, in.BasicAuth.Password, password, ""); file != "" {
+				out.BasicAuth.PasswordFile = file
+			} else {
+				out.BasicAuth.Password = password
+			}
 		}
 	}
 
@@ -819,7 +891,11 @@ func (cg *configGenerator) convertHTTPConfig(ctx context.Context, in monitoringv
 		if err != nil {
 			return nil, errors.Errorf("failed to get bearer token key %q from secret %q", in.BearerTokenSecret.Key, in.BearerTokenSecret.Name)
 		}
-		out.BearerToken = bearerToken
+		if file := cg.credentialFile(crKey.Namespace, *in.BearerTokenSecret, bearerToken, ""); file != "" {
+			out.BearerTokenFile = file
+		} else {
+			out.BearerToken = bearerToken
+		}
 	}
 
 	return out, nil
@@ -17,9 +17,11 @@ package alertmanager
 import (
 	"context"
 	"net/url"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/blang/semver/v4"
 	"github.com/google/go-cmp/cmp"
 	"github.com/prometheus-operator/prometheus-operator/pkg/assets"
 	"github.com/prometheus/alertmanager/config"
@@ -655,3 +657,127 @@ templates: []
 		})
 	}
 }
+
+func TestGenerateConfigCredentialsAsFiles(t *testing.T) {
+	secretKey := func(key string) *corev1.SecretKeySelector {
+		return &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "creds"},
+			Key:                  key,
+		}
+	}
+
+	kclient := fake.NewSimpleClientset(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "creds",
+				Namespace: "mynamespace",
+			},
+			Data: map[string][]byte{
+				"url":      []byte("http://webhook.example.com\n"),
+				"token":    []byte("bearer-token"),
+				"slack":    []byte("http://slack.example.com"),
+				"opsgenie": []byte("1234abc"),
+			},
+		},
+	)
+
+	baseConfig := alertmanagerConfig{
+		Route:     &route{Receiver: "null"},
+		Receivers: []*receiver{{Name: "null"}},
+	}
+
+	amConfigs := map[string]*monitoringv1alpha1.AlertmanagerConfig{
+		"mynamespace": {
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "myamc",
+				Namespace: "mynamespace",
+			},
+			Spec: monitoringv1alpha1.AlertmanagerConfigSpec{
+				Route: &monitoringv1alpha1.Route{
+					Receiver: "test",
+				},
+				Receivers: []monitoringv1alpha1.Receiver{{
+					Name: "test",
+					WebhookConfigs: []monitoringv1alpha1.WebhookConfig{{
+						URLSecret: secretKey("url"),
+						HTTPConfig: &monitoringv1alpha1.HTTPConfig{
+							BearerTokenSecret: secretKey("token"),
+						},
+					}},
+					SlackConfigs: []monitoringv1alpha1.SlackConfig{{
+						APIURL: secretKey("slack"),
+					}},
+					OpsGenieConfigs: []monitoringv1alpha1.OpsGenieConfig{{
+						APIKey: secretKey("opsgenie"),
+					}},
+				}},
+			},
+		},
+	}
+
+	for _, tc := range []struct {
+		name                string
+		version             string
+		expected            []string
+		expectedCredentials map[string][]byte
+	}{
+		{
+			name:    "Alertmanager v0.22",
+			version: "0.22.0",
+			expected: []string{`
+  webhook_configs:
+  - url: http://webhook.example.com
+    http_config:
+      bearer_token_file: /etc/alertmanager/credentials/secret_mynamespace_creds_token`, `
+  opsgenie_configs:
+  - api_key: 1234abc`, `
+  slack_configs:
+  - api_url_file: /etc/alertmanager/credentials/secret_mynamespace_creds_slack`,
+			},
+			expectedCredentials: map[string][]byte{
+				"secret_mynamespace_creds_token": []byte("bearer-token"),
+				"secret_mynamespace_creds_slack": []byte("http://slack.example.com"),
+			},
+		},
+		{
+			name:    "Alertmanager v0.26",
+			version: "0.26.0",
+			expected: []string{`
+  webhook_configs:
+  - url_file: /etc/alertmanager/credentials/secret_mynamespace_creds_url
+    http_config:
+      bearer_token_file: /etc/alertmanager/credentials/secret_mynamespace_creds_token`, `
+  opsgenie_configs:
+  - api_key_file: /etc/alertmanager/credentials/secret_mynamespace_creds_opsgenie`, `
+  slack_configs:
+  - api_url_file: /etc/alertmanager/credentials/secret_mynamespace_creds_slack`,
+			},
+			expectedCredentials: map[string][]byte{
+				"secret_mynamespace_creds_url":      []byte("http://webhook.example.com"),
+				"secret_mynamespace_creds_token":    []byte("bearer-token"),
+				"secret_mynamespace_creds_slack":    []byte("http://slack.example.com"),
+				"secret_mynamespace_creds_opsgenie": []byte("1234abc"),
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			store := assets.NewStore(kclient.CoreV1(), kclient.CoreV1())
+			cg := newConfigGenerator(nil, store).withCredentialsAsFiles(semver.MustParse(tc.version))
+			cfgBytes, err := cg.generateConfig(context.Background(), baseConfig, amConfigs)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			result := string(cfgBytes)
+			for _, s := range tc.expected {
+				if !strings.Contains(result, s) {
+					t.Fatalf("expected Alertmanager configuration to contain:\n%s\nFull config:\n%s", s, result)
+				}
+			}
+
+			if diff := cmp.Diff(tc.expectedCredentials, cg.credentials); diff != "" {
+				t.Errorf("Unexpected credentials (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -16,9 +16,9 @@ package alertmanager
 
 import (
 	"github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/prometheus-operator/prometheus-operator/pkg/informers"
 
 	"github.com/prometheus/client_golang/prometheus"
-	"k8s.io/client-go/tools/cache"
 )
 
 var (
@@ -33,11 +33,14 @@ var (
 )
 
 type alertmanagerCollector struct {
-	stores []cache.Store
+	informers *informers.ForResource
+	selected  func(interface{}) bool
 }
 
-func newAlertmanagerCollectorForStores(s ...cache.Store) *alertmanagerCollector {
-	return &alertmanagerCollector{stores: s}
+// newAlertmanagerCollectorForInformers returns a collector for the objects of
+// the informers which are accepted by the selected function.
+func newAlertmanagerCollectorForInformers(infs *informers.ForResource, selected func(interface{}) bool) *alertmanagerCollector {
+	return &alertmanagerCollector{informers: infs, selected: selected}
 }
 
 // Describe implements the prometheus.Collector interface.
@@ -47,8 +50,11 @@ func (c *alertmanagerCollector) Describe(ch chan<- *prometheus.Desc) {
 
 // Collect implements the prometheus.Collector interface.
 func (c *alertmanagerCollector) Collect(ch chan<- prometheus.Metric) {
-	for _, s := range c.stores {
-		for _, p := range s.List() {
+	for _, inf := range c.informers.GetInformers() {
+		for _, p := range inf.Informer().GetStore().List() {
+			if !c.selected(p) {
+				continue
+			}
 			c.collectAlertmanager(ch, p.(*v1.Alertmanager))
 		}
 	}
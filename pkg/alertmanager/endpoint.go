@@ -0,0 +1,144 @@
+// Copyright 2023 The prometheus-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alertmanager
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/blang/semver/v4"
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/labels"
+
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/prometheus-operator/prometheus-operator/pkg/informers"
+	"github.com/prometheus-operator/prometheus-operator/pkg/operator"
+	"github.com/prometheus-operator/prometheus-operator/pkg/webconfig"
+)
+
+const (
+	// GoverningServiceName is the name of the headless Service created by
+	// the operator in front of the Alertmanager pods of a namespace.
+	GoverningServiceName = governingServiceName
+	// NameLabelName is the pod label holding the name of the Alertmanager
+	// resource.
+	NameLabelName = "alertmanager"
+)
+
+// Endpoint describes how the API of the pods of an Alertmanager resource is
+// reached from within the cluster.
+type Endpoint struct {
+	// Namespace and Name of the Alertmanager resource.
+	Namespace string
+	Name      string
+	// PortName is the name of the governing Service port.
+	PortName string
+	// Scheme is either "http" or "https".
+	Scheme string
+	// PathPrefix is the route prefix of the Alertmanager API.
+	PathPrefix string
+	// Replicas is the number of Alertmanager pods.
+	Replicas int32
+}
+
+// NewEndpoint returns the endpoint of the given Alertmanager. It returns an
+// error if the Alertmanager API isn't reachable from other pods or requires
+// credentials which the clients can't resolve.
+func NewEndpoint(am *monitoringv1.Alertmanager) (Endpoint, error) {
+	if am.Spec.ListenLocal {
+		return Endpoint{}, errors.New("the Alertmanager API listens on the loopback interface only")
+	}
+
+	version, err := semver.ParseTolerant(operator.StringValOrDefault(am.Spec.Version, operator.DefaultAlertmanagerVersion))
+	if err != nil {
+		return Endpoint{}, errors.Wrap(err, "failed to parse alertmanager version")
+	}
+
+	// The passwords are stored in the Alertmanager's namespace and can't be
+	// shared with the clients.
+	webFields := webConfigFileFields(am, version)
+	if len(webFields.BasicAuthUsers) > 0 {
+		return Endpoint{}, errors.New("the Alertmanager API requires basic authentication")
+	}
+
+	e := Endpoint{
+		Namespace:  am.Namespace,
+		Name:       am.Name,
+		PortName:   defaultPortName,
+		Scheme:     webconfig.Scheme(webFields),
+		PathPrefix: "/",
+		Replicas:   minReplicas,
+	}
+	if am.Spec.PortName != "" {
+		e.PortName = am.Spec.PortName
+	}
+	if am.Spec.RoutePrefix != "" {
+		e.PathPrefix = am.Spec.RoutePrefix
+	}
+	if am.Spec.Replicas != nil {
+		e.Replicas = *am.Spec.Replicas
+		if e.Replicas < 0 {
+			e.Replicas = 0
+		}
+	}
+
+	return e, nil
+}
+
+// URLs returns the URL of the Alertmanager API of each pod.
+func (e Endpoint) URLs() []string {
+	urls := make([]string, 0, e.Replicas)
+	for i := int32(0); i < e.Replicas; i++ {
+		urls = append(urls, fmt.Sprintf("%s://%s-%d.%s.%s.svc:9093%s", e.Scheme, prefixedName(e.Name), i, governingServiceName, e.Namespace, e.PathPrefix))
+	}
+	return urls
+}
+
+// SelectEndpoints returns the endpoints of the Alertmanager resources
+// matching the selector in the given namespaces, sorted by namespace and
+// name. The Alertmanagers which can't be reached are skipped.
+func SelectEndpoints(logger log.Logger, amInfs *informers.ForResource, namespaces []string, selector labels.Selector) ([]Endpoint, error) {
+	var endpoints []Endpoint
+	for _, ns := range namespaces {
+		err := amInfs.ListAllByNamespace(ns, selector, func(obj interface{}) {
+			am := obj.(*monitoringv1.Alertmanager)
+			e, err := NewEndpoint(am)
+			if err != nil {
+				level.Warn(logger).Log(
+					"msg", "skipping alertmanager",
+					"err", err,
+					"alertmanager", am.Name,
+					"namespace", am.Namespace,
+				)
+				return
+			}
+			endpoints = append(endpoints, e)
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list alertmanagers in namespace %s", ns)
+		}
+	}
+
+	sort.Slice(endpoints, func(i, j int) bool {
+		if endpoints[i].Namespace != endpoints[j].Namespace {
+			return endpoints[i].Namespace < endpoints[j].Namespace
+		}
+		return endpoints[i].Name < endpoints[j].Name
+	})
+
+	return endpoints, nil
+}
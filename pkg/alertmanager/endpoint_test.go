@@ -0,0 +1,121 @@
+// Copyright 2023 The prometheus-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alertmanager
+
+import (
+	"testing"
+
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestEndpointURLs(t *testing.T) {
+	replicas := int32(2)
+	zero := int32(0)
+
+	for _, tc := range []struct {
+		name string
+		spec monitoringv1.AlertmanagerSpec
+		urls []string
+		err  bool
+	}{
+		{
+			name: "defaults",
+			urls: []string{
+				"http://alertmanager-main-0.alertmanager-operated.monitoring.svc:9093/",
+			},
+		},
+		{
+			name: "replicas and route prefix",
+			spec: monitoringv1.AlertmanagerSpec{
+				Replicas:    &replicas,
+				RoutePrefix: "/alertmanager",
+			},
+			urls: []string{
+				"http://alertmanager-main-0.alertmanager-operated.monitoring.svc:9093/alertmanager",
+				"http://alertmanager-main-1.alertmanager-operated.monitoring.svc:9093/alertmanager",
+			},
+		},
+		{
+			name: "tls",
+			spec: monitoringv1.AlertmanagerSpec{
+				Web: &monitoringv1.AlertmanagerWebSpec{
+					WebConfigFileFields: monitoringv1.WebConfigFileFields{
+						TLSConfig: &monitoringv1.WebTLSConfig{},
+					},
+				},
+			},
+			urls: []string{
+				"https://alertmanager-main-0.alertmanager-operated.monitoring.svc:9093/",
+			},
+		},
+		{
+			name: "tls unsupported by the version",
+			spec: monitoringv1.AlertmanagerSpec{
+				Version: "v0.21.0",
+				Web: &monitoringv1.AlertmanagerWebSpec{
+					WebConfigFileFields: monitoringv1.WebConfigFileFields{
+						TLSConfig: &monitoringv1.WebTLSConfig{},
+					},
+				},
+			},
+			urls: []string{
+				"http://alertmanager-main-0.alertmanager-operated.monitoring.svc:9093/",
+			},
+		},
+		{
+			name: "no replicas",
+			spec: monitoringv1.AlertmanagerSpec{
+				Replicas: &zero,
+			},
+			urls: []string{},
+		},
+		{
+			name: "listen local",
+			spec: monitoringv1.AlertmanagerSpec{
+				ListenLocal: true,
+			},
+			err: true,
+		},
+		{
+			name: "basic authentication",
+			spec: monitoringv1.AlertmanagerSpec{
+				Web: &monitoringv1.AlertmanagerWebSpec{
+					WebConfigFileFields: monitoringv1.WebConfigFileFields{
+						BasicAuthUsers: []monitoringv1.WebBasicAuthUser{{Username: "alice"}},
+					},
+				},
+			},
+			err: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			e, err := NewEndpoint(&monitoringv1.Alertmanager{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "main",
+					Namespace: "monitoring",
+				},
+				Spec: tc.spec,
+			})
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.urls, e.URLs())
+		})
+	}
+}
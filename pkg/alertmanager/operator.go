@@ -22,8 +22,10 @@ import (
 	"reflect"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	monitoringv1alpha1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1alpha1"
 	"github.com/prometheus-operator/prometheus-operator/pkg/assets"
@@ -32,7 +34,9 @@ import (
 	"github.com/prometheus-operator/prometheus-operator/pkg/k8sutil"
 	"github.com/prometheus-operator/prometheus-operator/pkg/listwatch"
 	"github.com/prometheus-operator/prometheus-operator/pkg/operator"
+	"github.com/prometheus-operator/prometheus-operator/pkg/webconfig"
 
+	"github.com/blang/semver/v4"
 	"github.com/go-kit/log"
 	"github.com/go-kit/log/level"
 	"github.com/mitchellh/hashstructure"
@@ -47,6 +51,7 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/metadata"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
 )
@@ -66,23 +71,35 @@ var (
 // Operator manages life cycle of Alertmanager deployments and
 // monitoring configurations.
 type Operator struct {
-	kclient kubernetes.Interface
-	mclient monitoringclient.Interface
-	logger  log.Logger
+	kclient  kubernetes.Interface
+	mclient  monitoringclient.Interface
+	mdClient metadata.Interface
+	logger   log.Logger
 
 	nsAlrtInf    cache.SharedIndexInformer
 	nsAlrtCfgInf cache.SharedIndexInformer
 
+	nsSelector *informers.NamespaceSelector
+
 	alrtInfs    *informers.ForResource
 	alrtCfgInfs *informers.ForResource
 	secrInfs    *informers.ForResource
 	ssetInfs    *informers.ForResource
 
+	// assetCache resolves the secrets referenced by the Alertmanager and
+	// AlertmanagerConfig resources. The secret informers only hold the
+	// objects' metadata.
+	assetCache *assets.Cache
+
 	queue workqueue.RateLimitingInterface
 
 	metrics *operator.Metrics
 
-	config Config
+	// configMtx protects the settings which can be updated at runtime by
+	// ApplyConfig.
+	configMtx  sync.RWMutex
+	config     Config
+	amSelector labels.Selector
 }
 
 type Config struct {
@@ -114,12 +131,42 @@ func New(ctx context.Context, c operator.Config, logger log.Logger, r prometheus
 		return nil, errors.Wrap(err, "instantiating monitoring client failed")
 	}
 
+	mdClient, err := metadata.NewForConfig(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "instantiating metadata client failed")
+	}
+
+	namespaceLabels, err := operator.CheckControllerPermissions(
+		ctx,
+		logger,
+		client.AuthorizationV1().SelfSubjectAccessReviews(),
+		"alertmanager",
+		operator.NamespacedAttributes{
+			AllowList: c.Namespaces.AllowList,
+			Attributes: append(
+				operator.ListWatchAttributes(monitoring.GroupName, monitoringv1alpha1.AlertmanagerConfigName),
+				operator.ListWatchAttributes("", "secrets")...,
+			),
+		},
+		operator.NamespacedAttributes{
+			AllowList: c.Namespaces.AlertmanagerAllowList,
+			Attributes: append(
+				operator.ListWatchAttributes(monitoring.GroupName, monitoringv1.AlertmanagerName),
+				operator.ListWatchAttributes("apps", "statefulsets")...,
+			),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	o := &Operator{
-		kclient: client,
-		mclient: mclient,
-		logger:  logger,
-		queue:   workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "alertmanager"),
-		metrics: operator.NewMetrics("alertmanager", r),
+		kclient:  client,
+		mclient:  mclient,
+		mdClient: mdClient,
+		logger:   logger,
+		queue:    workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "alertmanager"),
+		metrics:  operator.NewMetrics("alertmanager", r),
 		config: Config{
 			Host:                         c.Host,
 			LocalHost:                    c.LocalHost,
@@ -133,6 +180,10 @@ func New(ctx context.Context, c operator.Config, logger log.Logger, r prometheus
 		},
 	}
 
+	if !namespaceLabels {
+		o.metrics.DisableFeature("namespace-labels")
+	}
+
 	if err := o.bootstrap(ctx); err != nil {
 		return nil, err
 	}
@@ -143,19 +194,25 @@ func New(ctx context.Context, c operator.Config, logger log.Logger, r prometheus
 func (c *Operator) bootstrap(ctx context.Context) error {
 	var err error
 
-	if _, err := labels.Parse(c.config.AlertManagerSelector); err != nil {
+	c.amSelector, err = labels.Parse(c.config.AlertManagerSelector)
+	if err != nil {
 		return errors.Wrap(err, "can not parse alertmanager selector value")
 	}
 
+	c.nsSelector, err = informers.NewNamespaceSelector(c.config.Namespaces.AllowSelector, c.config.Namespaces.DenySelector, c.logger)
+	if err != nil {
+		return err
+	}
+
+	// The instance selector is evaluated by the controller instead of the
+	// API server so that it can be updated at runtime.
 	c.alrtInfs, err = informers.NewInformersForResource(
 		informers.NewMonitoringInformerFactories(
-			c.config.Namespaces.AlertmanagerAllowList,
+			c.nsSelector.AllowList(c.config.Namespaces.AlertmanagerAllowList),
 			c.config.Namespaces.DenyList,
 			c.mclient,
 			resyncPeriod,
-			func(options *metav1.ListOptions) {
-				options.LabelSelector = c.config.AlertManagerSelector
-			},
+			nil,
 		),
 		monitoringv1.SchemeGroupVersion.WithResource(monitoringv1.AlertmanagerName),
 	)
@@ -163,15 +220,11 @@ func (c *Operator) bootstrap(ctx context.Context) error {
 		return errors.Wrap(err, "error creating alertmanager informers")
 	}
 
-	var alertmanagerStores []cache.Store
-	for _, informer := range c.alrtInfs.GetInformers() {
-		alertmanagerStores = append(alertmanagerStores, informer.Informer().GetStore())
-	}
-	c.metrics.MustRegister(newAlertmanagerCollectorForStores(alertmanagerStores...))
+	c.metrics.MustRegister(newAlertmanagerCollectorForInformers(c.alrtInfs, c.isSelected))
 
 	c.alrtCfgInfs, err = informers.NewInformersForResource(
 		informers.NewMonitoringInformerFactories(
-			c.config.Namespaces.AllowList,
+			c.nsSelector.AllowList(c.config.Namespaces.AllowList),
 			c.config.Namespaces.DenyList,
 			c.mclient,
 			resyncPeriod,
@@ -187,11 +240,13 @@ func (c *Operator) bootstrap(ctx context.Context) error {
 	if err != nil {
 		return errors.Wrap(err, "can not parse secrets selector value")
 	}
+	// Only the metadata of secrets is watched, the referenced objects are
+	// fetched on demand by the asset cache.
 	c.secrInfs, err = informers.NewInformersForResource(
-		informers.NewKubeInformerFactories(
-			c.config.Namespaces.AllowList,
+		informers.NewMetadataInformerFactories(
+			c.nsSelector.AllowList(c.config.Namespaces.AllowList),
 			c.config.Namespaces.DenyList,
-			c.kclient,
+			c.mdClient,
 			resyncPeriod,
 			func(options *metav1.ListOptions) {
 				options.FieldSelector = secretListWatchSelector.String()
@@ -203,9 +258,12 @@ func (c *Operator) bootstrap(ctx context.Context) error {
 		return errors.Wrap(err, "error creating secret informers")
 	}
 
+	c.assetCache = assets.NewCache(c.kclient.CoreV1(), c.kclient.CoreV1(), nil, c.secrInfs)
+	c.metrics.MustRegister(c.assetCache)
+
 	c.ssetInfs, err = informers.NewInformersForResource(
 		informers.NewKubeInformerFactories(
-			c.config.Namespaces.AlertmanagerAllowList,
+			c.nsSelector.AllowList(c.config.Namespaces.AlertmanagerAllowList),
 			c.config.Namespaces.DenyList,
 			c.kclient,
 			resyncPeriod,
@@ -245,6 +303,14 @@ func (c *Operator) bootstrap(ctx context.Context) error {
 		c.nsAlrtInf = newNamespaceInformer(c, c.config.Namespaces.AlertmanagerAllowList)
 	}
 
+	// Informers for namespaces matching the namespace selector are created
+	// and removed at runtime.
+	c.nsSelector.Register(c.config.Namespaces.AllowList, c.alrtCfgInfs, c.secrInfs)
+	c.nsSelector.Register(c.config.Namespaces.AlertmanagerAllowList, c.alrtInfs, c.ssetInfs)
+	if c.nsSelector != nil {
+		c.nsAlrtCfgInf.AddEventHandler(c.nsSelector)
+	}
+
 	return nil
 }
 
@@ -299,6 +365,7 @@ func (c *Operator) addHandlers() {
 		DeleteFunc: c.handleSecretDelete,
 		UpdateFunc: c.handleSecretUpdate,
 	})
+	c.secrInfs.AddEventHandler(c.assetCache.SecretEventHandler())
 	c.ssetInfs.AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    c.handleStatefulSetAdd,
 		DeleteFunc: c.handleStatefulSetDelete,
@@ -361,7 +428,7 @@ func (c *Operator) handleSecretDelete(obj interface{}) {
 }
 
 func (c *Operator) handleSecretUpdate(old, cur interface{}) {
-	if old.(*v1.Secret).ResourceVersion == cur.(*v1.Secret).ResourceVersion {
+	if old.(*metav1.PartialObjectMetadata).ResourceVersion == cur.(*metav1.PartialObjectMetadata).ResourceVersion {
 		return
 	}
 
@@ -480,6 +547,61 @@ func (c *Operator) Run(ctx context.Context) error {
 	return nil
 }
 
+// ApplyConfig updates the settings of the controller which can be changed at
+// runtime (instance selector, labels, config reloader and default base image)
+// and enqueues all Alertmanager objects for reconciliation.
+func (c *Operator) ApplyConfig(conf operator.Config) error {
+	amSelector, err := labels.Parse(conf.AlertManagerSelector)
+	if err != nil {
+		return errors.Wrap(err, "can not parse alertmanager selector value")
+	}
+
+	c.configMtx.Lock()
+	c.config.AlertManagerSelector = conf.AlertManagerSelector
+	c.amSelector = amSelector
+	c.config.Labels = conf.Labels
+	c.config.ReloaderConfig = conf.ReloaderConfig
+	c.config.AlertmanagerDefaultBaseImage = conf.AlertmanagerDefaultBaseImage
+	c.configMtx.Unlock()
+
+	// Enqueue the keys of all the objects, the objects which don't match the
+	// new instance selector anymore are forgotten by the sync.
+	err = c.alrtInfs.ListAll(labels.Everything(), func(obj interface{}) {
+		if key, ok := c.keyFunc(obj); ok {
+			c.enqueue(key)
+		}
+	})
+	if err != nil {
+		return errors.Wrap(err, "listing all Alertmanager instances from cache failed")
+	}
+
+	return nil
+}
+
+// currentConfig returns a copy of the settings which can be updated at
+// runtime by ApplyConfig and the instance selector. The lock is only held
+// while copying so that a long sync doesn't block ApplyConfig.
+func (c *Operator) currentConfig() (Config, labels.Selector) {
+	c.configMtx.RLock()
+	defer c.configMtx.RUnlock()
+
+	return c.config, c.amSelector
+}
+
+// isSelected returns true if the object matches the instance selector. The
+// informers watch all the objects in the namespaces so that the selector can
+// be updated at runtime, the objects managed by other operators are filtered
+// out here.
+func (c *Operator) isSelected(obj interface{}) bool {
+	o, ok := c.getObject(obj)
+	if !ok {
+		return false
+	}
+
+	_, amSelector := c.currentConfig()
+	return amSelector.Matches(labels.Set(o.GetLabels()))
+}
+
 func (c *Operator) keyFunc(obj interface{}) (string, bool) {
 	k, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
 	if err != nil {
@@ -512,6 +634,10 @@ func (c *Operator) enqueue(obj interface{}) {
 
 	key, ok := obj.(string)
 	if !ok {
+		if !c.isSelected(obj) {
+			return
+		}
+
 		key, ok = c.keyFunc(obj)
 		if !ok {
 			return
@@ -537,7 +663,9 @@ func (c *Operator) processNextWorkItem(ctx context.Context) bool {
 	defer c.queue.Done(key)
 
 	c.metrics.ReconcileCounter().Inc()
+	startTime := time.Now()
 	err := c.sync(ctx, key.(string))
+	c.metrics.ReconcileDurationHistogram().Observe(time.Since(startTime).Seconds())
 	c.metrics.SetSyncStatus(key.(string), err == nil)
 	if err == nil {
 		c.queue.Forget(key)
@@ -599,6 +727,10 @@ func alertmanagerKeyToStatefulSetKey(key string) string {
 }
 
 func (c *Operator) handleAlertmanagerAdd(obj interface{}) {
+	if !c.isSelected(obj) {
+		return
+	}
+
 	key, ok := c.keyFunc(obj)
 	if !ok {
 		return
@@ -611,6 +743,10 @@ func (c *Operator) handleAlertmanagerAdd(obj interface{}) {
 }
 
 func (c *Operator) handleAlertmanagerDelete(obj interface{}) {
+	if !c.isSelected(obj) {
+		return
+	}
+
 	key, ok := c.keyFunc(obj)
 	if !ok {
 		return
@@ -626,6 +762,12 @@ func (c *Operator) handleAlertmanagerUpdate(old, cur interface{}) {
 		return
 	}
 
+	// An object which stops matching the instance selector is still enqueued
+	// to be forgotten by the sync.
+	if !c.isSelected(old) && !c.isSelected(cur) {
+		return
+	}
+
 	key, ok := c.keyFunc(cur)
 	if !ok {
 		return
@@ -729,7 +871,17 @@ func (c *Operator) sync(ctx context.Context, key string) error {
 		return err
 	}
 
+	config, amSelector := c.currentConfig()
+
 	am := aobj.(*monitoringv1.Alertmanager)
+	logger := log.With(c.logger, "key", key)
+
+	if !amSelector.Matches(labels.Set(am.Labels)) {
+		level.Debug(logger).Log("msg", "Alertmanager not matching the instance selector, skipping")
+		c.metrics.ForgetObject(key)
+		return nil
+	}
+
 	am = am.DeepCopy()
 	am.APIVersion = monitoringv1.SchemeGroupVersion.String()
 	am.Kind = monitoringv1.AlertmanagersKind
@@ -738,31 +890,34 @@ func (c *Operator) sync(ctx context.Context, key string) error {
 		return nil
 	}
 
-	logger := log.With(c.logger, "key", key)
 	level.Info(logger).Log("msg", "sync alertmanager")
 
-	assetStore := assets.NewStore(c.kclient.CoreV1(), c.kclient.CoreV1())
+	assetStore := c.assetCache.NewStore()
 
-	if err := c.provisionAlertmanagerConfiguration(ctx, am, assetStore); err != nil {
+	if err := c.provisionAlertmanagerConfiguration(ctx, am, config, assetStore); err != nil {
 		return errors.Wrap(err, "provision alertmanager configuration")
 	}
 
-	if err := c.createOrUpdateTLSAssetSecret(ctx, am, assetStore); err != nil {
+	if err := c.createOrUpdateTLSAssetSecret(ctx, am, config, assetStore); err != nil {
 		return errors.Wrap(err, "creating tls asset secret failed")
 	}
 
+	if err := c.createOrUpdateWebConfigSecret(ctx, am, config, assetStore); err != nil {
+		return errors.Wrap(err, "synchronizing web config secret failed")
+	}
+
 	// Create governing service if it doesn't exist.
 	svcClient := c.kclient.CoreV1().Services(am.Namespace)
-	if err = k8sutil.CreateOrUpdateService(ctx, svcClient, makeStatefulSetService(am, c.config)); err != nil {
+	if err = k8sutil.CreateOrUpdateService(ctx, svcClient, makeStatefulSetService(am, config)); err != nil {
 		return errors.Wrap(err, "synchronizing governing service failed")
 	}
 
-	newSSetInputHash, err := createSSetInputHash(*am, c.config)
+	newSSetInputHash, err := createSSetInputHash(*am, config)
 	if err != nil {
 		return err
 	}
 
-	sset, err := makeStatefulSet(am, c.config, newSSetInputHash)
+	sset, err := makeStatefulSet(am, config, newSSetInputHash)
 	if err != nil {
 		return errors.Wrap(err, "failed to make statefulset")
 	}
@@ -833,7 +988,7 @@ func createSSetInputHash(a monitoringv1.Alertmanager, c Config) (string, error)
 	return fmt.Sprintf("%d", hash), nil
 }
 
-func (c *Operator) provisionAlertmanagerConfiguration(ctx context.Context, am *monitoringv1.Alertmanager, store *assets.Store) error {
+func (c *Operator) provisionAlertmanagerConfiguration(ctx context.Context, am *monitoringv1.Alertmanager, config Config, store *assets.Store) error {
 	secretName := defaultConfigSecretName(am.Name)
 	if am.Spec.ConfigSecret != "" {
 		secretName = am.Spec.ConfigSecret
@@ -880,11 +1035,11 @@ receivers:
 			"alertmanager", am.Name, "namespace", am.Namespace,
 		)
 
-		err = c.createOrUpdateGeneratedConfigSecret(ctx, am, rawBaseConfig, secretData)
+		err = c.createOrUpdateGeneratedConfigSecret(ctx, am, config, rawBaseConfig, secretData)
 		if err != nil {
 			return errors.Wrap(err, "create or update generated config secret failed")
 		}
-		return nil
+		return c.createOrUpdateCredentialsSecret(ctx, am, config, nil)
 	}
 
 	amConfigs, err := c.selectAlertmanagerConfigs(ctx, am, store)
@@ -893,12 +1048,26 @@ receivers:
 	}
 
 	generator := newConfigGenerator(c.logger, store)
+	if am.Spec.CredentialsAsFiles {
+		version, err := semver.ParseTolerant(operator.StringValOrDefault(am.Spec.Version, operator.DefaultAlertmanagerVersion))
+		if err != nil {
+			return errors.Wrap(err, "failed to parse alertmanager version")
+		}
+		generator = generator.withCredentialsAsFiles(version)
+	}
+
 	generatedConfig, err := generator.generateConfig(ctx, *baseConfig, amConfigs)
 	if err != nil {
 		return errors.Wrap(err, "generating Alertmanager config yaml failed")
 	}
 
-	err = c.createOrUpdateGeneratedConfigSecret(ctx, am, generatedConfig, secretData)
+	// The credentials secret is updated first to ensure that the files
+	// referenced by the generated configuration exist.
+	if err := c.createOrUpdateCredentialsSecret(ctx, am, config, generator.credentials); err != nil {
+		return err
+	}
+
+	err = c.createOrUpdateGeneratedConfigSecret(ctx, am, config, generatedConfig, secretData)
 	if err != nil {
 		return errors.Wrap(err, "create or update generated config secret failed")
 	}
@@ -906,14 +1075,50 @@ receivers:
 	return nil
 }
 
-func (c *Operator) createOrUpdateGeneratedConfigSecret(ctx context.Context, am *monitoringv1.Alertmanager, conf []byte, additionalData map[string][]byte) error {
+// createOrUpdateCredentialsSecret creates the secret holding the receiver
+// credentials referenced by the generated configuration when they are
+// mounted as files.
+func (c *Operator) createOrUpdateCredentialsSecret(ctx context.Context, am *monitoringv1.Alertmanager, config Config, credentials map[string][]byte) error {
+	if !am.Spec.CredentialsAsFiles {
+		return nil
+	}
+
+	boolTrue := true
+	sClient := c.kclient.CoreV1().Secrets(am.Namespace)
+
+	credentialsSecret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   credentialsSecretName(am.Name),
+			Labels: config.Labels.Merge(managedByOperatorLabels),
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion:         am.APIVersion,
+					BlockOwnerDeletion: &boolTrue,
+					Controller:         &boolTrue,
+					Kind:               am.Kind,
+					Name:               am.Name,
+					UID:                am.UID,
+				},
+			},
+		},
+		Data: credentials,
+	}
+
+	if err := k8sutil.CreateOrUpdateSecret(ctx, sClient, credentialsSecret); err != nil {
+		return errors.Wrap(err, "failed to create credentials secret for Alertmanager")
+	}
+
+	return nil
+}
+
+func (c *Operator) createOrUpdateGeneratedConfigSecret(ctx context.Context, am *monitoringv1.Alertmanager, config Config, conf []byte, additionalData map[string][]byte) error {
 	boolTrue := true
 	sClient := c.kclient.CoreV1().Secrets(am.Namespace)
 
 	generatedConfigSecret := &v1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:   generatedConfigSecretName(am.Name),
-			Labels: c.config.Labels.Merge(managedByOperatorLabels),
+			Labels: config.Labels.Merge(managedByOperatorLabels),
 			OwnerReferences: []metav1.OwnerReference{
 				{
 					APIVersion:         am.APIVersion,
@@ -933,6 +1138,11 @@ func (c *Operator) createOrUpdateGeneratedConfigSecret(ctx context.Context, am *
 	}
 	generatedConfigSecret.Data[alertmanagerConfigFile] = conf
 
+	if key, ok := c.keyFunc(am); ok {
+		// The Alertmanager configuration isn't compressed.
+		c.metrics.SetConfigSize(key, len(conf), -1)
+	}
+
 	err := k8sutil.CreateOrUpdateSecret(ctx, sClient, generatedConfigSecret)
 	if err != nil {
 		return errors.Wrap(err, "failed to update generated config secret")
@@ -1374,14 +1584,14 @@ func configureHTTPConfigInStore(ctx context.Context, httpConfig *monitoringv1alp
 	return store.AddSafeTLSConfig(ctx, namespace, httpConfig.TLSConfig)
 }
 
-func (c *Operator) createOrUpdateTLSAssetSecret(ctx context.Context, am *monitoringv1.Alertmanager, store *assets.Store) error {
+func (c *Operator) createOrUpdateTLSAssetSecret(ctx context.Context, am *monitoringv1.Alertmanager, config Config, store *assets.Store) error {
 	boolTrue := true
 	sClient := c.kclient.CoreV1().Secrets(am.Namespace)
 
 	tlsAssetsSecret := &v1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:   tlsAssetsSecretName(am.Name),
-			Labels: c.config.Labels.Merge(managedByOperatorLabels),
+			Labels: config.Labels.Merge(managedByOperatorLabels),
 			OwnerReferences: []metav1.OwnerReference{
 				{
 					APIVersion:         am.APIVersion,
@@ -1408,6 +1618,47 @@ func (c *Operator) createOrUpdateTLSAssetSecret(ctx context.Context, am *monitor
 	return nil
 }
 
+func (c *Operator) createOrUpdateWebConfigSecret(ctx context.Context, am *monitoringv1.Alertmanager, config Config, store *assets.Store) error {
+	version, err := semver.ParseTolerant(operator.StringValOrDefault(am.Spec.Version, operator.DefaultAlertmanagerVersion))
+	if err != nil {
+		return errors.Wrap(err, "failed to parse alertmanager version")
+	}
+
+	// The web config file is only mounted from Alertmanager v0.22.0 on.
+	if version.LT(semver.MustParse("0.22.0")) {
+		return nil
+	}
+
+	webConfig, err := webconfig.New(webConfigDir, webConfigSecretName(am.Name), webConfigFileFields(am, version))
+	if err != nil {
+		return err
+	}
+
+	boolTrue := true
+	ownerReference := metav1.OwnerReference{
+		APIVersion:         am.APIVersion,
+		BlockOwnerDeletion: &boolTrue,
+		Controller:         &boolTrue,
+		Kind:               am.Kind,
+		Name:               am.Name,
+		UID:                am.UID,
+	}
+
+	err = webConfig.CreateOrUpdateSecret(
+		ctx,
+		c.kclient.CoreV1().Secrets(am.Namespace),
+		store,
+		am.Namespace,
+		config.Labels.Merge(managedByOperatorLabels),
+		ownerReference,
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to create web config for Alertmanager")
+	}
+
+	return nil
+}
+
 //checkAlertmanagerSpecDeprecation checks for deprecated fields in the prometheus spec and logs a warning if applicable
 func checkAlertmanagerSpecDeprecation(key string, a *monitoringv1.Alertmanager, logger log.Logger) {
 	deprecationWarningf := "alertmanager key=%v, field %v is deprecated, '%v' field should be used instead"
@@ -1486,3 +1737,7 @@ func needsUpdate(pod *v1.Pod, tmpl v1.PodTemplateSpec) bool {
 func tlsAssetsSecretName(name string) string {
 	return fmt.Sprintf("%s-tls-assets", prefixedName(name))
 }
+
+func credentialsSecretName(name string) string {
+	return fmt.Sprintf("%s-credentials", prefixedName(name))
+}
@@ -1041,7 +1041,7 @@ func TestProvisionAlertmanagerConfiguration(t *testing.T) {
 			}
 
 			store := assets.NewStore(c.CoreV1(), c.CoreV1())
-			err = o.provisionAlertmanagerConfiguration(context.Background(), tc.am, store)
+			err = o.provisionAlertmanagerConfiguration(context.Background(), tc.am, o.config, store)
 
 			if !tc.ok {
 				if err == nil {
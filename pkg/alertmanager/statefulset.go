@@ -31,17 +31,20 @@ import (
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	"github.com/prometheus-operator/prometheus-operator/pkg/k8sutil"
 	"github.com/prometheus-operator/prometheus-operator/pkg/operator"
+	"github.com/prometheus-operator/prometheus-operator/pkg/webconfig"
 )
 
 const (
 	governingServiceName   = "alertmanager-operated"
 	defaultRetention       = "120h"
 	tlsAssetsDir           = "/etc/alertmanager/certs"
+	credentialsDir         = "/etc/alertmanager/credentials"
 	secretsDir             = "/etc/alertmanager/secrets/"
 	configmapsDir          = "/etc/alertmanager/configmaps/"
 	alertmanagerConfigDir  = "/etc/alertmanager/config"
 	alertmanagerConfigFile = "alertmanager.yaml"
 	alertmanagerStorageDir = "/alertmanager"
+	webConfigDir           = "/etc/alertmanager/web_config"
 	sSetInputHashName      = "prometheus-operator-input-hash"
 	defaultPortName        = "web"
 )
@@ -147,6 +150,10 @@ func makeStatefulSet(am *monitoringv1.Alertmanager, config Config, inputHash str
 
 	statefulset.Spec.Template.Spec.Volumes = append(statefulset.Spec.Template.Spec.Volumes, am.Spec.Volumes...)
 
+	if err := operator.ApplyPodTemplateOverride(&statefulset.Spec.Template, am.Spec.PodTemplateOverride); err != nil {
+		return nil, err
+	}
+
 	return statefulset, nil
 }
 
@@ -277,19 +284,9 @@ func makeStatefulSetSpec(a *monitoringv1.Alertmanager, config Config) (*appsv1.S
 		amArgs = append(amArgs, fmt.Sprintf("--cluster.peer-timeout=%s", a.Spec.ClusterPeerTimeout))
 	}
 
-	livenessProbeHandler := v1.Handler{
-		HTTPGet: &v1.HTTPGetAction{
-			Path: path.Clean(webRoutePrefix + "/-/healthy"),
-			Port: intstr.FromString(a.Spec.PortName),
-		},
-	}
-
-	readinessProbeHandler := v1.Handler{
-		HTTPGet: &v1.HTTPGetAction{
-			Path: path.Clean(webRoutePrefix + "/-/ready"),
-			Port: intstr.FromString(a.Spec.PortName),
-		},
-	}
+	webFields := webConfigFileFields(a, version)
+	livenessProbeHandler := webconfig.ProbeHandler(webFields, path.Clean(webRoutePrefix+"/-/healthy"), 9093, a.Spec.PortName, false)
+	readinessProbeHandler := webconfig.ProbeHandler(webFields, path.Clean(webRoutePrefix+"/-/ready"), 9093, a.Spec.PortName, false)
 
 	var livenessProbe *v1.Probe
 	var readinessProbe *v1.Probe
@@ -319,7 +316,7 @@ func makeStatefulSetSpec(a *monitoringv1.Alertmanager, config Config) (*appsv1.S
 		"app.kubernetes.io/name":       "alertmanager",
 		"app.kubernetes.io/managed-by": "prometheus-operator",
 		"app.kubernetes.io/instance":   a.Name,
-		"alertmanager":                 a.Name,
+		NameLabelName:                  a.Name,
 	}
 	if a.Spec.PodMetadata != nil {
 		if a.Spec.PodMetadata.Labels != nil {
@@ -338,6 +335,10 @@ func makeStatefulSetSpec(a *monitoringv1.Alertmanager, config Config) (*appsv1.S
 	}
 
 	podAnnotations["kubectl.kubernetes.io/default-container"] = "alertmanager"
+	// Let Prometheus discover the scheme of the Alertmanager API.
+	if webFields.TLSConfig != nil {
+		podAnnotations[webconfig.SchemeAnnotation] = "https"
+	}
 
 	var clusterPeerDomain string
 	if config.ClusterDomain != "" {
@@ -458,6 +459,22 @@ func makeStatefulSetSpec(a *monitoringv1.Alertmanager, config Config) (*appsv1.S
 		},
 	}
 
+	if a.Spec.CredentialsAsFiles {
+		volumes = append(volumes, v1.Volume{
+			Name: "credentials",
+			VolumeSource: v1.VolumeSource{
+				Secret: &v1.SecretVolumeSource{
+					SecretName: credentialsSecretName(a.Name),
+				},
+			},
+		})
+		amVolumeMounts = append(amVolumeMounts, v1.VolumeMount{
+			Name:      "credentials",
+			ReadOnly:  true,
+			MountPath: credentialsDir,
+		})
+	}
+
 	reloadWatchDirs := []string{alertmanagerConfigDir}
 	configReloaderVolumeMounts := []v1.VolumeMount{
 		{
@@ -509,6 +526,20 @@ func makeStatefulSetSpec(a *monitoringv1.Alertmanager, config Config) (*appsv1.S
 		reloadWatchDirs = append(reloadWatchDirs, mountPath)
 	}
 
+	// Mount the web config file for versions greater than 0.22.0 so that
+	// reconfiguring between HTTP and HTTPS doesn't require a redeployment.
+	if version.GTE(semver.MustParse("0.22.0")) {
+		webConfig, err := webconfig.New(webConfigDir, webConfigSecretName(a.Name), webFields)
+		if err != nil {
+			return nil, err
+		}
+
+		confArg, configVols, configMounts := webConfig.GetMountParameters()
+		amArgs = append(amArgs, confArg)
+		volumes = append(volumes, configVols...)
+		amVolumeMounts = append(amVolumeMounts, configMounts...)
+	}
+
 	amVolumeMounts = append(amVolumeMounts, a.Spec.VolumeMounts...)
 
 	terminationGracePeriod := int64(120)
@@ -518,6 +549,25 @@ func makeStatefulSetSpec(a *monitoringv1.Alertmanager, config Config) (*appsv1.S
 	var watchedDirectories []string
 	watchedDirectories = append(watchedDirectories, reloadWatchDirs...)
 
+	reloaderOpts := []operator.ReloaderOption{
+		operator.ReloaderResources(config.ReloaderConfig),
+		operator.ReloaderURL(url.URL{
+			Scheme: webconfig.Scheme(webFields),
+			Host:   config.LocalHost + ":9093",
+			Path:   path.Clean(webRoutePrefix + "/-/reload"),
+		}),
+		operator.ListenLocal(a.Spec.ListenLocal),
+		operator.LocalHost(config.LocalHost),
+		operator.LogFormat(a.Spec.LogFormat),
+		operator.LogLevel(a.Spec.LogLevel),
+		operator.WatchedDirectories(watchedDirectories),
+		operator.VolumeMounts(configReloaderVolumeMounts),
+		operator.Shard(-1),
+	}
+	if u := webconfig.BasicAuthUser(webFields); u != nil {
+		reloaderOpts = append(reloaderOpts, operator.ReloaderBasicAuth(u.Username, u.Password))
+	}
+
 	defaultContainers := []v1.Container{
 		{
 			Args:           amArgs,
@@ -528,7 +578,7 @@ func makeStatefulSetSpec(a *monitoringv1.Alertmanager, config Config) (*appsv1.S
 			LivenessProbe:  livenessProbe,
 			ReadinessProbe: readinessProbe,
 			Resources:      a.Spec.Resources,
-			Env: []v1.EnvVar{
+			Env: append([]v1.EnvVar{
 				{
 					// Necessary for '--cluster.listen-address' flag
 					Name: "POD_IP",
@@ -538,25 +588,10 @@ func makeStatefulSetSpec(a *monitoringv1.Alertmanager, config Config) (*appsv1.S
 						},
 					},
 				},
-			},
+			}, webconfig.BasicAuthEnvVars(webFields)...),
 			TerminationMessagePolicy: v1.TerminationMessageFallbackToLogsOnError,
 		},
-		operator.CreateConfigReloader(
-			"config-reloader",
-			operator.ReloaderResources(config.ReloaderConfig),
-			operator.ReloaderURL(url.URL{
-				Scheme: "http",
-				Host:   config.LocalHost + ":9093",
-				Path:   path.Clean(webRoutePrefix + "/-/reload"),
-			}),
-			operator.ListenLocal(a.Spec.ListenLocal),
-			operator.LocalHost(config.LocalHost),
-			operator.LogFormat(a.Spec.LogFormat),
-			operator.LogLevel(a.Spec.LogLevel),
-			operator.WatchedDirectories(watchedDirectories),
-			operator.VolumeMounts(configReloaderVolumeMounts),
-			operator.Shard(-1),
-		),
+		operator.CreateConfigReloader("config-reloader", reloaderOpts...),
 	}
 
 	containers, err := k8sutil.MergePatchContainers(defaultContainers, a.Spec.Containers)
@@ -593,6 +628,14 @@ func makeStatefulSetSpec(a *monitoringv1.Alertmanager, config Config) (*appsv1.S
 				Tolerations:                   a.Spec.Tolerations,
 				Affinity:                      a.Spec.Affinity,
 				TopologySpreadConstraints:     a.Spec.TopologySpreadConstraints,
+				HostAliases:                   a.Spec.HostAliases,
+				DNSPolicy:                     operator.DNSPolicy(a.Spec.DNSPolicy, a.Spec.HostNetwork),
+				DNSConfig:                     a.Spec.DNSConfig,
+				RuntimeClassName:              a.Spec.RuntimeClassName,
+				AutomountServiceAccountToken:  a.Spec.AutomountServiceAccountToken,
+				HostNetwork:                   a.Spec.HostNetwork,
+				SchedulerName:                 a.Spec.SchedulerName,
+				ReadinessGates:                a.Spec.ReadinessGates,
 			},
 		},
 	}, nil
@@ -610,6 +653,19 @@ func volumeName(name string) string {
 	return fmt.Sprintf("%s-db", prefixedName(name))
 }
 
+func webConfigSecretName(name string) string {
+	return prefixedName(name) + "-web-config"
+}
+
+// webConfigFileFields returns the fields of the web config file which are
+// effective for the given Alertmanager version.
+func webConfigFileFields(a *monitoringv1.Alertmanager, version semver.Version) monitoringv1.WebConfigFileFields {
+	if a.Spec.Web == nil || version.LT(semver.MustParse("0.22.0")) {
+		return monitoringv1.WebConfigFileFields{}
+	}
+	return a.Spec.Web.WebConfigFileFields
+}
+
 func prefixedName(name string) string {
 	return fmt.Sprintf("alertmanager-%s", name)
 }
@@ -276,6 +276,71 @@ func TestListenLocal(t *testing.T) {
 	}
 }
 
+func TestWebTLS(t *testing.T) {
+	sset, err := makeStatefulSet(&monitoringv1.Alertmanager{
+		Spec: monitoringv1.AlertmanagerSpec{
+			Web: &monitoringv1.AlertmanagerWebSpec{
+				WebConfigFileFields: monitoringv1.WebConfigFileFields{
+					TLSConfig: &monitoringv1.WebTLSConfig{
+						KeySecret: v1.SecretKeySelector{
+							LocalObjectReference: v1.LocalObjectReference{Name: "web-tls"},
+							Key:                  "tls.key",
+						},
+						Cert: monitoringv1.SecretOrConfigMap{
+							Secret: &v1.SecretKeySelector{
+								LocalObjectReference: v1.LocalObjectReference{Name: "web-tls"},
+								Key:                  "tls.crt",
+							},
+						},
+					},
+				},
+			},
+		},
+	}, defaultTestConfig, "")
+	require.NoError(t, err)
+
+	containers := sset.Spec.Template.Spec.Containers
+	require.Contains(t, containers[0].Args, "--web.config.file=/etc/alertmanager/web_config/web-config.yaml")
+	require.Equal(t, v1.URISchemeHTTPS, containers[0].ReadinessProbe.HTTPGet.Scheme)
+	require.Equal(t, v1.URISchemeHTTPS, containers[0].LivenessProbe.HTTPGet.Scheme)
+	require.Equal(t, "https", sset.Spec.Template.Annotations["operator.prometheus.io/web-scheme"])
+
+	require.Equal(t, "config-reloader", containers[1].Name)
+	require.Contains(t, containers[1].Args, "--reload-url=https://:9093/-/reload")
+	require.Contains(t, containers[1].Args, "--reload-tls-insecure-skip-verify")
+}
+
+func TestWebBasicAuth(t *testing.T) {
+	password := v1.SecretKeySelector{
+		LocalObjectReference: v1.LocalObjectReference{Name: "web-users"},
+		Key:                  "admin",
+	}
+	sset, err := makeStatefulSet(&monitoringv1.Alertmanager{
+		Spec: monitoringv1.AlertmanagerSpec{
+			Web: &monitoringv1.AlertmanagerWebSpec{
+				WebConfigFileFields: monitoringv1.WebConfigFileFields{
+					BasicAuthUsers: []monitoringv1.WebBasicAuthUser{
+						{Username: "admin", Password: password},
+					},
+				},
+			},
+		},
+	}, defaultTestConfig, "")
+	require.NoError(t, err)
+
+	containers := sset.Spec.Template.Spec.Containers
+	require.NotNil(t, containers[0].ReadinessProbe.Exec)
+	require.NotNil(t, containers[0].LivenessProbe.Exec)
+	require.Contains(t, containers[0].Env, v1.EnvVar{Name: "WEB_BASIC_AUTH_USERNAME", Value: "admin"})
+	require.NotContains(t, sset.Spec.Template.Annotations, "operator.prometheus.io/web-scheme")
+
+	require.Contains(t, containers[1].Env, v1.EnvVar{Name: "RELOAD_BASIC_AUTH_USERNAME", Value: "admin"})
+	require.Contains(t, containers[1].Env, v1.EnvVar{
+		Name:      "RELOAD_BASIC_AUTH_PASSWORD",
+		ValueFrom: &v1.EnvVarSource{SecretKeyRef: &password},
+	})
+}
+
 // below Alertmanager v0.13.0 all flags are with single dash.
 func TestMakeStatefulSetSpecSingleDoubleDashedArgs(t *testing.T) {
 	tests := []struct {
@@ -99,32 +99,36 @@ type receiver struct {
 type webhookConfig struct {
 	VSendResolved *bool             `yaml:"send_resolved,omitempty" json:"send_resolved,omitempty"`
 	URL           string            `yaml:"url,omitempty" json:"url,omitempty"`
+	URLFile       string            `yaml:"url_file,omitempty" json:"url_file,omitempty"`
 	HTTPConfig    *httpClientConfig `yaml:"http_config,omitempty" json:"http_config,omitempty"`
 	MaxAlerts     int32             `yaml:"max_alerts,omitempty" json:"max_alerts,omitempty"`
 }
 
 type pagerdutyConfig struct {
-	VSendResolved *bool             `yaml:"send_resolved,omitempty" json:"send_resolved,omitempty"`
-	HTTPConfig    *httpClientConfig `yaml:"http_config,omitempty" json:"http_config,omitempty"`
-	ServiceKey    string            `yaml:"service_key,omitempty" json:"service_key,omitempty"`
-	RoutingKey    string            `yaml:"routing_key,omitempty" json:"routing_key,omitempty"`
-	URL           string            `yaml:"url,omitempty" json:"url,omitempty"`
-	Client        string            `yaml:"client,omitempty" json:"client,omitempty"`
-	ClientURL     string            `yaml:"client_url,omitempty" json:"client_url,omitempty"`
-	Description   string            `yaml:"description,omitempty" json:"description,omitempty"`
-	Details       map[string]string `yaml:"details,omitempty" json:"details,omitempty"`
-	Images        []pagerdutyImage  `yaml:"images,omitempty" json:"images,omitempty"`
-	Links         []pagerdutyLink   `yaml:"links,omitempty" json:"links,omitempty"`
-	Severity      string            `yaml:"severity,omitempty" json:"severity,omitempty"`
-	Class         string            `yaml:"class,omitempty" json:"class,omitempty"`
-	Component     string            `yaml:"component,omitempty" json:"component,omitempty"`
-	Group         string            `yaml:"group,omitempty" json:"group,omitempty"`
+	VSendResolved  *bool             `yaml:"send_resolved,omitempty" json:"send_resolved,omitempty"`
+	HTTPConfig     *httpClientConfig `yaml:"http_config,omitempty" json:"http_config,omitempty"`
+	ServiceKey     string            `yaml:"service_key,omitempty" json:"service_key,omitempty"`
+	ServiceKeyFile string            `yaml:"service_key_file,omitempty" json:"service_key_file,omitempty"`
+	RoutingKey     string            `yaml:"routing_key,omitempty" json:"routing_key,omitempty"`
+	RoutingKeyFile string            `yaml:"routing_key_file,omitempty" json:"routing_key_file,omitempty"`
+	URL            string            `yaml:"url,omitempty" json:"url,omitempty"`
+	Client         string            `yaml:"client,omitempty" json:"client,omitempty"`
+	ClientURL      string            `yaml:"client_url,omitempty" json:"client_url,omitempty"`
+	Description    string            `yaml:"description,omitempty" json:"description,omitempty"`
+	Details        map[string]string `yaml:"details,omitempty" json:"details,omitempty"`
+	Images         []pagerdutyImage  `yaml:"images,omitempty" json:"images,omitempty"`
+	Links          []pagerdutyLink   `yaml:"links,omitempty" json:"links,omitempty"`
+	Severity       string            `yaml:"severity,omitempty" json:"severity,omitempty"`
+	Class          string            `yaml:"class,omitempty" json:"class,omitempty"`
+	Component      string            `yaml:"component,omitempty" json:"component,omitempty"`
+	Group          string            `yaml:"group,omitempty" json:"group,omitempty"`
 }
 
 type opsgenieConfig struct {
 	VSendResolved *bool               `yaml:"send_resolved,omitempty" json:"send_resolved,omitempty"`
 	HTTPConfig    *httpClientConfig   `yaml:"http_config,omitempty" json:"http_config,omitempty"`
 	APIKey        string              `yaml:"api_key,omitempty" json:"api_key,omitempty"`
+	APIKeyFile    string              `yaml:"api_key_file,omitempty" json:"api_key_file,omitempty"`
 	APIURL        string              `yaml:"api_url,omitempty" json:"api_url,omitempty"`
 	Message       string              `yaml:"message,omitempty" json:"message,omitempty"`
 	Description   string              `yaml:"description,omitempty" json:"description,omitempty"`
@@ -154,6 +158,7 @@ type slackConfig struct {
 	VSendResolved *bool             `yaml:"send_resolved,omitempty" json:"send_resolved,omitempty"`
 	HTTPConfig    *httpClientConfig `yaml:"http_config,omitempty" json:"http_config,omitempty"`
 	APIURL        string            `yaml:"api_url,omitempty" json:"api_url,omitempty"`
+	APIURLFile    string            `yaml:"api_url_file,omitempty" json:"api_url_file,omitempty"`
 	Channel       string            `yaml:"channel,omitempty" json:"channel,omitempty"`
 	Username      string            `yaml:"username,omitempty" json:"username,omitempty"`
 	Color         string            `yaml:"color,omitempty" json:"color,omitempty"`
@@ -289,6 +294,7 @@ type victorOpsConfig struct {
 	VSendResolved     *bool             `yaml:"send_resolved,omitempty" json:"send_resolved,omitempty"`
 	HTTPConfig        *httpClientConfig `yaml:"http_config,omitempty" json:"http_config,omitempty"`
 	APIKey            string            `yaml:"api_key,omitempty" json:"api_key,omitempty"`
+	APIKeyFile        string            `yaml:"api_key_file,omitempty" json:"api_key_file,omitempty"`
 	APIURL            string            `yaml:"api_url,omitempty" json:"api_url,omitempty"`
 	RoutingKey        string            `yaml:"routing_key,omitempty" json:"routing_key,omitempty"`
 	MessageType       string            `yaml:"message_type,omitempty" json:"message_type,omitempty"`
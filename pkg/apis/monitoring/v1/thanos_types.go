@@ -82,6 +82,37 @@ type ThanosRulerSpec struct {
 	Tolerations []v1.Toleration `json:"tolerations,omitempty"`
 	// If specified, the pod's topology spread constraints.
 	TopologySpreadConstraints []v1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+	// Optional list of hosts and IPs that will be injected into the pods'
+	// hosts file.
+	HostAliases []v1.HostAlias `json:"hostAliases,omitempty"`
+	// DNS policy of the pods. Defaults to ClusterFirstWithHostNet when
+	// hostNetwork is enabled, to the Kubernetes default otherwise.
+	DNSPolicy v1.DNSPolicy `json:"dnsPolicy,omitempty"`
+	// DNS parameters of the pods, merged with the configuration generated
+	// from the DNS policy.
+	DNSConfig *v1.PodDNSConfig `json:"dnsConfig,omitempty"`
+	// Name of the RuntimeClass object used to run the pods.
+	RuntimeClassName *string `json:"runtimeClassName,omitempty"`
+	// AutomountServiceAccountToken indicates whether a service account token
+	// should be automatically mounted in the pods.
+	AutomountServiceAccountToken *bool `json:"automountServiceAccountToken,omitempty"`
+	// Use the host's network namespace for the pods.
+	// When using hostNetwork, make sure that the ports don't conflict with
+	// other workloads running on the nodes.
+	HostNetwork bool `json:"hostNetwork,omitempty"`
+	// Name of the scheduler dispatching the pods. Defaults to the default
+	// scheduler.
+	SchedulerName string `json:"schedulerName,omitempty"`
+	// Additional conditions evaluated for the readiness of the pods.
+	ReadinessGates []v1.PodReadinessGate `json:"readinessGates,omitempty"`
+	// PodTemplateOverride is applied to the pod template generated by the
+	// operator as the last step, after the `containers` and
+	// `initContainers` overrides. It allows changing fields which aren't
+	// exposed by the resource. Overriding the pod template is entirely
+	// outside the scope of what the maintainers will support and by doing
+	// so, you accept that this behaviour may break at any time without
+	// notice.
+	PodTemplateOverride *PodTemplateOverride `json:"podTemplateOverride,omitempty"`
 	// SecurityContext holds pod-level security attributes and common container settings.
 	// This defaults to the default PodSecurityContext.
 	SecurityContext *v1.PodSecurityContext `json:"securityContext,omitempty"`
@@ -104,6 +135,9 @@ type ThanosRulerSpec struct {
 	// ListenLocal makes the Thanos ruler listen on loopback, so that it
 	// does not bind against the Pod IP.
 	ListenLocal bool `json:"listenLocal,omitempty"`
+	// Defines the configuration of the Thanos ruler web server.
+	// Only valid in Thanos versions 0.24.0 and newer.
+	Web *ThanosRulerWebSpec `json:"web,omitempty"`
 	// QueryEndpoints defines Thanos querier endpoints from which to query metrics.
 	// Maps to the --query flag of thanos ruler.
 	QueryEndpoints []string `json:"queryEndpoints,omitempty"`
@@ -120,6 +154,16 @@ type ThanosRulerSpec struct {
 	// Define configuration for connecting to alertmanager.  Only available with thanos v0.10.0
 	// and higher.  Maps to the `alertmanagers.config` arg.
 	AlertManagersConfig *v1.SecretKeySelector `json:"alertmanagersConfig,omitempty"`
+	// Alertmanager resources to be selected for Thanos Ruler to send alerts
+	// to. The URLs of the selected Alertmanager pods are appended to
+	// `alertmanagersUrl`. It can't be used with `alertmanagersConfig`. If nil,
+	// no Alertmanager resource is selected. The Alertmanagers listening on
+	// the loopback interface, serving their API over HTTPS or requiring
+	// basic authentication are skipped.
+	AlertmanagerSelector *metav1.LabelSelector `json:"alertmanagerSelector,omitempty"`
+	// Namespaces to be selected for Alertmanager discovery. If nil, only
+	// check own namespace.
+	AlertmanagerNamespaceSelector *metav1.LabelSelector `json:"alertmanagerNamespaceSelector,omitempty"`
 	// A label selector to select which PrometheusRules to mount for alerting and
 	// recording.
 	RuleSelector *metav1.LabelSelector `json:"ruleSelector,omitempty"`
@@ -187,6 +231,12 @@ type ThanosRulerSpec struct {
 	AlertQueryURL string `json:"alertQueryUrl,omitempty"`
 }
 
+// ThanosRulerWebSpec defines the configuration of the Thanos ruler web server.
+// +k8s:openapi-gen=true
+type ThanosRulerWebSpec struct {
+	WebConfigFileFields `json:",inline"`
+}
+
 // ThanosRulerStatus is the most recent observed status of the ThanosRuler. Read-only. Not
 // included when requesting from the apiserver, only from the Prometheus
 // Operator API itself. More info:
@@ -83,10 +83,10 @@ type PrometheusList struct {
 	Items []*Prometheus `json:"items"`
 }
 
-// PrometheusSpec is a specification of the desired behavior of the Prometheus cluster. More info:
-// https://github.com/kubernetes/community/blob/master/contributors/devel/sig-architecture/api-conventions.md#spec-and-status
+// CommonPrometheusFields are the options available to both the Prometheus
+// server and agent.
 // +k8s:openapi-gen=true
-type PrometheusSpec struct {
+type CommonPrometheusFields struct {
 	// PodMetadata configures Labels and Annotations which are propagated to the prometheus pods.
 	PodMetadata *EmbeddedObjectMetadata `json:"podMetadata,omitempty"`
 	// ServiceMonitors to be selected for target discovery. *Deprecated:* if
@@ -107,6 +107,11 @@ type PrometheusSpec struct {
 	ProbeSelector *metav1.LabelSelector `json:"probeSelector,omitempty"`
 	// *Experimental* Namespaces to be selected for Probe discovery. If nil, only check own namespace.
 	ProbeNamespaceSelector *metav1.LabelSelector `json:"probeNamespaceSelector,omitempty"`
+	// *Experimental* ScrapeConfigs to be selected for target discovery.
+	ScrapeConfigSelector *metav1.LabelSelector `json:"scrapeConfigSelector,omitempty"`
+	// Namespaces to be selected for ScrapeConfig discovery. If nil, only
+	// check own namespace.
+	ScrapeConfigNamespaceSelector *metav1.LabelSelector `json:"scrapeConfigNamespaceSelector,omitempty"`
 	// Version of Prometheus to be deployed.
 	Version string `json:"version,omitempty"`
 	// Tag of Prometheus container image to be deployed. Defaults to the value of `version`.
@@ -156,13 +161,6 @@ type PrometheusSpec struct {
 	// name. Defaults to the value of `prometheus`. External label will
 	// _not_ be added when value is set to empty string (`""`).
 	PrometheusExternalLabelName *string `json:"prometheusExternalLabelName,omitempty"`
-	// Time duration Prometheus shall retain data for. Default is '24h',
-	// and must match the regular expression `[0-9]+(ms|s|m|h|d|w|y)` (milliseconds seconds minutes hours days weeks years).
-	Retention string `json:"retention,omitempty"`
-	// Maximum amount of disk space used by blocks. Supported units: B, KB, MB, GB, TB, PB, EB. Ex: `512MB`.
-	RetentionSize string `json:"retentionSize,omitempty"`
-	// Disable prometheus compaction.
-	DisableCompaction bool `json:"disableCompaction,omitempty"`
 	// Enable compression of the write-ahead log using Snappy. This flag is
 	// only available in versions of Prometheus >= 2.11.0.
 	WALCompression *bool `json:"walCompression,omitempty"`
@@ -174,20 +172,9 @@ type PrometheusSpec struct {
 	ScrapeInterval string `json:"scrapeInterval,omitempty"`
 	// Number of seconds to wait for target to respond before erroring.
 	ScrapeTimeout string `json:"scrapeTimeout,omitempty"`
-	// Interval between consecutive evaluations. Default: `1m`
-	EvaluationInterval string `json:"evaluationInterval,omitempty"`
-	// /--rules.*/ command-line arguments.
-	Rules Rules `json:"rules,omitempty"`
 	// The labels to add to any time series or alerts when communicating with
 	// external systems (federation, remote storage, Alertmanager).
 	ExternalLabels map[string]string `json:"externalLabels,omitempty"`
-	// Enable access to prometheus web admin API. Defaults to the value of `false`.
-	// WARNING: Enabling the admin APIs enables mutating endpoints, to delete data,
-	// shutdown Prometheus, and more. Enabling this should be done with care and the
-	// user is advised to add additional authentication authorization via a proxy to
-	// ensure only clients authorized to perform these actions can do so.
-	// For more information see https://prometheus.io/docs/prometheus/latest/querying/api/#tsdb-admin-apis
-	EnableAdminAPI bool `json:"enableAdminAPI,omitempty"`
 	// Enable access to Prometheus disabled features. By default, no features are enabled.
 	// Enabling disabled features is entirely outside the scope of what the maintainers will
 	// support and by doing so, you accept that this behaviour may break at any
@@ -203,8 +190,6 @@ type PrometheusSpec struct {
 	// and the actual ExternalURL is still true, but the server serves requests
 	// under a different route prefix. For example for use with `kubectl proxy`.
 	RoutePrefix string `json:"routePrefix,omitempty"`
-	// QuerySpec defines the query command line flags when starting Prometheus.
-	Query *QuerySpec `json:"query,omitempty"`
 	// Storage spec to specify how storage shall be used.
 	Storage *StorageSpec `json:"storage,omitempty"`
 	// Volumes allows configuration of additional volumes on the output StatefulSet definition. Volumes specified will
@@ -216,17 +201,6 @@ type PrometheusSpec struct {
 	VolumeMounts []v1.VolumeMount `json:"volumeMounts,omitempty"`
 	// WebSpec defines the web command line flags when starting Prometheus.
 	Web *WebSpec `json:"web,omitempty"`
-	// A selector to select which PrometheusRules to mount for loading alerting/recording
-	// rules from. Until (excluding) Prometheus Operator v0.24.0 Prometheus
-	// Operator will migrate any legacy rule ConfigMaps to PrometheusRule custom
-	// resources selected by RuleSelector. Make sure it does not match any config
-	// maps that you do not want to be migrated.
-	RuleSelector *metav1.LabelSelector `json:"ruleSelector,omitempty"`
-	// Namespaces to be selected for PrometheusRules discovery. If unspecified, only
-	// the same namespace as the Prometheus object is in is used.
-	RuleNamespaceSelector *metav1.LabelSelector `json:"ruleNamespaceSelector,omitempty"`
-	// Define details regarding alerting.
-	Alerting *AlertingSpec `json:"alerting,omitempty"`
 	// Define resources requests and limits for single Pods.
 	Resources v1.ResourceRequirements `json:"resources,omitempty"`
 	// Define which Nodes the Pods are scheduled on.
@@ -248,10 +222,39 @@ type PrometheusSpec struct {
 	Tolerations []v1.Toleration `json:"tolerations,omitempty"`
 	// If specified, the pod's topology spread constraints.
 	TopologySpreadConstraints []v1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+	// Optional list of hosts and IPs that will be injected into the pods'
+	// hosts file.
+	HostAliases []v1.HostAlias `json:"hostAliases,omitempty"`
+	// DNS policy of the pods. Defaults to ClusterFirstWithHostNet when
+	// hostNetwork is enabled, to the Kubernetes default otherwise.
+	DNSPolicy v1.DNSPolicy `json:"dnsPolicy,omitempty"`
+	// DNS parameters of the pods, merged with the configuration generated
+	// from the DNS policy.
+	DNSConfig *v1.PodDNSConfig `json:"dnsConfig,omitempty"`
+	// Name of the RuntimeClass object used to run the pods.
+	RuntimeClassName *string `json:"runtimeClassName,omitempty"`
+	// AutomountServiceAccountToken indicates whether a service account token
+	// should be automatically mounted in the pods.
+	AutomountServiceAccountToken *bool `json:"automountServiceAccountToken,omitempty"`
+	// Use the host's network namespace for the pods.
+	// When using hostNetwork, make sure that the ports don't conflict with
+	// other workloads running on the nodes.
+	HostNetwork bool `json:"hostNetwork,omitempty"`
+	// Name of the scheduler dispatching the pods. Defaults to the default
+	// scheduler.
+	SchedulerName string `json:"schedulerName,omitempty"`
+	// Additional conditions evaluated for the readiness of the pods.
+	ReadinessGates []v1.PodReadinessGate `json:"readinessGates,omitempty"`
+	// PodTemplateOverride is applied to the pod template generated by the
+	// operator as the last step, after the `containers` and
+	// `initContainers` overrides. It allows changing fields which aren't
+	// exposed by the resource. Overriding the pod template is entirely
+	// outside the scope of what the maintainers will support and by doing
+	// so, you accept that this behaviour may break at any time without
+	// notice.
+	PodTemplateOverride *PodTemplateOverride `json:"podTemplateOverride,omitempty"`
 	// If specified, the remote_write spec. This is an experimental feature, it may change in any upcoming release in a breaking way.
 	RemoteWrite []RemoteWriteSpec `json:"remoteWrite,omitempty"`
-	// If specified, the remote_read spec. This is an experimental feature, it may change in any upcoming release in a breaking way.
-	RemoteRead []RemoteReadSpec `json:"remoteRead,omitempty"`
 	// SecurityContext holds pod-level security attributes and common container settings.
 	// This defaults to the default PodSecurityContext.
 	SecurityContext *v1.PodSecurityContext `json:"securityContext,omitempty"`
@@ -291,44 +294,11 @@ type PrometheusSpec struct {
 	// notes to ensure that no incompatible scrape configs are going to break
 	// Prometheus after the upgrade.
 	AdditionalScrapeConfigs *v1.SecretKeySelector `json:"additionalScrapeConfigs,omitempty"`
-	// AdditionalAlertRelabelConfigs allows specifying a key of a Secret containing
-	// additional Prometheus alert relabel configurations. Alert relabel configurations
-	// specified are appended to the configurations generated by the Prometheus
-	// Operator. Alert relabel configurations specified must have the form as specified
-	// in the official Prometheus documentation:
-	// https://prometheus.io/docs/prometheus/latest/configuration/configuration/#alert_relabel_configs.
-	// As alert relabel configs are appended, the user is responsible to make sure it
-	// is valid. Note that using this feature may expose the possibility to
-	// break upgrades of Prometheus. It is advised to review Prometheus release
-	// notes to ensure that no incompatible alert relabel configs are going to break
-	// Prometheus after the upgrade.
-	AdditionalAlertRelabelConfigs *v1.SecretKeySelector `json:"additionalAlertRelabelConfigs,omitempty"`
-	// AdditionalAlertManagerConfigs allows specifying a key of a Secret containing
-	// additional Prometheus AlertManager configurations. AlertManager configurations
-	// specified are appended to the configurations generated by the Prometheus
-	// Operator. Job configurations specified must have the form as specified
-	// in the official Prometheus documentation:
-	// https://prometheus.io/docs/prometheus/latest/configuration/configuration/#alertmanager_config.
-	// As AlertManager configs are appended, the user is responsible to make sure it
-	// is valid. Note that using this feature may expose the possibility to
-	// break upgrades of Prometheus. It is advised to review Prometheus release
-	// notes to ensure that no incompatible AlertManager configs are going to break
-	// Prometheus after the upgrade.
-	AdditionalAlertManagerConfigs *v1.SecretKeySelector `json:"additionalAlertManagerConfigs,omitempty"`
 	// APIServerConfig allows specifying a host and auth methods to access apiserver.
 	// If left empty, Prometheus is assumed to run inside of the cluster
 	// and will discover API servers automatically and use the pod's CA certificate
 	// and bearer token file at /var/run/secrets/kubernetes.io/serviceaccount/.
 	APIServerConfig *APIServerConfig `json:"apiserverConfig,omitempty"`
-	// Thanos configuration allows configuring various aspects of a Prometheus
-	// server in a Thanos environment.
-	//
-	// This section is experimental, it may change significantly without
-	// deprecation notice in any release.
-	//
-	// This is experimental and may change significantly without backward
-	// compatibility in any release.
-	Thanos *ThanosSpec `json:"thanos,omitempty"`
 	// Priority class assigned to the Pods
 	PriorityClassName string `json:"priorityClassName,omitempty"`
 	// Port name used for the pods and governing service.
@@ -338,6 +308,32 @@ type PrometheusSpec struct {
 	// based on a service monitor can access arbitrary files on the file system
 	// of the Prometheus container e.g. bearer token files.
 	ArbitraryFSAccessThroughSMs ArbitraryFSAccessThroughSMsConfig `json:"arbitraryFSAccessThroughSMs,omitempty"`
+	// FileSystemAccess defines whether the ServiceMonitor, PodMonitor, Probe
+	// and ScrapeConfig objects selected by the Prometheus instance can
+	// reference files on the file system of the Prometheus container (e.g.
+	// bearer token files, TLS files or file service discovery).
+	// `DenyOtherNamespaces` rejects the objects referencing files unless
+	// they're in the namespace of the Prometheus resource, `Deny` rejects them
+	// in all namespaces and `Allow` accepts them. Defaults to
+	// `DenyOtherNamespaces`, or to `Deny` when
+	// `arbitraryFSAccessThroughSMs.deny` is true.
+	// +kubebuilder:validation:Enum=Allow;DenyOtherNamespaces;Deny
+	FileSystemAccess FileSystemAccessPolicy `json:"fileSystemAccess,omitempty"`
+	// ScrapeClasses defines named sets of scrape settings which ServiceMonitor,
+	// PodMonitor and Probe objects select with their `scrapeClassName` field.
+	// The settings of the class are merged into the generated scrape
+	// configurations. At most one class can be marked as the default, it
+	// applies to the objects which don't select any class.
+	ScrapeClasses []ScrapeClass `json:"scrapeClasses,omitempty"`
+	// CredentialsAsFiles mounts the bearer tokens, passwords and OAuth2 client
+	// secrets referenced by the monitoring resources as files into the
+	// Prometheus pods. The generated configuration references these files
+	// (e.g. `password_file`) instead of containing the secret values.
+	// Prometheus can't read the SigV4 keys of the remote write endpoints and
+	// the Consul token, EC2 keys and Azure client secret of the ScrapeConfig
+	// resources from files, these values are still written in the
+	// configuration and the operator logs a warning.
+	CredentialsAsFiles bool `json:"credentialsAsFiles,omitempty"`
 	// OverrideHonorLabels if set to true overrides all user configured honor_labels.
 	// If HonorLabels is set in ServiceMonitor or PodMonitor to true, this overrides honor_labels to false.
 	OverrideHonorLabels bool `json:"overrideHonorLabels,omitempty"`
@@ -357,17 +353,6 @@ type PrometheusSpec struct {
 	// Label name is this field's value.
 	// Label value is the namespace of the created object (mentioned above).
 	EnforcedNamespaceLabel string `json:"enforcedNamespaceLabel,omitempty"`
-	// PrometheusRulesExcludedFromEnforce - list of prometheus rules to be excluded from enforcing
-	// of adding namespace labels. Works only if enforcedNamespaceLabel set to true.
-	// Make sure both ruleNamespace and ruleName are set for each pair
-	PrometheusRulesExcludedFromEnforce []PrometheusRuleExcludeConfig `json:"prometheusRulesExcludedFromEnforce,omitempty"`
-	// QueryLogFile specifies the file to which PromQL queries are logged.
-	// Note that this location must be writable, and can be persisted using an attached volume.
-	// Alternatively, the location can be set to a stdout location such as `/dev/stdout` to log
-	// querie information to the default Prometheus log stream.
-	// This is only available in versions of Prometheus >= 2.16.0.
-	// For more details, see the Prometheus docs (https://prometheus.io/docs/guides/query-log/)
-	QueryLogFile string `json:"queryLogFile,omitempty"`
 	// EnforcedSampleLimit defines global limit on number of scraped samples
 	// that will be accepted. This overrides any SampleLimit set per
 	// ServiceMonitor or/and PodMonitor. It is meant to be used by admins to
@@ -375,9 +360,6 @@ type PrometheusSpec struct {
 	// the desired limit.
 	// Note that if SampleLimit is lower that value will be taken instead.
 	EnforcedSampleLimit *uint64 `json:"enforcedSampleLimit,omitempty"`
-	// AllowOverlappingBlocks enables vertical compaction and vertical query merge in Prometheus.
-	// This is still experimental in Prometheus so it may change in any upcoming release.
-	AllowOverlappingBlocks bool `json:"allowOverlappingBlocks,omitempty"`
 	// EnforcedTargetLimit defines a global limit on the number of scraped
 	// targets.  This overrides any TargetLimit set per ServiceMonitor or/and
 	// PodMonitor.  It is meant to be used by admins to enforce the TargetLimit
@@ -401,6 +383,121 @@ type PrometheusSpec struct {
 	// entire scrape will be treated as failed. 0 means no limit.
 	// Only valid in Prometheus versions 2.27.0 and newer.
 	EnforcedLabelValueLengthLimit *uint64 `json:"enforcedLabelValueLengthLimit,omitempty"`
+	// Default scrape protocol and native histogram settings of the scrape
+	// jobs. The scrape protocols apply to all the scrape jobs through the
+	// global section of the configuration, the other settings apply to the
+	// jobs generated from the ServiceMonitor, PodMonitor and Probe objects
+	// which don't define them.
+	ScrapeOptions `json:",inline"`
+}
+
+// PrometheusSpec is a specification of the desired behavior of the Prometheus cluster. More info:
+// https://github.com/kubernetes/community/blob/master/contributors/devel/sig-architecture/api-conventions.md#spec-and-status
+// +k8s:openapi-gen=true
+type PrometheusSpec struct {
+	CommonPrometheusFields `json:",inline"`
+
+	// Time duration Prometheus shall retain data for. Default is '24h',
+	// and must match the regular expression `[0-9]+(ms|s|m|h|d|w|y)` (milliseconds seconds minutes hours days weeks years).
+	Retention string `json:"retention,omitempty"`
+	// Maximum amount of disk space used by blocks. Supported units: B, KB, MB, GB, TB, PB, EB. Ex: `512MB`.
+	RetentionSize string `json:"retentionSize,omitempty"`
+	// Disable prometheus compaction.
+	DisableCompaction bool `json:"disableCompaction,omitempty"`
+	// TSDB defines the tuning settings of the Prometheus TSDB.
+	TSDB *TSDBSpec `json:"tsdb,omitempty"`
+	// Interval between consecutive evaluations. Default: `1m`
+	EvaluationInterval string `json:"evaluationInterval,omitempty"`
+	// /--rules.*/ command-line arguments.
+	Rules Rules `json:"rules,omitempty"`
+	// RuleSharding distributes the selected PrometheusRules across the
+	// shards instead of having every shard evaluate all of them. A
+	// PrometheusRule is evaluated by the shard set in its
+	// `operator.prometheus.io/shard` annotation or by the default shard.
+	// Individual rule groups can be assigned to other shards with the
+	// `operator.prometheus.io/group-shards` annotation whose value is a JSON
+	// object mapping the group names to the shards, e.g. `{"group-a": 1}`.
+	// PrometheusRules with an invalid annotation are rejected.
+	// If nil, every shard evaluates all the rules.
+	RuleSharding *RuleShardingSpec `json:"ruleSharding,omitempty"`
+	// Enable access to prometheus web admin API. Defaults to the value of `false`.
+	// WARNING: Enabling the admin APIs enables mutating endpoints, to delete data,
+	// shutdown Prometheus, and more. Enabling this should be done with care and the
+	// user is advised to add additional authentication authorization via a proxy to
+	// ensure only clients authorized to perform these actions can do so.
+	// For more information see https://prometheus.io/docs/prometheus/latest/querying/api/#tsdb-admin-apis
+	EnableAdminAPI bool `json:"enableAdminAPI,omitempty"`
+	// QuerySpec defines the query command line flags when starting Prometheus.
+	Query *QuerySpec `json:"query,omitempty"`
+	// A selector to select which PrometheusRules to mount for loading alerting/recording
+	// rules from. Until (excluding) Prometheus Operator v0.24.0 Prometheus
+	// Operator will migrate any legacy rule ConfigMaps to PrometheusRule custom
+	// resources selected by RuleSelector. Make sure it does not match any config
+	// maps that you do not want to be migrated.
+	RuleSelector *metav1.LabelSelector `json:"ruleSelector,omitempty"`
+	// Namespaces to be selected for PrometheusRules discovery. If unspecified, only
+	// the same namespace as the Prometheus object is in is used.
+	RuleNamespaceSelector *metav1.LabelSelector `json:"ruleNamespaceSelector,omitempty"`
+	// Define details regarding alerting.
+	Alerting *AlertingSpec `json:"alerting,omitempty"`
+	// If specified, the remote_read spec. This is an experimental feature, it may change in any upcoming release in a breaking way.
+	RemoteRead []RemoteReadSpec `json:"remoteRead,omitempty"`
+	// AdditionalAlertRelabelConfigs allows specifying a key of a Secret containing
+	// additional Prometheus alert relabel configurations. Alert relabel configurations
+	// specified are appended to the configurations generated by the Prometheus
+	// Operator. Alert relabel configurations specified must have the form as specified
+	// in the official Prometheus documentation:
+	// https://prometheus.io/docs/prometheus/latest/configuration/configuration/#alert_relabel_configs.
+	// As alert relabel configs are appended, the user is responsible to make sure it
+	// is valid. Note that using this feature may expose the possibility to
+	// break upgrades of Prometheus. It is advised to review Prometheus release
+	// notes to ensure that no incompatible alert relabel configs are going to break
+	// Prometheus after the upgrade.
+	AdditionalAlertRelabelConfigs *v1.SecretKeySelector `json:"additionalAlertRelabelConfigs,omitempty"`
+	// AdditionalAlertManagerConfigs allows specifying a key of a Secret containing
+	// additional Prometheus AlertManager configurations. AlertManager configurations
+	// specified are appended to the configurations generated by the Prometheus
+	// Operator. Job configurations specified must have the form as specified
+	// in the official Prometheus documentation:
+	// https://prometheus.io/docs/prometheus/latest/configuration/configuration/#alertmanager_config.
+	// As AlertManager configs are appended, the user is responsible to make sure it
+	// is valid. Note that using this feature may expose the possibility to
+	// break upgrades of Prometheus. It is advised to review Prometheus release
+	// notes to ensure that no incompatible AlertManager configs are going to break
+	// Prometheus after the upgrade.
+	AdditionalAlertManagerConfigs *v1.SecretKeySelector `json:"additionalAlertManagerConfigs,omitempty"`
+	// Thanos configuration allows configuring various aspects of a Prometheus
+	// server in a Thanos environment.
+	//
+	// This section is experimental, it may change significantly without
+	// deprecation notice in any release.
+	//
+	// This is experimental and may change significantly without backward
+	// compatibility in any release.
+	Thanos *ThanosSpec `json:"thanos,omitempty"`
+	// PrometheusRulesExcludedFromEnforce - list of prometheus rules to be excluded from enforcing
+	// of adding namespace labels. Works only if enforcedNamespaceLabel set to true.
+	// Make sure both ruleNamespace and ruleName are set for each pair
+	PrometheusRulesExcludedFromEnforce []PrometheusRuleExcludeConfig `json:"prometheusRulesExcludedFromEnforce,omitempty"`
+	// QueryTenancy injects a prom-label-proxy sidecar in front of the query
+	// API which restricts the queries to the series of a single namespace.
+	// The web port of the governing service routes to the proxy and
+	// Prometheus listens on the loopback interface only (as with
+	// `listenLocal`): the UI and the metrics of Prometheus aren't reachable
+	// from outside the pod while the Thanos sidecar keeps querying Prometheus
+	// directly.
+	// It can't be used with `web.tlsConfig` or `web.basicAuthUsers`.
+	QueryTenancy *QueryTenancySpec `json:"queryTenancy,omitempty"`
+	// QueryLogFile specifies the file to which PromQL queries are logged.
+	// Note that this location must be writable, and can be persisted using an attached volume.
+	// Alternatively, the location can be set to a stdout location such as `/dev/stdout` to log
+	// querie information to the default Prometheus log stream.
+	// This is only available in versions of Prometheus >= 2.16.0.
+	// For more details, see the Prometheus docs (https://prometheus.io/docs/guides/query-log/)
+	QueryLogFile string `json:"queryLogFile,omitempty"`
+	// AllowOverlappingBlocks enables vertical compaction and vertical query merge in Prometheus.
+	// This is still experimental in Prometheus so it may change in any upcoming release.
+	AllowOverlappingBlocks bool `json:"allowOverlappingBlocks,omitempty"`
 }
 
 // PrometheusRuleExcludeConfig enables users to configure excluded PrometheusRule names and their namespaces
@@ -412,6 +509,79 @@ type PrometheusRuleExcludeConfig struct {
 	RuleName string `json:"ruleName"`
 }
 
+// ScrapeClass defines settings shared by the scrape configurations of the
+// ServiceMonitor, PodMonitor and Probe objects selecting the class.
+// +k8s:openapi-gen=true
+type ScrapeClass struct {
+	// Name of the scrape class.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Default indicates that the scrape class applies to the objects which
+	// don't select any class. Only one scrape class can be the default.
+	Default *bool `json:"default,omitempty"`
+	// TLS configuration merged into the TLS configuration of the endpoints.
+	// The fields set by the endpoint take precedence over the ones of the
+	// scrape class.
+	// Secrets and ConfigMaps are read from the namespace of the Prometheus
+	// object.
+	TLSConfig *TLSConfig `json:"tlsConfig,omitempty"`
+	// Authorization section used when the endpoint doesn't define any
+	// authentication method. The secret is read from the namespace of the
+	// Prometheus object.
+	// Only valid in Prometheus versions 2.26.0 and newer.
+	Authorization *Authorization `json:"authorization,omitempty"`
+	// Relabelings applied to the targets after the relabelings generated by
+	// the operator and before the relabelings defined by the endpoint.
+	Relabelings []*RelabelConfig `json:"relabelings,omitempty"`
+	// MetricRelabelings applied to the samples before the metric relabelings
+	// defined by the endpoint.
+	MetricRelabelings []*RelabelConfig `json:"metricRelabelings,omitempty"`
+}
+
+// PodTemplateOverrideType is the type of patch applied to the generated pod
+// template.
+type PodTemplateOverrideType string
+
+const (
+	// StrategicMergePodTemplateOverride merges the patch into the pod
+	// template using the Kubernetes strategic merge patch semantics.
+	StrategicMergePodTemplateOverride PodTemplateOverrideType = "StrategicMerge"
+	// JSONPatchPodTemplateOverride applies the patch to the pod template as
+	// a JSON patch (RFC 6902).
+	JSONPatchPodTemplateOverride PodTemplateOverrideType = "JSONPatch"
+)
+
+// PodTemplateOverride defines a patch applied to the pod template generated
+// by the operator.
+// +k8s:openapi-gen=true
+type PodTemplateOverride struct {
+	// Type of the patch. Defaults to StrategicMerge.
+	// +kubebuilder:validation:Enum=StrategicMerge;JSONPatch
+	Type PodTemplateOverrideType `json:"type,omitempty"`
+	// Patch document in JSON or YAML format. A strategic merge patch is a
+	// partial PodTemplateSpec, a JSON patch is a list of operations on the
+	// PodTemplateSpec.
+	// +kubebuilder:validation:MinLength=1
+	Patch string `json:"patch"`
+}
+
+// FileSystemAccessPolicy defines whether the monitoring objects selected by a
+// Prometheus instance can reference files on the file system of the
+// Prometheus container.
+type FileSystemAccessPolicy string
+
+const (
+	// AllowFileSystemAccess accepts the objects referencing files in all
+	// namespaces.
+	AllowFileSystemAccess FileSystemAccessPolicy = "Allow"
+	// DenyOtherNamespacesFileSystemAccess rejects the objects referencing
+	// files unless they're in the namespace of the Prometheus resource.
+	DenyOtherNamespacesFileSystemAccess FileSystemAccessPolicy = "DenyOtherNamespaces"
+	// DenyFileSystemAccess rejects the objects referencing files in all
+	// namespaces.
+	DenyFileSystemAccess FileSystemAccessPolicy = "Deny"
+)
+
 // ArbitraryFSAccessThroughSMsConfig enables users to configure, whether
 // a service monitor selected by the Prometheus instance is allowed to use
 // arbitrary files on the file system of the Prometheus container. This is the case
@@ -450,7 +620,27 @@ type PrometheusStatus struct {
 // +k8s:openapi-gen=true
 type AlertingSpec struct {
 	// AlertmanagerEndpoints Prometheus should fire alerts against.
-	Alertmanagers []AlertmanagerEndpoints `json:"alertmanagers"`
+	// +optional
+	Alertmanagers []AlertmanagerEndpoints `json:"alertmanagers,omitempty"`
+	// Alertmanager resources to be selected for Prometheus to fire alerts
+	// against. The operator resolves the governing Service, port, scheme and
+	// path prefix of the selected Alertmanagers. If nil, no Alertmanager
+	// resource is selected. The Alertmanagers listening on the loopback
+	// interface or requiring basic authentication are skipped.
+	AlertmanagerSelector *metav1.LabelSelector `json:"alertmanagerSelector,omitempty"`
+	// Namespaces to be selected for Alertmanager discovery. If nil, only
+	// check own namespace.
+	AlertmanagerNamespaceSelector *metav1.LabelSelector `json:"alertmanagerNamespaceSelector,omitempty"`
+	// TLS Config to use for the connection to the selected Alertmanagers
+	// which serve their API over HTTPS. The Secrets and ConfigMaps are read
+	// from the namespace of the Prometheus object.
+	AlertmanagerTLSConfig *TLSConfig `json:"alertmanagerTLSConfig,omitempty"`
+	// AlertRelabelConfigs to apply to the alerts before they're sent to the
+	// Alertmanagers. They're applied after the relabeling dropping the
+	// replica label and before the relabeling configurations of the
+	// `additionalAlertRelabelConfigs` Secret.
+	// More info: https://prometheus.io/docs/prometheus/latest/configuration/configuration/#alert_relabel_configs
+	AlertRelabelConfigs []RelabelConfig `json:"alertRelabelConfigs,omitempty"`
 }
 
 // StorageSpec defines the configured storage for a group Prometheus servers.
@@ -533,6 +723,122 @@ type WebSpec struct {
 	// The prometheus web page title
 	PageTitle *string       `json:"pageTitle,omitempty"`
 	TLSConfig *WebTLSConfig `json:"tlsConfig,omitempty"`
+	// Defines the HTTP parameters for the web server.
+	HTTPConfig *WebHTTPConfig `json:"httpConfig,omitempty"`
+	// Users allowed to access the web server with basic authentication. The
+	// passwords are read from Secrets in the same namespace and hashed by the
+	// operator.
+	// The first user is used by the components running next to the web
+	// server (config-reloader, Thanos sidecar and probes).
+	// Only valid in Prometheus versions 2.24.0 and newer.
+	BasicAuthUsers []WebBasicAuthUser `json:"basicAuthUsers,omitempty"`
+}
+
+// ConfigFileFields returns the fields of the WebSpec which are written to the
+// file passed to the --web.config.file flag.
+func (w *WebSpec) ConfigFileFields() WebConfigFileFields {
+	if w == nil {
+		return WebConfigFileFields{}
+	}
+	return WebConfigFileFields{
+		TLSConfig:      w.TLSConfig,
+		HTTPConfig:     w.HTTPConfig,
+		BasicAuthUsers: w.BasicAuthUsers,
+	}
+}
+
+// WebConfigFileFields defines the content of the file passed to the
+// --web.config.file flag.
+// +k8s:openapi-gen=true
+type WebConfigFileFields struct {
+	// Defines the TLS parameters for HTTPS.
+	TLSConfig *WebTLSConfig `json:"tlsConfig,omitempty"`
+	// Defines the HTTP parameters for the web server.
+	HTTPConfig *WebHTTPConfig `json:"httpConfig,omitempty"`
+	// Users allowed to access the web server with basic authentication. The
+	// passwords are read from Secrets in the same namespace and hashed by the
+	// operator.
+	// The first user is used by the components running next to the web
+	// server (config-reloader, Thanos sidecar and probes).
+	BasicAuthUsers []WebBasicAuthUser `json:"basicAuthUsers,omitempty"`
+}
+
+// Validate semantically validates the given WebConfigFileFields section.
+func (c *WebConfigFileFields) Validate() error {
+	if c == nil {
+		return nil
+	}
+
+	if err := c.TLSConfig.Validate(); err != nil {
+		return err
+	}
+
+	users := make(map[string]struct{}, len(c.BasicAuthUsers))
+	for _, u := range c.BasicAuthUsers {
+		if u.Username == "" {
+			return fmt.Errorf("basic auth username can't be empty")
+		}
+		if u.Password.Name == "" || u.Password.Key == "" {
+			return fmt.Errorf("basic auth password of user %q must reference a secret key", u.Username)
+		}
+		if _, ok := users[u.Username]; ok {
+			return fmt.Errorf("duplicate basic auth user %q", u.Username)
+		}
+		users[u.Username] = struct{}{}
+	}
+
+	return nil
+}
+
+// WebBasicAuthUser defines a user allowed to access the web server with
+// basic authentication.
+// +k8s:openapi-gen=true
+type WebBasicAuthUser struct {
+	// The name of the user.
+	Username string `json:"username"`
+	// The secret key holding the clear-text password of the user.
+	Password v1.SecretKeySelector `json:"password"`
+}
+
+// WebHTTPConfig defines HTTP parameters for the web server.
+// +k8s:openapi-gen=true
+type WebHTTPConfig struct {
+	// Enable HTTP/2 support. Note that HTTP/2 is only supported with TLS.
+	// When TLSConfig is not configured, HTTP/2 will be disabled.
+	// Whenever the value of the field changes, a rolling update will be triggered.
+	HTTP2 *bool `json:"http2,omitempty"`
+	// List of headers that can be added to HTTP responses.
+	Headers *WebHTTPHeaders `json:"headers,omitempty"`
+}
+
+// WebHTTPHeaders defines the list of headers that can be added to HTTP
+// responses.
+// +k8s:openapi-gen=true
+type WebHTTPHeaders struct {
+	// Set the Content-Security-Policy header to HTTP responses.
+	// Unset if blank.
+	ContentSecurityPolicy string `json:"contentSecurityPolicy,omitempty"`
+	// Set the X-Frame-Options header to HTTP responses.
+	// Unset if blank. Accepted values are deny and sameorigin.
+	// https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/X-Frame-Options
+	// +kubebuilder:validation:Enum="";Deny;SameOrigin
+	XFrameOptions string `json:"xFrameOptions,omitempty"`
+	// Set the X-Content-Type-Options header to HTTP responses.
+	// Unset if blank. Accepted value is nosniff.
+	// https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/X-Content-Type-Options
+	// +kubebuilder:validation:Enum="";NoSniff
+	XContentTypeOptions string `json:"xContentTypeOptions,omitempty"`
+	// Set the X-XSS-Protection header to all responses.
+	// Unset if blank.
+	// https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/X-XSS-Protection
+	XXSSProtection string `json:"xXSSProtection,omitempty"`
+	// Set the Strict-Transport-Security header to HTTP responses.
+	// Unset if blank.
+	// Please make sure that you use this with care as this header might force
+	// browsers to load Prometheus and the other applications hosted on the same
+	// domain and subdomains over HTTPS.
+	// https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Strict-Transport-Security
+	StrictTransportSecurity string `json:"strictTransportSecurity,omitempty"`
 }
 
 // WebTLSConfig defines the TLS parameters for HTTPS.
@@ -604,6 +910,74 @@ func (c *WebTLSConfig) Validate() error {
 	return nil
 }
 
+// ScrapeProtocol represents a protocol used by Prometheus for scraping
+// metrics.
+// +kubebuilder:validation:Enum=PrometheusProto;OpenMetricsText0.0.1;OpenMetricsText1.0.0;PrometheusText0.0.4
+type ScrapeProtocol string
+
+// ScrapeOptions defines the scrape protocol negotiation and native histogram
+// settings of a scrape job.
+// +k8s:openapi-gen=true
+type ScrapeOptions struct {
+	// ScrapeProtocols defines the protocols to negotiate during a scrape, in
+	// order of preference. Only valid in Prometheus versions 2.49.0 and newer.
+	ScrapeProtocols []ScrapeProtocol `json:"scrapeProtocols,omitempty"`
+	// ScrapeClassicHistograms defines whether the classic histograms are
+	// also scraped when they are exposed as native histograms. Only valid in
+	// Prometheus versions 2.45.0 and newer.
+	ScrapeClassicHistograms *bool `json:"scrapeClassicHistograms,omitempty"`
+	// NativeHistogramBucketLimit defines the maximum number of buckets of the
+	// native histograms, the resolution of a histogram exceeding the limit is
+	// reduced. Only valid in Prometheus versions 2.45.0 and newer.
+	NativeHistogramBucketLimit *uint64 `json:"nativeHistogramBucketLimit,omitempty"`
+	// EnableCompression defines whether the scrape requests ask for a
+	// compressed response. Only valid in Prometheus versions 2.49.0 and newer.
+	EnableCompression *bool `json:"enableCompression,omitempty"`
+}
+
+// TSDBSpec defines the tuning settings of the Prometheus TSDB.
+// +k8s:openapi-gen=true
+type TSDBSpec struct {
+	// OutOfOrderTimeWindow defines how old an out-of-order sample can be
+	// compared to the latest sample of the series to be ingested. Only
+	// available in versions of Prometheus >= 2.39.0.
+	OutOfOrderTimeWindow string `json:"outOfOrderTimeWindow,omitempty"`
+	// MinBlockDuration defines the minimum duration of the blocks persisted
+	// from the head. It can't be set when the compaction is disabled.
+	MinBlockDuration string `json:"minBlockDuration,omitempty"`
+	// MaxBlockDuration defines the maximum duration of the compacted blocks.
+	// It can't be set when the compaction is disabled.
+	MaxBlockDuration string `json:"maxBlockDuration,omitempty"`
+	// HeadChunksWriteQueueSize defines the size of the queue through which
+	// the head chunks are written to the disk. Only available in versions of
+	// Prometheus >= 2.30.0.
+	HeadChunksWriteQueueSize *int32 `json:"headChunksWriteQueueSize,omitempty"`
+	// MaxExemplars defines the maximum number of exemplars stored in memory
+	// for all series. The `exemplar-storage` feature must be enabled with
+	// `enableFeatures`. Only available in versions of Prometheus >= 2.30.0.
+	MaxExemplars *int64 `json:"maxExemplars,omitempty"`
+}
+
+// QueryTenancySpec configures the prom-label-proxy sidecar which enforces a
+// namespace label on the queries.
+// +k8s:openapi-gen=true
+type QueryTenancySpec struct {
+	// Image of the prom-label-proxy container. Defaults to the image built in
+	// the operator.
+	Image *string `json:"image,omitempty"`
+	// Label enforced on the queries. Defaults to the `enforcedNamespaceLabel`
+	// of the Prometheus resource, or to `namespace`.
+	Label string `json:"label,omitempty"`
+	// Header of the requests from which the value of the enforced label is
+	// read. Defaults to `X-Namespace`. The proxy doesn't authenticate the
+	// clients: to enforce the common name of a client certificate, terminate
+	// TLS in front of the proxy (e.g. with an ingress controller) and forward
+	// the common name in this header.
+	Header string `json:"header,omitempty"`
+	// Resources defines the resource requirements for the proxy container.
+	Resources v1.ResourceRequirements `json:"resources,omitempty"`
+}
+
 // ThanosSpec defines parameters for a Prometheus server within a Thanos deployment.
 // +k8s:openapi-gen=true
 type ThanosSpec struct {
@@ -689,6 +1063,14 @@ type RemoteWriteSpec struct {
 	BearerTokenFile string `json:"bearerTokenFile,omitempty"`
 	// Authorization section for remote write
 	Authorization *Authorization `json:"authorization,omitempty"`
+	// Sigv4 configures AWS Signature Version 4 for the URL.
+	// Only valid in Prometheus versions 2.26.0 and newer.
+	// It is mutually exclusive with the other authentication methods.
+	Sigv4 *Sigv4 `json:"sigv4,omitempty"`
+	// AzureAD configures Azure AD authentication for the URL.
+	// Only valid in Prometheus versions 2.45.0 and newer.
+	// It is mutually exclusive with the other authentication methods.
+	AzureAD *AzureAD `json:"azureAd,omitempty"`
 	// TLS Config to use for remote write.
 	TLSConfig *TLSConfig `json:"tlsConfig,omitempty"`
 	// Optional ProxyURL
@@ -699,6 +1081,43 @@ type RemoteWriteSpec struct {
 	MetadataConfig *MetadataConfig `json:"metadataConfig,omitempty"`
 }
 
+// Sigv4 configures AWS Signature Version 4 for the remote write requests.
+// +k8s:openapi-gen=true
+type Sigv4 struct {
+	// Region is the AWS region. If blank, the region from the default
+	// credentials chain is used.
+	Region string `json:"region,omitempty"`
+	// AccessKey is the AWS API key. If not specified, the environment
+	// variable `AWS_ACCESS_KEY_ID` is used. The value is written in the
+	// Prometheus configuration even when credentialsAsFiles is enabled.
+	AccessKey *v1.SecretKeySelector `json:"accessKey,omitempty"`
+	// SecretKey is the AWS API secret. If not specified, the environment
+	// variable `AWS_SECRET_ACCESS_KEY` is used. The value is written in the
+	// Prometheus configuration even when credentialsAsFiles is enabled.
+	SecretKey *v1.SecretKeySelector `json:"secretKey,omitempty"`
+	// Profile is the named AWS profile used to authenticate.
+	Profile string `json:"profile,omitempty"`
+	// RoleArn is the Amazon Resource Name of the role used to authenticate.
+	RoleArn string `json:"roleArn,omitempty"`
+}
+
+// AzureAD configures Azure AD authentication for the remote write requests.
+// +k8s:openapi-gen=true
+type AzureAD struct {
+	// The Azure Cloud. Options are 'AzurePublic', 'AzureChina', or 'AzureGovernment'.
+	// +kubebuilder:validation:Enum=AzureChina;AzureGovernment;AzurePublic
+	Cloud *string `json:"cloud,omitempty"`
+	// ManagedIdentity defines the Azure User-assigned Managed identity.
+	ManagedIdentity ManagedIdentity `json:"managedIdentity"`
+}
+
+// ManagedIdentity defines the Azure User-assigned Managed identity.
+// +k8s:openapi-gen=true
+type ManagedIdentity struct {
+	// The client id
+	ClientID string `json:"clientId"`
+}
+
 // QueueConfig allows the tuning of remote_write queue_config parameters. This object
 // is referenced in the RemoteWriteSpec object.
 // +k8s:openapi-gen=true
@@ -824,6 +1243,10 @@ type AlertmanagerEndpoints struct {
 	APIVersion string `json:"apiVersion,omitempty"`
 	// Timeout is a per-target Alertmanager timeout when pushing alerts.
 	Timeout *string `json:"timeout,omitempty"`
+	// AlertRelabelConfigs to apply to the alerts sent to this Alertmanager,
+	// after the global alert relabeling. Only valid in Prometheus versions
+	// 2.51.0 and newer.
+	AlertRelabelConfigs []RelabelConfig `json:"alertRelabelConfigs,omitempty"`
 }
 
 // ServiceMonitor defines monitoring for a set of services.
@@ -870,6 +1293,9 @@ type ServiceMonitorSpec struct {
 	// Per-scrape limit on length of labels value that will be accepted for a sample.
 	// Only valid in Prometheus versions 2.27.0 and newer.
 	LabelValueLengthLimit uint64 `json:"labelValueLengthLimit,omitempty"`
+	// The scrape class to apply. When not set, the default scrape class of
+	// the Prometheus resource (if any) is applied.
+	ScrapeClassName *string `json:"scrapeClassName,omitempty"`
 }
 
 // Endpoint defines a scrapeable endpoint serving Prometheus metrics.
@@ -917,6 +1343,8 @@ type Endpoint struct {
 	RelabelConfigs []*RelabelConfig `json:"relabelings,omitempty"`
 	// ProxyURL eg http://proxyserver:2195 Directs scrapes to proxy through this endpoint.
 	ProxyURL *string `json:"proxyUrl,omitempty"`
+	// Scrape protocol and native histogram settings of the endpoint.
+	ScrapeOptions `json:",inline"`
 }
 
 // PodMonitor defines monitoring for a set of pods.
@@ -956,6 +1384,9 @@ type PodMonitorSpec struct {
 	// Per-scrape limit on length of labels value that will be accepted for a sample.
 	// Only valid in Prometheus versions 2.27.0 and newer.
 	LabelValueLengthLimit uint64 `json:"labelValueLengthLimit,omitempty"`
+	// The scrape class to apply. When not set, the default scrape class of
+	// the Prometheus resource (if any) is applied.
+	ScrapeClassName *string `json:"scrapeClassName,omitempty"`
 }
 
 // PodMetricsEndpoint defines a scrapeable endpoint of a Kubernetes Pod serving Prometheus metrics.
@@ -1001,6 +1432,8 @@ type PodMetricsEndpoint struct {
 	RelabelConfigs []*RelabelConfig `json:"relabelings,omitempty"`
 	// ProxyURL eg http://proxyserver:2195 Directs scrapes to proxy through this endpoint.
 	ProxyURL *string `json:"proxyUrl,omitempty"`
+	// Scrape protocol and native histogram settings of the endpoint.
+	ScrapeOptions `json:",inline"`
 }
 
 // PodMetricsEndpointTLSConfig specifies TLS configuration parameters.
@@ -1026,7 +1459,7 @@ type ProbeSpec struct {
 	// The job name assigned to scraped metrics by default.
 	JobName string `json:"jobName,omitempty"`
 	// Specification for the prober to use for probing targets.
-	// The prober.URL parameter is required. Targets cannot be probed if left empty.
+	// Either the prober.url or the prober.name parameter is required. Targets cannot be probed if both are left empty.
 	ProberSpec ProberSpec `json:"prober,omitempty"`
 	// The module to use for probing specifying how to probe the target.
 	// Example module configuring in the blackbox exporter:
@@ -1067,6 +1500,11 @@ type ProbeSpec struct {
 	// Per-scrape limit on length of labels value that will be accepted for a sample.
 	// Only valid in Prometheus versions 2.27.0 and newer.
 	LabelValueLengthLimit uint64 `json:"labelValueLengthLimit,omitempty"`
+	// The scrape class to apply. When not set, the default scrape class of
+	// the Prometheus resource (if any) is applied.
+	ScrapeClassName *string `json:"scrapeClassName,omitempty"`
+	// Scrape protocol and native histogram settings of the probe.
+	ScrapeOptions `json:",inline"`
 }
 
 // ProbeTargets defines a set of static and dynamically discovered targets for the prober.
@@ -1077,6 +1515,12 @@ type ProbeTargets struct {
 	StaticConfig *ProbeTargetStaticConfig `json:"staticConfig,omitempty"`
 	// Ingress defines the set of dynamically discovered ingress objects which hosts are considered for probing.
 	Ingress *ProbeTargetIngress `json:"ingress,omitempty"`
+	// Service defines the set of dynamically discovered service objects which DNS names are considered for probing.
+	Service *ProbeTargetService `json:"service,omitempty"`
+	// HTTPRoute defines the set of dynamically discovered Gateway API HTTPRoute objects which hostnames and paths are considered for probing.
+	// The HTTPRoute resource of the gateway.networking.k8s.io API (v1 or v1beta1) needs to be served by the cluster.
+	// Wildcard hostnames are ignored and the HTTPRoutes without other hostnames or without path matches of type Exact or PathPrefix are skipped.
+	HTTPRoute *ProbeTargetHTTPRoute `json:"httpRoute,omitempty"`
 }
 
 // ProbeTargetStaticConfig defines the set of static targets considered for probing.
@@ -1103,11 +1547,55 @@ type ProbeTargetIngress struct {
 	RelabelConfigs []*RelabelConfig `json:"relabelingConfigs,omitempty"`
 }
 
+// ProbeTargetService defines the set of Service objects considered for probing.
+// The probed URL is built from the DNS name of the service, the port and the path.
+// +k8s:openapi-gen=true
+type ProbeTargetService struct {
+	// Select Service objects by labels.
+	Selector metav1.LabelSelector `json:"selector,omitempty"`
+	// Select Service objects by namespace.
+	NamespaceSelector NamespaceSelector `json:"namespaceSelector,omitempty"`
+	// Name of the service port to probe. All the ports of the service are probed if empty.
+	Port string `json:"port,omitempty"`
+	// Scheme of the probed URL.
+	// Defaults to `http`.
+	// +kubebuilder:validation:Enum=http;https
+	Scheme string `json:"scheme,omitempty"`
+	// Path of the probed URL.
+	Path string `json:"path,omitempty"`
+	// RelabelConfigs to apply to samples before ingestion.
+	// More info: https://prometheus.io/docs/prometheus/latest/configuration/configuration/#relabel_config
+	RelabelConfigs []*RelabelConfig `json:"relabelingConfigs,omitempty"`
+}
+
+// ProbeTargetHTTPRoute defines the set of Gateway API HTTPRoute objects considered for probing.
+// The probed URLs are built from the hostnames and the path matches (of type
+// `Exact` or `PathPrefix`) of the route.
+// +k8s:openapi-gen=true
+type ProbeTargetHTTPRoute struct {
+	// Select HTTPRoute objects by labels.
+	Selector metav1.LabelSelector `json:"selector,omitempty"`
+	// Select HTTPRoute objects by namespace.
+	NamespaceSelector NamespaceSelector `json:"namespaceSelector,omitempty"`
+	// Scheme of the probed URLs.
+	// Defaults to `http`.
+	// +kubebuilder:validation:Enum=http;https
+	Scheme string `json:"scheme,omitempty"`
+	// RelabelConfigs to apply to samples before ingestion.
+	// More info: https://prometheus.io/docs/prometheus/latest/configuration/configuration/#relabel_config
+	RelabelConfigs []*RelabelConfig `json:"relabelingConfigs,omitempty"`
+}
+
 // ProberSpec contains specification parameters for the Prober used for probing.
 // +k8s:openapi-gen=true
 type ProberSpec struct {
-	// Mandatory URL of the prober.
-	URL string `json:"url"`
+	// URL of the prober. It can't be set together with `name`.
+	URL string `json:"url,omitempty"`
+	// Name of the Prober object, in the same namespace as the Probe, which
+	// deploys the prober. The URL is inferred from the Prober object.
+	// The Probe is rejected if the Prober doesn't exist.
+	// It can't be set together with `url`.
+	Name string `json:"name,omitempty"`
 	// HTTP scheme to use for scraping.
 	// Defaults to `http`.
 	Scheme string `json:"scheme,omitempty"`
@@ -1499,6 +1987,43 @@ type AlertmanagerSpec struct {
 	Tolerations []v1.Toleration `json:"tolerations,omitempty"`
 	// If specified, the pod's topology spread constraints.
 	TopologySpreadConstraints []v1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+	// Optional list of hosts and IPs that will be injected into the pods'
+	// hosts file.
+	HostAliases []v1.HostAlias `json:"hostAliases,omitempty"`
+	// DNS policy of the pods. Defaults to ClusterFirstWithHostNet when
+	// hostNetwork is enabled, to the Kubernetes default otherwise.
+	DNSPolicy v1.DNSPolicy `json:"dnsPolicy,omitempty"`
+	// DNS parameters of the pods, merged with the configuration generated
+	// from the DNS policy.
+	DNSConfig *v1.PodDNSConfig `json:"dnsConfig,omitempty"`
+	// Name of the RuntimeClass object used to run the pods.
+	RuntimeClassName *string `json:"runtimeClassName,omitempty"`
+	// AutomountServiceAccountToken indicates whether a service account token
+	// should be automatically mounted in the pods.
+	AutomountServiceAccountToken *bool `json:"automountServiceAccountToken,omitempty"`
+	// Use the host's network namespace for the pods.
+	// When using hostNetwork, make sure that the ports don't conflict with
+	// other workloads running on the nodes.
+	HostNetwork bool `json:"hostNetwork,omitempty"`
+	// Name of the scheduler dispatching the pods. Defaults to the default
+	// scheduler.
+	SchedulerName string `json:"schedulerName,omitempty"`
+	// Additional conditions evaluated for the readiness of the pods.
+	ReadinessGates []v1.PodReadinessGate `json:"readinessGates,omitempty"`
+	// PodTemplateOverride is applied to the pod template generated by the
+	// operator as the last step, after the `containers` and
+	// `initContainers` overrides. It allows changing fields which aren't
+	// exposed by the resource. Overriding the pod template is entirely
+	// outside the scope of what the maintainers will support and by doing
+	// so, you accept that this behaviour may break at any time without
+	// notice.
+	PodTemplateOverride *PodTemplateOverride `json:"podTemplateOverride,omitempty"`
+	// CredentialsAsFiles mounts the receiver credentials referenced by the
+	// AlertmanagerConfig resources as files into the Alertmanager pods. The
+	// generated configuration references these files (e.g. `api_key_file`)
+	// instead of containing the secret values when the Alertmanager version
+	// supports it.
+	CredentialsAsFiles bool `json:"credentialsAsFiles,omitempty"`
 	// SecurityContext holds pod-level security attributes and common container settings.
 	// This defaults to the default PodSecurityContext.
 	SecurityContext *v1.PodSecurityContext `json:"securityContext,omitempty"`
@@ -1550,6 +2075,15 @@ type AlertmanagerSpec struct {
 	// Namespaces to be selected for AlertmanagerConfig discovery. If nil, only
 	// check own namespace.
 	AlertmanagerConfigNamespaceSelector *metav1.LabelSelector `json:"alertmanagerConfigNamespaceSelector,omitempty"`
+	// Defines the web command line flags when starting Alertmanager.
+	// Only valid in Alertmanager versions 0.22.0 and newer.
+	Web *AlertmanagerWebSpec `json:"web,omitempty"`
+}
+
+// AlertmanagerWebSpec defines the web command line flags when starting Alertmanager.
+// +k8s:openapi-gen=true
+type AlertmanagerWebSpec struct {
+	WebConfigFileFields `json:",inline"`
 }
 
 // AlertmanagerList is a list of Alertmanagers.
@@ -1609,6 +2143,18 @@ type NamespaceSelector struct {
 	// implementation to support label selections.
 }
 
+// RuleShardingSpec defines how the PrometheusRules are assigned to the
+// shards of a Prometheus deployment.
+// +k8s:openapi-gen=true
+type RuleShardingSpec struct {
+	// DefaultShard is the shard evaluating the PrometheusRules which don't
+	// have the `operator.prometheus.io/shard` annotation. It must be lower
+	// than the number of shards, the reconciliation fails otherwise.
+	// Defaults to 0.
+	// +kubebuilder:validation:Minimum=0
+	DefaultShard int32 `json:"defaultShard,omitempty"`
+}
+
 // /--rules.*/ command-line arguments
 // +k8s:openapi-gen=true
 type Rules struct {
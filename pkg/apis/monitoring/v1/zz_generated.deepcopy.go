@@ -64,6 +64,28 @@ func (in *AlertingSpec) DeepCopyInto(out *AlertingSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.AlertmanagerSelector != nil {
+		in, out := &in.AlertmanagerSelector, &out.AlertmanagerSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AlertmanagerNamespaceSelector != nil {
+		in, out := &in.AlertmanagerNamespaceSelector, &out.AlertmanagerNamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AlertmanagerTLSConfig != nil {
+		in, out := &in.AlertmanagerTLSConfig, &out.AlertmanagerTLSConfig
+		*out = new(TLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AlertRelabelConfigs != nil {
+		in, out := &in.AlertRelabelConfigs, &out.AlertRelabelConfigs
+		*out = make([]RelabelConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertingSpec.
@@ -118,6 +140,13 @@ func (in *AlertmanagerEndpoints) DeepCopyInto(out *AlertmanagerEndpoints) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.AlertRelabelConfigs != nil {
+		in, out := &in.AlertRelabelConfigs, &out.AlertRelabelConfigs
+		*out = make([]RelabelConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertmanagerEndpoints.
@@ -233,6 +262,38 @@ func (in *AlertmanagerSpec) DeepCopyInto(out *AlertmanagerSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.HostAliases != nil {
+		in, out := &in.HostAliases, &out.HostAliases
+		*out = make([]corev1.HostAlias, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		*out = new(corev1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RuntimeClassName != nil {
+		in, out := &in.RuntimeClassName, &out.RuntimeClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.AutomountServiceAccountToken != nil {
+		in, out := &in.AutomountServiceAccountToken, &out.AutomountServiceAccountToken
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ReadinessGates != nil {
+		in, out := &in.ReadinessGates, &out.ReadinessGates
+		*out = make([]corev1.PodReadinessGate, len(*in))
+		copy(*out, *in)
+	}
+	if in.PodTemplateOverride != nil {
+		in, out := &in.PodTemplateOverride, &out.PodTemplateOverride
+		*out = new(PodTemplateOverride)
+		**out = **in
+	}
 	if in.SecurityContext != nil {
 		in, out := &in.SecurityContext, &out.SecurityContext
 		*out = new(corev1.PodSecurityContext)
@@ -267,6 +328,11 @@ func (in *AlertmanagerSpec) DeepCopyInto(out *AlertmanagerSpec) {
 		*out = new(metav1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Web != nil {
+		in, out := &in.Web, &out.Web
+		*out = new(AlertmanagerWebSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertmanagerSpec.
@@ -294,6 +360,22 @@ func (in *AlertmanagerStatus) DeepCopy() *AlertmanagerStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertmanagerWebSpec) DeepCopyInto(out *AlertmanagerWebSpec) {
+	*out = *in
+	in.WebConfigFileFields.DeepCopyInto(&out.WebConfigFileFields)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertmanagerWebSpec.
+func (in *AlertmanagerWebSpec) DeepCopy() *AlertmanagerWebSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertmanagerWebSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArbitraryFSAccessThroughSMsConfig) DeepCopyInto(out *ArbitraryFSAccessThroughSMsConfig) {
 	*out = *in
@@ -341,182 +423,489 @@ func (in *AuthorizationValidationError) DeepCopy() *AuthorizationValidationError
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BasicAuth) DeepCopyInto(out *BasicAuth) {
-	*out = *in
-	in.Username.DeepCopyInto(&out.Username)
-	in.Password.DeepCopyInto(&out.Password)
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BasicAuth.
-func (in *BasicAuth) DeepCopy() *BasicAuth {
-	if in == nil {
-		return nil
-	}
-	out := new(BasicAuth)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EmbeddedObjectMetadata) DeepCopyInto(out *EmbeddedObjectMetadata) {
+func (in *AzureAD) DeepCopyInto(out *AzureAD) {
 	*out = *in
-	if in.Labels != nil {
-		in, out := &in.Labels, &out.Labels
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.Annotations != nil {
-		in, out := &in.Annotations, &out.Annotations
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.Cloud != nil {
+		in, out := &in.Cloud, &out.Cloud
+		*out = new(string)
+		**out = **in
 	}
+	out.ManagedIdentity = in.ManagedIdentity
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmbeddedObjectMetadata.
-func (in *EmbeddedObjectMetadata) DeepCopy() *EmbeddedObjectMetadata {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureAD.
+func (in *AzureAD) DeepCopy() *AzureAD {
 	if in == nil {
 		return nil
 	}
-	out := new(EmbeddedObjectMetadata)
+	out := new(AzureAD)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EmbeddedPersistentVolumeClaim) DeepCopyInto(out *EmbeddedPersistentVolumeClaim) {
+func (in *BasicAuth) DeepCopyInto(out *BasicAuth) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.EmbeddedObjectMetadata.DeepCopyInto(&out.EmbeddedObjectMetadata)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	in.Username.DeepCopyInto(&out.Username)
+	in.Password.DeepCopyInto(&out.Password)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmbeddedPersistentVolumeClaim.
-func (in *EmbeddedPersistentVolumeClaim) DeepCopy() *EmbeddedPersistentVolumeClaim {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BasicAuth.
+func (in *BasicAuth) DeepCopy() *BasicAuth {
 	if in == nil {
 		return nil
 	}
-	out := new(EmbeddedPersistentVolumeClaim)
+	out := new(BasicAuth)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Endpoint) DeepCopyInto(out *Endpoint) {
+func (in *CommonPrometheusFields) DeepCopyInto(out *CommonPrometheusFields) {
 	*out = *in
-	if in.TargetPort != nil {
-		in, out := &in.TargetPort, &out.TargetPort
-		*out = new(intstr.IntOrString)
-		**out = **in
+	if in.PodMetadata != nil {
+		in, out := &in.PodMetadata, &out.PodMetadata
+		*out = new(EmbeddedObjectMetadata)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.Params != nil {
-		in, out := &in.Params, &out.Params
-		*out = make(map[string][]string, len(*in))
-		for key, val := range *in {
-			var outVal []string
-			if val == nil {
-				(*out)[key] = nil
-			} else {
-				in, out := &val, &outVal
-				*out = make([]string, len(*in))
-				copy(*out, *in)
-			}
-			(*out)[key] = outVal
-		}
+	if in.ServiceMonitorSelector != nil {
+		in, out := &in.ServiceMonitorSelector, &out.ServiceMonitorSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.TLSConfig != nil {
-		in, out := &in.TLSConfig, &out.TLSConfig
-		*out = new(TLSConfig)
+	if in.ServiceMonitorNamespaceSelector != nil {
+		in, out := &in.ServiceMonitorNamespaceSelector, &out.ServiceMonitorNamespaceSelector
+		*out = new(metav1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
-	in.BearerTokenSecret.DeepCopyInto(&out.BearerTokenSecret)
-	if in.Authorization != nil {
-		in, out := &in.Authorization, &out.Authorization
-		*out = new(SafeAuthorization)
+	if in.PodMonitorSelector != nil {
+		in, out := &in.PodMonitorSelector, &out.PodMonitorSelector
+		*out = new(metav1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.HonorTimestamps != nil {
-		in, out := &in.HonorTimestamps, &out.HonorTimestamps
-		*out = new(bool)
-		**out = **in
+	if in.PodMonitorNamespaceSelector != nil {
+		in, out := &in.PodMonitorNamespaceSelector, &out.PodMonitorNamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.BasicAuth != nil {
-		in, out := &in.BasicAuth, &out.BasicAuth
-		*out = new(BasicAuth)
+	if in.ProbeSelector != nil {
+		in, out := &in.ProbeSelector, &out.ProbeSelector
+		*out = new(metav1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.OAuth2 != nil {
-		in, out := &in.OAuth2, &out.OAuth2
-		*out = new(OAuth2)
+	if in.ProbeNamespaceSelector != nil {
+		in, out := &in.ProbeNamespaceSelector, &out.ProbeNamespaceSelector
+		*out = new(metav1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.MetricRelabelConfigs != nil {
-		in, out := &in.MetricRelabelConfigs, &out.MetricRelabelConfigs
-		*out = make([]*RelabelConfig, len(*in))
-		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(RelabelConfig)
-				(*in).DeepCopyInto(*out)
-			}
-		}
+	if in.ScrapeConfigSelector != nil {
+		in, out := &in.ScrapeConfigSelector, &out.ScrapeConfigSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.RelabelConfigs != nil {
-		in, out := &in.RelabelConfigs, &out.RelabelConfigs
-		*out = make([]*RelabelConfig, len(*in))
-		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(RelabelConfig)
-				(*in).DeepCopyInto(*out)
-			}
-		}
+	if in.ScrapeConfigNamespaceSelector != nil {
+		in, out := &in.ScrapeConfigNamespaceSelector, &out.ScrapeConfigNamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.ProxyURL != nil {
-		in, out := &in.ProxyURL, &out.ProxyURL
+	if in.Image != nil {
+		in, out := &in.Image, &out.Image
 		*out = new(string)
 		**out = **in
 	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Endpoint.
-func (in *Endpoint) DeepCopy() *Endpoint {
-	if in == nil {
-		return nil
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
 	}
-	out := new(Endpoint)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MetadataConfig) DeepCopyInto(out *MetadataConfig) {
-	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetadataConfig.
-func (in *MetadataConfig) DeepCopy() *MetadataConfig {
-	if in == nil {
-		return nil
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
 	}
-	out := new(MetadataConfig)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NamespaceSelector) DeepCopyInto(out *NamespaceSelector) {
-	*out = *in
-	if in.MatchNames != nil {
-		in, out := &in.MatchNames, &out.MatchNames
+	if in.Shards != nil {
+		in, out := &in.Shards, &out.Shards
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ReplicaExternalLabelName != nil {
+		in, out := &in.ReplicaExternalLabelName, &out.ReplicaExternalLabelName
+		*out = new(string)
+		**out = **in
+	}
+	if in.PrometheusExternalLabelName != nil {
+		in, out := &in.PrometheusExternalLabelName, &out.PrometheusExternalLabelName
+		*out = new(string)
+		**out = **in
+	}
+	if in.WALCompression != nil {
+		in, out := &in.WALCompression, &out.WALCompression
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ExternalLabels != nil {
+		in, out := &in.ExternalLabels, &out.ExternalLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.EnableFeatures != nil {
+		in, out := &in.EnableFeatures, &out.EnableFeatures
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-}
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		*out = new(StorageSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]corev1.Volume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VolumeMounts != nil {
+		in, out := &in.VolumeMounts, &out.VolumeMounts
+		*out = make([]corev1.VolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Web != nil {
+		in, out := &in.Web, &out.Web
+		*out = new(WebSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Secrets != nil {
+		in, out := &in.Secrets, &out.Secrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ConfigMaps != nil {
+		in, out := &in.ConfigMaps, &out.ConfigMaps
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(corev1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]corev1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.HostAliases != nil {
+		in, out := &in.HostAliases, &out.HostAliases
+		*out = make([]corev1.HostAlias, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		*out = new(corev1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RuntimeClassName != nil {
+		in, out := &in.RuntimeClassName, &out.RuntimeClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.AutomountServiceAccountToken != nil {
+		in, out := &in.AutomountServiceAccountToken, &out.AutomountServiceAccountToken
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ReadinessGates != nil {
+		in, out := &in.ReadinessGates, &out.ReadinessGates
+		*out = make([]corev1.PodReadinessGate, len(*in))
+		copy(*out, *in)
+	}
+	if in.PodTemplateOverride != nil {
+		in, out := &in.PodTemplateOverride, &out.PodTemplateOverride
+		*out = new(PodTemplateOverride)
+		**out = **in
+	}
+	if in.RemoteWrite != nil {
+		in, out := &in.RemoteWrite, &out.RemoteWrite
+		*out = make([]RemoteWriteSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(corev1.PodSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Containers != nil {
+		in, out := &in.Containers, &out.Containers
+		*out = make([]corev1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.InitContainers != nil {
+		in, out := &in.InitContainers, &out.InitContainers
+		*out = make([]corev1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AdditionalScrapeConfigs != nil {
+		in, out := &in.AdditionalScrapeConfigs, &out.AdditionalScrapeConfigs
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.APIServerConfig != nil {
+		in, out := &in.APIServerConfig, &out.APIServerConfig
+		*out = new(APIServerConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	out.ArbitraryFSAccessThroughSMs = in.ArbitraryFSAccessThroughSMs
+	if in.ScrapeClasses != nil {
+		in, out := &in.ScrapeClasses, &out.ScrapeClasses
+		*out = make([]ScrapeClass, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.EnforcedSampleLimit != nil {
+		in, out := &in.EnforcedSampleLimit, &out.EnforcedSampleLimit
+		*out = new(uint64)
+		**out = **in
+	}
+	if in.EnforcedTargetLimit != nil {
+		in, out := &in.EnforcedTargetLimit, &out.EnforcedTargetLimit
+		*out = new(uint64)
+		**out = **in
+	}
+	if in.EnforcedLabelLimit != nil {
+		in, out := &in.EnforcedLabelLimit, &out.EnforcedLabelLimit
+		*out = new(uint64)
+		**out = **in
+	}
+	if in.EnforcedLabelNameLengthLimit != nil {
+		in, out := &in.EnforcedLabelNameLengthLimit, &out.EnforcedLabelNameLengthLimit
+		*out = new(uint64)
+		**out = **in
+	}
+	if in.EnforcedLabelValueLengthLimit != nil {
+		in, out := &in.EnforcedLabelValueLengthLimit, &out.EnforcedLabelValueLengthLimit
+		*out = new(uint64)
+		**out = **in
+	}
+	in.ScrapeOptions.DeepCopyInto(&out.ScrapeOptions)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommonPrometheusFields.
+func (in *CommonPrometheusFields) DeepCopy() *CommonPrometheusFields {
+	if in == nil {
+		return nil
+	}
+	out := new(CommonPrometheusFields)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmbeddedObjectMetadata) DeepCopyInto(out *EmbeddedObjectMetadata) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmbeddedObjectMetadata.
+func (in *EmbeddedObjectMetadata) DeepCopy() *EmbeddedObjectMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(EmbeddedObjectMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmbeddedPersistentVolumeClaim) DeepCopyInto(out *EmbeddedPersistentVolumeClaim) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.EmbeddedObjectMetadata.DeepCopyInto(&out.EmbeddedObjectMetadata)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmbeddedPersistentVolumeClaim.
+func (in *EmbeddedPersistentVolumeClaim) DeepCopy() *EmbeddedPersistentVolumeClaim {
+	if in == nil {
+		return nil
+	}
+	out := new(EmbeddedPersistentVolumeClaim)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Endpoint) DeepCopyInto(out *Endpoint) {
+	*out = *in
+	if in.TargetPort != nil {
+		in, out := &in.TargetPort, &out.TargetPort
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.TLSConfig != nil {
+		in, out := &in.TLSConfig, &out.TLSConfig
+		*out = new(TLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	in.BearerTokenSecret.DeepCopyInto(&out.BearerTokenSecret)
+	if in.Authorization != nil {
+		in, out := &in.Authorization, &out.Authorization
+		*out = new(SafeAuthorization)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HonorTimestamps != nil {
+		in, out := &in.HonorTimestamps, &out.HonorTimestamps
+		*out = new(bool)
+		**out = **in
+	}
+	if in.BasicAuth != nil {
+		in, out := &in.BasicAuth, &out.BasicAuth
+		*out = new(BasicAuth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OAuth2 != nil {
+		in, out := &in.OAuth2, &out.OAuth2
+		*out = new(OAuth2)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MetricRelabelConfigs != nil {
+		in, out := &in.MetricRelabelConfigs, &out.MetricRelabelConfigs
+		*out = make([]*RelabelConfig, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(RelabelConfig)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.RelabelConfigs != nil {
+		in, out := &in.RelabelConfigs, &out.RelabelConfigs
+		*out = make([]*RelabelConfig, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(RelabelConfig)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.ProxyURL != nil {
+		in, out := &in.ProxyURL, &out.ProxyURL
+		*out = new(string)
+		**out = **in
+	}
+	in.ScrapeOptions.DeepCopyInto(&out.ScrapeOptions)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Endpoint.
+func (in *Endpoint) DeepCopy() *Endpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(Endpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedIdentity) DeepCopyInto(out *ManagedIdentity) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedIdentity.
+func (in *ManagedIdentity) DeepCopy() *ManagedIdentity {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedIdentity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetadataConfig) DeepCopyInto(out *MetadataConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetadataConfig.
+func (in *MetadataConfig) DeepCopy() *MetadataConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MetadataConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceSelector) DeepCopyInto(out *NamespaceSelector) {
+	*out = *in
+	if in.MatchNames != nil {
+		in, out := &in.MatchNames, &out.MatchNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceSelector.
 func (in *NamespaceSelector) DeepCopy() *NamespaceSelector {
@@ -648,6 +1037,7 @@ func (in *PodMetricsEndpoint) DeepCopyInto(out *PodMetricsEndpoint) {
 		*out = new(string)
 		**out = **in
 	}
+	in.ScrapeOptions.DeepCopyInto(&out.ScrapeOptions)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodMetricsEndpoint.
@@ -739,6 +1129,11 @@ func (in *PodMonitorSpec) DeepCopyInto(out *PodMonitorSpec) {
 	}
 	in.Selector.DeepCopyInto(&out.Selector)
 	in.NamespaceSelector.DeepCopyInto(&out.NamespaceSelector)
+	if in.ScrapeClassName != nil {
+		in, out := &in.ScrapeClassName, &out.ScrapeClassName
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodMonitorSpec.
@@ -751,6 +1146,21 @@ func (in *PodMonitorSpec) DeepCopy() *PodMonitorSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodTemplateOverride) DeepCopyInto(out *PodTemplateOverride) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodTemplateOverride.
+func (in *PodTemplateOverride) DeepCopy() *PodTemplateOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(PodTemplateOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Probe) DeepCopyInto(out *Probe) {
 	*out = *in
@@ -834,6 +1244,12 @@ func (in *ProbeSpec) DeepCopyInto(out *ProbeSpec) {
 		*out = new(SafeAuthorization)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ScrapeClassName != nil {
+		in, out := &in.ScrapeClassName, &out.ScrapeClassName
+		*out = new(string)
+		**out = **in
+	}
+	in.ScrapeOptions.DeepCopyInto(&out.ScrapeOptions)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbeSpec.
@@ -862,6 +1278,34 @@ func (in *ProbeTLSConfig) DeepCopy() *ProbeTLSConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProbeTargetHTTPRoute) DeepCopyInto(out *ProbeTargetHTTPRoute) {
+	*out = *in
+	in.Selector.DeepCopyInto(&out.Selector)
+	in.NamespaceSelector.DeepCopyInto(&out.NamespaceSelector)
+	if in.RelabelConfigs != nil {
+		in, out := &in.RelabelConfigs, &out.RelabelConfigs
+		*out = make([]*RelabelConfig, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(RelabelConfig)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbeTargetHTTPRoute.
+func (in *ProbeTargetHTTPRoute) DeepCopy() *ProbeTargetHTTPRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(ProbeTargetHTTPRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProbeTargetIngress) DeepCopyInto(out *ProbeTargetIngress) {
 	*out = *in
@@ -890,6 +1334,34 @@ func (in *ProbeTargetIngress) DeepCopy() *ProbeTargetIngress {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProbeTargetService) DeepCopyInto(out *ProbeTargetService) {
+	*out = *in
+	in.Selector.DeepCopyInto(&out.Selector)
+	in.NamespaceSelector.DeepCopyInto(&out.NamespaceSelector)
+	if in.RelabelConfigs != nil {
+		in, out := &in.RelabelConfigs, &out.RelabelConfigs
+		*out = make([]*RelabelConfig, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(RelabelConfig)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbeTargetService.
+func (in *ProbeTargetService) DeepCopy() *ProbeTargetService {
+	if in == nil {
+		return nil
+	}
+	out := new(ProbeTargetService)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProbeTargetStaticConfig) DeepCopyInto(out *ProbeTargetStaticConfig) {
 	*out = *in
@@ -941,6 +1413,16 @@ func (in *ProbeTargets) DeepCopyInto(out *ProbeTargets) {
 		*out = new(ProbeTargetIngress)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Service != nil {
+		in, out := &in.Service, &out.Service
+		*out = new(ProbeTargetService)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HTTPRoute != nil {
+		in, out := &in.HTTPRoute, &out.HTTPRoute
+		*out = new(ProbeTargetHTTPRoute)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbeTargets.
@@ -1105,118 +1587,23 @@ func (in *PrometheusRuleSpec) DeepCopy() *PrometheusRuleSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PrometheusSpec) DeepCopyInto(out *PrometheusSpec) {
 	*out = *in
-	if in.PodMetadata != nil {
-		in, out := &in.PodMetadata, &out.PodMetadata
-		*out = new(EmbeddedObjectMetadata)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.ServiceMonitorSelector != nil {
-		in, out := &in.ServiceMonitorSelector, &out.ServiceMonitorSelector
-		*out = new(metav1.LabelSelector)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.ServiceMonitorNamespaceSelector != nil {
-		in, out := &in.ServiceMonitorNamespaceSelector, &out.ServiceMonitorNamespaceSelector
-		*out = new(metav1.LabelSelector)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.PodMonitorSelector != nil {
-		in, out := &in.PodMonitorSelector, &out.PodMonitorSelector
-		*out = new(metav1.LabelSelector)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.PodMonitorNamespaceSelector != nil {
-		in, out := &in.PodMonitorNamespaceSelector, &out.PodMonitorNamespaceSelector
-		*out = new(metav1.LabelSelector)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.ProbeSelector != nil {
-		in, out := &in.ProbeSelector, &out.ProbeSelector
-		*out = new(metav1.LabelSelector)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.ProbeNamespaceSelector != nil {
-		in, out := &in.ProbeNamespaceSelector, &out.ProbeNamespaceSelector
-		*out = new(metav1.LabelSelector)
+	in.CommonPrometheusFields.DeepCopyInto(&out.CommonPrometheusFields)
+	if in.TSDB != nil {
+		in, out := &in.TSDB, &out.TSDB
+		*out = new(TSDBSpec)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.Image != nil {
-		in, out := &in.Image, &out.Image
-		*out = new(string)
-		**out = **in
-	}
-	if in.ImagePullSecrets != nil {
-		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
-		*out = make([]corev1.LocalObjectReference, len(*in))
-		copy(*out, *in)
-	}
-	if in.Replicas != nil {
-		in, out := &in.Replicas, &out.Replicas
-		*out = new(int32)
-		**out = **in
-	}
-	if in.Shards != nil {
-		in, out := &in.Shards, &out.Shards
-		*out = new(int32)
-		**out = **in
-	}
-	if in.ReplicaExternalLabelName != nil {
-		in, out := &in.ReplicaExternalLabelName, &out.ReplicaExternalLabelName
-		*out = new(string)
-		**out = **in
-	}
-	if in.PrometheusExternalLabelName != nil {
-		in, out := &in.PrometheusExternalLabelName, &out.PrometheusExternalLabelName
-		*out = new(string)
-		**out = **in
-	}
-	if in.WALCompression != nil {
-		in, out := &in.WALCompression, &out.WALCompression
-		*out = new(bool)
-		**out = **in
-	}
 	out.Rules = in.Rules
-	if in.ExternalLabels != nil {
-		in, out := &in.ExternalLabels, &out.ExternalLabels
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.EnableFeatures != nil {
-		in, out := &in.EnableFeatures, &out.EnableFeatures
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.RuleSharding != nil {
+		in, out := &in.RuleSharding, &out.RuleSharding
+		*out = new(RuleShardingSpec)
+		**out = **in
 	}
 	if in.Query != nil {
 		in, out := &in.Query, &out.Query
 		*out = new(QuerySpec)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.Storage != nil {
-		in, out := &in.Storage, &out.Storage
-		*out = new(StorageSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Volumes != nil {
-		in, out := &in.Volumes, &out.Volumes
-		*out = make([]corev1.Volume, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.VolumeMounts != nil {
-		in, out := &in.VolumeMounts, &out.VolumeMounts
-		*out = make([]corev1.VolumeMount, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.Web != nil {
-		in, out := &in.Web, &out.Web
-		*out = new(WebSpec)
-		(*in).DeepCopyInto(*out)
-	}
 	if in.RuleSelector != nil {
 		in, out := &in.RuleSelector, &out.RuleSelector
 		*out = new(metav1.LabelSelector)
@@ -1232,50 +1619,6 @@ func (in *PrometheusSpec) DeepCopyInto(out *PrometheusSpec) {
 		*out = new(AlertingSpec)
 		(*in).DeepCopyInto(*out)
 	}
-	in.Resources.DeepCopyInto(&out.Resources)
-	if in.NodeSelector != nil {
-		in, out := &in.NodeSelector, &out.NodeSelector
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.Secrets != nil {
-		in, out := &in.Secrets, &out.Secrets
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.ConfigMaps != nil {
-		in, out := &in.ConfigMaps, &out.ConfigMaps
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.Affinity != nil {
-		in, out := &in.Affinity, &out.Affinity
-		*out = new(corev1.Affinity)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Tolerations != nil {
-		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]corev1.Toleration, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.TopologySpreadConstraints != nil {
-		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
-		*out = make([]corev1.TopologySpreadConstraint, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.RemoteWrite != nil {
-		in, out := &in.RemoteWrite, &out.RemoteWrite
-		*out = make([]RemoteWriteSpec, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 	if in.RemoteRead != nil {
 		in, out := &in.RemoteRead, &out.RemoteRead
 		*out = make([]RemoteReadSpec, len(*in))
@@ -1283,30 +1626,6 @@ func (in *PrometheusSpec) DeepCopyInto(out *PrometheusSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.SecurityContext != nil {
-		in, out := &in.SecurityContext, &out.SecurityContext
-		*out = new(corev1.PodSecurityContext)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Containers != nil {
-		in, out := &in.Containers, &out.Containers
-		*out = make([]corev1.Container, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.InitContainers != nil {
-		in, out := &in.InitContainers, &out.InitContainers
-		*out = make([]corev1.Container, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.AdditionalScrapeConfigs != nil {
-		in, out := &in.AdditionalScrapeConfigs, &out.AdditionalScrapeConfigs
-		*out = new(corev1.SecretKeySelector)
-		(*in).DeepCopyInto(*out)
-	}
 	if in.AdditionalAlertRelabelConfigs != nil {
 		in, out := &in.AdditionalAlertRelabelConfigs, &out.AdditionalAlertRelabelConfigs
 		*out = new(corev1.SecretKeySelector)
@@ -1317,46 +1636,20 @@ func (in *PrometheusSpec) DeepCopyInto(out *PrometheusSpec) {
 		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.APIServerConfig != nil {
-		in, out := &in.APIServerConfig, &out.APIServerConfig
-		*out = new(APIServerConfig)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Thanos != nil {
-		in, out := &in.Thanos, &out.Thanos
-		*out = new(ThanosSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	out.ArbitraryFSAccessThroughSMs = in.ArbitraryFSAccessThroughSMs
-	if in.PrometheusRulesExcludedFromEnforce != nil {
-		in, out := &in.PrometheusRulesExcludedFromEnforce, &out.PrometheusRulesExcludedFromEnforce
-		*out = make([]PrometheusRuleExcludeConfig, len(*in))
-		copy(*out, *in)
-	}
-	if in.EnforcedSampleLimit != nil {
-		in, out := &in.EnforcedSampleLimit, &out.EnforcedSampleLimit
-		*out = new(uint64)
-		**out = **in
-	}
-	if in.EnforcedTargetLimit != nil {
-		in, out := &in.EnforcedTargetLimit, &out.EnforcedTargetLimit
-		*out = new(uint64)
-		**out = **in
-	}
-	if in.EnforcedLabelLimit != nil {
-		in, out := &in.EnforcedLabelLimit, &out.EnforcedLabelLimit
-		*out = new(uint64)
-		**out = **in
-	}
-	if in.EnforcedLabelNameLengthLimit != nil {
-		in, out := &in.EnforcedLabelNameLengthLimit, &out.EnforcedLabelNameLengthLimit
-		*out = new(uint64)
-		**out = **in
+	if in.Thanos != nil {
+		in, out := &in.Thanos, &out.Thanos
+		*out = new(ThanosSpec)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.EnforcedLabelValueLengthLimit != nil {
-		in, out := &in.EnforcedLabelValueLengthLimit, &out.EnforcedLabelValueLengthLimit
-		*out = new(uint64)
-		**out = **in
+	if in.PrometheusRulesExcludedFromEnforce != nil {
+		in, out := &in.PrometheusRulesExcludedFromEnforce, &out.PrometheusRulesExcludedFromEnforce
+		*out = make([]PrometheusRuleExcludeConfig, len(*in))
+		copy(*out, *in)
+	}
+	if in.QueryTenancy != nil {
+		in, out := &in.QueryTenancy, &out.QueryTenancy
+		*out = new(QueryTenancySpec)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -1420,6 +1713,27 @@ func (in *QuerySpec) DeepCopy() *QuerySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QueryTenancySpec) DeepCopyInto(out *QueryTenancySpec) {
+	*out = *in
+	if in.Image != nil {
+		in, out := &in.Image, &out.Image
+		*out = new(string)
+		**out = **in
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryTenancySpec.
+func (in *QueryTenancySpec) DeepCopy() *QueryTenancySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(QueryTenancySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *QueueConfig) DeepCopyInto(out *QueueConfig) {
 	*out = *in
@@ -1534,6 +1848,16 @@ func (in *RemoteWriteSpec) DeepCopyInto(out *RemoteWriteSpec) {
 		*out = new(Authorization)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Sigv4 != nil {
+		in, out := &in.Sigv4, &out.Sigv4
+		*out = new(Sigv4)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AzureAD != nil {
+		in, out := &in.AzureAD, &out.AzureAD
+		*out = new(AzureAD)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.TLSConfig != nil {
 		in, out := &in.TLSConfig, &out.TLSConfig
 		*out = new(TLSConfig)
@@ -1613,6 +1937,21 @@ func (in *RuleGroup) DeepCopy() *RuleGroup {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuleShardingSpec) DeepCopyInto(out *RuleShardingSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuleShardingSpec.
+func (in *RuleShardingSpec) DeepCopy() *RuleShardingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RuleShardingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Rules) DeepCopyInto(out *Rules) {
 	*out = *in
@@ -1686,6 +2025,93 @@ func (in *SafeTLSConfig) DeepCopy() *SafeTLSConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScrapeClass) DeepCopyInto(out *ScrapeClass) {
+	*out = *in
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = new(bool)
+		**out = **in
+	}
+	if in.TLSConfig != nil {
+		in, out := &in.TLSConfig, &out.TLSConfig
+		*out = new(TLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Authorization != nil {
+		in, out := &in.Authorization, &out.Authorization
+		*out = new(Authorization)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Relabelings != nil {
+		in, out := &in.Relabelings, &out.Relabelings
+		*out = make([]*RelabelConfig, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(RelabelConfig)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.MetricRelabelings != nil {
+		in, out := &in.MetricRelabelings, &out.MetricRelabelings
+		*out = make([]*RelabelConfig, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(RelabelConfig)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScrapeClass.
+func (in *ScrapeClass) DeepCopy() *ScrapeClass {
+	if in == nil {
+		return nil
+	}
+	out := new(ScrapeClass)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScrapeOptions) DeepCopyInto(out *ScrapeOptions) {
+	*out = *in
+	if in.ScrapeProtocols != nil {
+		in, out := &in.ScrapeProtocols, &out.ScrapeProtocols
+		*out = make([]ScrapeProtocol, len(*in))
+		copy(*out, *in)
+	}
+	if in.ScrapeClassicHistograms != nil {
+		in, out := &in.ScrapeClassicHistograms, &out.ScrapeClassicHistograms
+		*out = new(bool)
+		**out = **in
+	}
+	if in.NativeHistogramBucketLimit != nil {
+		in, out := &in.NativeHistogramBucketLimit, &out.NativeHistogramBucketLimit
+		*out = new(uint64)
+		**out = **in
+	}
+	if in.EnableCompression != nil {
+		in, out := &in.EnableCompression, &out.EnableCompression
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScrapeOptions.
+func (in *ScrapeOptions) DeepCopy() *ScrapeOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ScrapeOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecretOrConfigMap) DeepCopyInto(out *SecretOrConfigMap) {
 	*out = *in
@@ -1794,6 +2220,11 @@ func (in *ServiceMonitorSpec) DeepCopyInto(out *ServiceMonitorSpec) {
 	}
 	in.Selector.DeepCopyInto(&out.Selector)
 	in.NamespaceSelector.DeepCopyInto(&out.NamespaceSelector)
+	if in.ScrapeClassName != nil {
+		in, out := &in.ScrapeClassName, &out.ScrapeClassName
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceMonitorSpec.
@@ -1806,6 +2237,31 @@ func (in *ServiceMonitorSpec) DeepCopy() *ServiceMonitorSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Sigv4) DeepCopyInto(out *Sigv4) {
+	*out = *in
+	if in.AccessKey != nil {
+		in, out := &in.AccessKey, &out.AccessKey
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretKey != nil {
+		in, out := &in.SecretKey, &out.SecretKey
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Sigv4.
+func (in *Sigv4) DeepCopy() *Sigv4 {
+	if in == nil {
+		return nil
+	}
+	out := new(Sigv4)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StorageSpec) DeepCopyInto(out *StorageSpec) {
 	*out = *in
@@ -1858,6 +2314,31 @@ func (in *TLSConfigValidationError) DeepCopy() *TLSConfigValidationError {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TSDBSpec) DeepCopyInto(out *TSDBSpec) {
+	*out = *in
+	if in.HeadChunksWriteQueueSize != nil {
+		in, out := &in.HeadChunksWriteQueueSize, &out.HeadChunksWriteQueueSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxExemplars != nil {
+		in, out := &in.MaxExemplars, &out.MaxExemplars
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TSDBSpec.
+func (in *TSDBSpec) DeepCopy() *TSDBSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TSDBSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ThanosRuler) DeepCopyInto(out *ThanosRuler) {
 	*out = *in
@@ -1954,6 +2435,38 @@ func (in *ThanosRulerSpec) DeepCopyInto(out *ThanosRulerSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.HostAliases != nil {
+		in, out := &in.HostAliases, &out.HostAliases
+		*out = make([]corev1.HostAlias, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		*out = new(corev1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RuntimeClassName != nil {
+		in, out := &in.RuntimeClassName, &out.RuntimeClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.AutomountServiceAccountToken != nil {
+		in, out := &in.AutomountServiceAccountToken, &out.AutomountServiceAccountToken
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ReadinessGates != nil {
+		in, out := &in.ReadinessGates, &out.ReadinessGates
+		*out = make([]corev1.PodReadinessGate, len(*in))
+		copy(*out, *in)
+	}
+	if in.PodTemplateOverride != nil {
+		in, out := &in.PodTemplateOverride, &out.PodTemplateOverride
+		*out = new(PodTemplateOverride)
+		**out = **in
+	}
 	if in.SecurityContext != nil {
 		in, out := &in.SecurityContext, &out.SecurityContext
 		*out = new(corev1.PodSecurityContext)
@@ -1981,6 +2494,11 @@ func (in *ThanosRulerSpec) DeepCopyInto(out *ThanosRulerSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Web != nil {
+		in, out := &in.Web, &out.Web
+		*out = new(ThanosRulerWebSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.QueryEndpoints != nil {
 		in, out := &in.QueryEndpoints, &out.QueryEndpoints
 		*out = make([]string, len(*in))
@@ -2001,6 +2519,16 @@ func (in *ThanosRulerSpec) DeepCopyInto(out *ThanosRulerSpec) {
 		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AlertmanagerSelector != nil {
+		in, out := &in.AlertmanagerSelector, &out.AlertmanagerSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AlertmanagerNamespaceSelector != nil {
+		in, out := &in.AlertmanagerNamespaceSelector, &out.AlertmanagerNamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.RuleSelector != nil {
 		in, out := &in.RuleSelector, &out.RuleSelector
 		*out = new(metav1.LabelSelector)
@@ -2079,6 +2607,22 @@ func (in *ThanosRulerStatus) DeepCopy() *ThanosRulerStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ThanosRulerWebSpec) DeepCopyInto(out *ThanosRulerWebSpec) {
+	*out = *in
+	in.WebConfigFileFields.DeepCopyInto(&out.WebConfigFileFields)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ThanosRulerWebSpec.
+func (in *ThanosRulerWebSpec) DeepCopy() *ThanosRulerWebSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ThanosRulerWebSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ThanosSpec) DeepCopyInto(out *ThanosSpec) {
 	*out = *in
@@ -2140,6 +2684,94 @@ func (in *ThanosSpec) DeepCopy() *ThanosSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebBasicAuthUser) DeepCopyInto(out *WebBasicAuthUser) {
+	*out = *in
+	in.Password.DeepCopyInto(&out.Password)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebBasicAuthUser.
+func (in *WebBasicAuthUser) DeepCopy() *WebBasicAuthUser {
+	if in == nil {
+		return nil
+	}
+	out := new(WebBasicAuthUser)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebConfigFileFields) DeepCopyInto(out *WebConfigFileFields) {
+	*out = *in
+	if in.TLSConfig != nil {
+		in, out := &in.TLSConfig, &out.TLSConfig
+		*out = new(WebTLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HTTPConfig != nil {
+		in, out := &in.HTTPConfig, &out.HTTPConfig
+		*out = new(WebHTTPConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BasicAuthUsers != nil {
+		in, out := &in.BasicAuthUsers, &out.BasicAuthUsers
+		*out = make([]WebBasicAuthUser, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebConfigFileFields.
+func (in *WebConfigFileFields) DeepCopy() *WebConfigFileFields {
+	if in == nil {
+		return nil
+	}
+	out := new(WebConfigFileFields)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebHTTPConfig) DeepCopyInto(out *WebHTTPConfig) {
+	*out = *in
+	if in.HTTP2 != nil {
+		in, out := &in.HTTP2, &out.HTTP2
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = new(WebHTTPHeaders)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebHTTPConfig.
+func (in *WebHTTPConfig) DeepCopy() *WebHTTPConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(WebHTTPConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebHTTPHeaders) DeepCopyInto(out *WebHTTPHeaders) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebHTTPHeaders.
+func (in *WebHTTPHeaders) DeepCopy() *WebHTTPHeaders {
+	if in == nil {
+		return nil
+	}
+	out := new(WebHTTPHeaders)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WebSpec) DeepCopyInto(out *WebSpec) {
 	*out = *in
@@ -2153,6 +2785,18 @@ func (in *WebSpec) DeepCopyInto(out *WebSpec) {
 		*out = new(WebTLSConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.HTTPConfig != nil {
+		in, out := &in.HTTPConfig, &out.HTTPConfig
+		*out = new(WebHTTPConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BasicAuthUsers != nil {
+		in, out := &in.BasicAuthUsers, &out.BasicAuthUsers
+		*out = make([]WebBasicAuthUser, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebSpec.
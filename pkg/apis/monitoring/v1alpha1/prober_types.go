@@ -0,0 +1,238 @@
+// Copyright 2022 The prometheus-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	ProberKind    = "Prober"
+	ProberName    = "probers"
+	ProberKindKey = "prober"
+)
+
+// Prober defines a blackbox exporter deployment managed by the operator.
+// Probe objects reference it by name with `spec.prober.name`.
+// +genclient
+// +k8s:openapi-gen=true
+// +kubebuilder:resource:categories="prometheus-operator"
+// +kubebuilder:printcolumn:name="Replicas",type="integer",JSONPath=".spec.replicas",description="The desired replicas number of the blackbox exporter"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type Prober struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// Specification of the desired behavior of the prober.
+	Spec BlackboxProberSpec `json:"spec"`
+}
+
+// ProberList is a list of Probers.
+// +k8s:openapi-gen=true
+type ProberList struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard list metadata
+	// More info: https://github.com/kubernetes/community/blob/master/contributors/devel/sig-architecture/api-conventions.md#metadata
+	metav1.ListMeta `json:"metadata,omitempty"`
+	// List of Probers
+	Items []*Prober `json:"items"`
+}
+
+// BlackboxProberSpec is a specification of the desired behavior of the
+// blackbox exporter.
+// +k8s:openapi-gen=true
+type BlackboxProberSpec struct {
+	// Image of the blackbox exporter. If not set, the operator uses its
+	// default image.
+	Image *string `json:"image,omitempty"`
+	// Number of replicas of the blackbox exporter. Defaults to 1.
+	Replicas *int32 `json:"replicas,omitempty"`
+	// Modules of the blackbox exporter. The name of the module is the value
+	// of the `module` field of the Probe objects.
+	// +kubebuilder:validation:MinItems=1
+	Modules []BlackboxModule `json:"modules"`
+	// Log level of the blackbox exporter.
+	// +kubebuilder:validation:Enum="";debug;info;warn;error
+	LogLevel string `json:"logLevel,omitempty"`
+	// PodMetadata configures labels and annotations which are propagated to
+	// the blackbox exporter pods.
+	PodMetadata *monitoringv1.EmbeddedObjectMetadata `json:"podMetadata,omitempty"`
+	// An optional list of references to secrets in the same namespace
+	// to use for pulling the blackbox exporter image.
+	ImagePullSecrets []v1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+	// Resources defines the resource requirements of the blackbox exporter
+	// container.
+	Resources v1.ResourceRequirements `json:"resources,omitempty"`
+	// Define which Nodes the Pods are scheduled on.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// If specified, the pod's tolerations.
+	Tolerations []v1.Toleration `json:"tolerations,omitempty"`
+	// If specified, the pod's scheduling constraints.
+	Affinity *v1.Affinity `json:"affinity,omitempty"`
+	// SecurityContext holds pod-level security attributes and common container settings.
+	// This defaults to the default PodSecurityContext.
+	SecurityContext *v1.PodSecurityContext `json:"securityContext,omitempty"`
+	// ServiceAccountName is the name of the ServiceAccount to use to run the
+	// blackbox exporter pods.
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+}
+
+// BlackboxModule defines a module of the blackbox exporter.
+// More info: https://github.com/prometheus/blackbox_exporter/blob/master/CONFIGURATION.md#module
+// +k8s:openapi-gen=true
+type BlackboxModule struct {
+	// Name of the module.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// The protocol over which the probe takes place.
+	// +kubebuilder:validation:Enum=http;tcp;icmp;dns;grpc
+	Prober string `json:"prober"`
+	// How long the probe waits before giving up.
+	Timeout string `json:"timeout,omitempty"`
+	// Configuration of the HTTP prober.
+	HTTP *BlackboxHTTPProbe `json:"http,omitempty"`
+	// Configuration of the TCP prober.
+	TCP *BlackboxTCPProbe `json:"tcp,omitempty"`
+	// Configuration of the ICMP prober.
+	ICMP *BlackboxICMPProbe `json:"icmp,omitempty"`
+	// Configuration of the DNS prober.
+	DNS *BlackboxDNSProbe `json:"dns,omitempty"`
+	// Configuration of the gRPC prober.
+	GRPC *BlackboxGRPCProbe `json:"grpc,omitempty"`
+}
+
+// BlackboxHTTPProbe configures the HTTP prober of the blackbox exporter.
+// +k8s:openapi-gen=true
+type BlackboxHTTPProbe struct {
+	// Accepted status codes. Defaults to 2xx.
+	ValidStatusCodes []int `json:"validStatusCodes,omitempty"`
+	// Accepted HTTP versions (e.g. `HTTP/1.1`).
+	ValidHTTPVersions []string `json:"validHTTPVersions,omitempty"`
+	// The HTTP method of the probe. Defaults to GET.
+	Method string `json:"method,omitempty"`
+	// The HTTP headers set for the probe.
+	Headers map[string]string `json:"headers,omitempty"`
+	// The body of the request.
+	Body string `json:"body,omitempty"`
+	// Whether redirects should be followed. Defaults to true.
+	FollowRedirects *bool `json:"followRedirects,omitempty"`
+	// Fail the probe if SSL is present.
+	FailIfSSL bool `json:"failIfSSL,omitempty"`
+	// Fail the probe if SSL is not present.
+	FailIfNotSSL bool `json:"failIfNotSSL,omitempty"`
+	// Fail the probe if the body matches one of the regular expressions.
+	FailIfBodyMatchesRegexp []string `json:"failIfBodyMatchesRegexp,omitempty"`
+	// Fail the probe if the body doesn't match one of the regular expressions.
+	FailIfBodyNotMatchesRegexp []string `json:"failIfBodyNotMatchesRegexp,omitempty"`
+	// The IP protocol of the probe.
+	// +kubebuilder:validation:Enum=ip4;ip6
+	PreferredIPProtocol string `json:"preferredIPProtocol,omitempty"`
+	// Whether to fall back to the other IP protocol. Defaults to true.
+	IPProtocolFallback *bool `json:"ipProtocolFallback,omitempty"`
+	// Whether to skip the verification of the server certificate.
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// BlackboxTCPProbe configures the TCP prober of the blackbox exporter.
+// +k8s:openapi-gen=true
+type BlackboxTCPProbe struct {
+	// The IP protocol of the probe.
+	// +kubebuilder:validation:Enum=ip4;ip6
+	PreferredIPProtocol string `json:"preferredIPProtocol,omitempty"`
+	// Whether to fall back to the other IP protocol. Defaults to true.
+	IPProtocolFallback *bool `json:"ipProtocolFallback,omitempty"`
+	// The query sent in the TCP probe and the expected associated response.
+	QueryResponse []BlackboxQueryResponse `json:"queryResponse,omitempty"`
+	// Whether to use TLS after connecting.
+	TLS bool `json:"tls,omitempty"`
+	// Whether to skip the verification of the server certificate.
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// BlackboxQueryResponse defines a step of a TCP probe.
+// +k8s:openapi-gen=true
+type BlackboxQueryResponse struct {
+	// Regular expression which the response should match.
+	Expect string `json:"expect,omitempty"`
+	// Data sent to the target.
+	Send string `json:"send,omitempty"`
+	// Whether to upgrade the connection to TLS.
+	StartTLS bool `json:"startTLS,omitempty"`
+}
+
+// BlackboxICMPProbe configures the ICMP prober of the blackbox exporter.
+// +k8s:openapi-gen=true
+type BlackboxICMPProbe struct {
+	// The IP protocol of the probe.
+	// +kubebuilder:validation:Enum=ip4;ip6
+	PreferredIPProtocol string `json:"preferredIPProtocol,omitempty"`
+	// Whether to fall back to the other IP protocol. Defaults to true.
+	IPProtocolFallback *bool `json:"ipProtocolFallback,omitempty"`
+	// The size of the payload.
+	PayloadSize int `json:"payloadSize,omitempty"`
+	// Whether to set the DF-bit in the IP-header (IPv4 only).
+	DontFragment bool `json:"dontFragment,omitempty"`
+}
+
+// BlackboxDNSProbe configures the DNS prober of the blackbox exporter.
+// +k8s:openapi-gen=true
+type BlackboxDNSProbe struct {
+	// The name to resolve.
+	// +kubebuilder:validation:MinLength=1
+	QueryName string `json:"queryName"`
+	// The type of the DNS query. Defaults to ANY.
+	QueryType string `json:"queryType,omitempty"`
+	// The transport protocol of the query.
+	// +kubebuilder:validation:Enum=udp;tcp
+	TransportProtocol string `json:"transportProtocol,omitempty"`
+	// Accepted response codes. Defaults to NOERROR.
+	ValidRcodes []string `json:"validRcodes,omitempty"`
+	// Whether the recursion desired bit is set. Defaults to true.
+	Recursion *bool `json:"recursionDesired,omitempty"`
+	// The IP protocol of the probe.
+	// +kubebuilder:validation:Enum=ip4;ip6
+	PreferredIPProtocol string `json:"preferredIPProtocol,omitempty"`
+	// Whether to fall back to the other IP protocol. Defaults to true.
+	IPProtocolFallback *bool `json:"ipProtocolFallback,omitempty"`
+}
+
+// BlackboxGRPCProbe configures the gRPC prober of the blackbox exporter.
+// +k8s:openapi-gen=true
+type BlackboxGRPCProbe struct {
+	// The service name of the health check.
+	Service string `json:"service,omitempty"`
+	// Whether to use TLS.
+	TLS bool `json:"tls,omitempty"`
+	// Whether to skip the verification of the server certificate.
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+	// The IP protocol of the probe.
+	// +kubebuilder:validation:Enum=ip4;ip6
+	PreferredIPProtocol string `json:"preferredIPProtocol,omitempty"`
+	// Whether to fall back to the other IP protocol. Defaults to true.
+	IPProtocolFallback *bool `json:"ipProtocolFallback,omitempty"`
+}
+
+// DeepCopyObject implements the runtime.Object interface.
+func (l *Prober) DeepCopyObject() runtime.Object {
+	return l.DeepCopy()
+}
+
+// DeepCopyObject implements the runtime.Object interface.
+func (l *ProberList) DeepCopyObject() runtime.Object {
+	return l.DeepCopy()
+}
@@ -0,0 +1,100 @@
+// Copyright 2021 The prometheus-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	PrometheusAgentsKind   = "PrometheusAgent"
+	PrometheusAgentName    = "prometheusagents"
+	PrometheusAgentKindKey = "prometheusagent"
+)
+
+// PrometheusAgentMode defines how the Prometheus agent pods are deployed.
+// +kubebuilder:validation:Enum=StatefulSet;DaemonSet
+type PrometheusAgentMode string
+
+const (
+	// StatefulSetMode deploys the Prometheus agent pods with a StatefulSet.
+	StatefulSetMode PrometheusAgentMode = "StatefulSet"
+	// DaemonSetMode deploys one Prometheus agent pod per node with a
+	// DaemonSet. Each pod only scrapes the targets running on its node.
+	DaemonSetMode PrometheusAgentMode = "DaemonSet"
+)
+
+// PrometheusAgent defines a Prometheus deployment running in agent mode.
+// +genclient
+// +k8s:openapi-gen=true
+// +kubebuilder:resource:categories="prometheus-operator"
+// +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".spec.version",description="The version of Prometheus"
+// +kubebuilder:printcolumn:name="Mode",type="string",JSONPath=".spec.mode",description="The deployment mode of the Prometheus agent"
+// +kubebuilder:printcolumn:name="Replicas",type="integer",JSONPath=".spec.replicas",description="The desired replicas number of Prometheus agents"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type PrometheusAgent struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// Specification of the desired behavior of the Prometheus agent. More info:
+	// https://github.com/kubernetes/community/blob/master/contributors/devel/sig-architecture/api-conventions.md#spec-and-status
+	Spec PrometheusAgentSpec `json:"spec"`
+	// Most recent observed status of the Prometheus agent. Read-only. Not
+	// included when requesting from the apiserver, only from the Prometheus
+	// Operator API itself. More info:
+	// https://github.com/kubernetes/community/blob/master/contributors/devel/sig-architecture/api-conventions.md#spec-and-status
+	Status *monitoringv1.PrometheusStatus `json:"status,omitempty"`
+}
+
+// PrometheusAgentList is a list of PrometheusAgents.
+// +k8s:openapi-gen=true
+type PrometheusAgentList struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard list metadata
+	// More info: https://github.com/kubernetes/community/blob/master/contributors/devel/sig-architecture/api-conventions.md#metadata
+	metav1.ListMeta `json:"metadata,omitempty"`
+	// List of PrometheusAgents
+	Items []*PrometheusAgent `json:"items"`
+}
+
+// PrometheusAgentSpec is a specification of the desired behavior of the
+// Prometheus agent. It shares the fields of the Prometheus specification
+// which apply to agent mode, the fields related to rules, alerting, querying,
+// remote read, the TSDB and the Thanos sidecar are specific to Prometheus.
+// +k8s:openapi-gen=true
+type PrometheusAgentSpec struct {
+	// Mode defines how the Prometheus agent pods are deployed. When set to
+	// DaemonSet, one pod runs on each node selected by the node selector,
+	// tolerations and affinity, and scrapes only the targets of the
+	// ServiceMonitors and PodMonitors running on the same node. The
+	// replicas, shards, storage and probeSelector fields can't be set in
+	// this mode. Defaults to StatefulSet.
+	// +optional
+	Mode PrometheusAgentMode `json:"mode,omitempty"`
+
+	monitoringv1.CommonPrometheusFields `json:",inline"`
+}
+
+// DeepCopyObject implements the runtime.Object interface.
+func (l *PrometheusAgent) DeepCopyObject() runtime.Object {
+	return l.DeepCopy()
+}
+
+// DeepCopyObject implements the runtime.Object interface.
+func (l *PrometheusAgentList) DeepCopyObject() runtime.Object {
+	return l.DeepCopy()
+}
@@ -49,6 +49,12 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&AlertmanagerConfig{},
 		&AlertmanagerConfigList{},
+		&PrometheusAgent{},
+		&PrometheusAgentList{},
+		&ScrapeConfig{},
+		&ScrapeConfigList{},
+		&Prober{},
+		&ProberList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
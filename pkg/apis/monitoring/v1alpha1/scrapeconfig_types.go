@@ -0,0 +1,365 @@
+// Copyright 2021 The prometheus-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	ScrapeConfigsKind   = "ScrapeConfig"
+	ScrapeConfigName    = "scrapeconfigs"
+	ScrapeConfigKindKey = "scrapeconfig"
+)
+
+// ScrapeConfig defines a namespaced Prometheus scrape_config to be aggregated
+// across multiple namespaces into the Prometheus configuration. It covers the
+// service discovery mechanisms which aren't based on Kubernetes objects.
+// +genclient
+// +k8s:openapi-gen=true
+// +kubebuilder:resource:categories="prometheus-operator"
+type ScrapeConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ScrapeConfigSpec `json:"spec"`
+}
+
+// ScrapeConfigList is a list of ScrapeConfigs.
+// +k8s:openapi-gen=true
+type ScrapeConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard list metadata
+	// More info: https://github.com/kubernetes/community/blob/master/contributors/devel/sig-architecture/api-conventions.md#metadata
+	metav1.ListMeta `json:"metadata,omitempty"`
+	// List of ScrapeConfigs
+	Items []*ScrapeConfig `json:"items"`
+}
+
+// ScrapeConfigSpec is a specification of the desired configuration for a
+// scrape configuration.
+// +k8s:openapi-gen=true
+type ScrapeConfigSpec struct {
+	// StaticConfigs defines a list of static targets with a common label set.
+	// +optional
+	StaticConfigs []StaticConfig `json:"staticConfigs,omitempty"`
+	// FileSDConfigs defines a list of file service discovery configurations.
+	// +optional
+	FileSDConfigs []FileSDConfig `json:"fileSDConfigs,omitempty"`
+	// HTTPSDConfigs defines a list of HTTP service discovery configurations.
+	// +optional
+	HTTPSDConfigs []HTTPSDConfig `json:"httpSDConfigs,omitempty"`
+	// DNSSDConfigs defines a list of DNS service discovery configurations.
+	// +optional
+	DNSSDConfigs []DNSSDConfig `json:"dnsSDConfigs,omitempty"`
+	// ConsulSDConfigs defines a list of Consul service discovery configurations.
+	// +optional
+	ConsulSDConfigs []ConsulSDConfig `json:"consulSDConfigs,omitempty"`
+	// EC2SDConfigs defines a list of EC2 service discovery configurations.
+	// +optional
+	EC2SDConfigs []EC2SDConfig `json:"ec2SDConfigs,omitempty"`
+	// AzureSDConfigs defines a list of Azure service discovery configurations.
+	// +optional
+	AzureSDConfigs []AzureSDConfig `json:"azureSDConfigs,omitempty"`
+	// RelabelConfigs to apply to the targets before scraping.
+	// More info: https://prometheus.io/docs/prometheus/latest/configuration/configuration/#relabel_config
+	// +optional
+	RelabelConfigs []*monitoringv1.RelabelConfig `json:"relabelings,omitempty"`
+	// MetricRelabelConfigs to apply to the samples before ingestion.
+	// +optional
+	MetricRelabelConfigs []*monitoringv1.RelabelConfig `json:"metricRelabelings,omitempty"`
+	// MetricsPath HTTP path to scrape for metrics. If empty, Prometheus uses the default value (e.g. /metrics).
+	// +optional
+	MetricsPath *string `json:"metricsPath,omitempty"`
+	// Interval at which metrics should be scraped. If not specified,
+	// Prometheus' global scrape interval is used.
+	// +optional
+	ScrapeInterval string `json:"scrapeInterval,omitempty"`
+	// Timeout after which the scrape is ended. If not specified, Prometheus'
+	// global scrape timeout is used.
+	// +optional
+	ScrapeTimeout string `json:"scrapeTimeout,omitempty"`
+	// HonorTimestamps controls whether Prometheus respects the timestamps present in scraped data.
+	// +optional
+	HonorTimestamps *bool `json:"honorTimestamps,omitempty"`
+	// HonorLabels chooses the metric's labels on collisions with target labels.
+	// +optional
+	HonorLabels *bool `json:"honorLabels,omitempty"`
+	// Optional HTTP URL parameters
+	// +optional
+	Params map[string][]string `json:"params,omitempty"`
+	// Configures the protocol scheme used for requests.
+	// If empty, Prometheus uses HTTP by default.
+	// +kubebuilder:validation:Enum=HTTP;HTTPS
+	// +optional
+	Scheme *string `json:"scheme,omitempty"`
+	// BasicAuth information to use on every scrape request.
+	// +optional
+	BasicAuth *monitoringv1.BasicAuth `json:"basicAuth,omitempty"`
+	// Authorization header to use on every scrape request.
+	// +optional
+	Authorization *monitoringv1.SafeAuthorization `json:"authorization,omitempty"`
+	// TLS configuration to use on every scrape request
+	// +optional
+	TLSConfig *monitoringv1.SafeTLSConfig `json:"tlsConfig,omitempty"`
+	// SampleLimit defines per-scrape limit on number of scraped samples that will be accepted.
+	// +optional
+	SampleLimit uint64 `json:"sampleLimit,omitempty"`
+	// TargetLimit defines a limit on the number of scraped targets that will be accepted.
+	// +optional
+	TargetLimit uint64 `json:"targetLimit,omitempty"`
+	// Per-scrape limit on number of labels that will be accepted for a sample.
+	// Only valid in Prometheus versions 2.27.0 and newer.
+	// +optional
+	LabelLimit uint64 `json:"labelLimit,omitempty"`
+	// Per-scrape limit on length of labels name that will be accepted for a sample.
+	// Only valid in Prometheus versions 2.27.0 and newer.
+	// +optional
+	LabelNameLengthLimit uint64 `json:"labelNameLengthLimit,omitempty"`
+	// Per-scrape limit on length of labels value that will be accepted for a sample.
+	// Only valid in Prometheus versions 2.27.0 and newer.
+	// +optional
+	LabelValueLengthLimit uint64 `json:"labelValueLengthLimit,omitempty"`
+}
+
+// StaticConfig defines a Prometheus static configuration.
+// See https://prometheus.io/docs/prometheus/latest/configuration/configuration/#static_config
+// +k8s:openapi-gen=true
+type StaticConfig struct {
+	// List of targets for this static configuration.
+	// +optional
+	Targets []string `json:"targets,omitempty"`
+	// Labels assigned to all metrics scraped from the targets.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// FileSDConfig defines a Prometheus file service discovery configuration.
+// See https://prometheus.io/docs/prometheus/latest/configuration/configuration/#file_sd_config
+// +k8s:openapi-gen=true
+type FileSDConfig struct {
+	// List of files to be used for file discovery. The files need to be
+	// mounted into the Prometheus pods, for instance with the `configMaps`
+	// or `secrets` fields of the Prometheus resource. The last path segment
+	// may contain a single * that matches any character sequence.
+	// +kubebuilder:validation:MinItems:=1
+	Files []SDFile `json:"files"`
+	// RefreshInterval configures the refresh interval at which Prometheus will
+	// reload the content of the files.
+	// +optional
+	RefreshInterval string `json:"refreshInterval,omitempty"`
+}
+
+// SDFile represents a file used for service discovery.
+// +kubebuilder:validation:Pattern=`^[^*]*(\*[^/]*)?\.(json|yml|yaml|JSON|YML|YAML)$`
+type SDFile string
+
+// HTTPSDConfig defines a Prometheus HTTP service discovery configuration.
+// See https://prometheus.io/docs/prometheus/latest/configuration/configuration/#http_sd_config
+// +k8s:openapi-gen=true
+type HTTPSDConfig struct {
+	// URL from which the targets are fetched.
+	// +kubebuilder:validation:MinLength:=1
+	// +kubebuilder:validation:Pattern:="^http(s)?://.+$"
+	URL string `json:"url"`
+	// RefreshInterval configures the refresh interval at which Prometheus will
+	// re-query the endpoint to update the target list.
+	// +optional
+	RefreshInterval string `json:"refreshInterval,omitempty"`
+	// BasicAuth information to authenticate against the target HTTP endpoint.
+	// +optional
+	BasicAuth *monitoringv1.BasicAuth `json:"basicAuth,omitempty"`
+	// Authorization header configuration to authenticate against the target
+	// HTTP endpoint.
+	// +optional
+	Authorization *monitoringv1.SafeAuthorization `json:"authorization,omitempty"`
+	// TLS configuration applying to the target HTTP endpoint.
+	// +optional
+	TLSConfig *monitoringv1.SafeTLSConfig `json:"tlsConfig,omitempty"`
+}
+
+// DNSSDConfig defines a Prometheus DNS service discovery configuration.
+// See https://prometheus.io/docs/prometheus/latest/configuration/configuration/#dns_sd_config
+// +k8s:openapi-gen=true
+type DNSSDConfig struct {
+	// A list of DNS domain names to be queried.
+	// +kubebuilder:validation:MinItems:=1
+	Names []string `json:"names"`
+	// RefreshInterval configures the time after which the provided names are
+	// refreshed.
+	// +optional
+	RefreshInterval string `json:"refreshInterval,omitempty"`
+	// The type of DNS query to perform. One of SRV, A, AAAA or MX.
+	// If not set, Prometheus uses its default value (SRV).
+	// +kubebuilder:validation:Enum=SRV;A;AAAA;MX
+	// +optional
+	Type *string `json:"type,omitempty"`
+	// The port number used if the query type is not SRV.
+	// +optional
+	Port *int `json:"port,omitempty"`
+}
+
+// ConsulSDConfig defines a Prometheus Consul service discovery configuration.
+// See https://prometheus.io/docs/prometheus/latest/configuration/configuration/#consul_sd_config
+// +k8s:openapi-gen=true
+type ConsulSDConfig struct {
+	// A valid string consisting of a hostname or IP followed by an optional port number.
+	// +kubebuilder:validation:MinLength:=1
+	Server string `json:"server"`
+	// Consul ACL TokenRef, if not provided it will use the ACL from the local Consul Agent.
+	// The token is written in the Prometheus configuration even when
+	// credentialsAsFiles is enabled.
+	// +optional
+	TokenRef *v1.SecretKeySelector `json:"tokenRef,omitempty"`
+	// Consul Datacenter name, if not provided it will use the local Consul Agent Datacenter.
+	// +optional
+	Datacenter *string `json:"datacenter,omitempty"`
+	// Namespaces are only supported in Consul Enterprise.
+	// +optional
+	Namespace *string `json:"namespace,omitempty"`
+	// Admin Partitions are only supported in Consul Enterprise.
+	// +optional
+	Partition *string `json:"partition,omitempty"`
+	// HTTP Scheme default "http"
+	// +kubebuilder:validation:Enum=HTTP;HTTPS
+	// +optional
+	Scheme *string `json:"scheme,omitempty"`
+	// A list of services for which targets are retrieved. If omitted, all services are scraped.
+	// +optional
+	Services []string `json:"services,omitempty"`
+	// An optional list of tags used to filter nodes for a given service. Services must contain all tags in the list.
+	// +optional
+	Tags []string `json:"tags,omitempty"`
+	// The string by which Consul tags are joined into the tag label.
+	// If unset, Prometheus uses its default value.
+	// +optional
+	TagSeparator *string `json:"tagSeparator,omitempty"`
+	// Node metadata key/value pairs to filter nodes for a given service.
+	// +optional
+	NodeMeta map[string]string `json:"nodeMeta,omitempty"`
+	// Allow stale Consul results (see https://www.consul.io/api/features/consistency.html). Will reduce load on Consul.
+	// If unset, Prometheus uses its default value.
+	// +optional
+	AllowStale *bool `json:"allowStale,omitempty"`
+	// The time after which the provided names are refreshed.
+	// If unset, Prometheus uses its default value.
+	// +optional
+	RefreshInterval string `json:"refreshInterval,omitempty"`
+	// BasicAuth information to authenticate against the Consul Server.
+	// +optional
+	BasicAuth *monitoringv1.BasicAuth `json:"basicAuth,omitempty"`
+	// Authorization header configuration to authenticate against the Consul Server.
+	// +optional
+	Authorization *monitoringv1.SafeAuthorization `json:"authorization,omitempty"`
+	// TLS configuration to connect to the Consul API.
+	// +optional
+	TLSConfig *monitoringv1.SafeTLSConfig `json:"tlsConfig,omitempty"`
+}
+
+// EC2SDConfig defines a Prometheus EC2 service discovery configuration.
+// See https://prometheus.io/docs/prometheus/latest/configuration/configuration/#ec2_sd_config
+// +k8s:openapi-gen=true
+type EC2SDConfig struct {
+	// The AWS region. If blank, the region from the instance metadata is used.
+	// +optional
+	Region *string `json:"region,omitempty"`
+	// AccessKey is the AWS API key. If not specified, the environment
+	// variable `AWS_ACCESS_KEY_ID` is used. The key is written in the
+	// Prometheus configuration even when credentialsAsFiles is enabled.
+	// +optional
+	AccessKey *v1.SecretKeySelector `json:"accessKey,omitempty"`
+	// SecretKey is the AWS API secret. If not specified, the environment
+	// variable `AWS_SECRET_ACCESS_KEY` is used. The secret is written in the
+	// Prometheus configuration even when credentialsAsFiles is enabled.
+	// +optional
+	SecretKey *v1.SecretKeySelector `json:"secretKey,omitempty"`
+	// AWS Role ARN, an alternative to using AWS API keys.
+	// +optional
+	RoleARN *string `json:"roleARN,omitempty"`
+	// RefreshInterval configures the refresh interval at which Prometheus will
+	// re-read the instance list.
+	// +optional
+	RefreshInterval string `json:"refreshInterval,omitempty"`
+	// The port to scrape metrics from. If using the public IP address, this
+	// must instead be specified in the relabeling rule.
+	// +optional
+	Port *int `json:"port,omitempty"`
+	// Filters can be used optionally to filter the instance list by other
+	// criteria.
+	// +optional
+	Filters []EC2Filter `json:"filters,omitempty"`
+}
+
+// EC2Filter is the configuration for filtering EC2 instances.
+// +k8s:openapi-gen=true
+type EC2Filter struct {
+	// Name of the filter.
+	Name string `json:"name"`
+	// Values of the filter.
+	Values []string `json:"values"`
+}
+
+// AzureSDConfig defines a Prometheus Azure service discovery configuration.
+// See https://prometheus.io/docs/prometheus/latest/configuration/configuration/#azure_sd_config
+// +k8s:openapi-gen=true
+type AzureSDConfig struct {
+	// The Azure environment.
+	// +optional
+	Environment *string `json:"environment,omitempty"`
+	// The authentication method, either OAuth or ManagedIdentity.
+	// See https://docs.microsoft.com/en-us/azure/active-directory/managed-identities-azure-resources/overview
+	// +kubebuilder:validation:Enum=OAuth;ManagedIdentity
+	// +optional
+	AuthenticationMethod *string `json:"authenticationMethod,omitempty"`
+	// The subscription ID. Always required.
+	// +kubebuilder:validation:MinLength=1
+	SubscriptionID string `json:"subscriptionID"`
+	// Optional tenant ID. Only required with the OAuth authentication method.
+	// +optional
+	TenantID *string `json:"tenantID,omitempty"`
+	// Optional client ID. Only required with the OAuth authentication method.
+	// +optional
+	ClientID *string `json:"clientID,omitempty"`
+	// Optional client secret. Only required with the OAuth authentication method.
+	// The secret is written in the Prometheus configuration even when
+	// credentialsAsFiles is enabled.
+	// +optional
+	ClientSecret *v1.SecretKeySelector `json:"clientSecret,omitempty"`
+	// Optional resource group name. Limits discovery to this resource group.
+	// +optional
+	ResourceGroup *string `json:"resourceGroup,omitempty"`
+	// RefreshInterval configures the refresh interval at which Prometheus will
+	// re-read the instance list.
+	// +optional
+	RefreshInterval string `json:"refreshInterval,omitempty"`
+	// The port to scrape metrics from. If using the public IP address, this
+	// must instead be specified in the relabeling rule.
+	// +optional
+	Port *int `json:"port,omitempty"`
+}
+
+// DeepCopyObject implements the runtime.Object interface.
+func (l *ScrapeConfig) DeepCopyObject() runtime.Object {
+	return l.DeepCopy()
+}
+
+// DeepCopyObject implements the runtime.Object interface.
+func (l *ScrapeConfigList) DeepCopyObject() runtime.Object {
+	return l.DeepCopy()
+}
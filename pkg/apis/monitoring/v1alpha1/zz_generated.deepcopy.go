@@ -104,6 +104,504 @@ func (in *AlertmanagerConfigSpec) DeepCopy() *AlertmanagerConfigSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureSDConfig) DeepCopyInto(out *AzureSDConfig) {
+	*out = *in
+	if in.Environment != nil {
+		in, out := &in.Environment, &out.Environment
+		*out = new(string)
+		**out = **in
+	}
+	if in.AuthenticationMethod != nil {
+		in, out := &in.AuthenticationMethod, &out.AuthenticationMethod
+		*out = new(string)
+		**out = **in
+	}
+	if in.TenantID != nil {
+		in, out := &in.TenantID, &out.TenantID
+		*out = new(string)
+		**out = **in
+	}
+	if in.ClientID != nil {
+		in, out := &in.ClientID, &out.ClientID
+		*out = new(string)
+		**out = **in
+	}
+	if in.ClientSecret != nil {
+		in, out := &in.ClientSecret, &out.ClientSecret
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResourceGroup != nil {
+		in, out := &in.ResourceGroup, &out.ResourceGroup
+		*out = new(string)
+		**out = **in
+	}
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureSDConfig.
+func (in *AzureSDConfig) DeepCopy() *AzureSDConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureSDConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlackboxDNSProbe) DeepCopyInto(out *BlackboxDNSProbe) {
+	*out = *in
+	if in.ValidRcodes != nil {
+		in, out := &in.ValidRcodes, &out.ValidRcodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Recursion != nil {
+		in, out := &in.Recursion, &out.Recursion
+		*out = new(bool)
+		**out = **in
+	}
+	if in.IPProtocolFallback != nil {
+		in, out := &in.IPProtocolFallback, &out.IPProtocolFallback
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlackboxDNSProbe.
+func (in *BlackboxDNSProbe) DeepCopy() *BlackboxDNSProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(BlackboxDNSProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlackboxGRPCProbe) DeepCopyInto(out *BlackboxGRPCProbe) {
+	*out = *in
+	if in.IPProtocolFallback != nil {
+		in, out := &in.IPProtocolFallback, &out.IPProtocolFallback
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlackboxGRPCProbe.
+func (in *BlackboxGRPCProbe) DeepCopy() *BlackboxGRPCProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(BlackboxGRPCProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlackboxHTTPProbe) DeepCopyInto(out *BlackboxHTTPProbe) {
+	*out = *in
+	if in.ValidStatusCodes != nil {
+		in, out := &in.ValidStatusCodes, &out.ValidStatusCodes
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	if in.ValidHTTPVersions != nil {
+		in, out := &in.ValidHTTPVersions, &out.ValidHTTPVersions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.FollowRedirects != nil {
+		in, out := &in.FollowRedirects, &out.FollowRedirects
+		*out = new(bool)
+		**out = **in
+	}
+	if in.FailIfBodyMatchesRegexp != nil {
+		in, out := &in.FailIfBodyMatchesRegexp, &out.FailIfBodyMatchesRegexp
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FailIfBodyNotMatchesRegexp != nil {
+		in, out := &in.FailIfBodyNotMatchesRegexp, &out.FailIfBodyNotMatchesRegexp
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IPProtocolFallback != nil {
+		in, out := &in.IPProtocolFallback, &out.IPProtocolFallback
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlackboxHTTPProbe.
+func (in *BlackboxHTTPProbe) DeepCopy() *BlackboxHTTPProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(BlackboxHTTPProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlackboxICMPProbe) DeepCopyInto(out *BlackboxICMPProbe) {
+	*out = *in
+	if in.IPProtocolFallback != nil {
+		in, out := &in.IPProtocolFallback, &out.IPProtocolFallback
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlackboxICMPProbe.
+func (in *BlackboxICMPProbe) DeepCopy() *BlackboxICMPProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(BlackboxICMPProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlackboxModule) DeepCopyInto(out *BlackboxModule) {
+	*out = *in
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(BlackboxHTTPProbe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TCP != nil {
+		in, out := &in.TCP, &out.TCP
+		*out = new(BlackboxTCPProbe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ICMP != nil {
+		in, out := &in.ICMP, &out.ICMP
+		*out = new(BlackboxICMPProbe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DNS != nil {
+		in, out := &in.DNS, &out.DNS
+		*out = new(BlackboxDNSProbe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GRPC != nil {
+		in, out := &in.GRPC, &out.GRPC
+		*out = new(BlackboxGRPCProbe)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlackboxModule.
+func (in *BlackboxModule) DeepCopy() *BlackboxModule {
+	if in == nil {
+		return nil
+	}
+	out := new(BlackboxModule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlackboxProberSpec) DeepCopyInto(out *BlackboxProberSpec) {
+	*out = *in
+	if in.Image != nil {
+		in, out := &in.Image, &out.Image
+		*out = new(string)
+		**out = **in
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Modules != nil {
+		in, out := &in.Modules, &out.Modules
+		*out = make([]BlackboxModule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PodMetadata != nil {
+		in, out := &in.PodMetadata, &out.PodMetadata
+		*out = new(monitoringv1.EmbeddedObjectMetadata)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(corev1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(corev1.PodSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlackboxProberSpec.
+func (in *BlackboxProberSpec) DeepCopy() *BlackboxProberSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BlackboxProberSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlackboxQueryResponse) DeepCopyInto(out *BlackboxQueryResponse) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlackboxQueryResponse.
+func (in *BlackboxQueryResponse) DeepCopy() *BlackboxQueryResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(BlackboxQueryResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlackboxTCPProbe) DeepCopyInto(out *BlackboxTCPProbe) {
+	*out = *in
+	if in.IPProtocolFallback != nil {
+		in, out := &in.IPProtocolFallback, &out.IPProtocolFallback
+		*out = new(bool)
+		**out = **in
+	}
+	if in.QueryResponse != nil {
+		in, out := &in.QueryResponse, &out.QueryResponse
+		*out = make([]BlackboxQueryResponse, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlackboxTCPProbe.
+func (in *BlackboxTCPProbe) DeepCopy() *BlackboxTCPProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(BlackboxTCPProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsulSDConfig) DeepCopyInto(out *ConsulSDConfig) {
+	*out = *in
+	if in.TokenRef != nil {
+		in, out := &in.TokenRef, &out.TokenRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Datacenter != nil {
+		in, out := &in.Datacenter, &out.Datacenter
+		*out = new(string)
+		**out = **in
+	}
+	if in.Namespace != nil {
+		in, out := &in.Namespace, &out.Namespace
+		*out = new(string)
+		**out = **in
+	}
+	if in.Partition != nil {
+		in, out := &in.Partition, &out.Partition
+		*out = new(string)
+		**out = **in
+	}
+	if in.Scheme != nil {
+		in, out := &in.Scheme, &out.Scheme
+		*out = new(string)
+		**out = **in
+	}
+	if in.Services != nil {
+		in, out := &in.Services, &out.Services
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TagSeparator != nil {
+		in, out := &in.TagSeparator, &out.TagSeparator
+		*out = new(string)
+		**out = **in
+	}
+	if in.NodeMeta != nil {
+		in, out := &in.NodeMeta, &out.NodeMeta
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.AllowStale != nil {
+		in, out := &in.AllowStale, &out.AllowStale
+		*out = new(bool)
+		**out = **in
+	}
+	if in.BasicAuth != nil {
+		in, out := &in.BasicAuth, &out.BasicAuth
+		*out = new(monitoringv1.BasicAuth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Authorization != nil {
+		in, out := &in.Authorization, &out.Authorization
+		*out = new(monitoringv1.SafeAuthorization)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLSConfig != nil {
+		in, out := &in.TLSConfig, &out.TLSConfig
+		*out = new(monitoringv1.SafeTLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsulSDConfig.
+func (in *ConsulSDConfig) DeepCopy() *ConsulSDConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsulSDConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSSDConfig) DeepCopyInto(out *DNSSDConfig) {
+	*out = *in
+	if in.Names != nil {
+		in, out := &in.Names, &out.Names
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Type != nil {
+		in, out := &in.Type, &out.Type
+		*out = new(string)
+		**out = **in
+	}
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSSDConfig.
+func (in *DNSSDConfig) DeepCopy() *DNSSDConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSSDConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EC2Filter) DeepCopyInto(out *EC2Filter) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EC2Filter.
+func (in *EC2Filter) DeepCopy() *EC2Filter {
+	if in == nil {
+		return nil
+	}
+	out := new(EC2Filter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EC2SDConfig) DeepCopyInto(out *EC2SDConfig) {
+	*out = *in
+	if in.Region != nil {
+		in, out := &in.Region, &out.Region
+		*out = new(string)
+		**out = **in
+	}
+	if in.AccessKey != nil {
+		in, out := &in.AccessKey, &out.AccessKey
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretKey != nil {
+		in, out := &in.SecretKey, &out.SecretKey
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RoleARN != nil {
+		in, out := &in.RoleARN, &out.RoleARN
+		*out = new(string)
+		**out = **in
+	}
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int)
+		**out = **in
+	}
+	if in.Filters != nil {
+		in, out := &in.Filters, &out.Filters
+		*out = make([]EC2Filter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EC2SDConfig.
+func (in *EC2SDConfig) DeepCopy() *EC2SDConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(EC2SDConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EmailConfig) DeepCopyInto(out *EmailConfig) {
 	*out = *in
@@ -149,6 +647,26 @@ func (in *EmailConfig) DeepCopy() *EmailConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FileSDConfig) DeepCopyInto(out *FileSDConfig) {
+	*out = *in
+	if in.Files != nil {
+		in, out := &in.Files, &out.Files
+		*out = make([]SDFile, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FileSDConfig.
+func (in *FileSDConfig) DeepCopy() *FileSDConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FileSDConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HTTPConfig) DeepCopyInto(out *HTTPConfig) {
 	*out = *in
@@ -179,6 +697,36 @@ func (in *HTTPConfig) DeepCopy() *HTTPConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPSDConfig) DeepCopyInto(out *HTTPSDConfig) {
+	*out = *in
+	if in.BasicAuth != nil {
+		in, out := &in.BasicAuth, &out.BasicAuth
+		*out = new(monitoringv1.BasicAuth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Authorization != nil {
+		in, out := &in.Authorization, &out.Authorization
+		*out = new(monitoringv1.SafeAuthorization)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLSConfig != nil {
+		in, out := &in.TLSConfig, &out.TLSConfig
+		*out = new(monitoringv1.SafeTLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPSDConfig.
+func (in *HTTPSDConfig) DeepCopy() *HTTPSDConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPSDConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InhibitRule) DeepCopyInto(out *InhibitRule) {
 	*out = *in
@@ -334,6 +882,119 @@ func (in *PagerDutyConfig) DeepCopy() *PagerDutyConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Prober) DeepCopyInto(out *Prober) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Prober.
+func (in *Prober) DeepCopy() *Prober {
+	if in == nil {
+		return nil
+	}
+	out := new(Prober)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProberList) DeepCopyInto(out *ProberList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]*Prober, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(Prober)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProberList.
+func (in *ProberList) DeepCopy() *ProberList {
+	if in == nil {
+		return nil
+	}
+	out := new(ProberList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusAgent) DeepCopyInto(out *PrometheusAgent) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	if in.Status != nil {
+		in, out := &in.Status, &out.Status
+		*out = new(monitoringv1.PrometheusStatus)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusAgent.
+func (in *PrometheusAgent) DeepCopy() *PrometheusAgent {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusAgent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusAgentList) DeepCopyInto(out *PrometheusAgentList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]*PrometheusAgent, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(PrometheusAgent)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusAgentList.
+func (in *PrometheusAgentList) DeepCopy() *PrometheusAgentList {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusAgentList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusAgentSpec) DeepCopyInto(out *PrometheusAgentSpec) {
+	*out = *in
+	in.CommonPrometheusFields.DeepCopyInto(&out.CommonPrometheusFields)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusAgentSpec.
+func (in *PrometheusAgentSpec) DeepCopy() *PrometheusAgentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusAgentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PushoverConfig) DeepCopyInto(out *PushoverConfig) {
 	*out = *in
@@ -472,6 +1133,188 @@ func (in *Route) DeepCopy() *Route {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScrapeConfig) DeepCopyInto(out *ScrapeConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScrapeConfig.
+func (in *ScrapeConfig) DeepCopy() *ScrapeConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ScrapeConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScrapeConfigList) DeepCopyInto(out *ScrapeConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]*ScrapeConfig, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(ScrapeConfig)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScrapeConfigList.
+func (in *ScrapeConfigList) DeepCopy() *ScrapeConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(ScrapeConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScrapeConfigSpec) DeepCopyInto(out *ScrapeConfigSpec) {
+	*out = *in
+	if in.StaticConfigs != nil {
+		in, out := &in.StaticConfigs, &out.StaticConfigs
+		*out = make([]StaticConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.FileSDConfigs != nil {
+		in, out := &in.FileSDConfigs, &out.FileSDConfigs
+		*out = make([]FileSDConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.HTTPSDConfigs != nil {
+		in, out := &in.HTTPSDConfigs, &out.HTTPSDConfigs
+		*out = make([]HTTPSDConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DNSSDConfigs != nil {
+		in, out := &in.DNSSDConfigs, &out.DNSSDConfigs
+		*out = make([]DNSSDConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ConsulSDConfigs != nil {
+		in, out := &in.ConsulSDConfigs, &out.ConsulSDConfigs
+		*out = make([]ConsulSDConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.EC2SDConfigs != nil {
+		in, out := &in.EC2SDConfigs, &out.EC2SDConfigs
+		*out = make([]EC2SDConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AzureSDConfigs != nil {
+		in, out := &in.AzureSDConfigs, &out.AzureSDConfigs
+		*out = make([]AzureSDConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RelabelConfigs != nil {
+		in, out := &in.RelabelConfigs, &out.RelabelConfigs
+		*out = make([]*monitoringv1.RelabelConfig, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(monitoringv1.RelabelConfig)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.MetricRelabelConfigs != nil {
+		in, out := &in.MetricRelabelConfigs, &out.MetricRelabelConfigs
+		*out = make([]*monitoringv1.RelabelConfig, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(monitoringv1.RelabelConfig)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.MetricsPath != nil {
+		in, out := &in.MetricsPath, &out.MetricsPath
+		*out = new(string)
+		**out = **in
+	}
+	if in.HonorTimestamps != nil {
+		in, out := &in.HonorTimestamps, &out.HonorTimestamps
+		*out = new(bool)
+		**out = **in
+	}
+	if in.HonorLabels != nil {
+		in, out := &in.HonorLabels, &out.HonorLabels
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.Scheme != nil {
+		in, out := &in.Scheme, &out.Scheme
+		*out = new(string)
+		**out = **in
+	}
+	if in.BasicAuth != nil {
+		in, out := &in.BasicAuth, &out.BasicAuth
+		*out = new(monitoringv1.BasicAuth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Authorization != nil {
+		in, out := &in.Authorization, &out.Authorization
+		*out = new(monitoringv1.SafeAuthorization)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLSConfig != nil {
+		in, out := &in.TLSConfig, &out.TLSConfig
+		*out = new(monitoringv1.SafeTLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScrapeConfigSpec.
+func (in *ScrapeConfigSpec) DeepCopy() *ScrapeConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ScrapeConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SlackAction) DeepCopyInto(out *SlackAction) {
 	*out = *in
@@ -576,6 +1419,33 @@ func (in *SlackField) DeepCopy() *SlackField {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StaticConfig) DeepCopyInto(out *StaticConfig) {
+	*out = *in
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StaticConfig.
+func (in *StaticConfig) DeepCopy() *StaticConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(StaticConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VictorOpsConfig) DeepCopyInto(out *VictorOpsConfig) {
 	*out = *in
@@ -0,0 +1,242 @@
+// Copyright 2023 The prometheus-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package assets
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ObjectGetter returns objects from an informer cache. The key of the object
+// is "<namespace>/<name>" and a NotFound error is returned when the object
+// isn't in the cache.
+type ObjectGetter interface {
+	Get(key string) (runtime.Object, error)
+}
+
+// Cache resolves the ConfigMaps and Secrets referenced by the asset stores.
+// The informers shared by the operator only hold the metadata of the objects
+// hence the full objects are fetched lazily from the API server and kept for
+// as long as their resource version matches the one known by the informers.
+// The memory usage thus depends on the objects referenced by the monitoring
+// resources rather than on all the objects of the watched namespaces.
+//
+// The objects which aren't known by the informers (for instance because
+// their namespace isn't watched or because they are filtered out by the
+// informer's selectors) are always fetched from the API server.
+//
+// Cache is safe for concurrent use. The returned objects are shared and must
+// not be modified.
+type Cache struct {
+	cmClient corev1client.ConfigMapsGetter
+	sClient  corev1client.SecretsGetter
+
+	cmGetter ObjectGetter
+	sGetter  ObjectGetter
+
+	mtx        sync.Mutex
+	configMaps map[string]*v1.ConfigMap
+	secrets    map[string]*v1.Secret
+
+	hits   *prometheus.CounterVec
+	misses *prometheus.CounterVec
+}
+
+// NewCache returns a cache backed by the given metadata informers. Any of the
+// getters can be nil in which case all the objects of the kind are fetched
+// from the API server.
+func NewCache(cmClient corev1client.ConfigMapsGetter, sClient corev1client.SecretsGetter, cmGetter, sGetter ObjectGetter) *Cache {
+	c := &Cache{
+		cmClient:   cmClient,
+		sClient:    sClient,
+		cmGetter:   cmGetter,
+		sGetter:    sGetter,
+		configMaps: map[string]*v1.ConfigMap{},
+		secrets:    map[string]*v1.Secret{},
+		hits: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "prometheus_operator_assets_cache_hits_total",
+			Help: "Number of ConfigMap and Secret lookups served from the cache",
+		}, []string{"resource"}),
+		misses: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "prometheus_operator_assets_cache_misses_total",
+			Help: "Number of ConfigMap and Secret lookups which required a request to the API server",
+		}, []string{"resource"}),
+	}
+
+	for _, resource := range []string{"configmap", "secret"} {
+		c.hits.WithLabelValues(resource)
+		c.misses.WithLabelValues(resource)
+	}
+
+	return c
+}
+
+// NewStore returns an empty store fetching the objects from the cache.
+func (c *Cache) NewStore() *Store {
+	s := NewStore(c.cmClient, c.sClient)
+	s.cache = c
+	return s
+}
+
+// ConfigMapEventHandler returns the event handler which should be registered
+// to the ConfigMap informers to release the deleted objects.
+func (c *Cache) ConfigMapEventHandler() cache.ResourceEventHandler {
+	return cache.ResourceEventHandlerFuncs{
+		DeleteFunc: func(obj interface{}) {
+			c.forget("configmap", obj)
+		},
+	}
+}
+
+// SecretEventHandler returns the event handler which should be registered to
+// the Secret informers to release the deleted objects.
+func (c *Cache) SecretEventHandler() cache.ResourceEventHandler {
+	return cache.ResourceEventHandlerFuncs{
+		DeleteFunc: func(obj interface{}) {
+			c.forget("secret", obj)
+		},
+	}
+}
+
+// Describe implements the prometheus.Collector interface.
+func (c *Cache) Describe(ch chan<- *prometheus.Desc) {
+	c.hits.Describe(ch)
+	c.misses.Describe(ch)
+}
+
+// Collect implements the prometheus.Collector interface.
+func (c *Cache) Collect(ch chan<- prometheus.Metric) {
+	c.hits.Collect(ch)
+	c.misses.Collect(ch)
+}
+
+func (c *Cache) getConfigMap(ctx context.Context, namespace, name string) (*v1.ConfigMap, error) {
+	key := namespace + "/" + name
+	rv, err := c.resourceVersion(c.cmGetter, "configmap", key)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mtx.Lock()
+	cm, found := c.configMaps[key]
+	if rv == "" {
+		delete(c.configMaps, key)
+	}
+	c.mtx.Unlock()
+
+	if found && rv != "" && cm.ResourceVersion == rv {
+		c.hits.WithLabelValues("configmap").Inc()
+		return cm, nil
+	}
+
+	c.misses.WithLabelValues("configmap").Inc()
+	cm, err = c.cmClient.ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	if rv != "" {
+		c.mtx.Lock()
+		c.configMaps[key] = cm
+		c.mtx.Unlock()
+	}
+
+	return cm, nil
+}
+
+func (c *Cache) getSecret(ctx context.Context, namespace, name string) (*v1.Secret, error) {
+	key := namespace + "/" + name
+	rv, err := c.resourceVersion(c.sGetter, "secret", key)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mtx.Lock()
+	secret, found := c.secrets[key]
+	if rv == "" {
+		delete(c.secrets, key)
+	}
+	c.mtx.Unlock()
+
+	if found && rv != "" && secret.ResourceVersion == rv {
+		c.hits.WithLabelValues("secret").Inc()
+		return secret, nil
+	}
+
+	c.misses.WithLabelValues("secret").Inc()
+	secret, err = c.sClient.Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	if rv != "" {
+		c.mtx.Lock()
+		c.secrets[key] = secret
+		c.mtx.Unlock()
+	}
+
+	return secret, nil
+}
+
+// resourceVersion returns the resource version of the object known by the
+// informer or an empty string if the informer doesn't know the object.
+func (c *Cache) resourceVersion(getter ObjectGetter, resource, key string) (string, error) {
+	if getter == nil {
+		return "", nil
+	}
+
+	obj, err := getter.Get(key)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", errors.Wrapf(err, "failed to get %s %q from the informer cache", resource, key)
+	}
+
+	o, err := meta.Accessor(obj)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get metadata of %s %q", resource, key)
+	}
+
+	return o.GetResourceVersion(), nil
+}
+
+// forget releases the cached object matching the deleted informer object.
+func (c *Cache) forget(resource string, obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		return
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	switch resource {
+	case "configmap":
+		delete(c.configMaps, key)
+	case "secret":
+		delete(c.secrets, key)
+	}
+}
@@ -0,0 +1,215 @@
+// Copyright 2023 The prometheus-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package assets
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	metadatafake "k8s.io/client-go/metadata/fake"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/prometheus-operator/prometheus-operator/pkg/informers"
+)
+
+type fakeGetter map[string]runtime.Object
+
+func (g fakeGetter) Get(key string) (runtime.Object, error) {
+	obj, found := g[key]
+	if !found {
+		return nil, apierrors.NewNotFound(v1.Resource("secret"), key)
+	}
+	return obj, nil
+}
+
+func TestCache(t *testing.T) {
+	newSecret := func(ns, rv, value string) *v1.Secret {
+		return &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "secret",
+				Namespace:       ns,
+				ResourceVersion: rv,
+			},
+			Data: map[string][]byte{
+				"key": []byte(value),
+			},
+		}
+	}
+	newMetadata := func(ns, rv string) *metav1.PartialObjectMetadata {
+		return &metav1.PartialObjectMetadata{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "secret",
+				Namespace:       ns,
+				ResourceVersion: rv,
+			},
+		}
+	}
+
+	c := fake.NewSimpleClientset(
+		newSecret("watched", "1", "foo"),
+		newSecret("unwatched", "1", "foo"),
+		&v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cm",
+				Namespace: "watched",
+			},
+			Data: map[string]string{
+				"key": "foo",
+			},
+		},
+	)
+
+	informer := fakeGetter{"watched/secret": newMetadata("watched", "1")}
+	cache := NewCache(c.CoreV1(), c.CoreV1(), nil, informer)
+
+	secretSel := v1.SecretKeySelector{
+		LocalObjectReference: v1.LocalObjectReference{Name: "secret"},
+		Key:                  "key",
+	}
+	cmSel := v1.ConfigMapKeySelector{
+		LocalObjectReference: v1.LocalObjectReference{Name: "cm"},
+		Key:                  "key",
+	}
+
+	expectCounters := func(t *testing.T, resource string, hits, misses float64) {
+		t.Helper()
+		if v := testutil.ToFloat64(cache.hits.WithLabelValues(resource)); v != hits {
+			t.Errorf("expected %v %s cache hits, got %v", hits, resource, v)
+		}
+		if v := testutil.ToFloat64(cache.misses.WithLabelValues(resource)); v != misses {
+			t.Errorf("expected %v %s cache misses, got %v", misses, resource, v)
+		}
+	}
+
+	getSecret := func(t *testing.T, ns, expected string) {
+		t.Helper()
+		// Use a new store every time since a store fetches an object only
+		// once.
+		v, err := cache.NewStore().GetSecretKey(context.Background(), ns, secretSel)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v != expected {
+			t.Fatalf("expected %q, got %q", expected, v)
+		}
+	}
+
+	// The first lookup fetches the secret from the API server, the next
+	// ones are served from the cache.
+	getSecret(t, "watched", "foo")
+	expectCounters(t, "secret", 0, 1)
+	getSecret(t, "watched", "foo")
+	getSecret(t, "watched", "foo")
+	expectCounters(t, "secret", 2, 1)
+
+	// The secret is fetched again once the informer sees a new version.
+	if _, err := c.CoreV1().Secrets("watched").Update(context.Background(), newSecret("watched", "2", "bar"), metav1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	getSecret(t, "watched", "foo")
+	informer["watched/secret"] = newMetadata("watched", "2")
+	getSecret(t, "watched", "bar")
+	getSecret(t, "watched", "bar")
+	expectCounters(t, "secret", 4, 2)
+
+	// The objects unknown to the informers are always fetched from the API
+	// server.
+	getSecret(t, "unwatched", "foo")
+	getSecret(t, "unwatched", "foo")
+	expectCounters(t, "secret", 4, 4)
+
+	for i := 0; i < 2; i++ {
+		if _, err := cache.NewStore().GetConfigMapKey(context.Background(), "watched", cmSel); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expectCounters(t, "configmap", 0, 2)
+
+	// Deleted objects are released.
+	cache.SecretEventHandler().OnDelete(newMetadata("watched", "2"))
+	if len(cache.secrets) != 0 {
+		t.Fatalf("expected no cached secrets, got %d", len(cache.secrets))
+	}
+}
+
+// The ConfigMaps referenced by the monitoring resources have no specific
+// label, the metadata informer of the cache mustn't filter them out.
+func TestCacheUnlabeledConfigMap(t *testing.T) {
+	cm := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "ca",
+			Namespace:       "default",
+			ResourceVersion: "1",
+		},
+		Data: map[string]string{
+			"ca.crt": "foo",
+		},
+	}
+	c := fake.NewSimpleClientset(cm)
+
+	scheme := runtime.NewScheme()
+	if err := metav1.AddMetaToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	mdClient := metadatafake.NewSimpleMetadataClient(scheme, &metav1.PartialObjectMetadata{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: cm.ObjectMeta,
+	})
+
+	infs, err := informers.NewInformersForResource(
+		informers.NewMetadataInformerFactories(map[string]struct{}{v1.NamespaceAll: {}}, nil, mdClient, 0, nil),
+		v1.SchemeGroupVersion.WithResource(string(v1.ResourceConfigMaps)),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	infs.Start(stopCh)
+	for _, inf := range infs.GetInformers() {
+		if !cache.WaitForCacheSync(stopCh, inf.Informer().HasSynced) {
+			t.Fatal("failed to sync the configmap informer")
+		}
+	}
+
+	assetCache := NewCache(c.CoreV1(), c.CoreV1(), infs, nil)
+	sel := v1.ConfigMapKeySelector{
+		LocalObjectReference: v1.LocalObjectReference{Name: "ca"},
+		Key:                  "ca.crt",
+	}
+	for i := 0; i < 3; i++ {
+		v, err := assetCache.NewStore().GetConfigMapKey(context.Background(), "default", sel)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v != "foo" {
+			t.Fatalf("expected %q, got %q", "foo", v)
+		}
+	}
+
+	if v := testutil.ToFloat64(assetCache.hits.WithLabelValues("configmap")); v != 2 {
+		t.Fatalf("expected 2 configmap cache hits, got %v", v)
+	}
+	if v := testutil.ToFloat64(assetCache.misses.WithLabelValues("configmap")); v != 1 {
+		t.Fatalf("expected 1 configmap cache miss, got %v", v)
+	}
+}
@@ -40,12 +40,15 @@ import (
 type Store struct {
 	cmClient corev1client.ConfigMapsGetter
 	sClient  corev1client.SecretsGetter
+	// cache is nil when the objects are fetched from the API server.
+	cache    *Cache
 	objStore cache.Store
 
 	TLSAssets       map[TLSAssetKey]TLSAsset
 	TokenAssets     map[string]Token
 	BasicAuthAssets map[string]BasicAuthCredentials
 	OAuth2Assets    map[string]OAuth2Credentials
+	SigV4Assets     map[string]SigV4Credentials
 }
 
 // NewStore returns an empty assetStore.
@@ -57,6 +60,7 @@ func NewStore(cmClient corev1client.ConfigMapsGetter, sClient corev1client.Secre
 		TokenAssets:     make(map[string]Token),
 		BasicAuthAssets: make(map[string]BasicAuthCredentials),
 		OAuth2Assets:    make(map[string]OAuth2Credentials),
+		SigV4Assets:     make(map[string]SigV4Credentials),
 		objStore:        cache.NewStore(assetKeyFunc),
 	}
 }
@@ -201,6 +205,34 @@ func (s *Store) AddOAuth2(ctx context.Context, ns string, oauth2 *monitoringv1.O
 	return nil
 }
 
+// AddSigV4 processes the SigV4 SecretKeySelectors and adds the SigV4 data to the store.
+func (s *Store) AddSigV4(ctx context.Context, ns string, sigv4 *monitoringv1.Sigv4, key string) error {
+	if sigv4 == nil || (sigv4.AccessKey == nil && sigv4.SecretKey == nil) {
+		return nil
+	}
+
+	if sigv4.AccessKey == nil || sigv4.SecretKey == nil {
+		return errors.New("both accessKey and secretKey should be provided")
+	}
+
+	accessKeyID, err := s.GetSecretKey(ctx, ns, *sigv4.AccessKey)
+	if err != nil {
+		return errors.Wrap(err, "failed to get sigv4 access key")
+	}
+
+	secretKeyID, err := s.GetSecretKey(ctx, ns, *sigv4.SecretKey)
+	if err != nil {
+		return errors.Wrap(err, "failed to get sigv4 secret key")
+	}
+
+	s.SigV4Assets[key] = SigV4Credentials{
+		AccessKeyID: accessKeyID,
+		SecretKeyID: secretKeyID,
+	}
+
+	return nil
+}
+
 // AddToken processes the given SecretKeySelector and adds the referenced data to the store.
 func (s *Store) addToken(ctx context.Context, ns string, sel v1.SecretKeySelector, key string) error {
 	if sel.Name == "" {
@@ -225,6 +257,16 @@ func (s *Store) AddBearerToken(ctx context.Context, ns string, sel v1.SecretKeyS
 	return nil
 }
 
+// AddSecretKey processes the given SecretKeySelector and adds the referenced
+// data to the token assets of the store.
+func (s *Store) AddSecretKey(ctx context.Context, ns string, sel v1.SecretKeySelector, key string) error {
+	err := s.addToken(ctx, ns, sel, key)
+	if err != nil {
+		return errors.Wrap(err, "failed to get secret key")
+	}
+	return nil
+}
+
 func (s *Store) AddSafeAuthorizationCredentials(ctx context.Context, namespace string, auth *monitoringv1.SafeAuthorization, key string) error {
 	if auth == nil || auth.Credentials == nil {
 		return nil
@@ -282,7 +324,7 @@ func (s *Store) GetConfigMapKey(ctx context.Context, namespace string, sel v1.Co
 	}
 
 	if !exists {
-		cm, err := s.cmClient.ConfigMaps(namespace).Get(ctx, sel.Name, metav1.GetOptions{})
+		cm, err := s.getConfigMap(ctx, namespace, sel.Name)
 		if err != nil {
 			return "", errors.Wrapf(err, "unable to get configmap %q", sel.Name)
 		}
@@ -313,7 +355,7 @@ func (s *Store) GetSecretKey(ctx context.Context, namespace string, sel v1.Secre
 	}
 
 	if !exists {
-		secret, err := s.sClient.Secrets(namespace).Get(ctx, sel.Name, metav1.GetOptions{})
+		secret, err := s.getSecret(ctx, namespace, sel.Name)
 		if err != nil {
 			return "", errors.Wrapf(err, "unable to get secret %q", sel.Name)
 		}
@@ -330,3 +372,17 @@ func (s *Store) GetSecretKey(ctx context.Context, namespace string, sel v1.Secre
 
 	return string(secret.Data[sel.Key]), nil
 }
+
+func (s *Store) getConfigMap(ctx context.Context, namespace, name string) (*v1.ConfigMap, error) {
+	if s.cache != nil {
+		return s.cache.getConfigMap(ctx, namespace, name)
+	}
+	return s.cmClient.ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+}
+
+func (s *Store) getSecret(ctx context.Context, namespace, name string) (*v1.Secret, error) {
+	if s.cache != nil {
+		return s.cache.getSecret(ctx, namespace, name)
+	}
+	return s.sClient.Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+}
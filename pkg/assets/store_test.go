@@ -288,6 +288,106 @@ func TestAddBasicAuth(t *testing.T) {
 	}
 }
 
+func TestAddSigV4(t *testing.T) {
+	c := fake.NewSimpleClientset(
+		&v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "secret",
+				Namespace: "ns1",
+			},
+			Data: map[string][]byte{
+				"key1": []byte("val1"),
+				"key2": []byte("val2"),
+			},
+		},
+	)
+
+	selector := func(name, key string) *v1.SecretKeySelector {
+		return &v1.SecretKeySelector{
+			LocalObjectReference: v1.LocalObjectReference{
+				Name: name,
+			},
+			Key: key,
+		}
+	}
+
+	for _, tc := range []struct {
+		name  string
+		sigv4 *monitoringv1.Sigv4
+
+		err      bool
+		expected *SigV4Credentials
+	}{
+		{
+			name:  "nil sigv4",
+			sigv4: nil,
+		},
+		{
+			name:  "no credentials",
+			sigv4: &monitoringv1.Sigv4{Region: "us-east-1"},
+		},
+		{
+			name: "valid credentials",
+			sigv4: &monitoringv1.Sigv4{
+				AccessKey: selector("secret", "key1"),
+				SecretKey: selector("secret", "key2"),
+			},
+			expected: &SigV4Credentials{
+				AccessKeyID: "val1",
+				SecretKeyID: "val2",
+			},
+		},
+		{
+			name: "missing secret key",
+			sigv4: &monitoringv1.Sigv4{
+				AccessKey: selector("secret", "key1"),
+			},
+			err: true,
+		},
+		{
+			name: "wrong key for access key selector",
+			sigv4: &monitoringv1.Sigv4{
+				AccessKey: selector("secret", "key3"),
+				SecretKey: selector("secret", "key2"),
+			},
+			err: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			store := NewStore(c.CoreV1(), c.CoreV1())
+
+			err := store.AddSigV4(context.Background(), "ns1", tc.sigv4, "sigv4")
+
+			if tc.err {
+				if err == nil {
+					t.Fatal("expecting error, got no error")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("expecting no error, got %q", err)
+			}
+
+			s, found := store.SigV4Assets["sigv4"]
+			if tc.expected == nil {
+				if found {
+					t.Fatalf("expecting no credentials, got %v", s)
+				}
+				return
+			}
+
+			if !found {
+				t.Fatal("expecting to find key \"sigv4\" but got nothing")
+			}
+
+			if s != *tc.expected {
+				t.Fatalf("expecting credentials %v, got %v", *tc.expected, s)
+			}
+		})
+	}
+}
+
 func TestAddTLSConfig(t *testing.T) {
 	c := fake.NewSimpleClientset(
 		&v1.ConfigMap{
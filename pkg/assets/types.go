@@ -28,6 +28,13 @@ type OAuth2Credentials struct {
 	ClientSecret string
 }
 
+// SigV4Credentials represents the AWS credentials used to sign requests
+// with AWS Signature Version 4.
+type SigV4Credentials struct {
+	AccessKeyID string
+	SecretKeyID string
+}
+
 // Token represents text tokens such as bearer token, see
 // https://tools.ietf.org/html/rfc6750.
 type Token string
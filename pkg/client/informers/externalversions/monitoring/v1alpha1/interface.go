@@ -24,6 +24,12 @@ import (
 type Interface interface {
 	// AlertmanagerConfigs returns a AlertmanagerConfigInformer.
 	AlertmanagerConfigs() AlertmanagerConfigInformer
+	// Probers returns a ProberInformer.
+	Probers() ProberInformer
+	// PrometheusAgents returns a PrometheusAgentInformer.
+	PrometheusAgents() PrometheusAgentInformer
+	// ScrapeConfigs returns a ScrapeConfigInformer.
+	ScrapeConfigs() ScrapeConfigInformer
 }
 
 type version struct {
@@ -41,3 +47,18 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 func (v *version) AlertmanagerConfigs() AlertmanagerConfigInformer {
 	return &alertmanagerConfigInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
+
+// Probers returns a ProberInformer.
+func (v *version) Probers() ProberInformer {
+	return &proberInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// PrometheusAgents returns a PrometheusAgentInformer.
+func (v *version) PrometheusAgents() PrometheusAgentInformer {
+	return &prometheusAgentInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// ScrapeConfigs returns a ScrapeConfigInformer.
+func (v *version) ScrapeConfigs() ScrapeConfigInformer {
+	return &scrapeConfigInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
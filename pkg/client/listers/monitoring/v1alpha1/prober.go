@@ -0,0 +1,92 @@
+// Copyright The prometheus-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ProberLister helps list Probers.
+type ProberLister interface {
+	// List lists all Probers in the indexer.
+	List(selector labels.Selector) (ret []*v1alpha1.Prober, err error)
+	// Probers returns an object that can list and get Probers.
+	Probers(namespace string) ProberNamespaceLister
+	ProberListerExpansion
+}
+
+// proberLister implements the ProberLister interface.
+type proberLister struct {
+	indexer cache.Indexer
+}
+
+// NewProberLister returns a new ProberLister.
+func NewProberLister(indexer cache.Indexer) ProberLister {
+	return &proberLister{indexer: indexer}
+}
+
+// List lists all Probers in the indexer.
+func (s *proberLister) List(selector labels.Selector) (ret []*v1alpha1.Prober, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.Prober))
+	})
+	return ret, err
+}
+
+// Probers returns an object that can list and get Probers.
+func (s *proberLister) Probers(namespace string) ProberNamespaceLister {
+	return proberNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// ProberNamespaceLister helps list and get Probers.
+type ProberNamespaceLister interface {
+	// List lists all Probers in the indexer for a given namespace.
+	List(selector labels.Selector) (ret []*v1alpha1.Prober, err error)
+	// Get retrieves the Prober from the indexer for a given namespace and name.
+	Get(name string) (*v1alpha1.Prober, error)
+	ProberNamespaceListerExpansion
+}
+
+// proberNamespaceLister implements the ProberNamespaceLister
+// interface.
+type proberNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all Probers in the indexer for a given namespace.
+func (s proberNamespaceLister) List(selector labels.Selector) (ret []*v1alpha1.Prober, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.Prober))
+	})
+	return ret, err
+}
+
+// Get retrieves the Prober from the indexer for a given namespace and name.
+func (s proberNamespaceLister) Get(name string) (*v1alpha1.Prober, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("prober"), name)
+	}
+	return obj.(*v1alpha1.Prober), nil
+}
@@ -0,0 +1,92 @@
+// Copyright The prometheus-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// PrometheusAgentLister helps list PrometheusAgents.
+type PrometheusAgentLister interface {
+	// List lists all PrometheusAgents in the indexer.
+	List(selector labels.Selector) (ret []*v1alpha1.PrometheusAgent, err error)
+	// PrometheusAgents returns an object that can list and get PrometheusAgents.
+	PrometheusAgents(namespace string) PrometheusAgentNamespaceLister
+	PrometheusAgentListerExpansion
+}
+
+// prometheusAgentLister implements the PrometheusAgentLister interface.
+type prometheusAgentLister struct {
+	indexer cache.Indexer
+}
+
+// NewPrometheusAgentLister returns a new PrometheusAgentLister.
+func NewPrometheusAgentLister(indexer cache.Indexer) PrometheusAgentLister {
+	return &prometheusAgentLister{indexer: indexer}
+}
+
+// List lists all PrometheusAgents in the indexer.
+func (s *prometheusAgentLister) List(selector labels.Selector) (ret []*v1alpha1.PrometheusAgent, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.PrometheusAgent))
+	})
+	return ret, err
+}
+
+// PrometheusAgents returns an object that can list and get PrometheusAgents.
+func (s *prometheusAgentLister) PrometheusAgents(namespace string) PrometheusAgentNamespaceLister {
+	return prometheusAgentNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// PrometheusAgentNamespaceLister helps list and get PrometheusAgents.
+type PrometheusAgentNamespaceLister interface {
+	// List lists all PrometheusAgents in the indexer for a given namespace.
+	List(selector labels.Selector) (ret []*v1alpha1.PrometheusAgent, err error)
+	// Get retrieves the PrometheusAgent from the indexer for a given namespace and name.
+	Get(name string) (*v1alpha1.PrometheusAgent, error)
+	PrometheusAgentNamespaceListerExpansion
+}
+
+// prometheusAgentNamespaceLister implements the PrometheusAgentNamespaceLister
+// interface.
+type prometheusAgentNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all PrometheusAgents in the indexer for a given namespace.
+func (s prometheusAgentNamespaceLister) List(selector labels.Selector) (ret []*v1alpha1.PrometheusAgent, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.PrometheusAgent))
+	})
+	return ret, err
+}
+
+// Get retrieves the PrometheusAgent from the indexer for a given namespace and name.
+func (s prometheusAgentNamespaceLister) Get(name string) (*v1alpha1.PrometheusAgent, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("prometheusagent"), name)
+	}
+	return obj.(*v1alpha1.PrometheusAgent), nil
+}
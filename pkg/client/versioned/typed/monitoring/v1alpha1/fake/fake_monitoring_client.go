@@ -30,6 +30,18 @@ func (c *FakeMonitoringV1alpha1) AlertmanagerConfigs(namespace string) v1alpha1.
 	return &FakeAlertmanagerConfigs{c, namespace}
 }
 
+func (c *FakeMonitoringV1alpha1) Probers(namespace string) v1alpha1.ProberInterface {
+	return &FakeProbers{c, namespace}
+}
+
+func (c *FakeMonitoringV1alpha1) PrometheusAgents(namespace string) v1alpha1.PrometheusAgentInterface {
+	return &FakePrometheusAgents{c, namespace}
+}
+
+func (c *FakeMonitoringV1alpha1) ScrapeConfigs(namespace string) v1alpha1.ScrapeConfigInterface {
+	return &FakeScrapeConfigs{c, namespace}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeMonitoringV1alpha1) RESTClient() rest.Interface {
@@ -0,0 +1,128 @@
+// Copyright The prometheus-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeProbers implements ProberInterface
+type FakeProbers struct {
+	Fake *FakeMonitoringV1alpha1
+	ns   string
+}
+
+var probersResource = schema.GroupVersionResource{Group: "monitoring.coreos.com", Version: "v1alpha1", Resource: "probers"}
+
+var probersKind = schema.GroupVersionKind{Group: "monitoring.coreos.com", Version: "v1alpha1", Kind: "Prober"}
+
+// Get takes name of the prober, and returns the corresponding prober object, and an error if there is any.
+func (c *FakeProbers) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.Prober, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(probersResource, c.ns, name), &v1alpha1.Prober{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.Prober), err
+}
+
+// List takes label and field selectors, and returns the list of Probers that match those selectors.
+func (c *FakeProbers) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.ProberList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(probersResource, probersKind, c.ns, opts), &v1alpha1.ProberList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.ProberList{ListMeta: obj.(*v1alpha1.ProberList).ListMeta}
+	for _, item := range obj.(*v1alpha1.ProberList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested probers.
+func (c *FakeProbers) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(probersResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a prober and creates it.  Returns the server's representation of the prober, and an error, if there is any.
+func (c *FakeProbers) Create(ctx context.Context, prober *v1alpha1.Prober, opts v1.CreateOptions) (result *v1alpha1.Prober, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(probersResource, c.ns, prober), &v1alpha1.Prober{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.Prober), err
+}
+
+// Update takes the representation of a prober and updates it. Returns the server's representation of the prober, and an error, if there is any.
+func (c *FakeProbers) Update(ctx context.Context, prober *v1alpha1.Prober, opts v1.UpdateOptions) (result *v1alpha1.Prober, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(probersResource, c.ns, prober), &v1alpha1.Prober{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.Prober), err
+}
+
+// Delete takes name of the prober and deletes it. Returns an error if one occurs.
+func (c *FakeProbers) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(probersResource, c.ns, name), &v1alpha1.Prober{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeProbers) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(probersResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.ProberList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched prober.
+func (c *FakeProbers) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.Prober, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(probersResource, c.ns, name, pt, data, subresources...), &v1alpha1.Prober{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.Prober), err
+}
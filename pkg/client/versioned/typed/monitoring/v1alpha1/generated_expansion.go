@@ -17,3 +17,9 @@
 package v1alpha1
 
 type AlertmanagerConfigExpansion interface{}
+
+type ProberExpansion interface{}
+
+type PrometheusAgentExpansion interface{}
+
+type ScrapeConfigExpansion interface{}
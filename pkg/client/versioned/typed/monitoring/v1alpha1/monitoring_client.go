@@ -25,6 +25,9 @@ import (
 type MonitoringV1alpha1Interface interface {
 	RESTClient() rest.Interface
 	AlertmanagerConfigsGetter
+	ProbersGetter
+	PrometheusAgentsGetter
+	ScrapeConfigsGetter
 }
 
 // MonitoringV1alpha1Client is used to interact with features provided by the monitoring.coreos.com group.
@@ -36,6 +39,18 @@ func (c *MonitoringV1alpha1Client) AlertmanagerConfigs(namespace string) Alertma
 	return newAlertmanagerConfigs(c, namespace)
 }
 
+func (c *MonitoringV1alpha1Client) Probers(namespace string) ProberInterface {
+	return newProbers(c, namespace)
+}
+
+func (c *MonitoringV1alpha1Client) PrometheusAgents(namespace string) PrometheusAgentInterface {
+	return newPrometheusAgents(c, namespace)
+}
+
+func (c *MonitoringV1alpha1Client) ScrapeConfigs(namespace string) ScrapeConfigInterface {
+	return newScrapeConfigs(c, namespace)
+}
+
 // NewForConfig creates a new MonitoringV1alpha1Client for the given config.
 func NewForConfig(c *rest.Config) (*MonitoringV1alpha1Client, error) {
 	config := *c
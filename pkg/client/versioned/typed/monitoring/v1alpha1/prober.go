@@ -0,0 +1,176 @@
+// Copyright The prometheus-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	"time"
+
+	v1alpha1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1alpha1"
+	scheme "github.com/prometheus-operator/prometheus-operator/pkg/client/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// ProbersGetter has a method to return a ProberInterface.
+// A group's client should implement this interface.
+type ProbersGetter interface {
+	Probers(namespace string) ProberInterface
+}
+
+// ProberInterface has methods to work with Prober resources.
+type ProberInterface interface {
+	Create(ctx context.Context, prober *v1alpha1.Prober, opts v1.CreateOptions) (*v1alpha1.Prober, error)
+	Update(ctx context.Context, prober *v1alpha1.Prober, opts v1.UpdateOptions) (*v1alpha1.Prober, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.Prober, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.ProberList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.Prober, err error)
+	ProberExpansion
+}
+
+// probers implements ProberInterface
+type probers struct {
+	client rest.Interface
+	ns     string
+}
+
+// newProbers returns a Probers
+func newProbers(c *MonitoringV1alpha1Client, namespace string) *probers {
+	return &probers{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the prober, and returns the corresponding prober object, and an error if there is any.
+func (c *probers) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.Prober, err error) {
+	result = &v1alpha1.Prober{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("probers").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of Probers that match those selectors.
+func (c *probers) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.ProberList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.ProberList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("probers").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested probers.
+func (c *probers) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("probers").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a prober and creates it.  Returns the server's representation of the prober, and an error, if there is any.
+func (c *probers) Create(ctx context.Context, prober *v1alpha1.Prober, opts v1.CreateOptions) (result *v1alpha1.Prober, err error) {
+	result = &v1alpha1.Prober{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("probers").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(prober).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a prober and updates it. Returns the server's representation of the prober, and an error, if there is any.
+func (c *probers) Update(ctx context.Context, prober *v1alpha1.Prober, opts v1.UpdateOptions) (result *v1alpha1.Prober, err error) {
+	result = &v1alpha1.Prober{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("probers").
+		Name(prober.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(prober).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the prober and deletes it. Returns an error if one occurs.
+func (c *probers) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("probers").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *probers) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("probers").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched prober.
+func (c *probers) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.Prober, err error) {
+	result = &v1alpha1.Prober{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("probers").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -0,0 +1,78 @@
+// Copyright 2022 The prometheus-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package informers
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+)
+
+// NewDynamicInformerFactories creates factories for resources which have no
+// typed client (e.g. third-party CRDs) for the given allowed, and denied
+// namespaces these parameters being mutually exclusive.
+// The listed objects are of type *unstructured.Unstructured.
+// dynamicClient, defaultResync, and tweakListOptions are being passed to the underlying informer factory.
+func NewDynamicInformerFactories(
+	allowNamespaces, denyNamespaces map[string]struct{},
+	dynamicClient dynamic.Interface,
+	defaultResync time.Duration,
+	tweakListOptions func(*metav1.ListOptions),
+) FactoriesForNamespaces {
+	tweaks, namespaces := newInformerOptions(
+		allowNamespaces, denyNamespaces, tweakListOptions,
+	)
+
+	ret := dynamicInformersForNamespaces{
+		factories: map[string]dynamicinformer.DynamicSharedInformerFactory{},
+		newFactory: func(namespace string) dynamicinformer.DynamicSharedInformerFactory {
+			return dynamicinformer.NewFilteredDynamicSharedInformerFactory(
+				dynamicClient,
+				defaultResync,
+				namespace,
+				tweakListOptions,
+			)
+		},
+	}
+	for _, namespace := range namespaces {
+		ret.factories[namespace] = dynamicinformer.NewFilteredDynamicSharedInformerFactory(dynamicClient, defaultResync, namespace, tweaks)
+	}
+
+	return ret
+}
+
+type dynamicInformersForNamespaces struct {
+	factories map[string]dynamicinformer.DynamicSharedInformerFactory
+	// newFactory returns a new factory for namespaces added at runtime.
+	newFactory func(namespace string) dynamicinformer.DynamicSharedInformerFactory
+}
+
+func (i dynamicInformersForNamespaces) Namespaces() sets.String {
+	return sets.StringKeySet(i.factories)
+}
+
+func (i dynamicInformersForNamespaces) ForResource(namespace string, resource schema.GroupVersionResource) (InformLister, error) {
+	if f, ok := i.factories[namespace]; ok {
+		return f.ForResource(resource), nil
+	}
+
+	// A stopped informer can't be restarted hence a new factory is used for
+	// each namespace added at runtime.
+	return i.newFactory(namespace).ForResource(resource), nil
+}
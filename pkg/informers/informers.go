@@ -15,6 +15,9 @@
 package informers
 
 import (
+	"sort"
+	"sync"
+
 	"github.com/pkg/errors"
 	"github.com/prometheus-operator/prometheus-operator/pkg/listwatch"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -42,8 +45,21 @@ type FactoriesForNamespaces interface {
 
 // ForResource contains a slice of InformLister for a concrete resource type,
 // one per namespace.
+// Namespaces can be added and removed at runtime.
 type ForResource struct {
-	informers []InformLister
+	ifs      FactoriesForNamespaces
+	resource schema.GroupVersionResource
+
+	mtx       sync.RWMutex
+	informers []*namespacedInformer
+	handlers  []cache.ResourceEventHandler
+	stopCh    <-chan struct{}
+}
+
+type namespacedInformer struct {
+	InformLister
+	namespace string
+	stopCh    chan struct{}
 }
 
 // NewInformersForResource returns a composite informer exposing the most basic set of operations
@@ -53,36 +69,150 @@ type ForResource struct {
 // It takes a namespace aware informer factory, wrapped in a FactoriesForNamespaces interface
 // that is able to instantiate an informer for a given namespace.
 func NewInformersForResource(ifs FactoriesForNamespaces, resource schema.GroupVersionResource) (*ForResource, error) {
-	namespaces := ifs.Namespaces().List()
-	var informers []InformLister
+	w := &ForResource{
+		ifs:      ifs,
+		resource: resource,
+	}
 
-	for _, ns := range namespaces {
-		informer, err := ifs.ForResource(ns, resource)
-		if err != nil {
-			return nil, errors.Wrapf(err, "error getting informer in namespace %q for resource %v", ns, resource)
+	for _, ns := range ifs.Namespaces().List() {
+		if err := w.AddNamespace(ns); err != nil {
+			return nil, err
 		}
-		informers = append(informers, informer)
 	}
 
-	return &ForResource{
-		informers: informers,
-	}, nil
+	return w, nil
 }
 
 // Start starts all underlying informers, passing the given stop channel to each of them.
+// Informers of namespaces added afterwards are started immediately.
 func (w *ForResource) Start(stopCh <-chan struct{}) {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	w.stopCh = stopCh
+	for _, i := range w.informers {
+		w.run(i)
+	}
+}
+
+// run starts the informer until either the global stop channel or the
+// informer's stop channel is closed. It must be called with the lock held.
+func (w *ForResource) run(i *namespacedInformer) {
+	stopCh := make(chan struct{})
+	go func(globalStopCh <-chan struct{}) {
+		select {
+		case <-globalStopCh:
+		case <-i.stopCh:
+		}
+		close(stopCh)
+	}(w.stopCh)
+
+	go i.Informer().Run(stopCh)
+}
+
+// AddNamespace creates the informer for the given namespace if it doesn't
+// exist yet. The registered event handlers are added to the new informer
+// which is started if the other informers are already running.
+func (w *ForResource) AddNamespace(namespace string) error {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	for _, i := range w.informers {
+		if i.namespace == namespace {
+			return nil
+		}
+	}
+
+	informer, err := w.ifs.ForResource(namespace, w.resource)
+	if err != nil {
+		return errors.Wrapf(err, "error getting informer in namespace %q for resource %v", namespace, w.resource)
+	}
+
+	i := &namespacedInformer{
+		InformLister: informer,
+		namespace:    namespace,
+		stopCh:       make(chan struct{}),
+	}
+	for _, h := range w.handlers {
+		i.Informer().AddEventHandler(h)
+	}
+	if w.stopCh != nil {
+		w.run(i)
+	}
+
+	w.informers = append(w.informers, i)
+	sort.Slice(w.informers, func(a, b int) bool {
+		return w.informers[a].namespace < w.informers[b].namespace
+	})
+
+	return nil
+}
+
+// RemoveNamespace stops and removes the informer of the given namespace. The
+// registered event handlers are notified of the deletion of all the objects
+// cached by the informer. It returns false if the namespace is unknown.
+func (w *ForResource) RemoveNamespace(namespace string) bool {
+	w.mtx.Lock()
+	var (
+		removed  *namespacedInformer
+		handlers = w.handlers
+	)
+	for idx, i := range w.informers {
+		if i.namespace == namespace {
+			removed = i
+			w.informers = append(w.informers[:idx:idx], w.informers[idx+1:]...)
+			break
+		}
+	}
+	w.mtx.Unlock()
+
+	if removed == nil {
+		return false
+	}
+
+	close(removed.stopCh)
+	for _, obj := range removed.Informer().GetStore().List() {
+		for _, h := range handlers {
+			h.OnDelete(obj)
+		}
+	}
+
+	return true
+}
+
+// Namespaces returns the namespaces of the wrapped informers.
+func (w *ForResource) Namespaces() []string {
+	w.mtx.RLock()
+	defer w.mtx.RUnlock()
+
+	namespaces := make([]string, 0, len(w.informers))
 	for _, i := range w.informers {
-		go i.Informer().Run(stopCh)
+		namespaces = append(namespaces, i.namespace)
 	}
+
+	return namespaces
 }
 
 // GetInformers returns all wrapped informers.
 func (w *ForResource) GetInformers() []InformLister {
-	return w.informers
+	w.mtx.RLock()
+	defer w.mtx.RUnlock()
+
+	informers := make([]InformLister, 0, len(w.informers))
+	for _, i := range w.informers {
+		informers = append(informers, i.InformLister)
+	}
+
+	return informers
 }
 
-// AddEventHandler registers the given handler to all wrapped informers.
+// AddEventHandler registers the given handler to all wrapped informers,
+// including the informers of namespaces added later.
 func (w *ForResource) AddEventHandler(handler cache.ResourceEventHandler) {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	w.handlers = append(w.handlers, handler)
 	for _, i := range w.informers {
 		i.Informer().AddEventHandler(handler)
 	}
@@ -90,7 +220,7 @@ func (w *ForResource) AddEventHandler(handler cache.ResourceEventHandler) {
 
 // HasSynced returns true if all underlying informers have synced, else false.
 func (w *ForResource) HasSynced() bool {
-	for _, i := range w.informers {
+	for _, i := range w.GetInformers() {
 		if !i.Informer().HasSynced() {
 			return false
 		}
@@ -102,7 +232,7 @@ func (w *ForResource) HasSynced() bool {
 // ListAll invokes the ListAll method for all wrapped informers passing the
 // same selector and appendFn.
 func (w *ForResource) ListAll(selector labels.Selector, appendFn cache.AppendFunc) error {
-	for _, inf := range w.informers {
+	for _, inf := range w.GetInformers() {
 		err := cache.ListAll(inf.Informer().GetIndexer(), selector, appendFn)
 		if err != nil {
 			return err
@@ -116,7 +246,7 @@ func (w *ForResource) ListAll(selector labels.Selector, appendFn cache.AppendFun
 // While wrapped informers are usually namespace aware, it is still important to iterate over all of them
 // as some informers might wrap k8s.io/apimachinery/pkg/apis/meta/v1.NamespaceAll.
 func (w *ForResource) ListAllByNamespace(namespace string, selector labels.Selector, appendFn cache.AppendFunc) error {
-	for _, inf := range w.informers {
+	for _, inf := range w.GetInformers() {
 		err := cache.ListAllByNamespace(inf.Informer().GetIndexer(), namespace, selector, appendFn)
 		if err != nil {
 			return err
@@ -129,9 +259,10 @@ func (w *ForResource) ListAllByNamespace(namespace string, selector labels.Selec
 // Get invokes all wrapped informers and returns the first found runtime object.
 // It returns the first ocured error.
 func (w *ForResource) Get(name string) (runtime.Object, error) {
-	var err error
+	// Namespaces selected at runtime may not have any informer yet.
+	var err error = apierrors.NewNotFound(w.resource.GroupResource(), name)
 
-	for _, inf := range w.informers {
+	for _, inf := range w.GetInformers() {
 		var ret runtime.Object
 		ret, err = inf.Lister().Get(name)
 		if apierrors.IsNotFound(err) {
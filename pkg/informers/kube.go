@@ -39,21 +39,41 @@ func NewKubeInformerFactories(
 
 	opts := []informers.SharedInformerOption{informers.WithTweakListOptions(tweaks)}
 
-	ret := kubeInformersForNamespaces{}
+	ret := kubeInformersForNamespaces{
+		factories: map[string]informers.SharedInformerFactory{},
+		newFactory: func(namespace string) informers.SharedInformerFactory {
+			return informers.NewSharedInformerFactoryWithOptions(
+				kubeClient,
+				defaultResync,
+				informers.WithTweakListOptions(tweakListOptions),
+				informers.WithNamespace(namespace),
+			)
+		},
+	}
 	for _, namespace := range namespaces {
 		opts = append(opts, informers.WithNamespace(namespace))
-		ret[namespace] = informers.NewSharedInformerFactoryWithOptions(kubeClient, defaultResync, opts...)
+		ret.factories[namespace] = informers.NewSharedInformerFactoryWithOptions(kubeClient, defaultResync, opts...)
 	}
 
 	return ret
 }
 
-type kubeInformersForNamespaces map[string]informers.SharedInformerFactory
+type kubeInformersForNamespaces struct {
+	factories map[string]informers.SharedInformerFactory
+	// newFactory returns a new factory for namespaces added at runtime.
+	newFactory func(namespace string) informers.SharedInformerFactory
+}
 
 func (i kubeInformersForNamespaces) Namespaces() sets.String {
-	return sets.StringKeySet(i)
+	return sets.StringKeySet(i.factories)
 }
 
 func (i kubeInformersForNamespaces) ForResource(namespace string, resource schema.GroupVersionResource) (InformLister, error) {
-	return i[namespace].ForResource(resource)
+	if f, ok := i.factories[namespace]; ok {
+		return f.ForResource(resource)
+	}
+
+	// A stopped informer can't be restarted hence a new factory is used for
+	// each namespace added at runtime.
+	return i.newFactory(namespace).ForResource(resource)
 }
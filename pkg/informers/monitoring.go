@@ -39,21 +39,41 @@ func NewMonitoringInformerFactories(
 
 	opts := []informers.SharedInformerOption{informers.WithTweakListOptions(tweaks)}
 
-	ret := monitoringInformersForNamespaces{}
+	ret := monitoringInformersForNamespaces{
+		factories: map[string]informers.SharedInformerFactory{},
+		newFactory: func(namespace string) informers.SharedInformerFactory {
+			return informers.NewSharedInformerFactoryWithOptions(
+				monitoringClient,
+				defaultResync,
+				informers.WithTweakListOptions(tweakListOptions),
+				informers.WithNamespace(namespace),
+			)
+		},
+	}
 	for _, namespace := range namespaces {
 		opts = append(opts, informers.WithNamespace(namespace))
-		ret[namespace] = informers.NewSharedInformerFactoryWithOptions(monitoringClient, defaultResync, opts...)
+		ret.factories[namespace] = informers.NewSharedInformerFactoryWithOptions(monitoringClient, defaultResync, opts...)
 	}
 
 	return ret
 }
 
-type monitoringInformersForNamespaces map[string]informers.SharedInformerFactory
+type monitoringInformersForNamespaces struct {
+	factories map[string]informers.SharedInformerFactory
+	// newFactory returns a new factory for namespaces added at runtime.
+	newFactory func(namespace string) informers.SharedInformerFactory
+}
 
 func (i monitoringInformersForNamespaces) Namespaces() sets.String {
-	return sets.StringKeySet(i)
+	return sets.StringKeySet(i.factories)
 }
 
 func (i monitoringInformersForNamespaces) ForResource(namespace string, resource schema.GroupVersionResource) (InformLister, error) {
-	return i[namespace].ForResource(resource)
+	if f, ok := i.factories[namespace]; ok {
+		return f.ForResource(resource)
+	}
+
+	// A stopped informer can't be restarted hence a new factory is used for
+	// each namespace added at runtime.
+	return i.newFactory(namespace).ForResource(resource)
 }
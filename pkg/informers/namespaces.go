@@ -0,0 +1,166 @@
+// Copyright 2021 The prometheus-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package informers
+
+import (
+	"sync"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/pkg/errors"
+	"github.com/prometheus-operator/prometheus-operator/pkg/listwatch"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// NamespaceSelector adds and removes the namespaces of informers when
+// namespaces start or stop matching label selectors. It implements the
+// cache.ResourceEventHandler interface and should be registered to a
+// Namespace informer watching all namespaces.
+//
+// A nil *NamespaceSelector is valid and selects nothing dynamically.
+type NamespaceSelector struct {
+	logger log.Logger
+	allow  labels.Selector
+	deny   labels.Selector
+
+	mtx       sync.Mutex
+	resources []*ForResource
+	selected  map[string]struct{}
+}
+
+// NewNamespaceSelector returns a NamespaceSelector for the given allow and
+// deny label selectors. It returns nil if both selectors are empty.
+func NewNamespaceSelector(allowSelector, denySelector string, logger log.Logger) (*NamespaceSelector, error) {
+	if allowSelector == "" && denySelector == "" {
+		return nil, nil
+	}
+
+	allow, err := labels.Parse(allowSelector)
+	if err != nil {
+		return nil, errors.Wrap(err, "can not parse namespace selector value")
+	}
+
+	deny := labels.Nothing()
+	if denySelector != "" {
+		deny, err = labels.Parse(denySelector)
+		if err != nil {
+			return nil, errors.Wrap(err, "can not parse deny namespace selector value")
+		}
+	}
+
+	return &NamespaceSelector{
+		logger:   logger,
+		allow:    allow,
+		deny:     deny,
+		selected: map[string]struct{}{},
+	}, nil
+}
+
+// AllowList returns the namespaces for which informers should be created
+// statically. When the given allow list selects all namespaces, the namespaces
+// are selected at runtime and an empty list is returned.
+func (s *NamespaceSelector) AllowList(allowList map[string]struct{}) map[string]struct{} {
+	if s == nil || !listwatch.IsAllNamespaces(allowList) {
+		return allowList
+	}
+
+	return map[string]struct{}{}
+}
+
+// Register adds informers whose namespaces are managed by the selector. It is
+// a no-op unless the given allow list selects all namespaces. Nil resources
+// are ignored.
+func (s *NamespaceSelector) Register(allowList map[string]struct{}, resources ...*ForResource) {
+	if s == nil || !listwatch.IsAllNamespaces(allowList) {
+		return
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	registered := make([]*ForResource, 0, len(resources))
+	for _, r := range resources {
+		if r != nil {
+			registered = append(registered, r)
+		}
+	}
+
+	s.resources = append(s.resources, registered...)
+	for ns := range s.selected {
+		s.addNamespace(ns, registered)
+	}
+}
+
+// Matches returns true if the namespace is selected.
+func (s *NamespaceSelector) Matches(ns *v1.Namespace) bool {
+	set := labels.Set(ns.Labels)
+	return s.allow.Matches(set) && !s.deny.Matches(set)
+}
+
+// OnAdd implements the cache.ResourceEventHandler interface.
+func (s *NamespaceSelector) OnAdd(obj interface{}) {
+	ns, ok := obj.(*v1.Namespace)
+	if !ok {
+		return
+	}
+	s.update(ns.Name, s.Matches(ns))
+}
+
+// OnUpdate implements the cache.ResourceEventHandler interface.
+func (s *NamespaceSelector) OnUpdate(_, cur interface{}) {
+	s.OnAdd(cur)
+}
+
+// OnDelete implements the cache.ResourceEventHandler interface.
+func (s *NamespaceSelector) OnDelete(obj interface{}) {
+	if d, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = d.Obj
+	}
+
+	ns, ok := obj.(*v1.Namespace)
+	if !ok {
+		return
+	}
+	s.update(ns.Name, false)
+}
+
+func (s *NamespaceSelector) update(name string, selected bool) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	_, wasSelected := s.selected[name]
+	switch {
+	case selected && !wasSelected:
+		level.Info(s.logger).Log("msg", "namespace selected", "namespace", name)
+		s.selected[name] = struct{}{}
+		s.addNamespace(name, s.resources)
+	case !selected && wasSelected:
+		level.Info(s.logger).Log("msg", "namespace unselected", "namespace", name)
+		delete(s.selected, name)
+		for _, r := range s.resources {
+			r.RemoveNamespace(name)
+		}
+	}
+}
+
+func (s *NamespaceSelector) addNamespace(name string, resources []*ForResource) {
+	for _, r := range resources {
+		if err := r.AddNamespace(name); err != nil {
+			level.Error(s.logger).Log("msg", "failed to add namespace", "namespace", name, "err", err)
+		}
+	}
+}
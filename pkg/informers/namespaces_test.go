@@ -0,0 +1,108 @@
+// Copyright 2021 The prometheus-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package informers
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestNamespaceSelector(t *testing.T) {
+	c := fake.NewSimpleClientset(
+		&v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "a"}},
+		&v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: "b"}},
+	)
+
+	allowList := map[string]struct{}{v1.NamespaceAll: {}}
+	sel, err := NewNamespaceSelector("monitoring=enabled", "", log.NewNopLogger())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	infs, err := NewInformersForResource(
+		NewKubeInformerFactories(sel.AllowList(allowList), nil, c, 0, nil),
+		v1.SchemeGroupVersion.WithResource(string(v1.ResourceConfigMaps)),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The informers of disabled features are nil.
+	sel.Register(allowList, infs, nil)
+
+	if ns := infs.Namespaces(); len(ns) != 0 {
+		t.Fatalf("expected no namespace, got %v", ns)
+	}
+
+	var (
+		mtx     sync.Mutex
+		deleted int
+	)
+	infs.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		DeleteFunc: func(interface{}) {
+			mtx.Lock()
+			deleted++
+			mtx.Unlock()
+		},
+	})
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	infs.Start(stopCh)
+
+	nsA := &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "a", Labels: map[string]string{"monitoring": "enabled"}}}
+	nsB := &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "b"}}
+	sel.OnAdd(nsA)
+	sel.OnAdd(nsB)
+
+	if ns := infs.Namespaces(); !reflect.DeepEqual(ns, []string{"a"}) {
+		t.Fatalf("expected namespaces [a], got %v", ns)
+	}
+
+	if err := wait.PollImmediate(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+		return infs.HasSynced(), nil
+	}); err != nil {
+		t.Fatalf("informers not synced: %v", err)
+	}
+
+	var objs []interface{}
+	if err := infs.ListAll(labels.Everything(), func(obj interface{}) { objs = append(objs, obj) }); err != nil {
+		t.Fatal(err)
+	}
+	if len(objs) != 1 || objs[0].(*v1.ConfigMap).Namespace != "a" {
+		t.Fatalf("expected the configmap of namespace a, got %v", objs)
+	}
+
+	// Namespace "a" doesn't match anymore.
+	sel.OnUpdate(nsA, &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "a"}})
+	if ns := infs.Namespaces(); len(ns) != 0 {
+		t.Fatalf("expected no namespace, got %v", ns)
+	}
+
+	mtx.Lock()
+	defer mtx.Unlock()
+	if deleted != 1 {
+		t.Fatalf("expected 1 delete notification, got %d", deleted)
+	}
+}
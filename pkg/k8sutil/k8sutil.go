@@ -27,6 +27,7 @@ import (
 
 	"github.com/hashicorp/go-version"
 	"github.com/pkg/errors"
+	authv1 "k8s.io/api/authorization/v1"
 	v1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -34,6 +35,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/discovery"
 	clientappsv1 "k8s.io/client-go/kubernetes/typed/apps/v1"
+	clientauthv1 "k8s.io/client-go/kubernetes/typed/authorization/v1"
 	clientv1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -184,6 +186,44 @@ func UpdateStatefulSet(ctx context.Context, sstClient clientappsv1.StatefulSetIn
 	return nil
 }
 
+// UpdateDaemonSet merges metadata of existing DaemonSet with new one and updates it.
+func UpdateDaemonSet(ctx context.Context, dsetClient clientappsv1.DaemonSetInterface, dset *appsv1.DaemonSet) error {
+	existingDset, err := dsetClient.Get(ctx, dset.Name, metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrap(err, "getting daemon set object failed")
+	}
+
+	mergeMetadata(&dset.ObjectMeta, existingDset.ObjectMeta)
+	// Propagate annotations set by kubectl on spec.template.annotations. e.g performing a rolling restart.
+	mergeKubectlAnnotations(&dset.Spec.Template.ObjectMeta, existingDset.Spec.Template.ObjectMeta)
+
+	_, err = dsetClient.Update(ctx, dset, metav1.UpdateOptions{})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UpdateDeployment merges metadata of existing Deployment with new one and updates it.
+func UpdateDeployment(ctx context.Context, deployClient clientappsv1.DeploymentInterface, deploy *appsv1.Deployment) error {
+	existingDeploy, err := deployClient.Get(ctx, deploy.Name, metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrap(err, "getting deployment object failed")
+	}
+
+	mergeMetadata(&deploy.ObjectMeta, existingDeploy.ObjectMeta)
+	// Propagate annotations set by kubectl on spec.template.annotations. e.g performing a rolling restart.
+	mergeKubectlAnnotations(&deploy.Spec.Template.ObjectMeta, existingDeploy.Spec.Template.ObjectMeta)
+
+	_, err = deployClient.Update(ctx, deploy, metav1.UpdateOptions{})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // CreateOrUpdateSecret merges metadata of existing Secret with new one and updates it.
 func CreateOrUpdateSecret(ctx context.Context, secretClient clientv1.SecretInterface, desired *v1.Secret) error {
 	existingSecret, err := secretClient.Get(ctx, desired.Name, metav1.GetOptions{})
@@ -230,6 +270,104 @@ func GetMinorVersion(dclient discovery.DiscoveryInterface) (int, error) {
 	return ver.Segments()[1], nil
 }
 
+// IsAPIGroupVersionResourceSupported returns true if the API server serves
+// the given resource for the group version.
+func IsAPIGroupVersionResourceSupported(dclient discovery.DiscoveryInterface, groupVersion string, resource string) (bool, error) {
+	resources, err := dclient.ServerResourcesForGroupVersion(groupVersion)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	for _, r := range resources.APIResources {
+		if r.Name == resource {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// ResourceAttribute represents the authorization attributes to check on a
+// given resource.
+type ResourceAttribute struct {
+	Group    string
+	Version  string
+	Resource string
+	// Name is empty for checks on all the resources of the kind.
+	Name  string
+	Verbs []string
+}
+
+// IsAllowed returns whether the user (e.g. the operator's service account) has
+// been granted the required RBAC attributes in all the given namespaces. The
+// empty namespace (v1.NamespaceAll) checks the attributes for all namespaces,
+// it should also be used for cluster-scoped resources.
+// When the user lacks some of the permissions, the returned errors list them.
+func IsAllowed(
+	ctx context.Context,
+	ssarClient clientauthv1.SelfSubjectAccessReviewInterface,
+	namespaces []string,
+	attributes ...ResourceAttribute,
+) (bool, []error, error) {
+	if len(namespaces) == 0 {
+		namespaces = []string{v1.NamespaceAll}
+	}
+
+	var missing []error
+	for _, ns := range namespaces {
+		for _, ra := range attributes {
+			for _, verb := range ra.Verbs {
+				resourceAttributes := authv1.ResourceAttributes{
+					Verb:      verb,
+					Group:     ra.Group,
+					Version:   ra.Version,
+					Resource:  ra.Resource,
+					Name:      ra.Name,
+					Namespace: ns,
+				}
+
+				ssar, err := ssarClient.Create(ctx, &authv1.SelfSubjectAccessReview{
+					Spec: authv1.SelfSubjectAccessReviewSpec{
+						ResourceAttributes: &resourceAttributes,
+					},
+				}, metav1.CreateOptions{})
+				if err != nil {
+					return false, nil, errors.Wrapf(err, "failed to check %q permission on resource %q", verb, ra.Resource)
+				}
+
+				if !ssar.Status.Allowed {
+					missing = append(missing, permissionError(resourceAttributes, ssar.Status.Reason))
+				}
+			}
+		}
+	}
+
+	return len(missing) == 0, missing, nil
+}
+
+func permissionError(ra authv1.ResourceAttributes, reason string) error {
+	resource := ra.Resource
+	if ra.Group != "" {
+		resource += "." + ra.Group
+	}
+	if ra.Name != "" {
+		resource += "/" + ra.Name
+	}
+
+	scope := "all namespaces"
+	if ra.Namespace != v1.NamespaceAll {
+		scope = fmt.Sprintf("namespace %q", ra.Namespace)
+	}
+
+	if reason == "" {
+		return errors.Errorf("missing %q permission on resource %q in %s", ra.Verb, resource, scope)
+	}
+	return errors.Errorf("missing %q permission on resource %q in %s: %s", ra.Verb, resource, scope, reason)
+}
+
 // SanitizeVolumeName ensures that the given volume name is a valid DNS-1123 label
 // accepted by Kubernetes.
 func SanitizeVolumeName(name string) string {
@@ -22,9 +22,12 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 
+	authv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
 
 	"k8s.io/apimachinery/pkg/util/validation"
 )
@@ -462,3 +465,66 @@ func TestCreateOrUpdateImmutableFields(t *testing.T) {
 		}
 	})
 }
+
+func TestIsAllowed(t *testing.T) {
+	c := fake.NewSimpleClientset()
+	// Only listing secrets in the "default" namespace is allowed.
+	c.PrependReactor("create", "selfsubjectaccessreviews", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		ssar := action.(clienttesting.CreateAction).GetObject().(*authv1.SelfSubjectAccessReview)
+		ra := ssar.Spec.ResourceAttributes
+		ssar.Status.Allowed = ra.Namespace == "default" && ra.Resource == "secrets" && ra.Verb == "list"
+		return true, ssar, nil
+	})
+
+	for _, tc := range []struct {
+		name       string
+		namespaces []string
+		verbs      []string
+		allowed    bool
+		missing    int
+	}{
+		{
+			name:       "allowed",
+			namespaces: []string{"default"},
+			verbs:      []string{"list"},
+			allowed:    true,
+		},
+		{
+			name:       "missing verb",
+			namespaces: []string{"default"},
+			verbs:      []string{"list", "watch"},
+			missing:    1,
+		},
+		{
+			name:       "missing namespace",
+			namespaces: []string{"default", "other"},
+			verbs:      []string{"list"},
+			missing:    1,
+		},
+		{
+			name:    "all namespaces",
+			verbs:   []string{"list", "watch"},
+			missing: 2,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			allowed, missing, err := IsAllowed(
+				context.Background(),
+				c.AuthorizationV1().SelfSubjectAccessReviews(),
+				tc.namespaces,
+				ResourceAttribute{Resource: "secrets", Verbs: tc.verbs},
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if allowed != tc.allowed {
+				t.Fatalf("expected allowed to be %v, got %v", tc.allowed, allowed)
+			}
+
+			if len(missing) != tc.missing {
+				t.Fatalf("expected %d missing permissions, got %v", tc.missing, missing)
+			}
+		})
+	}
+}
@@ -21,6 +21,7 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 	"k8s.io/client-go/tools/metrics"
+	"k8s.io/client-go/util/workqueue"
 )
 
 type clientGoHTTPMetricAdapter struct {
@@ -32,6 +33,16 @@ type clientGoRateLimiterMetricAdapter struct {
 	duration *prometheus.SummaryVec
 }
 
+type workqueueMetricsProvider struct {
+	depth                   *prometheus.GaugeVec
+	adds                    *prometheus.CounterVec
+	latency                 *prometheus.HistogramVec
+	workDuration            *prometheus.HistogramVec
+	unfinishedWork          *prometheus.GaugeVec
+	longestRunningProcessor *prometheus.GaugeVec
+	retries                 *prometheus.CounterVec
+}
+
 // MustRegisterClientGoMetrics registers k8s.io/client-go metrics.
 // It panics if it encounters an error (e.g. metrics already registered).
 func MustRegisterClientGoMetrics(registerer prometheus.Registerer) {
@@ -73,6 +84,73 @@ func MustRegisterClientGoMetrics(registerer prometheus.Registerer) {
 	)
 
 	registerer.MustRegister(httpMetrics.count, httpMetrics.duration, rateLimiterMetrics.duration)
+
+	// The work queues are named after the controllers.
+	workqueueMetrics := &workqueueMetricsProvider{
+		depth: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "prometheus_operator_workqueue_depth",
+				Help: "Current depth of the work queue.",
+			},
+			[]string{"controller"},
+		),
+		adds: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "prometheus_operator_workqueue_adds_total",
+				Help: "Total number of items added to the work queue.",
+			},
+			[]string{"controller"},
+		),
+		latency: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "prometheus_operator_workqueue_queue_duration_seconds",
+				Help:    "How long in seconds an item stays in the work queue before being processed.",
+				Buckets: prometheus.ExponentialBuckets(0.001, 4, 10),
+			},
+			[]string{"controller"},
+		),
+		workDuration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "prometheus_operator_workqueue_work_duration_seconds",
+				Help:    "How long in seconds processing an item from the work queue takes.",
+				Buckets: prometheus.ExponentialBuckets(0.001, 4, 10),
+			},
+			[]string{"controller"},
+		),
+		unfinishedWork: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "prometheus_operator_workqueue_unfinished_work_seconds",
+				Help: "How many seconds of work has been done that is in progress and hasn't been observed by the work duration.",
+			},
+			[]string{"controller"},
+		),
+		longestRunningProcessor: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "prometheus_operator_workqueue_longest_running_processor_seconds",
+				Help: "How many seconds the longest running processor of the work queue has been running.",
+			},
+			[]string{"controller"},
+		),
+		retries: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "prometheus_operator_workqueue_retries_total",
+				Help: "Total number of retries handled by the work queue.",
+			},
+			[]string{"controller"},
+		),
+	}
+
+	workqueue.SetProvider(workqueueMetrics)
+
+	registerer.MustRegister(
+		workqueueMetrics.depth,
+		workqueueMetrics.adds,
+		workqueueMetrics.latency,
+		workqueueMetrics.workDuration,
+		workqueueMetrics.unfinishedWork,
+		workqueueMetrics.longestRunningProcessor,
+		workqueueMetrics.retries,
+	)
 }
 
 func (a *clientGoHTTPMetricAdapter) Increment(_ context.Context, code string, method string, host string) {
@@ -86,3 +164,31 @@ func (a *clientGoHTTPMetricAdapter) Observe(_ context.Context, verb string, u ur
 func (a *clientGoRateLimiterMetricAdapter) Observe(_ context.Context, verb string, u url.URL, latency time.Duration) {
 	a.duration.WithLabelValues(u.EscapedPath()).Observe(latency.Seconds())
 }
+
+func (p *workqueueMetricsProvider) NewDepthMetric(name string) workqueue.GaugeMetric {
+	return p.depth.WithLabelValues(name)
+}
+
+func (p *workqueueMetricsProvider) NewAddsMetric(name string) workqueue.CounterMetric {
+	return p.adds.WithLabelValues(name)
+}
+
+func (p *workqueueMetricsProvider) NewLatencyMetric(name string) workqueue.HistogramMetric {
+	return p.latency.WithLabelValues(name)
+}
+
+func (p *workqueueMetricsProvider) NewWorkDurationMetric(name string) workqueue.HistogramMetric {
+	return p.workDuration.WithLabelValues(name)
+}
+
+func (p *workqueueMetricsProvider) NewUnfinishedWorkSecondsMetric(name string) workqueue.SettableGaugeMetric {
+	return p.unfinishedWork.WithLabelValues(name)
+}
+
+func (p *workqueueMetricsProvider) NewLongestRunningProcessorSecondsMetric(name string) workqueue.SettableGaugeMetric {
+	return p.longestRunningProcessor.WithLabelValues(name)
+}
+
+func (p *workqueueMetricsProvider) NewRetriesMetric(name string) workqueue.CounterMetric {
+	return p.retries.WithLabelValues(name)
+}
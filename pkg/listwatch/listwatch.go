@@ -89,6 +89,13 @@ func NewUnprivilegedNamespaceListWatchFromClient(
 				level.Info(l).Log("msg", "namespace not found", "namespace", name)
 				continue
 			}
+			if apierrors.IsForbidden(err) {
+				// Without permission to get the namespace, its labels are
+				// unknown and the namespace is assumed to exist.
+				level.Debug(l).Log("msg", "not allowed to get namespace", "namespace", name)
+				list.Items = append(list.Items, v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}})
+				continue
+			}
 			if err != nil {
 				return nil, errors.Wrap(err, "unexpected error while listing namespaces")
 			}
@@ -36,7 +36,10 @@ type ConfigReloader struct {
 	localHost          string
 	logFormat          string
 	logLevel           string
+	nodeName           bool
 	reloadURL          url.URL
+	basicAuthUsername  string
+	basicAuthPassword  *v1.SecretKeySelector
 	runOnce            bool
 	shard              *int32
 	volumeMounts       []v1.VolumeMount
@@ -87,6 +90,15 @@ func ReloaderURL(reloadURL url.URL) ReloaderOption {
 	}
 }
 
+// ReloaderBasicAuth sets the basic auth credentials used by the
+// config-reloader container to call the reload URL
+func ReloaderBasicAuth(username string, password v1.SecretKeySelector) ReloaderOption {
+	return func(c *ConfigReloader) {
+		c.basicAuthUsername = username
+		c.basicAuthPassword = &password
+	}
+}
+
 // ListenLocal sets the listenLocal option for the config-reloader container
 func ListenLocal(listenLocal bool) ReloaderOption {
 	return func(c *ConfigReloader) {
@@ -129,6 +141,14 @@ func Shard(shard int32) ReloaderOption {
 	}
 }
 
+// NodeName sets the nodeName option for the config-reloader container which
+// exposes the name of the node as the NODE_NAME environment variable
+func NodeName() ReloaderOption {
+	return func(c *ConfigReloader) {
+		c.nodeName = true
+	}
+}
+
 // CreateConfigReloader returns the definition of the config-reloader
 // container.
 func CreateConfigReloader(name string, options ...ReloaderOption) v1.Container {
@@ -171,6 +191,11 @@ func CreateConfigReloader(name string, options ...ReloaderOption) v1.Container {
 
 	if len(configReloader.reloadURL.String()) > 0 {
 		args = append(args, fmt.Sprintf("--reload-url=%s", configReloader.reloadURL.String()))
+		// The reload URL targets the local web server whose certificate
+		// isn't issued for the local host.
+		if configReloader.reloadURL.Scheme == "https" {
+			args = append(args, "--reload-tls-insecure-skip-verify")
+		}
 	}
 
 	if len(configReloader.configFile) > 0 {
@@ -220,6 +245,30 @@ func CreateConfigReloader(name string, options ...ReloaderOption) v1.Container {
 		})
 	}
 
+	if configReloader.basicAuthPassword != nil {
+		envVars = append(envVars,
+			v1.EnvVar{
+				Name:  "RELOAD_BASIC_AUTH_USERNAME",
+				Value: configReloader.basicAuthUsername,
+			},
+			v1.EnvVar{
+				Name: "RELOAD_BASIC_AUTH_PASSWORD",
+				ValueFrom: &v1.EnvVarSource{
+					SecretKeyRef: configReloader.basicAuthPassword,
+				},
+			},
+		)
+	}
+
+	if configReloader.nodeName {
+		envVars = append(envVars, v1.EnvVar{
+			Name: "NODE_NAME",
+			ValueFrom: &v1.EnvVarSource{
+				FieldRef: &v1.ObjectFieldSelector{FieldPath: "spec.nodeName"},
+			},
+		})
+	}
+
 	return v1.Container{
 		Name:                     name,
 		Image:                    configReloader.config.Image,
@@ -114,3 +114,18 @@ func contains(s []string, str string) bool {
 	}
 	return false
 }
+
+func TestCreateConfigReloaderHTTPS(t *testing.T) {
+	container := CreateConfigReloader(
+		"config-reloader",
+		ReloaderResources(reloaderConfig),
+		ReloaderURL(url.URL{
+			Scheme: "https",
+			Host:   "localhost:9093",
+			Path:   "/-/reload",
+		}),
+	)
+	if !contains(container.Args, "--reload-tls-insecure-skip-verify") {
+		t.Errorf("Expected '--reload-tls-insecure-skip-verify' not found in %s", container.Args)
+	}
+}
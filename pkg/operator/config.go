@@ -15,8 +15,12 @@
 package operator
 
 import (
+	"errors"
+	"fmt"
 	"strings"
 
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/rest"
 )
 
@@ -25,6 +29,7 @@ type Config struct {
 	Host                         string
 	ClusterDomain                string
 	KubeletObject                string
+	KubeletSync                  KubeletSyncConfig
 	ListenAddress                string
 	TLSInsecure                  bool
 	TLSConfig                    rest.TLSClientConfig
@@ -44,6 +49,35 @@ type Config struct {
 	SecretListWatchSelector      string
 }
 
+// KubeletSyncConfig defines how the nodes are synchronized into the kubelet
+// Service/Endpoints objects.
+type KubeletSyncConfig struct {
+	// Label selector of the nodes to synchronize.
+	NodeSelector string
+	// Type of the node address published in the endpoints. When empty, the
+	// internal IP is preferred over the external IP.
+	AddressType v1.NodeAddressType
+	// Ports of the kubelet objects. When empty, the default kubelet ports
+	// are used.
+	Ports []KubeletPort
+	// When true, the Windows nodes are published into separate objects
+	// suffixed with "-windows".
+	SplitByOS bool
+	// Ports of the kubelet objects of the Windows nodes. When empty, Ports
+	// apply.
+	WindowsPorts []KubeletPort
+	// When not empty, the nodes are published into separate objects per
+	// value of the label (suffixed with the value). The nodes without the
+	// label are published into the main objects.
+	NodePoolLabel string
+}
+
+// KubeletPort is a port published by the kubelet Service/Endpoints objects.
+type KubeletPort struct {
+	Name string
+	Port int32
+}
+
 type ReloaderConfig struct {
 	CPURequest    string
 	CPULimit      string
@@ -96,4 +130,66 @@ type Namespaces struct {
 	AllowList, DenyList map[string]struct{}
 	// Allow list for prometheus/alertmanager custom resources.
 	PrometheusAllowList, AlertmanagerAllowList, ThanosRulerAllowList map[string]struct{}
+	// Label selectors of the namespaces for common custom resources. The
+	// namespaces are selected at runtime and the instance allow lists which
+	// aren't set explicitly follow the selected namespaces.
+	AllowSelector, DenySelector string
+}
+
+// NewNamespaces returns the namespace scoping of the operator from the given
+// lists. An empty allow list selects all namespaces and empty instance allow
+// lists default to the allow list.
+func NewNamespaces(allow, deny, prometheus, alertmanager, thanosRuler map[string]struct{}) (Namespaces, error) {
+	if len(allow) > 0 && len(deny) > 0 {
+		return Namespaces{}, errors.New("allow list and deny list of namespaces are mutually exclusive")
+	}
+
+	n := Namespaces{
+		AllowList:             allow,
+		DenyList:              deny,
+		PrometheusAllowList:   prometheus,
+		AlertmanagerAllowList: alertmanager,
+		ThanosRulerAllowList:  thanosRuler,
+	}
+
+	if len(n.AllowList) == 0 {
+		n.AllowList = map[string]struct{}{v1.NamespaceAll: {}}
+	}
+
+	if len(n.PrometheusAllowList) == 0 {
+		n.PrometheusAllowList = n.AllowList
+	}
+
+	if len(n.AlertmanagerAllowList) == 0 {
+		n.AlertmanagerAllowList = n.AllowList
+	}
+
+	if len(n.ThanosRulerAllowList) == 0 {
+		n.ThanosRulerAllowList = n.AllowList
+	}
+
+	return n, nil
+}
+
+// SetSelectors sets the label selectors of the namespaces. They are mutually
+// exclusive with the allow and deny lists.
+func (n *Namespaces) SetSelectors(allowSelector, denySelector string) error {
+	if allowSelector == "" && denySelector == "" {
+		return nil
+	}
+
+	if _, all := n.AllowList[v1.NamespaceAll]; !all || len(n.AllowList) != 1 || len(n.DenyList) > 0 {
+		return errors.New("namespace selectors and lists of namespaces are mutually exclusive")
+	}
+
+	for _, sel := range []string{allowSelector, denySelector} {
+		if _, err := labels.Parse(sel); err != nil {
+			return fmt.Errorf("invalid namespace selector %q: %w", sel, err)
+		}
+	}
+
+	n.AllowSelector = allowSelector
+	n.DenySelector = denySelector
+
+	return nil
 }
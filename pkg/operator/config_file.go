@@ -0,0 +1,433 @@
+// Copyright 2021 The prometheus-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	// OperatorConfigurationAPIVersion is the only supported version of the
+	// operator's configuration file.
+	OperatorConfigurationAPIVersion = "operator.monitoring.coreos.com/v1alpha1"
+	// OperatorConfigurationKind is the kind of the operator's configuration
+	// file.
+	OperatorConfigurationKind = "OperatorConfiguration"
+)
+
+// OperatorConfiguration is the versioned configuration file of the operator.
+// It is equivalent to the command-line flags and the values defined in the
+// file take precedence over the flags.
+type OperatorConfiguration struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+
+	// Web server of the operator.
+	Web *WebConfiguration `json:"web,omitempty"`
+	// Connection to the Kubernetes API server.
+	KubeClient *KubeClientConfiguration `json:"kubeClient,omitempty"`
+	// Service/Endpoints object to write kubelets into in format "namespace/name".
+	KubeletService string `json:"kubeletService,omitempty"`
+	// Synchronization of the nodes into the kubelet Service/Endpoints
+	// objects.
+	KubeletSync *KubeletSyncConfiguration `json:"kubeletSync,omitempty"`
+	// Image and resources of the config reloader sidecar.
+	ConfigReloader *ConfigReloaderConfiguration `json:"configReloader,omitempty"`
+	// Default base images (path without tag/version) of the managed workloads.
+	DefaultBaseImages *DefaultBaseImagesConfiguration `json:"defaultBaseImages,omitempty"`
+	// Namespaces watched by the operator.
+	Namespaces *NamespacesConfiguration `json:"namespaces,omitempty"`
+	// Labels added to all resources created by the operator.
+	Labels map[string]string `json:"labels,omitempty"`
+	// Label selectors to filter the custom resources managed by the operator.
+	InstanceSelectors *InstanceSelectorsConfiguration `json:"instanceSelectors,omitempty"`
+	// Field selector to filter Secrets to watch.
+	SecretFieldSelector string `json:"secretFieldSelector,omitempty"`
+	// Host used to communicate between local services on a pod.
+	LocalHost string `json:"localHost,omitempty"`
+	// Domain of the cluster, used to generate service FQDNs.
+	ClusterDomain string `json:"clusterDomain,omitempty"`
+	// Log level of the operator.
+	LogLevel string `json:"logLevel,omitempty"`
+	// Log format of the operator.
+	LogFormat string `json:"logFormat,omitempty"`
+}
+
+// WebConfiguration defines the web server settings of the operator.
+type WebConfiguration struct {
+	ListenAddress     string           `json:"listenAddress,omitempty"`
+	EnableTLS         *bool            `json:"enableTLS,omitempty"`
+	CertFile          string           `json:"certFile,omitempty"`
+	KeyFile           string           `json:"keyFile,omitempty"`
+	ClientCAFile      string           `json:"clientCAFile,omitempty"`
+	TLSReloadInterval *metav1.Duration `json:"tlsReloadInterval,omitempty"`
+	TLSMinVersion     string           `json:"tlsMinVersion,omitempty"`
+	TLSCipherSuites   []string         `json:"tlsCipherSuites,omitempty"`
+}
+
+// KubeClientConfiguration defines how the operator connects to the
+// Kubernetes API server.
+type KubeClientConfiguration struct {
+	APIServer   string `json:"apiServer,omitempty"`
+	CertFile    string `json:"certFile,omitempty"`
+	KeyFile     string `json:"keyFile,omitempty"`
+	CAFile      string `json:"caFile,omitempty"`
+	TLSInsecure *bool  `json:"tlsInsecure,omitempty"`
+}
+
+// KubeletSyncConfiguration defines how the nodes are synchronized into the
+// kubelet Service/Endpoints objects.
+type KubeletSyncConfiguration struct {
+	// Label selector of the nodes to synchronize.
+	NodeSelector string `json:"nodeSelector,omitempty"`
+	// Type of the node address published in the endpoints: InternalIP,
+	// ExternalIP or Hostname. When empty, the internal IP is preferred over
+	// the external IP.
+	AddressType string `json:"addressType,omitempty"`
+	// Ports of the kubelet objects, the default kubelet ports apply when
+	// empty.
+	Ports []KubeletPortConfiguration `json:"ports,omitempty"`
+	// Publish the Windows nodes into separate objects suffixed with
+	// "-windows".
+	SplitByOS bool `json:"splitByOS,omitempty"`
+	// Ports of the kubelet objects of the Windows nodes, the ports field
+	// applies when empty.
+	WindowsPorts []KubeletPortConfiguration `json:"windowsPorts,omitempty"`
+	// Node label defining the node pools. The nodes of each pool are
+	// published into separate objects suffixed with the label value.
+	NodePoolLabel string `json:"nodePoolLabel,omitempty"`
+}
+
+// KubeletPortConfiguration defines a port of the kubelet objects.
+type KubeletPortConfiguration struct {
+	Name string `json:"name"`
+	Port int32  `json:"port"`
+}
+
+// ConfigReloaderConfiguration defines the image and the resources of the
+// config reloader sidecar.
+type ConfigReloaderConfiguration struct {
+	Image         string `json:"image,omitempty"`
+	CPURequest    string `json:"cpuRequest,omitempty"`
+	CPULimit      string `json:"cpuLimit,omitempty"`
+	MemoryRequest string `json:"memoryRequest,omitempty"`
+	MemoryLimit   string `json:"memoryLimit,omitempty"`
+}
+
+// DefaultBaseImagesConfiguration defines the default base images of the
+// managed workloads.
+type DefaultBaseImagesConfiguration struct {
+	Alertmanager string `json:"alertmanager,omitempty"`
+	Prometheus   string `json:"prometheus,omitempty"`
+	Thanos       string `json:"thanos,omitempty"`
+}
+
+// NamespacesConfiguration defines the namespaces watched by the operator.
+type NamespacesConfiguration struct {
+	// Allow list, mutually exclusive with the deny list.
+	Allow []string `json:"allow,omitempty"`
+	// Deny list, mutually exclusive with the allow list.
+	Deny []string `json:"deny,omitempty"`
+	// Namespaces where Prometheus custom resources are watched.
+	PrometheusInstances []string `json:"prometheusInstances,omitempty"`
+	// Namespaces where Alertmanager custom resources are watched.
+	AlertmanagerInstances []string `json:"alertmanagerInstances,omitempty"`
+	// Namespaces where ThanosRuler custom resources are watched.
+	ThanosRulerInstances []string `json:"thanosRulerInstances,omitempty"`
+	// Label selector of the namespaces to watch, mutually exclusive with the
+	// allow and deny lists.
+	Selector string `json:"selector,omitempty"`
+	// Label selector of the namespaces not to watch, mutually exclusive with
+	// the allow and deny lists.
+	DenySelector string `json:"denySelector,omitempty"`
+}
+
+// InstanceSelectorsConfiguration defines the label selectors filtering the
+// custom resources managed by the operator.
+type InstanceSelectorsConfiguration struct {
+	Prometheus   *string `json:"prometheus,omitempty"`
+	Alertmanager *string `json:"alertmanager,omitempty"`
+	ThanosRuler  *string `json:"thanosRuler,omitempty"`
+}
+
+// LoadConfigurationFile reads and validates the operator's configuration file.
+func LoadConfigurationFile(filename string) (*OperatorConfiguration, error) {
+	b, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading configuration file")
+	}
+
+	return ParseConfiguration(b)
+}
+
+// ParseConfiguration parses and validates the operator's configuration from
+// its YAML representation. Unknown fields are rejected.
+func ParseConfiguration(b []byte) (*OperatorConfiguration, error) {
+	j, err := yaml.YAMLToJSON(b)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing configuration")
+	}
+
+	var oc OperatorConfiguration
+	dec := json.NewDecoder(bytes.NewReader(j))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&oc); err != nil {
+		return nil, errors.Wrap(err, "parsing configuration")
+	}
+
+	if err := oc.validate(); err != nil {
+		return nil, errors.Wrap(err, "invalid configuration")
+	}
+
+	return &oc, nil
+}
+
+func (oc *OperatorConfiguration) validate() error {
+	if oc.APIVersion != OperatorConfigurationAPIVersion {
+		return fmt.Errorf("unsupported apiVersion %q, expected %q", oc.APIVersion, OperatorConfigurationAPIVersion)
+	}
+
+	if oc.Kind != OperatorConfigurationKind {
+		return fmt.Errorf("unsupported kind %q, expected %q", oc.Kind, OperatorConfigurationKind)
+	}
+
+	if oc.Namespaces != nil && len(oc.Namespaces.Allow) > 0 && len(oc.Namespaces.Deny) > 0 {
+		return errors.New("namespaces.allow and namespaces.deny are mutually exclusive")
+	}
+
+	if oc.ConfigReloader != nil {
+		for name, q := range map[string]string{
+			"cpuRequest":    oc.ConfigReloader.CPURequest,
+			"cpuLimit":      oc.ConfigReloader.CPULimit,
+			"memoryRequest": oc.ConfigReloader.MemoryRequest,
+			"memoryLimit":   oc.ConfigReloader.MemoryLimit,
+		} {
+			if q == "" {
+				continue
+			}
+			if _, err := resource.ParseQuantity(q); err != nil {
+				return errors.Wrapf(err, "configReloader.%s", name)
+			}
+		}
+	}
+
+	if oc.InstanceSelectors != nil {
+		for name, sel := range map[string]*string{
+			"prometheus":   oc.InstanceSelectors.Prometheus,
+			"alertmanager": oc.InstanceSelectors.Alertmanager,
+			"thanosRuler":  oc.InstanceSelectors.ThanosRuler,
+		} {
+			if sel == nil {
+				continue
+			}
+			if _, err := labels.Parse(*sel); err != nil {
+				return errors.Wrapf(err, "instanceSelectors.%s", name)
+			}
+		}
+	}
+
+	if _, err := fields.ParseSelector(oc.SecretFieldSelector); err != nil {
+		return errors.Wrap(err, "secretFieldSelector")
+	}
+
+	if oc.KubeletSync != nil {
+		if err := oc.KubeletSync.validate(); err != nil {
+			return errors.Wrap(err, "kubeletSync")
+		}
+	}
+
+	return nil
+}
+
+func (ks *KubeletSyncConfiguration) validate() error {
+	if _, err := labels.Parse(ks.NodeSelector); err != nil {
+		return errors.Wrap(err, "nodeSelector")
+	}
+
+	switch v1.NodeAddressType(ks.AddressType) {
+	case "", v1.NodeInternalIP, v1.NodeExternalIP, v1.NodeHostName:
+	default:
+		return fmt.Errorf("addressType: unsupported value %q", ks.AddressType)
+	}
+
+	if ks.NodePoolLabel != "" {
+		if errs := validation.IsQualifiedName(ks.NodePoolLabel); len(errs) > 0 {
+			return fmt.Errorf("nodePoolLabel: invalid label name %q: %s", ks.NodePoolLabel, strings.Join(errs, ", "))
+		}
+	}
+
+	for name, ports := range map[string][]KubeletPortConfiguration{
+		"ports":        ks.Ports,
+		"windowsPorts": ks.WindowsPorts,
+	} {
+		names := map[string]struct{}{}
+		for _, p := range ports {
+			if errs := validation.IsValidPortName(p.Name); len(errs) > 0 {
+				return fmt.Errorf("%s: invalid port name %q: %s", name, p.Name, strings.Join(errs, ", "))
+			}
+			if errs := validation.IsValidPortNum(int(p.Port)); len(errs) > 0 {
+				return fmt.Errorf("%s: invalid port number %d: %s", name, p.Port, strings.Join(errs, ", "))
+			}
+			if _, found := names[p.Name]; found {
+				return fmt.Errorf("%s: duplicate port name %q", name, p.Name)
+			}
+			names[p.Name] = struct{}{}
+		}
+	}
+
+	return nil
+}
+
+func (ks *KubeletSyncConfiguration) applyTo(c *KubeletSyncConfig) {
+	toPorts := func(ports []KubeletPortConfiguration) []KubeletPort {
+		if len(ports) == 0 {
+			return nil
+		}
+		ret := make([]KubeletPort, 0, len(ports))
+		for _, p := range ports {
+			ret = append(ret, KubeletPort{Name: p.Name, Port: p.Port})
+		}
+		return ret
+	}
+
+	setString(&c.NodeSelector, ks.NodeSelector)
+	c.AddressType = v1.NodeAddressType(ks.AddressType)
+	c.Ports = toPorts(ks.Ports)
+	c.SplitByOS = ks.SplitByOS
+	c.WindowsPorts = toPorts(ks.WindowsPorts)
+	c.NodePoolLabel = ks.NodePoolLabel
+}
+
+// ApplyTo overrides the values of the given configuration with the values
+// defined in the configuration file.
+func (oc *OperatorConfiguration) ApplyTo(c *Config) error {
+	if web := oc.Web; web != nil {
+		setString(&c.ListenAddress, web.ListenAddress)
+		setString(&c.ServerTLSConfig.CertFile, web.CertFile)
+		setString(&c.ServerTLSConfig.KeyFile, web.KeyFile)
+		setString(&c.ServerTLSConfig.ClientCAFile, web.ClientCAFile)
+		setString(&c.ServerTLSConfig.MinVersion, web.TLSMinVersion)
+		if web.TLSReloadInterval != nil {
+			c.ServerTLSConfig.ReloadInterval = web.TLSReloadInterval.Duration
+		}
+		if len(web.TLSCipherSuites) > 0 {
+			c.ServerTLSConfig.CipherSuites = append([]string(nil), web.TLSCipherSuites...)
+		}
+	}
+
+	if kc := oc.KubeClient; kc != nil {
+		setString(&c.Host, kc.APIServer)
+		setString(&c.TLSConfig.CertFile, kc.CertFile)
+		setString(&c.TLSConfig.KeyFile, kc.KeyFile)
+		setString(&c.TLSConfig.CAFile, kc.CAFile)
+		if kc.TLSInsecure != nil {
+			c.TLSInsecure = *kc.TLSInsecure
+		}
+	}
+
+	if cr := oc.ConfigReloader; cr != nil {
+		setString(&c.ReloaderConfig.Image, cr.Image)
+		setString(&c.ReloaderConfig.CPURequest, cr.CPURequest)
+		setString(&c.ReloaderConfig.CPULimit, cr.CPULimit)
+		setString(&c.ReloaderConfig.MemoryRequest, cr.MemoryRequest)
+		setString(&c.ReloaderConfig.MemoryLimit, cr.MemoryLimit)
+	}
+
+	if bi := oc.DefaultBaseImages; bi != nil {
+		setString(&c.AlertmanagerDefaultBaseImage, bi.Alertmanager)
+		setString(&c.PrometheusDefaultBaseImage, bi.Prometheus)
+		setString(&c.ThanosDefaultBaseImage, bi.Thanos)
+	}
+
+	if ns := oc.Namespaces; ns != nil {
+		namespaces, err := NewNamespaces(
+			toSet(ns.Allow),
+			toSet(ns.Deny),
+			toSet(ns.PrometheusInstances),
+			toSet(ns.AlertmanagerInstances),
+			toSet(ns.ThanosRulerInstances),
+		)
+		if err != nil {
+			return err
+		}
+		if err := namespaces.SetSelectors(ns.Selector, ns.DenySelector); err != nil {
+			return err
+		}
+		c.Namespaces = namespaces
+	}
+
+	if oc.Labels != nil {
+		pairs := make([]string, 0, len(oc.Labels))
+		for k, v := range oc.Labels {
+			pairs = append(pairs, k+"="+v)
+		}
+		sort.Strings(pairs)
+		if err := c.Labels.Set(strings.Join(pairs, ",")); err != nil {
+			return err
+		}
+	}
+
+	if is := oc.InstanceSelectors; is != nil {
+		setStringPtr(&c.PromSelector, is.Prometheus)
+		setStringPtr(&c.AlertManagerSelector, is.Alertmanager)
+		setStringPtr(&c.ThanosRulerSelector, is.ThanosRuler)
+	}
+
+	setString(&c.KubeletObject, oc.KubeletService)
+	if oc.KubeletSync != nil {
+		oc.KubeletSync.applyTo(&c.KubeletSync)
+	}
+	setString(&c.SecretListWatchSelector, oc.SecretFieldSelector)
+	setString(&c.LocalHost, oc.LocalHost)
+	setString(&c.ClusterDomain, oc.ClusterDomain)
+	setString(&c.LogLevel, oc.LogLevel)
+	setString(&c.LogFormat, oc.LogFormat)
+
+	return nil
+}
+
+func setString(dst *string, v string) {
+	if v != "" {
+		*dst = v
+	}
+}
+
+func setStringPtr(dst *string, v *string) {
+	if v != nil {
+		*dst = *v
+	}
+}
+
+func toSet(l []string) map[string]struct{} {
+	s := make(map[string]struct{}, len(l))
+	for _, v := range l {
+		s[v] = struct{}{}
+	}
+	return s
+}
@@ -0,0 +1,282 @@
+// Copyright 2021 The prometheus-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestParseConfiguration(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		in   string
+		ok   bool
+	}{
+		{
+			name: "minimal",
+			in: `
+apiVersion: operator.monitoring.coreos.com/v1alpha1
+kind: OperatorConfiguration
+`,
+			ok: true,
+		},
+		{
+			name: "invalid apiVersion",
+			in: `
+apiVersion: operator.monitoring.coreos.com/v1
+kind: OperatorConfiguration
+`,
+		},
+		{
+			name: "invalid kind",
+			in: `
+apiVersion: operator.monitoring.coreos.com/v1alpha1
+kind: Config
+`,
+		},
+		{
+			name: "unknown field",
+			in: `
+apiVersion: operator.monitoring.coreos.com/v1alpha1
+kind: OperatorConfiguration
+foo: bar
+`,
+		},
+		{
+			name: "allow and deny namespaces",
+			in: `
+apiVersion: operator.monitoring.coreos.com/v1alpha1
+kind: OperatorConfiguration
+namespaces:
+  allow: [a]
+  deny: [b]
+`,
+		},
+		{
+			name: "invalid reloader quantity",
+			in: `
+apiVersion: operator.monitoring.coreos.com/v1alpha1
+kind: OperatorConfiguration
+configReloader:
+  cpuLimit: foo
+`,
+		},
+		{
+			name: "invalid instance selector",
+			in: `
+apiVersion: operator.monitoring.coreos.com/v1alpha1
+kind: OperatorConfiguration
+instanceSelectors:
+  prometheus: "foo in (bar"
+`,
+		},
+		{
+			name: "invalid secret field selector",
+			in: `
+apiVersion: operator.monitoring.coreos.com/v1alpha1
+kind: OperatorConfiguration
+secretFieldSelector: "foo"
+`,
+		},
+		{
+			name: "kubelet sync",
+			in: `
+apiVersion: operator.monitoring.coreos.com/v1alpha1
+kind: OperatorConfiguration
+kubeletSync:
+  nodeSelector: "kubernetes.io/os=linux"
+  addressType: ExternalIP
+  ports:
+  - name: https-metrics
+    port: 10250
+  splitByOS: true
+  nodePoolLabel: cloud.google.com/gke-nodepool
+`,
+			ok: true,
+		},
+		{
+			name: "invalid kubelet address type",
+			in: `
+apiVersion: operator.monitoring.coreos.com/v1alpha1
+kind: OperatorConfiguration
+kubeletSync:
+  addressType: InternalDNS
+`,
+		},
+		{
+			name: "duplicate kubelet port name",
+			in: `
+apiVersion: operator.monitoring.coreos.com/v1alpha1
+kind: OperatorConfiguration
+kubeletSync:
+  ports:
+  - name: https-metrics
+    port: 10250
+  - name: https-metrics
+    port: 10255
+`,
+		},
+		{
+			name: "invalid kubelet port number",
+			in: `
+apiVersion: operator.monitoring.coreos.com/v1alpha1
+kind: OperatorConfiguration
+kubeletSync:
+  windowsPorts:
+  - name: https-metrics
+    port: 0
+`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseConfiguration([]byte(tc.in))
+			if tc.ok && err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if !tc.ok && err == nil {
+				t.Fatal("expected error, got none")
+			}
+		})
+	}
+}
+
+func TestApplyConfiguration(t *testing.T) {
+	oc, err := ParseConfiguration([]byte(`
+apiVersion: operator.monitoring.coreos.com/v1alpha1
+kind: OperatorConfiguration
+web:
+  listenAddress: ":9090"
+  tlsReloadInterval: 5m
+configReloader:
+  cpuLimit: 200m
+defaultBaseImages:
+  prometheus: quay.io/prometheus/prometheus
+namespaces:
+  allow: [default]
+  alertmanagerInstances: [monitoring]
+labels:
+  team: b
+  env: prod
+instanceSelectors:
+  prometheus: ""
+logLevel: debug
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := Config{
+		ListenAddress: ":8080",
+		ReloaderConfig: ReloaderConfig{
+			CPULimit:   "100m",
+			CPURequest: "100m",
+		},
+		PrometheusDefaultBaseImage: "example.com/prometheus",
+		PromSelector:               "foo=bar",
+		AlertManagerSelector:       "foo=bar",
+		LogLevel:                   "info",
+		LogFormat:                  "logfmt",
+	}
+	if err := oc.ApplyTo(&c); err != nil {
+		t.Fatal(err)
+	}
+
+	exp := Config{
+		ListenAddress: ":9090",
+		ServerTLSConfig: TLSServerConfig{
+			ReloadInterval: 5 * time.Minute,
+		},
+		ReloaderConfig: ReloaderConfig{
+			CPULimit:   "200m",
+			CPURequest: "100m",
+		},
+		PrometheusDefaultBaseImage: "quay.io/prometheus/prometheus",
+		Namespaces: Namespaces{
+			AllowList:             map[string]struct{}{"default": {}},
+			DenyList:              map[string]struct{}{},
+			PrometheusAllowList:   map[string]struct{}{"default": {}},
+			AlertmanagerAllowList: map[string]struct{}{"monitoring": {}},
+			ThanosRulerAllowList:  map[string]struct{}{"default": {}},
+		},
+		Labels: Labels{
+			LabelsString: "env=prod,team=b",
+			LabelsMap:    map[string]string{"env": "prod", "team": "b"},
+		},
+		PromSelector:         "",
+		AlertManagerSelector: "foo=bar",
+		LogLevel:             "debug",
+		LogFormat:            "logfmt",
+	}
+	if !reflect.DeepEqual(exp, c) {
+		t.Fatalf("expected %+v, got %+v", exp, c)
+	}
+}
+
+func TestNewNamespaces(t *testing.T) {
+	if _, err := NewNamespaces(
+		map[string]struct{}{"a": {}},
+		map[string]struct{}{"b": {}},
+		nil, nil, nil,
+	); err == nil {
+		t.Fatal("expected error for allow and deny lists, got none")
+	}
+
+	n, err := NewNamespaces(nil, map[string]struct{}{"b": {}}, map[string]struct{}{"c": {}}, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	all := map[string]struct{}{v1.NamespaceAll: {}}
+	if !reflect.DeepEqual(n.AllowList, all) {
+		t.Fatalf("expected allow list %v, got %v", all, n.AllowList)
+	}
+	if !reflect.DeepEqual(n.PrometheusAllowList, map[string]struct{}{"c": {}}) {
+		t.Fatalf("unexpected prometheus allow list %v", n.PrometheusAllowList)
+	}
+	if !reflect.DeepEqual(n.AlertmanagerAllowList, all) {
+		t.Fatalf("expected alertmanager allow list %v, got %v", all, n.AlertmanagerAllowList)
+	}
+	if !reflect.DeepEqual(n.ThanosRulerAllowList, all) {
+		t.Fatalf("expected thanos ruler allow list %v, got %v", all, n.ThanosRulerAllowList)
+	}
+}
+
+func TestNamespacesSetSelectors(t *testing.T) {
+	n, err := NewNamespaces(map[string]struct{}{"a": {}}, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := n.SetSelectors("monitoring=enabled", ""); err == nil {
+		t.Fatal("expected error for selector and allow list, got none")
+	}
+
+	n, err = NewNamespaces(nil, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := n.SetSelectors("monitoring in (", ""); err == nil {
+		t.Fatal("expected error for invalid selector, got none")
+	}
+	if err := n.SetSelectors("monitoring=enabled", "team=foo"); err != nil {
+		t.Fatal(err)
+	}
+	if n.AllowSelector != "monitoring=enabled" || n.DenySelector != "team=foo" {
+		t.Fatalf("unexpected selectors %q and %q", n.AllowSelector, n.DenySelector)
+	}
+}
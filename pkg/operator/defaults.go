@@ -31,6 +31,20 @@ const (
 	DefaultThanosBaseImage = "quay.io/thanos/thanos"
 	// DefaultThanosImage is a default image pulling address for the Thanos long-term prometheus storage collector
 	DefaultThanosImage = DefaultThanosBaseImage + ":" + DefaultThanosVersion
+
+	// DefaultBlackboxExporterVersion is a default image tag for the blackbox exporter deployed for Probers
+	DefaultBlackboxExporterVersion = "v0.20.0"
+	// DefaultBlackboxExporterBaseImage is a base container registry address for the blackbox exporter
+	DefaultBlackboxExporterBaseImage = "quay.io/prometheus/blackbox-exporter"
+	// DefaultBlackboxExporterImage is a default image pulling address for the blackbox exporter
+	DefaultBlackboxExporterImage = DefaultBlackboxExporterBaseImage + ":" + DefaultBlackboxExporterVersion
+
+	// DefaultPromLabelProxyVersion is a default image tag for the prom-label-proxy enforcing query tenancy
+	DefaultPromLabelProxyVersion = "v0.7.0"
+	// DefaultPromLabelProxyBaseImage is a base container registry address for the prom-label-proxy
+	DefaultPromLabelProxyBaseImage = "quay.io/prometheuscommunity/prom-label-proxy"
+	// DefaultPromLabelProxyImage is a default image pulling address for the prom-label-proxy
+	DefaultPromLabelProxyImage = DefaultPromLabelProxyBaseImage + ":" + DefaultPromLabelProxyVersion
 )
 
 var (
@@ -40,6 +54,9 @@ var (
 	DefaultPrometheusBaseImage = "quay.io/prometheus/prometheus"
 	// DefaultPrometheusImage is a default image pulling address for the prometheus
 	DefaultPrometheusImage = DefaultPrometheusBaseImage + ":" + DefaultPrometheusVersion
+	// DefaultPrometheusAgentVersion is a default image tag for the prometheus
+	// running in agent mode, which requires at least v2.32.0
+	DefaultPrometheusAgentVersion = "v2.32.1"
 
 	// DefaultPrometheusConfigReloaderImage is an image that will be used as a sidecar to provide dynamic prometheus
 	// configuration reloading
@@ -43,6 +43,44 @@ var (
 		[]string{"resource", "state"},
 		nil,
 	)
+	configSizeDesc = prometheus.NewDesc(
+		"prometheus_operator_config_size_bytes",
+		"Size in bytes of the configuration generated by the controller for an object, before compression",
+		[]string{"key"},
+		nil,
+	)
+	compressedConfigSizeDesc = prometheus.NewDesc(
+		"prometheus_operator_config_compressed_size_bytes",
+		"Size in bytes of the compressed configuration stored by the controller for an object",
+		[]string{"key"},
+		nil,
+	)
+	scrapeJobsDesc = prometheus.NewDesc(
+		"prometheus_operator_scrape_jobs",
+		"Number of scrape jobs generated by the controller for an object",
+		[]string{"key"},
+		nil,
+	)
+	ruleConfigMapsDesc = prometheus.NewDesc(
+		"prometheus_operator_rule_configmaps",
+		"Number of rule ConfigMaps generated by the controller for an object",
+		[]string{"key"},
+		nil,
+	)
+	ruleSizeDesc = prometheus.NewDesc(
+		"prometheus_operator_rule_size_bytes",
+		"Total size in bytes of the rule files generated by the controller for an object",
+		[]string{"key"},
+		nil,
+	)
+
+	objectDescs = []*prometheus.Desc{
+		configSizeDesc,
+		compressedConfigSizeDesc,
+		scrapeJobsDesc,
+		ruleConfigMapsDesc,
+		ruleSizeDesc,
+	}
 )
 
 // Metrics represents metrics associated to an operator.
@@ -56,17 +94,22 @@ type Metrics struct {
 	reconcileCounter       prometheus.Counter
 	reconcileErrorsCounter prometheus.Counter
 	stsDeleteCreateCounter prometheus.Counter
+	reconcileDuration      prometheus.Histogram
 	// triggerByCounter is a set of counters keeping track of the amount
 	// of times Prometheus Operator was triggered to reconcile its created
 	// objects. It is split in the dimensions of Kubernetes objects and
 	// corresponding actions (add, delete, update).
 	triggerByCounter *prometheus.CounterVec
 	ready            prometheus.Gauge
+	disabledFeatures *prometheus.GaugeVec
 
 	// mtx protects all fields below.
 	mtx       sync.RWMutex
 	syncs     map[string]bool
 	resources map[resourceKey]map[string]int
+	// objects holds the per-object gauge values, indexed by descriptor and
+	// object's key.
+	objects map[*prometheus.Desc]map[string]float64
 }
 
 type resourceKey struct {
@@ -93,6 +136,11 @@ func NewMetrics(name string, r prometheus.Registerer) *Metrics {
 			Help: "Number of times a Kubernetes object add, delete or update event" +
 				" triggered the Prometheus Operator to reconcile an object",
 		}, []string{"triggered_by", "action"}),
+		reconcileDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "prometheus_operator_reconcile_duration_seconds",
+			Help:    "Histogram of reconcile operations' durations",
+			Buckets: []float64{.01, .05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60},
+		}),
 		stsDeleteCreateCounter: prometheus.NewCounter(prometheus.CounterOpts{
 			Name: "prometheus_operator_reconcile_sts_delete_create_total",
 			Help: "Number of times that reconciling a statefulset required deleting and re-creating it",
@@ -117,14 +165,20 @@ func NewMetrics(name string, r prometheus.Registerer) *Metrics {
 			Name: "prometheus_operator_ready",
 			Help: "1 when the controller is ready to reconcile resources, 0 otherwise",
 		}),
+		disabledFeatures: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "prometheus_operator_feature_disabled",
+			Help: "1 when a feature of the controller is disabled because the operator lacks the required permissions",
+		}, []string{"feature"}),
 
 		syncs:     make(map[string]bool),
 		resources: make(map[resourceKey]map[string]int),
+		objects:   make(map[*prometheus.Desc]map[string]float64),
 	}
 
 	m.reg.MustRegister(
 		m.reconcileCounter,
 		m.reconcileErrorsCounter,
+		m.reconcileDuration,
 		m.triggerByCounter,
 		m.stsDeleteCreateCounter,
 		m.listCounter,
@@ -132,6 +186,7 @@ func NewMetrics(name string, r prometheus.Registerer) *Metrics {
 		m.watchCounter,
 		m.watchFailedCounter,
 		m.ready,
+		m.disabledFeatures,
 		&m,
 	)
 
@@ -148,6 +203,12 @@ func (m *Metrics) ReconcileErrorsCounter() prometheus.Counter {
 	return m.reconcileErrorsCounter
 }
 
+// ReconcileDurationHistogram returns a histogram to track the duration of
+// reconcile operations.
+func (m *Metrics) ReconcileDurationHistogram() prometheus.Histogram {
+	return m.reconcileDuration
+}
+
 // StsDeleteCreateCounter returns a counter to track statefulset's recreations.
 func (m *Metrics) StsDeleteCreateCounter() prometheus.Counter {
 	return m.stsDeleteCreateCounter
@@ -197,6 +258,39 @@ func (m *Metrics) setResources(objKey string, resKey resourceKey, v int) {
 	m.resources[resKey][objKey] = v
 }
 
+// SetConfigSize sets the size in bytes of the configuration generated for the
+// given object's key, before and after compression. A negative compressed size
+// means that the configuration isn't compressed.
+func (m *Metrics) SetConfigSize(objKey string, size, compressedSize int) {
+	m.setObjectValue(configSizeDesc, objKey, float64(size))
+	if compressedSize >= 0 {
+		m.setObjectValue(compressedConfigSizeDesc, objKey, float64(compressedSize))
+	}
+}
+
+// SetScrapeJobs sets the number of scrape jobs generated for the given object's key.
+func (m *Metrics) SetScrapeJobs(objKey string, v int) {
+	m.setObjectValue(scrapeJobsDesc, objKey, float64(v))
+}
+
+// SetRuleConfigMaps sets the number of rule ConfigMaps and the total size in
+// bytes of the rule files generated for the given object's key.
+func (m *Metrics) SetRuleConfigMaps(objKey string, configMaps, size int) {
+	m.setObjectValue(ruleConfigMapsDesc, objKey, float64(configMaps))
+	m.setObjectValue(ruleSizeDesc, objKey, float64(size))
+}
+
+func (m *Metrics) setObjectValue(desc *prometheus.Desc, objKey string, v float64) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if _, found := m.objects[desc]; !found {
+		m.objects[desc] = make(map[string]float64)
+	}
+
+	m.objects[desc][objKey] = v
+}
+
 // SetSyncStatus tracks the status of the last sync operation for the given object.
 func (m *Metrics) SetSyncStatus(objKey string, success bool) {
 	m.mtx.Lock()
@@ -216,6 +310,10 @@ func (m *Metrics) ForgetObject(objKey string) {
 	for k := range m.resources {
 		delete(m.resources[k], objKey)
 	}
+
+	for desc := range m.objects {
+		delete(m.objects[desc], objKey)
+	}
 }
 
 // Ready returns a gauge to track whether the controller is ready or not.
@@ -223,6 +321,12 @@ func (m *Metrics) Ready() prometheus.Gauge {
 	return m.ready
 }
 
+// DisableFeature records that the given feature of the controller is
+// disabled.
+func (m *Metrics) DisableFeature(feature string) {
+	m.disabledFeatures.WithLabelValues(feature).Set(1)
+}
+
 // MustRegister registers metrics with the Metrics registerer.
 func (m *Metrics) MustRegister(metrics ...prometheus.Collector) {
 	m.reg.MustRegister(metrics...)
@@ -232,6 +336,9 @@ func (m *Metrics) MustRegister(metrics ...prometheus.Collector) {
 func (m *Metrics) Describe(ch chan<- *prometheus.Desc) {
 	ch <- resourcesDesc
 	ch <- syncsDesc
+	for _, desc := range objectDescs {
+		ch <- desc
+	}
 }
 
 // Collect implements the prometheus.Collector interface.
@@ -274,6 +381,17 @@ func (m *Metrics) Collect(ch chan<- prometheus.Metric) {
 			rKey.state.String(),
 		)
 	}
+
+	for desc, values := range m.objects {
+		for objKey, v := range values {
+			ch <- prometheus.MustNewConstMetric(
+				desc,
+				prometheus.GaugeValue,
+				v,
+				objKey,
+			)
+		}
+	}
 }
 
 type instrumentedListerWatcher struct {
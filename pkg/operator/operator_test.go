@@ -0,0 +1,73 @@
+// Copyright 2021 The prometheus-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestObjectMetrics(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m := NewMetrics("prometheus", reg)
+
+	m.SetConfigSize("ns/foo", 2048, 512)
+	m.SetScrapeJobs("ns/foo", 3)
+	m.SetRuleConfigMaps("ns/foo", 1, 100)
+	m.SetConfigSize("ns/bar", 1024, -1)
+
+	expected := `
+# HELP prometheus_operator_config_compressed_size_bytes Size in bytes of the compressed configuration stored by the controller for an object
+# TYPE prometheus_operator_config_compressed_size_bytes gauge
+prometheus_operator_config_compressed_size_bytes{controller="prometheus",key="ns/foo"} 512
+# HELP prometheus_operator_config_size_bytes Size in bytes of the configuration generated by the controller for an object, before compression
+# TYPE prometheus_operator_config_size_bytes gauge
+prometheus_operator_config_size_bytes{controller="prometheus",key="ns/bar"} 1024
+prometheus_operator_config_size_bytes{controller="prometheus",key="ns/foo"} 2048
+# HELP prometheus_operator_rule_configmaps Number of rule ConfigMaps generated by the controller for an object
+# TYPE prometheus_operator_rule_configmaps gauge
+prometheus_operator_rule_configmaps{controller="prometheus",key="ns/foo"} 1
+# HELP prometheus_operator_rule_size_bytes Total size in bytes of the rule files generated by the controller for an object
+# TYPE prometheus_operator_rule_size_bytes gauge
+prometheus_operator_rule_size_bytes{controller="prometheus",key="ns/foo"} 100
+# HELP prometheus_operator_scrape_jobs Number of scrape jobs generated by the controller for an object
+# TYPE prometheus_operator_scrape_jobs gauge
+prometheus_operator_scrape_jobs{controller="prometheus",key="ns/foo"} 3
+`
+	names := []string{
+		"prometheus_operator_config_compressed_size_bytes",
+		"prometheus_operator_config_size_bytes",
+		"prometheus_operator_rule_configmaps",
+		"prometheus_operator_rule_size_bytes",
+		"prometheus_operator_scrape_jobs",
+	}
+	if err := testutil.GatherAndCompare(reg, strings.NewReader(expected), names...); err != nil {
+		t.Fatal(err)
+	}
+
+	m.ForgetObject("ns/foo")
+
+	expected = `
+# HELP prometheus_operator_config_size_bytes Size in bytes of the configuration generated by the controller for an object, before compression
+# TYPE prometheus_operator_config_size_bytes gauge
+prometheus_operator_config_size_bytes{controller="prometheus",key="ns/bar"} 1024
+`
+	if err := testutil.GatherAndCompare(reg, strings.NewReader(expected), names...); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright 2023 The prometheus-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	clientauthv1 "k8s.io/client-go/kubernetes/typed/authorization/v1"
+
+	"github.com/prometheus-operator/prometheus-operator/pkg/k8sutil"
+	"github.com/prometheus-operator/prometheus-operator/pkg/listwatch"
+)
+
+// MissingPermissionsError is returned when the operator lacks the permissions
+// or the custom resource definitions required to run a controller.
+type MissingPermissionsError struct {
+	Controller string
+	Errors     []error
+}
+
+func (e *MissingPermissionsError) Error() string {
+	msgs := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		msgs = append(msgs, err.Error())
+	}
+	return fmt.Sprintf("%s controller: %s", e.Controller, strings.Join(msgs, ", "))
+}
+
+// CheckPermissions returns the permissions from the given attributes which
+// are missing in the namespaces of the allow list.
+// If the permissions can't be verified, the error is logged and the
+// permissions are assumed to be granted.
+func CheckPermissions(
+	ctx context.Context,
+	logger log.Logger,
+	ssarClient clientauthv1.SelfSubjectAccessReviewInterface,
+	allowList map[string]struct{},
+	attributes ...k8sutil.ResourceAttribute,
+) []error {
+	namespaces := make([]string, 0, len(allowList))
+	for ns := range allowList {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+
+	_, missing, err := k8sutil.IsAllowed(ctx, ssarClient, namespaces, attributes...)
+	if err != nil {
+		level.Warn(logger).Log("msg", "failed to verify the permissions of the operator", "err", err)
+		return nil
+	}
+
+	return missing
+}
+
+// NamespacedAttributes associates RBAC attributes with the namespaces in
+// which they are required.
+type NamespacedAttributes struct {
+	AllowList  map[string]struct{}
+	Attributes []k8sutil.ResourceAttribute
+}
+
+// CheckControllerPermissions verifies that the operator is allowed to watch
+// the resources of a controller as well as the namespaces in which they live.
+// It returns a *MissingPermissionsError when the controller can't run.
+// Otherwise the returned bool is false when the labels of the namespaces
+// can't be read, in which case the namespace label selectors don't match any
+// namespace.
+func CheckControllerPermissions(
+	ctx context.Context,
+	logger log.Logger,
+	ssarClient clientauthv1.SelfSubjectAccessReviewInterface,
+	controller string,
+	required ...NamespacedAttributes,
+) (bool, error) {
+	var (
+		missing         []error
+		namespaceLabels = true
+		checked         []map[string]struct{}
+	)
+
+	for _, r := range required {
+		missing = append(missing, CheckPermissions(ctx, logger, ssarClient, r.AllowList, r.Attributes...)...)
+
+		if containsNamespaces(checked, r.AllowList) {
+			continue
+		}
+		checked = append(checked, r.AllowList)
+
+		nsMissing, fatal := checkNamespacePermissions(ctx, logger, ssarClient, r.AllowList)
+		if len(nsMissing) == 0 {
+			continue
+		}
+
+		if fatal {
+			missing = append(missing, nsMissing...)
+			continue
+		}
+
+		namespaceLabels = false
+		for _, err := range nsMissing {
+			level.Warn(logger).Log("msg", "namespace label selectors are disabled", "err", err)
+		}
+	}
+
+	if len(missing) > 0 {
+		return false, &MissingPermissionsError{Controller: controller, Errors: missing}
+	}
+
+	return namespaceLabels, nil
+}
+
+func containsNamespaces(allowLists []map[string]struct{}, allowList map[string]struct{}) bool {
+	for _, l := range allowLists {
+		if listwatch.IdenticalNamespaces(l, allowList) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkNamespacePermissions returns the permissions which are missing to read
+// the namespaces of the allow list. When all namespaces are selected, the
+// namespaces need to be listed and watched and the returned bool is true if
+// it isn't allowed. Otherwise the namespaces are fetched one by one and the
+// controller can run without knowing their labels.
+func checkNamespacePermissions(
+	ctx context.Context,
+	logger log.Logger,
+	ssarClient clientauthv1.SelfSubjectAccessReviewInterface,
+	allowList map[string]struct{},
+) ([]error, bool) {
+	if listwatch.IsAllNamespaces(allowList) {
+		return CheckPermissions(ctx, logger, ssarClient, nil, k8sutil.ResourceAttribute{
+			Resource: "namespaces",
+			Verbs:    []string{"list", "watch"},
+		}), true
+	}
+
+	attributes := make([]k8sutil.ResourceAttribute, 0, len(allowList))
+	for ns := range allowList {
+		attributes = append(attributes, k8sutil.ResourceAttribute{
+			Resource: "namespaces",
+			Name:     ns,
+			Verbs:    []string{"get"},
+		})
+	}
+	sort.Slice(attributes, func(i, j int) bool { return attributes[i].Name < attributes[j].Name })
+
+	return CheckPermissions(ctx, logger, ssarClient, nil, attributes...), false
+}
+
+// ListWatchAttributes returns the attributes required to watch the given
+// resources of the API group.
+func ListWatchAttributes(group string, resources ...string) []k8sutil.ResourceAttribute {
+	attributes := make([]k8sutil.ResourceAttribute, 0, len(resources))
+	for _, resource := range resources {
+		attributes = append(attributes, k8sutil.ResourceAttribute{
+			Group:    group,
+			Resource: resource,
+			Verbs:    []string{"list", "watch"},
+		})
+	}
+	return attributes
+}
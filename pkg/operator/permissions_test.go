@@ -0,0 +1,97 @@
+// Copyright 2023 The prometheus-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-kit/log"
+	"github.com/pkg/errors"
+	authv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+func TestCheckControllerPermissions(t *testing.T) {
+	for _, tc := range []struct {
+		name            string
+		allowList       map[string]struct{}
+		denied          map[string]bool
+		namespaceLabels bool
+		err             bool
+	}{
+		{
+			name:            "all allowed",
+			allowList:       map[string]struct{}{"": {}},
+			namespaceLabels: true,
+		},
+		{
+			name:      "custom resource denied",
+			allowList: map[string]struct{}{"": {}},
+			denied:    map[string]bool{"servicemonitors": true},
+			err:       true,
+		},
+		{
+			name:      "namespace list denied",
+			allowList: map[string]struct{}{"": {}},
+			denied:    map[string]bool{"namespaces": true},
+			err:       true,
+		},
+		{
+			name:            "namespace get denied",
+			allowList:       map[string]struct{}{"default": {}},
+			denied:          map[string]bool{"namespaces": true},
+			namespaceLabels: false,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := fake.NewSimpleClientset()
+			c.PrependReactor("create", "selfsubjectaccessreviews", func(action clienttesting.Action) (bool, runtime.Object, error) {
+				ssar := action.(clienttesting.CreateAction).GetObject().(*authv1.SelfSubjectAccessReview)
+				ssar.Status.Allowed = !tc.denied[ssar.Spec.ResourceAttributes.Resource]
+				return true, ssar, nil
+			})
+
+			namespaceLabels, err := CheckControllerPermissions(
+				context.Background(),
+				log.NewNopLogger(),
+				c.AuthorizationV1().SelfSubjectAccessReviews(),
+				"test",
+				NamespacedAttributes{
+					AllowList:  tc.allowList,
+					Attributes: ListWatchAttributes("monitoring.coreos.com", "servicemonitors"),
+				},
+			)
+
+			if tc.err {
+				var mpe *MissingPermissionsError
+				if !errors.As(err, &mpe) {
+					t.Fatalf("expected missing permissions error, got %v", err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if namespaceLabels != tc.namespaceLabels {
+				t.Fatalf("expected namespace labels to be %v, got %v", tc.namespaceLabels, namespaceLabels)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright 2023 The prometheus-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"encoding/json"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+)
+
+// ApplyPodTemplateOverride applies the override to the pod template. The
+// template is left untouched if the override is nil.
+func ApplyPodTemplateOverride(tmpl *v1.PodTemplateSpec, override *monitoringv1.PodTemplateOverride) error {
+	if override == nil {
+		return nil
+	}
+
+	patch, err := yaml.YAMLToJSON([]byte(override.Patch))
+	if err != nil {
+		return errors.Wrap(err, "failed to parse the pod template override")
+	}
+
+	original, err := json.Marshal(tmpl)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the pod template")
+	}
+
+	var patched []byte
+	switch override.Type {
+	case "", monitoringv1.StrategicMergePodTemplateOverride:
+		patched, err = strategicpatch.StrategicMergePatch(original, patch, v1.PodTemplateSpec{})
+		if err != nil {
+			return errors.Wrap(err, "failed to apply the strategic merge patch to the pod template")
+		}
+	case monitoringv1.JSONPatchPodTemplateOverride:
+		p, err := jsonpatch.DecodePatch(patch)
+		if err != nil {
+			return errors.Wrap(err, "failed to decode the JSON patch")
+		}
+		patched, err = p.Apply(original)
+		if err != nil {
+			return errors.Wrap(err, "failed to apply the JSON patch to the pod template")
+		}
+	default:
+		return errors.Errorf("unsupported pod template override type %q", override.Type)
+	}
+
+	var result v1.PodTemplateSpec
+	if err := json.Unmarshal(patched, &result); err != nil {
+		return errors.Wrap(err, "failed to unmarshal the patched pod template")
+	}
+	*tmpl = result
+
+	return nil
+}
+
+// DNSPolicy returns the DNS policy of pods. Pods running in the host's network
+// namespace need the ClusterFirstWithHostNet policy to resolve cluster names.
+func DNSPolicy(policy v1.DNSPolicy, hostNetwork bool) v1.DNSPolicy {
+	if policy == "" && hostNetwork {
+		return v1.DNSClusterFirstWithHostNet
+	}
+	return policy
+}
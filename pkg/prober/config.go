@@ -0,0 +1,212 @@
+// Copyright 2022 The prometheus-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prober
+
+import (
+	"github.com/pkg/errors"
+	"github.com/prometheus/common/model"
+	"gopkg.in/yaml.v2"
+
+	monitoringv1alpha1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1alpha1"
+)
+
+// generateConfig returns the blackbox exporter configuration assembled from
+// the modules of the Prober.
+func generateConfig(p *monitoringv1alpha1.Prober) ([]byte, error) {
+	modules := yaml.MapSlice{}
+	seen := map[string]struct{}{}
+
+	for i, m := range p.Spec.Modules {
+		if _, ok := seen[m.Name]; ok {
+			return nil, errors.Errorf("modules[%d]: duplicate module name %q", i, m.Name)
+		}
+		seen[m.Name] = struct{}{}
+
+		module, err := generateModule(m)
+		if err != nil {
+			return nil, errors.Wrapf(err, "modules[%d]", i)
+		}
+		modules = append(modules, yaml.MapItem{Key: m.Name, Value: module})
+	}
+
+	return yaml.Marshal(yaml.MapSlice{{Key: "modules", Value: modules}})
+}
+
+func generateModule(m monitoringv1alpha1.BlackboxModule) (yaml.MapSlice, error) {
+	cfg := yaml.MapSlice{{Key: "prober", Value: m.Prober}}
+
+	if m.Timeout != "" {
+		if _, err := model.ParseDuration(m.Timeout); err != nil {
+			return nil, errors.Wrap(err, "invalid timeout")
+		}
+		cfg = append(cfg, yaml.MapItem{Key: "timeout", Value: m.Timeout})
+	}
+
+	// Only the configuration block matching the prober may be set.
+	for prober, set := range map[string]bool{
+		"http": m.HTTP != nil,
+		"tcp":  m.TCP != nil,
+		"icmp": m.ICMP != nil,
+		"dns":  m.DNS != nil,
+		"grpc": m.GRPC != nil,
+	} {
+		if set && prober != m.Prober {
+			return nil, errors.Errorf("%s configuration can't be set for the %s prober", prober, m.Prober)
+		}
+	}
+
+	switch {
+	case m.HTTP != nil:
+		cfg = append(cfg, yaml.MapItem{Key: "http", Value: generateHTTPProbe(m.HTTP)})
+	case m.TCP != nil:
+		cfg = append(cfg, yaml.MapItem{Key: "tcp", Value: generateTCPProbe(m.TCP)})
+	case m.ICMP != nil:
+		cfg = append(cfg, yaml.MapItem{Key: "icmp", Value: generateICMPProbe(m.ICMP)})
+	case m.DNS != nil:
+		cfg = append(cfg, yaml.MapItem{Key: "dns", Value: generateDNSProbe(m.DNS)})
+	case m.GRPC != nil:
+		cfg = append(cfg, yaml.MapItem{Key: "grpc", Value: generateGRPCProbe(m.GRPC)})
+	}
+
+	return cfg, nil
+}
+
+func addIPProtocol(cfg yaml.MapSlice, preferred string, fallback *bool) yaml.MapSlice {
+	if preferred != "" {
+		cfg = append(cfg, yaml.MapItem{Key: "preferred_ip_protocol", Value: preferred})
+	}
+	if fallback != nil {
+		cfg = append(cfg, yaml.MapItem{Key: "ip_protocol_fallback", Value: *fallback})
+	}
+	return cfg
+}
+
+func addInsecureSkipVerify(cfg yaml.MapSlice, insecure bool) yaml.MapSlice {
+	if insecure {
+		cfg = append(cfg, yaml.MapItem{Key: "tls_config", Value: yaml.MapSlice{{Key: "insecure_skip_verify", Value: true}}})
+	}
+	return cfg
+}
+
+func generateHTTPProbe(h *monitoringv1alpha1.BlackboxHTTPProbe) yaml.MapSlice {
+	cfg := yaml.MapSlice{}
+
+	if len(h.ValidStatusCodes) > 0 {
+		cfg = append(cfg, yaml.MapItem{Key: "valid_status_codes", Value: h.ValidStatusCodes})
+	}
+	if len(h.ValidHTTPVersions) > 0 {
+		cfg = append(cfg, yaml.MapItem{Key: "valid_http_versions", Value: h.ValidHTTPVersions})
+	}
+	if h.Method != "" {
+		cfg = append(cfg, yaml.MapItem{Key: "method", Value: h.Method})
+	}
+	if len(h.Headers) > 0 {
+		cfg = append(cfg, yaml.MapItem{Key: "headers", Value: h.Headers})
+	}
+	if h.Body != "" {
+		cfg = append(cfg, yaml.MapItem{Key: "body", Value: h.Body})
+	}
+	if h.FollowRedirects != nil {
+		cfg = append(cfg, yaml.MapItem{Key: "follow_redirects", Value: *h.FollowRedirects})
+	}
+	if h.FailIfSSL {
+		cfg = append(cfg, yaml.MapItem{Key: "fail_if_ssl", Value: true})
+	}
+	if h.FailIfNotSSL {
+		cfg = append(cfg, yaml.MapItem{Key: "fail_if_not_ssl", Value: true})
+	}
+	if len(h.FailIfBodyMatchesRegexp) > 0 {
+		cfg = append(cfg, yaml.MapItem{Key: "fail_if_body_matches_regexp", Value: h.FailIfBodyMatchesRegexp})
+	}
+	if len(h.FailIfBodyNotMatchesRegexp) > 0 {
+		cfg = append(cfg, yaml.MapItem{Key: "fail_if_body_not_matches_regexp", Value: h.FailIfBodyNotMatchesRegexp})
+	}
+	cfg = addIPProtocol(cfg, h.PreferredIPProtocol, h.IPProtocolFallback)
+
+	return addInsecureSkipVerify(cfg, h.InsecureSkipVerify)
+}
+
+func generateTCPProbe(t *monitoringv1alpha1.BlackboxTCPProbe) yaml.MapSlice {
+	cfg := addIPProtocol(yaml.MapSlice{}, t.PreferredIPProtocol, t.IPProtocolFallback)
+
+	if len(t.QueryResponse) > 0 {
+		steps := make([]yaml.MapSlice, 0, len(t.QueryResponse))
+		for _, qr := range t.QueryResponse {
+			step := yaml.MapSlice{}
+			if qr.Expect != "" {
+				step = append(step, yaml.MapItem{Key: "expect", Value: qr.Expect})
+			}
+			if qr.Send != "" {
+				step = append(step, yaml.MapItem{Key: "send", Value: qr.Send})
+			}
+			if qr.StartTLS {
+				step = append(step, yaml.MapItem{Key: "starttls", Value: true})
+			}
+			steps = append(steps, step)
+		}
+		cfg = append(cfg, yaml.MapItem{Key: "query_response", Value: steps})
+	}
+	if t.TLS {
+		cfg = append(cfg, yaml.MapItem{Key: "tls", Value: true})
+	}
+
+	return addInsecureSkipVerify(cfg, t.InsecureSkipVerify)
+}
+
+func generateICMPProbe(i *monitoringv1alpha1.BlackboxICMPProbe) yaml.MapSlice {
+	cfg := addIPProtocol(yaml.MapSlice{}, i.PreferredIPProtocol, i.IPProtocolFallback)
+
+	if i.PayloadSize > 0 {
+		cfg = append(cfg, yaml.MapItem{Key: "payload_size", Value: i.PayloadSize})
+	}
+	if i.DontFragment {
+		cfg = append(cfg, yaml.MapItem{Key: "dont_fragment", Value: true})
+	}
+
+	return cfg
+}
+
+func generateDNSProbe(d *monitoringv1alpha1.BlackboxDNSProbe) yaml.MapSlice {
+	cfg := yaml.MapSlice{{Key: "query_name", Value: d.QueryName}}
+
+	if d.QueryType != "" {
+		cfg = append(cfg, yaml.MapItem{Key: "query_type", Value: d.QueryType})
+	}
+	if d.TransportProtocol != "" {
+		cfg = append(cfg, yaml.MapItem{Key: "transport_protocol", Value: d.TransportProtocol})
+	}
+	if len(d.ValidRcodes) > 0 {
+		cfg = append(cfg, yaml.MapItem{Key: "valid_rcodes", Value: d.ValidRcodes})
+	}
+	if d.Recursion != nil {
+		cfg = append(cfg, yaml.MapItem{Key: "recursion_desired", Value: *d.Recursion})
+	}
+
+	return addIPProtocol(cfg, d.PreferredIPProtocol, d.IPProtocolFallback)
+}
+
+func generateGRPCProbe(g *monitoringv1alpha1.BlackboxGRPCProbe) yaml.MapSlice {
+	cfg := yaml.MapSlice{}
+
+	if g.Service != "" {
+		cfg = append(cfg, yaml.MapItem{Key: "service", Value: g.Service})
+	}
+	if g.TLS {
+		cfg = append(cfg, yaml.MapItem{Key: "tls", Value: true})
+	}
+	cfg = addIPProtocol(cfg, g.PreferredIPProtocol, g.IPProtocolFallback)
+
+	return addInsecureSkipVerify(cfg, g.InsecureSkipVerify)
+}
@@ -0,0 +1,170 @@
+// Copyright 2022 The prometheus-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prober
+
+import (
+	"testing"
+
+	monitoringv1alpha1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1alpha1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateConfig(t *testing.T) {
+	falseVal := false
+
+	for _, tc := range []struct {
+		name     string
+		modules  []monitoringv1alpha1.BlackboxModule
+		expected string
+		err      bool
+	}{
+		{
+			name: "http module",
+			modules: []monitoringv1alpha1.BlackboxModule{
+				{
+					Name:    "http_2xx",
+					Prober:  "http",
+					Timeout: "5s",
+					HTTP: &monitoringv1alpha1.BlackboxHTTPProbe{
+						ValidStatusCodes:    []int{200, 204},
+						Method:              "POST",
+						Headers:             map[string]string{"X-B": "b", "X-A": "a"},
+						FollowRedirects:     &falseVal,
+						PreferredIPProtocol: "ip4",
+						InsecureSkipVerify:  true,
+					},
+				},
+			},
+			expected: `modules:
+  http_2xx:
+    prober: http
+    timeout: 5s
+    http:
+      valid_status_codes:
+      - 200
+      - 204
+      method: POST
+      headers:
+        X-A: a
+        X-B: b
+      follow_redirects: false
+      preferred_ip_protocol: ip4
+      tls_config:
+        insecure_skip_verify: true
+`,
+		},
+		{
+			name: "multiple modules",
+			modules: []monitoringv1alpha1.BlackboxModule{
+				{
+					Name:   "tcp_connect",
+					Prober: "tcp",
+					TCP: &monitoringv1alpha1.BlackboxTCPProbe{
+						QueryResponse: []monitoringv1alpha1.BlackboxQueryResponse{
+							{Expect: "^SSH-2.0-"},
+							{Send: "QUIT", StartTLS: true},
+						},
+					},
+				},
+				{
+					Name:   "icmp",
+					Prober: "icmp",
+				},
+				{
+					Name:   "dns",
+					Prober: "dns",
+					DNS: &monitoringv1alpha1.BlackboxDNSProbe{
+						QueryName:         "example.com",
+						QueryType:         "A",
+						TransportProtocol: "udp",
+						Recursion:         &falseVal,
+					},
+				},
+				{
+					Name:   "grpc",
+					Prober: "grpc",
+					GRPC: &monitoringv1alpha1.BlackboxGRPCProbe{
+						Service: "health",
+						TLS:     true,
+					},
+				},
+			},
+			expected: `modules:
+  tcp_connect:
+    prober: tcp
+    tcp:
+      query_response:
+      - expect: ^SSH-2.0-
+      - send: QUIT
+        starttls: true
+  icmp:
+    prober: icmp
+  dns:
+    prober: dns
+    dns:
+      query_name: example.com
+      query_type: A
+      transport_protocol: udp
+      recursion_desired: false
+  grpc:
+    prober: grpc
+    grpc:
+      service: health
+      tls: true
+`,
+		},
+		{
+			name: "duplicate module names",
+			modules: []monitoringv1alpha1.BlackboxModule{
+				{Name: "icmp", Prober: "icmp"},
+				{Name: "icmp", Prober: "icmp"},
+			},
+			err: true,
+		},
+		{
+			name: "invalid timeout",
+			modules: []monitoringv1alpha1.BlackboxModule{
+				{Name: "icmp", Prober: "icmp", Timeout: "5"},
+			},
+			err: true,
+		},
+		{
+			name: "configuration not matching the prober",
+			modules: []monitoringv1alpha1.BlackboxModule{
+				{
+					Name:   "http_2xx",
+					Prober: "http",
+					TCP:    &monitoringv1alpha1.BlackboxTCPProbe{TLS: true},
+				},
+			},
+			err: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg, err := generateConfig(&monitoringv1alpha1.Prober{
+				Spec: monitoringv1alpha1.BlackboxProberSpec{
+					Modules: tc.modules,
+				},
+			})
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, string(cfg))
+		})
+	}
+}
@@ -0,0 +1,233 @@
+// Copyright 2022 The prometheus-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prober
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+
+	monitoringv1alpha1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1alpha1"
+	"github.com/prometheus-operator/prometheus-operator/pkg/operator"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	proberNameLabelName = "operator.prometheus.io/prober-name"
+	inputHashName       = "prometheus-operator-input-hash"
+
+	configDir      = "/etc/blackbox_exporter/config"
+	configFilename = "blackbox.yml"
+	defaultPort    = 9115
+	portName       = "http"
+)
+
+// resourceName returns the name of the Deployment, Service and Secret
+// created for the Prober.
+func resourceName(name string) string {
+	return "prober-" + name
+}
+
+// configSecretName returns the name of the Secret holding the configuration
+// of the blackbox exporter.
+func configSecretName(name string) string {
+	return resourceName(name) + "-config"
+}
+
+// ServiceAddress returns the address of the blackbox exporter deployed for
+// the Prober with the given name and namespace.
+func ServiceAddress(name, namespace string) string {
+	return fmt.Sprintf("%s.%s.svc:%d", resourceName(name), namespace, defaultPort)
+}
+
+func ownerReference(p *monitoringv1alpha1.Prober) metav1.OwnerReference {
+	boolTrue := true
+	return metav1.OwnerReference{
+		APIVersion:         monitoringv1alpha1.SchemeGroupVersion.String(),
+		Kind:               monitoringv1alpha1.ProberKind,
+		Name:               p.Name,
+		UID:                p.UID,
+		BlockOwnerDeletion: &boolTrue,
+		Controller:         &boolTrue,
+	}
+}
+
+func selectorLabels(p *monitoringv1alpha1.Prober) map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/name":       "blackbox-exporter",
+		"app.kubernetes.io/managed-by": "prometheus-operator",
+		"app.kubernetes.io/instance":   p.Name,
+		proberNameLabelName:            p.Name,
+	}
+}
+
+func makeConfigSecret(p *monitoringv1alpha1.Prober, config Config, data []byte) *v1.Secret {
+	return &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            configSecretName(p.Name),
+			Labels:          config.Labels.Merge(map[string]string{proberNameLabelName: p.Name}),
+			OwnerReferences: []metav1.OwnerReference{ownerReference(p)},
+		},
+		Data: map[string][]byte{
+			configFilename: data,
+		},
+	}
+}
+
+func makeService(p *monitoringv1alpha1.Prober, config Config) *v1.Service {
+	return &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            resourceName(p.Name),
+			Labels:          config.Labels.Merge(map[string]string{proberNameLabelName: p.Name}),
+			OwnerReferences: []metav1.OwnerReference{ownerReference(p)},
+		},
+		Spec: v1.ServiceSpec{
+			Ports: []v1.ServicePort{
+				{
+					Name:       portName,
+					Port:       defaultPort,
+					TargetPort: intstr.FromString(portName),
+				},
+			},
+			Selector: selectorLabels(p),
+		},
+	}
+}
+
+func makeDeployment(p *monitoringv1alpha1.Prober, config Config, inputHash string) *appsv1.Deployment {
+	image := operator.DefaultBlackboxExporterImage
+	if p.Spec.Image != nil && *p.Spec.Image != "" {
+		image = *p.Spec.Image
+	}
+
+	replicas := int32(1)
+	if p.Spec.Replicas != nil {
+		replicas = *p.Spec.Replicas
+	}
+
+	podLabels := map[string]string{}
+	podAnnotations := map[string]string{}
+	if p.Spec.PodMetadata != nil {
+		for k, v := range p.Spec.PodMetadata.Labels {
+			podLabels[k] = v
+		}
+		for k, v := range p.Spec.PodMetadata.Annotations {
+			podAnnotations[k] = v
+		}
+	}
+	podAnnotations["kubectl.kubernetes.io/default-container"] = "blackbox-exporter"
+	selector := selectorLabels(p)
+	for k, v := range selector {
+		podLabels[k] = v
+	}
+
+	args := []string{
+		fmt.Sprintf("--config.file=%s", path.Join(configDir, configFilename)),
+		fmt.Sprintf("--web.listen-address=:%d", defaultPort),
+	}
+	if p.Spec.LogLevel != "" {
+		args = append(args, fmt.Sprintf("--log.level=%s", p.Spec.LogLevel))
+	}
+
+	configMount := v1.VolumeMount{
+		Name:      "config",
+		MountPath: configDir,
+		ReadOnly:  true,
+	}
+
+	probe := &v1.Probe{
+		Handler: v1.Handler{
+			HTTPGet: &v1.HTTPGetAction{
+				Path: "/-/healthy",
+				Port: intstr.FromString(portName),
+			},
+		},
+	}
+
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            resourceName(p.Name),
+			Labels:          config.Labels.Merge(map[string]string{proberNameLabelName: p.Name}),
+			Annotations:     map[string]string{inputHashName: inputHash},
+			OwnerReferences: []metav1.OwnerReference{ownerReference(p)},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: selector,
+			},
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      podLabels,
+					Annotations: podAnnotations,
+				},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
+						{
+							Name:  "blackbox-exporter",
+							Image: image,
+							Args:  args,
+							Ports: []v1.ContainerPort{
+								{
+									Name:          portName,
+									ContainerPort: defaultPort,
+									Protocol:      v1.ProtocolTCP,
+								},
+							},
+							VolumeMounts:             []v1.VolumeMount{configMount},
+							LivenessProbe:            probe,
+							ReadinessProbe:           probe,
+							Resources:                p.Spec.Resources,
+							TerminationMessagePolicy: v1.TerminationMessageFallbackToLogsOnError,
+						},
+						operator.CreateConfigReloader(
+							"config-reloader",
+							operator.ReloaderResources(config.ReloaderConfig),
+							operator.ReloaderURL(url.URL{
+								Scheme: "http",
+								Host:   fmt.Sprintf("%s:%d", config.LocalHost, defaultPort),
+								Path:   "/-/reload",
+							}),
+							operator.LocalHost(config.LocalHost),
+							operator.WatchedDirectories([]string{configDir}),
+							operator.VolumeMounts([]v1.VolumeMount{configMount}),
+							operator.Shard(-1),
+						),
+					},
+					Volumes: []v1.Volume{
+						{
+							Name: "config",
+							VolumeSource: v1.VolumeSource{
+								Secret: &v1.SecretVolumeSource{
+									SecretName: configSecretName(p.Name),
+								},
+							},
+						},
+					},
+					ImagePullSecrets:   p.Spec.ImagePullSecrets,
+					NodeSelector:       p.Spec.NodeSelector,
+					Tolerations:        p.Spec.Tolerations,
+					Affinity:           p.Spec.Affinity,
+					SecurityContext:    p.Spec.SecurityContext,
+					ServiceAccountName: p.Spec.ServiceAccountName,
+				},
+			},
+		},
+	}
+}
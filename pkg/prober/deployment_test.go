@@ -0,0 +1,89 @@
+// Copyright 2022 The prometheus-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prober
+
+import (
+	"testing"
+
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	monitoringv1alpha1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1alpha1"
+	"github.com/prometheus-operator/prometheus-operator/pkg/operator"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var defaultTestConfig = Config{
+	ReloaderConfig: operator.ReloaderConfig{
+		Image:         "quay.io/prometheus-operator/prometheus-config-reloader:latest",
+		CPURequest:    "100m",
+		CPULimit:      "100m",
+		MemoryRequest: "50Mi",
+		MemoryLimit:   "50Mi",
+	},
+	LocalHost: "localhost",
+}
+
+func TestMakeDeployment(t *testing.T) {
+	replicas := int32(2)
+	p := &monitoringv1alpha1.Prober{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "blackbox",
+			Namespace: "monitoring",
+		},
+		Spec: monitoringv1alpha1.BlackboxProberSpec{
+			Replicas: &replicas,
+			LogLevel: "debug",
+			PodMetadata: &monitoringv1.EmbeddedObjectMetadata{
+				Labels: map[string]string{
+					"team": "sre",
+					// Selector labels can't be overridden.
+					proberNameLabelName: "other",
+				},
+			},
+			Modules: []monitoringv1alpha1.BlackboxModule{{Name: "icmp", Prober: "icmp"}},
+		},
+	}
+
+	deploy := makeDeployment(p, defaultTestConfig, "1234")
+
+	require.Equal(t, "prober-blackbox", deploy.Name)
+	require.Equal(t, "1234", deploy.Annotations[inputHashName])
+	require.Equal(t, replicas, *deploy.Spec.Replicas)
+	require.Equal(t, "sre", deploy.Spec.Template.Labels["team"])
+	require.Equal(t, "blackbox", deploy.Spec.Template.Labels[proberNameLabelName])
+	for k, v := range deploy.Spec.Selector.MatchLabels {
+		require.Equal(t, v, deploy.Spec.Template.Labels[k])
+	}
+
+	require.Len(t, deploy.Spec.Template.Spec.Containers, 2)
+	exporter := deploy.Spec.Template.Spec.Containers[0]
+	require.Equal(t, operator.DefaultBlackboxExporterImage, exporter.Image)
+	require.Equal(t, []string{
+		"--config.file=/etc/blackbox_exporter/config/blackbox.yml",
+		"--web.listen-address=:9115",
+		"--log.level=debug",
+	}, exporter.Args)
+
+	reloader := deploy.Spec.Template.Spec.Containers[1]
+	require.Equal(t, "config-reloader", reloader.Name)
+	require.Contains(t, reloader.Args, "--reload-url=http://localhost:9115/-/reload")
+	require.Contains(t, reloader.Args, "--watched-dir=/etc/blackbox_exporter/config")
+
+	require.Equal(t, "prober-blackbox-config", deploy.Spec.Template.Spec.Volumes[0].Secret.SecretName)
+}
+
+func TestServiceAddress(t *testing.T) {
+	require.Equal(t, "prober-blackbox.monitoring.svc:9115", ServiceAddress("blackbox", "monitoring"))
+}
@@ -0,0 +1,562 @@
+// Copyright 2022 The prometheus-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prober
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mitchellh/hashstructure"
+	"github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring"
+	monitoringv1alpha1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1alpha1"
+	monitoringclient "github.com/prometheus-operator/prometheus-operator/pkg/client/versioned"
+	"github.com/prometheus-operator/prometheus-operator/pkg/informers"
+	"github.com/prometheus-operator/prometheus-operator/pkg/k8sutil"
+	"github.com/prometheus-operator/prometheus-operator/pkg/listwatch"
+	"github.com/prometheus-operator/prometheus-operator/pkg/operator"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const resyncPeriod = 5 * time.Minute
+
+// Operator manages the blackbox exporter deployments described by Prober
+// objects.
+type Operator struct {
+	kclient kubernetes.Interface
+	mclient monitoringclient.Interface
+	logger  log.Logger
+
+	proberInfs *informers.ForResource
+	deployInfs *informers.ForResource
+
+	// nsInf watches the namespaces when the namespace selector is enabled. It
+	// is nil otherwise.
+	nsInf      cache.SharedIndexInformer
+	nsSelector *informers.NamespaceSelector
+
+	queue workqueue.RateLimitingInterface
+
+	metrics *operator.Metrics
+
+	// configMtx protects the settings which can be updated at runtime by
+	// ApplyConfig.
+	configMtx sync.RWMutex
+	config    Config
+}
+
+// Config defines configuration parameters for the Operator.
+type Config struct {
+	ReloaderConfig operator.ReloaderConfig
+	Namespaces     operator.Namespaces
+	Labels         operator.Labels
+	LocalHost      string
+}
+
+// New creates a new controller.
+func New(ctx context.Context, conf operator.Config, logger log.Logger, r prometheus.Registerer) (*Operator, error) {
+	cfg, err := k8sutil.NewClusterConfig(conf.Host, conf.TLSInsecure, &conf.TLSConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "instantiating cluster config failed")
+	}
+
+	client, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "instantiating kubernetes client failed")
+	}
+
+	mclient, err := monitoringclient.NewForConfig(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "instantiating monitoring client failed")
+	}
+
+	// The Prober CRD is optional, the controller doesn't run when it isn't
+	// installed.
+	supported, err := k8sutil.IsAPIGroupVersionResourceSupported(client.Discovery(), monitoringv1alpha1.SchemeGroupVersion.String(), monitoringv1alpha1.ProberName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to check whether the Prober CRD is installed")
+	}
+	if !supported {
+		return nil, &operator.MissingPermissionsError{
+			Controller: "prober",
+			Errors:     []error{errors.Errorf("resource %q not served by the API server", monitoringv1alpha1.SchemeGroupVersion.WithResource(monitoringv1alpha1.ProberName))},
+		}
+	}
+
+	namespaceLabels, err := operator.CheckControllerPermissions(
+		ctx,
+		logger,
+		client.AuthorizationV1().SelfSubjectAccessReviews(),
+		"prober",
+		operator.NamespacedAttributes{
+			AllowList: conf.Namespaces.AllowList,
+			Attributes: append(
+				operator.ListWatchAttributes(monitoring.GroupName, monitoringv1alpha1.ProberName),
+				operator.ListWatchAttributes("apps", "deployments")...,
+			),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	o, err := newOperator(ctx, client, mclient, conf, logger, r)
+	if err != nil {
+		return nil, err
+	}
+
+	if !namespaceLabels {
+		o.metrics.DisableFeature("namespace-labels")
+	}
+
+	return o, nil
+}
+
+// newOperator creates the controller and its informers from the given
+// clients.
+func newOperator(ctx context.Context, client kubernetes.Interface, mclient monitoringclient.Interface, conf operator.Config, logger log.Logger, r prometheus.Registerer) (*Operator, error) {
+	var err error
+	o := &Operator{
+		kclient: client,
+		mclient: mclient,
+		logger:  logger,
+		queue:   workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "prober"),
+		metrics: operator.NewMetrics("prober", r),
+		config: Config{
+			ReloaderConfig: conf.ReloaderConfig,
+			Namespaces:     conf.Namespaces,
+			Labels:         conf.Labels,
+			LocalHost:      conf.LocalHost,
+		},
+	}
+
+	o.nsSelector, err = informers.NewNamespaceSelector(o.config.Namespaces.AllowSelector, o.config.Namespaces.DenySelector, o.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	// Probers live next to the Probe objects referencing them.
+	o.proberInfs, err = informers.NewInformersForResource(
+		informers.NewMonitoringInformerFactories(
+			o.nsSelector.AllowList(o.config.Namespaces.AllowList),
+			o.config.Namespaces.DenyList,
+			mclient,
+			resyncPeriod,
+			nil,
+		),
+		monitoringv1alpha1.SchemeGroupVersion.WithResource(monitoringv1alpha1.ProberName),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating prober informers")
+	}
+
+	o.deployInfs, err = informers.NewInformersForResource(
+		informers.NewKubeInformerFactories(
+			o.nsSelector.AllowList(o.config.Namespaces.AllowList),
+			o.config.Namespaces.DenyList,
+			o.kclient,
+			resyncPeriod,
+			func(options *metav1.ListOptions) {
+				options.LabelSelector = proberNameLabelName
+			},
+		),
+		appsv1.SchemeGroupVersion.WithResource("deployments"),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating deployment informers")
+	}
+
+	// Informers for namespaces matching the namespace selector are created
+	// and removed at runtime, driven by the namespace informer.
+	o.nsSelector.Register(o.config.Namespaces.AllowList, o.proberInfs, o.deployInfs)
+	if o.nsSelector != nil && listwatch.IsAllNamespaces(o.config.Namespaces.AllowList) {
+		o.nsInf = cache.NewSharedIndexInformer(
+			o.metrics.NewInstrumentedListerWatcher(
+				&cache.ListWatch{
+					ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+						listwatch.DenyTweak(&options, "metadata.name", o.config.Namespaces.DenyList)
+						return o.kclient.CoreV1().Namespaces().List(ctx, options)
+					},
+					WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+						listwatch.DenyTweak(&options, "metadata.name", o.config.Namespaces.DenyList)
+						return o.kclient.CoreV1().Namespaces().Watch(ctx, options)
+					},
+				},
+			),
+			&v1.Namespace{}, resyncPeriod, cache.Indexers{},
+		)
+		o.nsInf.AddEventHandler(o.nsSelector)
+	}
+
+	return o, nil
+}
+
+// waitForCacheSync waits for the informers' caches to be synced.
+func (o *Operator) waitForCacheSync(ctx context.Context) error {
+	for _, infs := range []struct {
+		name                 string
+		informersForResource *informers.ForResource
+	}{
+		{"Prober", o.proberInfs},
+		{"Deployment", o.deployInfs},
+	} {
+		for _, inf := range infs.informersForResource.GetInformers() {
+			if !operator.WaitForNamedCacheSync(ctx, "prober", log.With(o.logger, "informer", infs.name), inf.Informer()) {
+				return errors.Errorf("failed to sync cache for %s informer", infs.name)
+			}
+		}
+	}
+
+	if o.nsInf != nil {
+		if !operator.WaitForNamedCacheSync(ctx, "prober", log.With(o.logger, "informer", "Namespace"), o.nsInf) {
+			return errors.New("failed to sync cache for Namespace informer")
+		}
+	}
+
+	level.Info(o.logger).Log("msg", "successfully synced all caches")
+	return nil
+}
+
+// addHandlers adds the eventhandlers to the informers.
+func (o *Operator) addHandlers() {
+	o.proberInfs.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    o.handleProberAdd,
+		DeleteFunc: o.handleProberDelete,
+		UpdateFunc: o.handleProberUpdate,
+	})
+	o.deployInfs.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    o.handleDeploymentAdd,
+		DeleteFunc: o.handleDeploymentDelete,
+		UpdateFunc: o.handleDeploymentUpdate,
+	})
+}
+
+// Run the controller.
+func (o *Operator) Run(ctx context.Context) error {
+	defer o.queue.ShutDown()
+
+	errChan := make(chan error)
+	go func() {
+		v, err := o.kclient.Discovery().ServerVersion()
+		if err != nil {
+			errChan <- errors.Wrap(err, "communicating with server failed")
+			return
+		}
+		level.Info(o.logger).Log("msg", "connection established", "cluster-version", v)
+		errChan <- nil
+	}()
+
+	select {
+	case err := <-errChan:
+		if err != nil {
+			return err
+		}
+		level.Info(o.logger).Log("msg", "CRD API endpoints ready")
+	case <-ctx.Done():
+		return nil
+	}
+
+	go o.worker(ctx)
+
+	go o.proberInfs.Start(ctx.Done())
+	go o.deployInfs.Start(ctx.Done())
+	if o.nsInf != nil {
+		go o.nsInf.Run(ctx.Done())
+	}
+	if err := o.waitForCacheSync(ctx); err != nil {
+		return err
+	}
+	o.addHandlers()
+
+	o.metrics.Ready().Set(1)
+	<-ctx.Done()
+	return nil
+}
+
+// ApplyConfig updates the settings of the controller which can be changed at
+// runtime (labels and config reloader) and enqueues all Prober objects for
+// reconciliation.
+func (o *Operator) ApplyConfig(conf operator.Config) error {
+	o.configMtx.Lock()
+	o.config.Labels = conf.Labels
+	o.config.ReloaderConfig = conf.ReloaderConfig
+	o.configMtx.Unlock()
+
+	err := o.proberInfs.ListAll(labels.Everything(), func(obj interface{}) {
+		o.enqueue(obj)
+	})
+	if err != nil {
+		return errors.Wrap(err, "listing all Prober instances from cache failed")
+	}
+
+	return nil
+}
+
+func (o *Operator) keyFunc(obj interface{}) (string, bool) {
+	k, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		level.Error(o.logger).Log("msg", "creating key failed", "err", err)
+		return k, false
+	}
+	return k, true
+}
+
+func (o *Operator) handleProberAdd(obj interface{}) {
+	key, ok := o.keyFunc(obj)
+	if !ok {
+		return
+	}
+
+	level.Debug(o.logger).Log("msg", "Prober added", "key", key)
+	o.metrics.TriggerByCounter(monitoringv1alpha1.ProberKind, "add").Inc()
+	o.enqueue(key)
+}
+
+func (o *Operator) handleProberDelete(obj interface{}) {
+	key, ok := o.keyFunc(obj)
+	if !ok {
+		return
+	}
+
+	level.Debug(o.logger).Log("msg", "Prober deleted", "key", key)
+	o.metrics.TriggerByCounter(monitoringv1alpha1.ProberKind, "delete").Inc()
+	o.enqueue(key)
+}
+
+func (o *Operator) handleProberUpdate(old, cur interface{}) {
+	if old.(*monitoringv1alpha1.Prober).ResourceVersion == cur.(*monitoringv1alpha1.Prober).ResourceVersion {
+		return
+	}
+
+	key, ok := o.keyFunc(cur)
+	if !ok {
+		return
+	}
+
+	level.Debug(o.logger).Log("msg", "Prober updated", "key", key)
+	o.metrics.TriggerByCounter(monitoringv1alpha1.ProberKind, "update").Inc()
+	o.enqueue(key)
+}
+
+// proberKeyForDeployment returns the key of the Prober owning the
+// Deployment.
+func (o *Operator) proberKeyForDeployment(obj interface{}) (string, bool) {
+	key, ok := o.keyFunc(obj)
+	if !ok {
+		return "", false
+	}
+
+	parts := strings.SplitN(key, "/", 2)
+	if len(parts) != 2 || !strings.HasPrefix(parts[1], resourceName("")) {
+		return "", false
+	}
+
+	return parts[0] + "/" + strings.TrimPrefix(parts[1], resourceName("")), true
+}
+
+func (o *Operator) handleDeploymentAdd(obj interface{}) {
+	if key, ok := o.proberKeyForDeployment(obj); ok {
+		level.Debug(o.logger).Log("msg", "Deployment added")
+		o.metrics.TriggerByCounter("Deployment", "add").Inc()
+
+		o.enqueue(key)
+	}
+}
+
+func (o *Operator) handleDeploymentDelete(obj interface{}) {
+	if key, ok := o.proberKeyForDeployment(obj); ok {
+		level.Debug(o.logger).Log("msg", "Deployment deleted")
+		o.metrics.TriggerByCounter("Deployment", "delete").Inc()
+
+		o.enqueue(key)
+	}
+}
+
+func (o *Operator) handleDeploymentUpdate(oldo, curo interface{}) {
+	old := oldo.(*appsv1.Deployment)
+	cur := curo.(*appsv1.Deployment)
+
+	// Periodic resync may resend the Deployment without changes
+	// in-between. Also breaks loops created by updating the resource
+	// ourselves.
+	if old.ResourceVersion == cur.ResourceVersion {
+		return
+	}
+
+	if key, ok := o.proberKeyForDeployment(cur); ok {
+		level.Debug(o.logger).Log("msg", "Deployment updated")
+		o.metrics.TriggerByCounter("Deployment", "update").Inc()
+
+		o.enqueue(key)
+	}
+}
+
+// enqueue adds a key to the queue. If obj is a key already it gets added
+// directly. Otherwise, the key is extracted via keyFunc.
+func (o *Operator) enqueue(obj interface{}) {
+	if obj == nil {
+		return
+	}
+
+	key, ok := obj.(string)
+	if !ok {
+		key, ok = o.keyFunc(obj)
+		if !ok {
+			return
+		}
+	}
+
+	o.queue.Add(key)
+}
+
+// worker runs a worker thread that just dequeues items, processes them, and
+// marks them done. It enforces that the syncHandler is never invoked
+// concurrently with the same key.
+func (o *Operator) worker(ctx context.Context) {
+	for o.processNextWorkItem(ctx) {
+	}
+}
+
+func (o *Operator) processNextWorkItem(ctx context.Context) bool {
+	key, quit := o.queue.Get()
+	if quit {
+		return false
+	}
+	defer o.queue.Done(key)
+
+	o.metrics.ReconcileCounter().Inc()
+	startTime := time.Now()
+	err := o.sync(ctx, key.(string))
+	o.metrics.ReconcileDurationHistogram().Observe(time.Since(startTime).Seconds())
+	o.metrics.SetSyncStatus(key.(string), err == nil)
+	if err == nil {
+		o.queue.Forget(key)
+		return true
+	}
+
+	o.metrics.ReconcileErrorsCounter().Inc()
+	utilruntime.HandleError(errors.Wrapf(err, "Sync %q failed", key))
+	o.queue.AddRateLimited(key)
+
+	return true
+}
+
+func (o *Operator) sync(ctx context.Context, key string) error {
+	pobj, err := o.proberInfs.Get(key)
+	if apierrors.IsNotFound(err) {
+		o.metrics.ForgetObject(key)
+		// Dependent resources are cleaned up by K8s via OwnerReferences
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	o.configMtx.RLock()
+	defer o.configMtx.RUnlock()
+
+	p := pobj.(*monitoringv1alpha1.Prober)
+	logger := log.With(o.logger, "key", key)
+
+	level.Info(logger).Log("msg", "sync prober")
+
+	data, err := generateConfig(p)
+	if err != nil {
+		return errors.Wrap(err, "generating blackbox exporter configuration failed")
+	}
+
+	sClient := o.kclient.CoreV1().Secrets(p.Namespace)
+	if err := k8sutil.CreateOrUpdateSecret(ctx, sClient, makeConfigSecret(p, o.config, data)); err != nil {
+		return errors.Wrap(err, "synchronizing configuration secret failed")
+	}
+
+	svcClient := o.kclient.CoreV1().Services(p.Namespace)
+	if err := k8sutil.CreateOrUpdateService(ctx, svcClient, makeService(p, o.config)); err != nil {
+		return errors.Wrap(err, "synchronizing service failed")
+	}
+
+	// The configuration is reloaded by the config-reloader sidecar and
+	// doesn't need to be part of the hash.
+	inputHash, err := createInputHash(*p, o.config)
+	if err != nil {
+		return err
+	}
+
+	deploy := makeDeployment(p, o.config, inputHash)
+	deployClient := o.kclient.AppsV1().Deployments(p.Namespace)
+
+	obj, err := o.deployInfs.Get(p.Namespace + "/" + resourceName(p.Name))
+	if err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrap(err, "retrieving deployment failed")
+	}
+
+	if apierrors.IsNotFound(err) {
+		if _, err := deployClient.Create(ctx, deploy, metav1.CreateOptions{}); err != nil {
+			return errors.Wrap(err, "creating deployment failed")
+		}
+		return nil
+	}
+
+	if obj.(*appsv1.Deployment).ObjectMeta.Annotations[inputHashName] == inputHash {
+		level.Debug(logger).Log("msg", "new deployment generation inputs match current, skipping any actions")
+		return nil
+	}
+
+	if err := k8sutil.UpdateDeployment(ctx, deployClient, deploy); err != nil {
+		return errors.Wrap(err, "updating deployment failed")
+	}
+
+	return nil
+}
+
+func createInputHash(p monitoringv1alpha1.Prober, c Config) (string, error) {
+	hash, err := hashstructure.Hash(struct {
+		Labels      map[string]string
+		Annotations map[string]string
+		Generation  int64
+		Spec        monitoringv1alpha1.BlackboxProberSpec
+		C           Config
+	}{
+		Labels:      p.Labels,
+		Annotations: p.Annotations,
+		Generation:  p.Generation,
+		Spec:        p.Spec,
+		C:           c,
+	},
+		nil,
+	)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to calculate combined hash of Prober CRD and config")
+	}
+
+	return fmt.Sprintf("%d", hash), nil
+}
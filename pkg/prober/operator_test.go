@@ -0,0 +1,75 @@
+// Copyright 2022 The prometheus-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prober
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/prometheus/client_golang/prometheus"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+
+	monitoringfake "github.com/prometheus-operator/prometheus-operator/pkg/client/versioned/fake"
+	"github.com/prometheus-operator/prometheus-operator/pkg/operator"
+)
+
+func TestNamespaceSelector(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	kclient := fake.NewSimpleClientset(
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "selected", Labels: map[string]string{"monitoring": "enabled"}}},
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "other"}},
+	)
+
+	o, err := newOperator(
+		ctx,
+		kclient,
+		monitoringfake.NewSimpleClientset(),
+		operator.Config{
+			Namespaces: operator.Namespaces{
+				AllowList:     map[string]struct{}{v1.NamespaceAll: {}},
+				AllowSelector: "monitoring=enabled",
+			},
+		},
+		log.NewNopLogger(),
+		prometheus.NewRegistry(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.nsInf == nil {
+		t.Fatal("expected a namespace informer")
+	}
+
+	go o.nsInf.Run(ctx.Done())
+	if !cache.WaitForCacheSync(ctx.Done(), o.nsInf.HasSynced) {
+		t.Fatal("failed to sync the namespace informer")
+	}
+
+	err = wait.PollImmediate(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+		return reflect.DeepEqual(o.proberInfs.Namespaces(), []string{"selected"}), nil
+	})
+	if err != nil {
+		t.Fatalf("expected prober informers for namespaces [selected], got %v", o.proberInfs.Namespaces())
+	}
+}
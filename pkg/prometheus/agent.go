@@ -0,0 +1,700 @@
+// Copyright 2021 The prometheus-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/blang/semver/v4"
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/cache"
+
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	monitoringv1alpha1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1alpha1"
+	"github.com/prometheus-operator/prometheus-operator/pkg/assets"
+	"github.com/prometheus-operator/prometheus-operator/pkg/k8sutil"
+	"github.com/prometheus-operator/prometheus-operator/pkg/operator"
+)
+
+// isAgent returns true if p is the Prometheus view of a PrometheusAgent
+// object.
+func isAgent(p *monitoringv1.Prometheus) bool {
+	return p.Kind == monitoringv1alpha1.PrometheusAgentsKind
+}
+
+// metricsFor returns the controller metrics matching the kind of p.
+func (c *Operator) metricsFor(p *monitoringv1.Prometheus) *operator.Metrics {
+	if isAgent(p) {
+		return c.agentMetrics
+	}
+	return c.metrics
+}
+
+// prometheusForAgent returns a Prometheus object sharing the metadata and the
+// common Prometheus fields of the PrometheusAgent object. It lets the agent
+// reuse the code generating the configuration and the assets of Prometheus. The
+// TypeMeta fields are those of the PrometheusAgent object so that the owner
+// references of the generated resources point to it.
+func prometheusForAgent(a *monitoringv1alpha1.PrometheusAgent) *monitoringv1.Prometheus {
+	p := &monitoringv1.Prometheus{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: monitoringv1alpha1.SchemeGroupVersion.String(),
+			Kind:       monitoringv1alpha1.PrometheusAgentsKind,
+		},
+		ObjectMeta: *a.ObjectMeta.DeepCopy(),
+		Spec: monitoringv1.PrometheusSpec{
+			CommonPrometheusFields: *a.Spec.CommonPrometheusFields.DeepCopy(),
+		},
+	}
+
+	if p.Spec.Version == "" {
+		p.Spec.Version = operator.DefaultPrometheusAgentVersion
+	}
+
+	return p
+}
+
+// ValidateAgent returns an error if the PrometheusAgent object uses a
+// Prometheus version or fields which aren't supported by its mode. It is used
+// both by the controller and by the admission webhook.
+func ValidateAgent(a *monitoringv1alpha1.PrometheusAgent) error {
+	spec := a.Spec.CommonPrometheusFields
+
+	if spec.Version != "" {
+		version, err := semver.ParseTolerant(spec.Version)
+		if err != nil {
+			return errors.Wrap(err, "failed to parse prometheus version")
+		}
+		if version.LT(semver.MustParse("2.32.0")) {
+			return errors.Errorf("agent mode requires Prometheus >= v2.32.0, got %s", spec.Version)
+		}
+	}
+
+	switch a.Spec.Mode {
+	case "", monitoringv1alpha1.StatefulSetMode:
+	case monitoringv1alpha1.DaemonSetMode:
+		var forbidden []string
+		if spec.Replicas != nil {
+			forbidden = append(forbidden, "replicas")
+		}
+		if spec.Shards != nil {
+			forbidden = append(forbidden, "shards")
+		}
+		if spec.Storage != nil {
+			forbidden = append(forbidden, "storage")
+		}
+		if spec.ProbeSelector != nil {
+			forbidden = append(forbidden, "probeSelector")
+		}
+		if spec.ScrapeConfigSelector != nil {
+			forbidden = append(forbidden, "scrapeConfigSelector")
+		}
+		if len(forbidden) > 0 {
+			return errors.Errorf("fields not supported in DaemonSet mode: %s", strings.Join(forbidden, ", "))
+		}
+	default:
+		return errors.Errorf("unknown mode %q", a.Spec.Mode)
+	}
+
+	return nil
+}
+
+func (c *Operator) handleAgentAdd(obj interface{}) {
+	if !c.isSelected(obj) {
+		return
+	}
+
+	key, ok := c.keyFunc(obj)
+	if !ok {
+		return
+	}
+
+	level.Debug(c.logger).Log("msg", "PrometheusAgent added", "key", key)
+	c.agentMetrics.TriggerByCounter(monitoringv1alpha1.PrometheusAgentsKind, "add").Inc()
+	c.enqueueAgent(key)
+}
+
+func (c *Operator) handleAgentDelete(obj interface{}) {
+	if !c.isSelected(obj) {
+		return
+	}
+
+	key, ok := c.keyFunc(obj)
+	if !ok {
+		return
+	}
+
+	level.Debug(c.logger).Log("msg", "PrometheusAgent deleted", "key", key)
+	c.agentMetrics.TriggerByCounter(monitoringv1alpha1.PrometheusAgentsKind, "delete").Inc()
+	c.enqueueAgent(key)
+}
+
+func (c *Operator) handleAgentUpdate(old, cur interface{}) {
+	if old.(*monitoringv1alpha1.PrometheusAgent).ResourceVersion == cur.(*monitoringv1alpha1.PrometheusAgent).ResourceVersion {
+		return
+	}
+
+	if !c.isSelected(old) && !c.isSelected(cur) {
+		return
+	}
+
+	key, ok := c.keyFunc(cur)
+	if !ok {
+		return
+	}
+
+	level.Debug(c.logger).Log("msg", "PrometheusAgent updated", "key", key)
+	c.agentMetrics.TriggerByCounter(monitoringv1alpha1.PrometheusAgentsKind, "update").Inc()
+	c.enqueueAgent(key)
+}
+
+// agentKeyForWorkload returns the key of the PrometheusAgent object owning
+// the given StatefulSet or DaemonSet.
+func (c *Operator) agentKeyForWorkload(obj interface{}) (string, bool) {
+	o, ok := c.getObject(obj)
+	if !ok {
+		return "", false
+	}
+
+	for _, ref := range o.GetOwnerReferences() {
+		if ref.Kind == monitoringv1alpha1.PrometheusAgentsKind && ref.APIVersion == monitoringv1alpha1.SchemeGroupVersion.String() {
+			return o.GetNamespace() + "/" + ref.Name, true
+		}
+	}
+
+	return "", false
+}
+
+// agentWorkloadHandlers returns the event handlers enqueuing the
+// PrometheusAgent objects owning the workloads of the given kind.
+func (c *Operator) agentWorkloadHandlers(kind string) cache.ResourceEventHandlerFuncs {
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if key, ok := c.agentKeyForWorkload(obj); ok {
+				level.Debug(c.logger).Log("msg", kind+" added", "key", key)
+				c.agentMetrics.TriggerByCounter(kind, "add").Inc()
+
+				c.enqueueAgent(key)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if key, ok := c.agentKeyForWorkload(obj); ok {
+				level.Debug(c.logger).Log("msg", kind+" deleted", "key", key)
+				c.agentMetrics.TriggerByCounter(kind, "delete").Inc()
+
+				c.enqueueAgent(key)
+			}
+		},
+		UpdateFunc: func(oldo, curo interface{}) {
+			old, ok := c.getObject(oldo)
+			if !ok {
+				return
+			}
+			cur, ok := c.getObject(curo)
+			if !ok {
+				return
+			}
+
+			// Periodic resync may resend the workload without changes
+			// in-between. Also breaks loops created by updating the resource
+			// ourselves.
+			if old.GetResourceVersion() == cur.GetResourceVersion() {
+				return
+			}
+
+			if key, ok := c.agentKeyForWorkload(curo); ok {
+				level.Debug(c.logger).Log("msg", kind+" updated", "key", key)
+				c.agentMetrics.TriggerByCounter(kind, "update").Inc()
+
+				c.enqueueAgent(key)
+			}
+		},
+	}
+}
+
+// enqueueAgent adds a key to the agent queue. If obj is a key already it gets
+// added directly. Otherwise, the key is extracted via keyFunc.
+func (c *Operator) enqueueAgent(obj interface{}) {
+	if obj == nil {
+		return
+	}
+
+	key, ok := obj.(string)
+	if !ok {
+		if !c.isSelected(obj) {
+			return
+		}
+
+		key, ok = c.keyFunc(obj)
+		if !ok {
+			return
+		}
+	}
+
+	c.agentQueue.Add(key)
+}
+
+// enqueueAgentsForNamespace enqueues all PrometheusAgent objects that belong
+// to the given namespace or select monitors in the given namespace.
+func (c *Operator) enqueueAgentsForNamespace(ns *v1.Namespace) {
+	if c.agentInfs == nil {
+		return
+	}
+
+	err := c.agentInfs.ListAll(labels.Everything(), func(obj interface{}) {
+		a := obj.(*monitoringv1alpha1.PrometheusAgent)
+		if a.Namespace == ns.Name {
+			c.enqueueAgent(a)
+			return
+		}
+
+		for name, selector := range map[string]*metav1.LabelSelector{
+			"PodMonitors":     a.Spec.PodMonitorNamespaceSelector,
+			"Probes":          a.Spec.ProbeNamespaceSelector,
+			"ScrapeConfigs":   a.Spec.ScrapeConfigNamespaceSelector,
+			"ServiceMonitors": a.Spec.ServiceMonitorNamespaceSelector,
+		} {
+			s, err := metav1.LabelSelectorAsSelector(selector)
+			if err != nil {
+				level.Error(c.logger).Log(
+					"msg", fmt.Sprintf("failed to convert %s namespace selector of %q to selector", name, a.Name),
+					"err", err,
+				)
+				return
+			}
+
+			if s.Matches(labels.Set(ns.Labels)) {
+				c.enqueueAgent(a)
+				return
+			}
+		}
+	})
+	if err != nil {
+		level.Error(c.logger).Log(
+			"msg", "listing all PrometheusAgent instances from cache failed",
+			"err", err,
+		)
+	}
+}
+
+// enqueueAgentsForNamespaceUpdate enqueues all PrometheusAgent objects whose
+// monitor selection changes because of the namespace labels update.
+func (c *Operator) enqueueAgentsForNamespaceUpdate(old, cur *v1.Namespace) {
+	if c.agentInfs == nil {
+		return
+	}
+
+	err := c.agentInfs.ListAll(labels.Everything(), func(obj interface{}) {
+		a := obj.(*monitoringv1alpha1.PrometheusAgent)
+
+		for name, selector := range map[string]*metav1.LabelSelector{
+			"PodMonitors":     a.Spec.PodMonitorNamespaceSelector,
+			"Probes":          a.Spec.ProbeNamespaceSelector,
+			"ScrapeConfigs":   a.Spec.ScrapeConfigNamespaceSelector,
+			"ServiceMonitors": a.Spec.ServiceMonitorNamespaceSelector,
+		} {
+			sync, err := k8sutil.LabelSelectionHasChanged(old.Labels, cur.Labels, selector)
+			if err != nil {
+				level.Error(c.logger).Log(
+					"err", err,
+					"name", a.Name,
+					"namespace", a.Namespace,
+					"subresource", name,
+				)
+				return
+			}
+
+			if sync {
+				c.enqueueAgent(a)
+				return
+			}
+		}
+	})
+	if err != nil {
+		level.Error(c.logger).Log(
+			"msg", "listing all PrometheusAgent instances from cache failed",
+			"err", err,
+		)
+	}
+}
+
+// agentWorker runs a worker thread that just dequeues items, processes them,
+// and marks them done. It enforces that the syncAgent is never invoked
+// concurrently with the same key.
+func (c *Operator) agentWorker(ctx context.Context) {
+	for c.processNextAgentItem(ctx) {
+	}
+}
+
+func (c *Operator) processNextAgentItem(ctx context.Context) bool {
+	key, quit := c.agentQueue.Get()
+	if quit {
+		return false
+	}
+	defer c.agentQueue.Done(key)
+
+	c.agentMetrics.ReconcileCounter().Inc()
+	startTime := time.Now()
+	err := c.syncAgent(ctx, key.(string))
+	c.agentMetrics.ReconcileDurationHistogram().Observe(time.Since(startTime).Seconds())
+	c.agentMetrics.SetSyncStatus(key.(string), err == nil)
+	if err == nil {
+		c.agentQueue.Forget(key)
+		return true
+	}
+
+	c.agentMetrics.ReconcileErrorsCounter().Inc()
+	utilruntime.HandleError(errors.Wrap(err, fmt.Sprintf("Sync %q failed", key)))
+	c.agentQueue.AddRateLimited(key)
+
+	return true
+}
+
+func (c *Operator) syncAgent(ctx context.Context, key string) error {
+	aobj, err := c.agentInfs.Get(key)
+
+	if apierrors.IsNotFound(err) {
+		c.agentMetrics.ForgetObject(key)
+		c.configGenerator.forgetScrapeConfigs(monitoringv1alpha1.PrometheusAgentsKind, key)
+		// Dependent resources are cleaned up by K8s via OwnerReferences
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	config, promSelector := c.currentConfig()
+
+	a := aobj.(*monitoringv1alpha1.PrometheusAgent)
+	logger := log.With(c.logger, "key", key)
+
+	if !promSelector.Matches(labels.Set(a.Labels)) {
+		level.Debug(logger).Log("msg", "PrometheusAgent not matching the instance selector, skipping")
+		c.agentMetrics.ForgetObject(key)
+		c.configGenerator.forgetScrapeConfigs(monitoringv1alpha1.PrometheusAgentsKind, key)
+		return nil
+	}
+
+	if a.Spec.Paused {
+		return nil
+	}
+
+	if err := ValidateAgent(a); err != nil {
+		return errors.Wrap(err, "invalid PrometheusAgent spec")
+	}
+
+	p := prometheusForAgent(a)
+
+	level.Info(logger).Log("msg", "sync prometheus agent")
+
+	assetStore := c.assetCache.NewStore()
+
+	if err := c.createOrUpdateAgentConfigurationSecret(ctx, a, p, config, assetStore); err != nil {
+		return errors.Wrap(err, "creating config failed")
+	}
+
+	if err := c.createOrUpdateTLSAssetSecret(ctx, p, config, assetStore); err != nil {
+		return errors.Wrap(err, "creating tls asset secret failed")
+	}
+
+	if err := c.createOrUpdateCredentialsSecret(ctx, p, config, assetStore); err != nil {
+		return errors.Wrap(err, "creating credentials secret failed")
+	}
+
+	if err := c.createOrUpdateWebConfigSecret(ctx, p, config, assetStore); err != nil {
+		return errors.Wrap(err, "synchronizing web config secret failed")
+	}
+
+	if a.Spec.Mode == monitoringv1alpha1.DaemonSetMode {
+		if err := c.syncAgentDaemonSet(ctx, logger, p, config); err != nil {
+			return err
+		}
+		return c.deleteAgentStatefulSets(ctx, p, map[string]struct{}{})
+	}
+
+	if err := c.syncAgentStatefulSets(ctx, logger, p, config); err != nil {
+		return err
+	}
+
+	return c.deleteAgentDaemonSet(ctx, p)
+}
+
+func (c *Operator) syncAgentStatefulSets(ctx context.Context, logger log.Logger, p *monitoringv1.Prometheus, config operator.Config) error {
+	// Create governing service if it doesn't exist.
+	svcClient := c.kclient.CoreV1().Services(p.Namespace)
+	if err := k8sutil.CreateOrUpdateService(ctx, svcClient, makeAgentStatefulSetService(p, config)); err != nil {
+		return errors.Wrap(err, "synchronizing governing service failed")
+	}
+
+	ssetClient := c.kclient.AppsV1().StatefulSets(p.Namespace)
+
+	expected := expectedAgentStatefulSetNames(p)
+	for shard, ssetName := range expected {
+		logger := log.With(logger, "statefulset", ssetName, "shard", fmt.Sprintf("%d", shard))
+		level.Debug(logger).Log("msg", "reconciling statefulset")
+
+		obj, err := c.ssetInfs.Get(p.Namespace + "/" + ssetName)
+		exists := !apierrors.IsNotFound(err)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrap(err, "retrieving statefulset failed")
+		}
+
+		spec := appsv1.StatefulSetSpec{}
+		if obj != nil {
+			spec = obj.(*appsv1.StatefulSet).Spec
+		}
+		newSSetInputHash, err := createSSetInputHash(*p, config, nil, spec)
+		if err != nil {
+			return err
+		}
+
+		sset, err := makeAgentStatefulSet(ssetName, *p, &config, newSSetInputHash, int32(shard))
+		if err != nil {
+			return errors.Wrap(err, "making statefulset failed")
+		}
+		operator.SanitizeSTS(sset)
+
+		if !exists {
+			level.Debug(logger).Log("msg", "creating statefulset")
+			if _, err := ssetClient.Create(ctx, sset, metav1.CreateOptions{}); err != nil {
+				return errors.Wrap(err, "creating statefulset failed")
+			}
+			continue
+		}
+
+		if newSSetInputHash == obj.(*appsv1.StatefulSet).ObjectMeta.Annotations[sSetInputHashName] {
+			level.Debug(logger).Log("msg", "new statefulset generation inputs match current, skipping any actions")
+			continue
+		}
+
+		level.Debug(logger).Log("msg", "updating current statefulset")
+
+		err = k8sutil.UpdateStatefulSet(ctx, ssetClient, sset)
+		sErr, ok := err.(*apierrors.StatusError)
+
+		if ok && sErr.ErrStatus.Code == 422 && sErr.ErrStatus.Reason == metav1.StatusReasonInvalid {
+			c.agentMetrics.StsDeleteCreateCounter().Inc()
+
+			// Gather only reason for failed update
+			failMsg := make([]string, len(sErr.ErrStatus.Details.Causes))
+			for i, cause := range sErr.ErrStatus.Details.Causes {
+				failMsg[i] = cause.Message
+			}
+
+			level.Info(logger).Log("msg", "recreating StatefulSet because the update operation wasn't possible", "reason", strings.Join(failMsg, ", "))
+			propagationPolicy := metav1.DeletePropagationForeground
+			if err := ssetClient.Delete(ctx, sset.GetName(), metav1.DeleteOptions{PropagationPolicy: &propagationPolicy}); err != nil {
+				return errors.Wrap(err, "failed to delete StatefulSet to avoid forbidden action")
+			}
+			continue
+		}
+
+		if err != nil {
+			return errors.Wrap(err, "updating StatefulSet failed")
+		}
+	}
+
+	ssets := map[string]struct{}{}
+	for _, ssetName := range expected {
+		ssets[ssetName] = struct{}{}
+	}
+
+	return c.deleteAgentStatefulSets(ctx, p, ssets)
+}
+
+// deleteAgentStatefulSets deletes the StatefulSets of the agent which aren't
+// in keep. It cleans up the StatefulSets when the shards are reduced or when
+// the agent switches to the DaemonSet mode.
+func (c *Operator) deleteAgentStatefulSets(ctx context.Context, p *monitoringv1.Prometheus, keep map[string]struct{}) error {
+	ssetClient := c.kclient.AppsV1().StatefulSets(p.Namespace)
+
+	err := c.ssetInfs.ListAllByNamespace(p.Namespace, labels.SelectorFromSet(labels.Set{agentNameLabelName: p.Name}), func(obj interface{}) {
+		s := obj.(*appsv1.StatefulSet)
+
+		if _, ok := keep[s.Name]; ok {
+			return
+		}
+
+		propagationPolicy := metav1.DeletePropagationForeground
+		if err := ssetClient.Delete(ctx, s.GetName(), metav1.DeleteOptions{PropagationPolicy: &propagationPolicy}); err != nil {
+			level.Error(c.logger).Log("err", err, "name", s.GetName(), "namespace", s.GetNamespace())
+		}
+	})
+	if err != nil {
+		return errors.Wrap(err, "listing StatefulSet resources failed")
+	}
+
+	return nil
+}
+
+func (c *Operator) syncAgentDaemonSet(ctx context.Context, logger log.Logger, p *monitoringv1.Prometheus, config operator.Config) error {
+	dsetName := agentPrefixedName(p.Name)
+	logger = log.With(logger, "daemonset", dsetName)
+	level.Debug(logger).Log("msg", "reconciling daemonset")
+
+	dsetClient := c.kclient.AppsV1().DaemonSets(p.Namespace)
+
+	obj, err := c.dsetInfs.Get(p.Namespace + "/" + dsetName)
+	exists := !apierrors.IsNotFound(err)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrap(err, "retrieving daemonset failed")
+	}
+
+	spec := appsv1.DaemonSetSpec{}
+	if obj != nil {
+		spec = obj.(*appsv1.DaemonSet).Spec
+	}
+	newInputHash, err := createSSetInputHash(*p, config, nil, spec)
+	if err != nil {
+		return err
+	}
+
+	dset, err := makeAgentDaemonSet(*p, &config, newInputHash)
+	if err != nil {
+		return errors.Wrap(err, "making daemonset failed")
+	}
+
+	if !exists {
+		level.Debug(logger).Log("msg", "creating daemonset")
+		if _, err := dsetClient.Create(ctx, dset, metav1.CreateOptions{}); err != nil {
+			return errors.Wrap(err, "creating daemonset failed")
+		}
+		return nil
+	}
+
+	if newInputHash == obj.(*appsv1.DaemonSet).ObjectMeta.Annotations[sSetInputHashName] {
+		level.Debug(logger).Log("msg", "new daemonset generation inputs match current, skipping any actions")
+		return nil
+	}
+
+	level.Debug(logger).Log("msg", "updating current daemonset")
+
+	err = k8sutil.UpdateDaemonSet(ctx, dsetClient, dset)
+	sErr, ok := err.(*apierrors.StatusError)
+
+	if ok && sErr.ErrStatus.Code == 422 && sErr.ErrStatus.Reason == metav1.StatusReasonInvalid {
+		level.Info(logger).Log("msg", "recreating DaemonSet because the update operation wasn't possible")
+		propagationPolicy := metav1.DeletePropagationForeground
+		if err := dsetClient.Delete(ctx, dset.GetName(), metav1.DeleteOptions{PropagationPolicy: &propagationPolicy}); err != nil {
+			return errors.Wrap(err, "failed to delete DaemonSet to avoid forbidden action")
+		}
+		return nil
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "updating DaemonSet failed")
+	}
+
+	return nil
+}
+
+// deleteAgentDaemonSet deletes the DaemonSet of the agent if it exists.
+func (c *Operator) deleteAgentDaemonSet(ctx context.Context, p *monitoringv1.Prometheus) error {
+	dsetName := agentPrefixedName(p.Name)
+
+	_, err := c.dsetInfs.Get(p.Namespace + "/" + dsetName)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "retrieving daemonset failed")
+	}
+
+	propagationPolicy := metav1.DeletePropagationForeground
+	err = c.kclient.AppsV1().DaemonSets(p.Namespace).Delete(ctx, dsetName, metav1.DeleteOptions{PropagationPolicy: &propagationPolicy})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrap(err, "deleting daemonset failed")
+	}
+
+	return nil
+}
+
+func (c *Operator) createOrUpdateAgentConfigurationSecret(ctx context.Context, a *monitoringv1alpha1.PrometheusAgent, p *monitoringv1.Prometheus, config operator.Config, store *assets.Store) error {
+	// If no service or pod monitor selectors are configured, the user wants to
+	// manage configuration themselves. Do create an empty Secret if it doesn't
+	// exist.
+	if p.Spec.ServiceMonitorSelector == nil && p.Spec.PodMonitorSelector == nil &&
+		p.Spec.ProbeSelector == nil && p.Spec.ScrapeConfigSelector == nil {
+		level.Debug(c.logger).Log("msg", "neither ServiceMonitor nor PodMonitor, nor Probe, nor ScrapeConfig selector specified, leaving configuration unmanaged", "prometheusagent", p.Name, "namespace", p.Namespace)
+		return c.createEmptyConfigurationSecret(ctx, p, config)
+	}
+
+	if err := addScrapeClassAssets(ctx, p, store); err != nil {
+		return errors.Wrap(err, "invalid scrape classes")
+	}
+
+	smons, err := c.selectServiceMonitors(ctx, p, store)
+	if err != nil {
+		return errors.Wrap(err, "selecting ServiceMonitors failed")
+	}
+
+	pmons, err := c.selectPodMonitors(ctx, p, store)
+	if err != nil {
+		return errors.Wrap(err, "selecting PodMonitors failed")
+	}
+
+	httpRouteTargets := map[string][]targetGroup{}
+	bmons, err := c.selectProbes(ctx, p, store, httpRouteTargets)
+	if err != nil {
+		return errors.Wrap(err, "selecting Probes failed")
+	}
+
+	scrapeConfigs, err := c.selectScrapeConfigs(ctx, p, store)
+	if err != nil {
+		return errors.Wrap(err, "selecting ScrapeConfigs failed")
+	}
+
+	if err := addRemoteWriteAndAPIServerAssets(ctx, p, store); err != nil {
+		return err
+	}
+
+	sClient := c.kclient.CoreV1().Secrets(p.Namespace)
+	secrets, err := sClient.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	additionalScrapeConfigs, err := c.loadAdditionalScrapeConfigsSecret(p.Spec.AdditionalScrapeConfigs, secrets)
+	if err != nil {
+		return errors.Wrap(err, "loading additional scrape configs from Secret failed")
+	}
+
+	conf, err := c.configGenerator.withHTTPRouteTargets(httpRouteTargets).GenerateAgentConfig(
+		p,
+		smons,
+		pmons,
+		bmons,
+		scrapeConfigs,
+		store,
+		additionalScrapeConfigs,
+		a.Spec.Mode == monitoringv1alpha1.DaemonSetMode,
+	)
+	if err != nil {
+		return errors.Wrap(err, "generating config failed")
+	}
+
+	return c.updateConfigurationSecret(ctx, p, config, conf, countScrapeJobs(smons, pmons, bmons, scrapeConfigs, additionalScrapeConfigs))
+}
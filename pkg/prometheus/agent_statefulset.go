@@ -0,0 +1,317 @@
+// Copyright 2021 The prometheus-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/prometheus-operator/prometheus-operator/pkg/operator"
+)
+
+const (
+	agentGoverningServiceName = "prometheus-agent-operated"
+	agentNameLabelName        = "operator.prometheus.io/agent-name"
+)
+
+func expectedAgentStatefulSetNames(p *monitoringv1.Prometheus) []string {
+	res := []string{}
+	shards := minShards
+	if p.Spec.Shards != nil && *p.Spec.Shards > 1 {
+		shards = *p.Spec.Shards
+	}
+
+	for i := int32(0); i < shards; i++ {
+		res = append(res, agentNameByShard(p.Name, i))
+	}
+
+	return res
+}
+
+func agentNameByShard(name string, shard int32) string {
+	base := agentPrefixedName(name)
+	if shard == 0 {
+		return base
+	}
+	return fmt.Sprintf("%s-shard-%d", base, shard)
+}
+
+// makeAgentStatefulSet returns the StatefulSet running the given shard of
+// the Prometheus agent. p is the Prometheus view of the PrometheusAgent
+// object.
+func makeAgentStatefulSet(
+	name string,
+	p monitoringv1.Prometheus,
+	config *operator.Config,
+	inputHash string,
+	shard int32,
+) (*appsv1.StatefulSet, error) {
+	p = *p.DeepCopy()
+
+	if p.Spec.Replicas == nil {
+		p.Spec.Replicas = &minReplicas
+	}
+	intZero := int32(0)
+	if *p.Spec.Replicas < 0 {
+		p.Spec.Replicas = &intZero
+	}
+
+	template, selectorLabels, err := makeAgentPodTemplate(p, config, &shard)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := map[string]string{
+		shardLabelName:     fmt.Sprintf("%d", shard),
+		agentNameLabelName: p.Name,
+	}
+
+	// PodManagementPolicy is set to Parallel to mitigate issues in kubernetes: https://github.com/kubernetes/kubernetes/issues/60164
+	sset := &appsv1.StatefulSet{
+		ObjectMeta: makeWorkloadObjectMeta(name, p, config, inputHash, labels),
+		Spec: appsv1.StatefulSetSpec{
+			ServiceName:         agentGoverningServiceName,
+			Replicas:            p.Spec.Replicas,
+			PodManagementPolicy: appsv1.ParallelPodManagement,
+			UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
+				Type: appsv1.RollingUpdateStatefulSetStrategyType,
+			},
+			Selector: &metav1.LabelSelector{
+				MatchLabels: selectorLabels,
+			},
+			Template: *template,
+		},
+	}
+
+	addStorage(&sset.Spec, p.Spec.Storage, agentVolumeName(p.Name))
+	sset.Spec.Template.Spec.Volumes = append(sset.Spec.Template.Spec.Volumes, p.Spec.Volumes...)
+
+	if err := operator.ApplyPodTemplateOverride(&sset.Spec.Template, p.Spec.PodTemplateOverride); err != nil {
+		return nil, err
+	}
+
+	return sset, nil
+}
+
+// makeAgentDaemonSet returns the DaemonSet running the Prometheus agent on
+// every node. p is the Prometheus view of the PrometheusAgent object.
+func makeAgentDaemonSet(
+	p monitoringv1.Prometheus,
+	config *operator.Config,
+	inputHash string,
+) (*appsv1.DaemonSet, error) {
+	p = *p.DeepCopy()
+
+	template, selectorLabels, err := makeAgentPodTemplate(p, config, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := map[string]string{
+		agentNameLabelName: p.Name,
+	}
+
+	dset := &appsv1.DaemonSet{
+		ObjectMeta: makeWorkloadObjectMeta(agentPrefixedName(p.Name), p, config, inputHash, labels),
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: selectorLabels,
+			},
+			UpdateStrategy: appsv1.DaemonSetUpdateStrategy{
+				Type: appsv1.RollingUpdateDaemonSetStrategyType,
+			},
+			Template: *template,
+		},
+	}
+
+	// The WAL doesn't outlive the pod in DaemonSet mode.
+	dset.Spec.Template.Spec.Volumes = append(dset.Spec.Template.Spec.Volumes, v1.Volume{
+		Name: agentVolumeName(p.Name),
+		VolumeSource: v1.VolumeSource{
+			EmptyDir: &v1.EmptyDirVolumeSource{},
+		},
+	})
+	dset.Spec.Template.Spec.Volumes = append(dset.Spec.Template.Spec.Volumes, p.Spec.Volumes...)
+
+	if err := operator.ApplyPodTemplateOverride(&dset.Spec.Template, p.Spec.PodTemplateOverride); err != nil {
+		return nil, err
+	}
+
+	return dset, nil
+}
+
+func makeAgentStatefulSetService(p *monitoringv1.Prometheus, config operator.Config) *v1.Service {
+	portName := defaultPortName
+	if p.Spec.PortName != "" {
+		portName = p.Spec.PortName
+	}
+
+	return &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: agentGoverningServiceName,
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					Name:       p.GetName(),
+					Kind:       p.Kind,
+					APIVersion: p.APIVersion,
+					UID:        p.GetUID(),
+				},
+			},
+			Labels: config.Labels.Merge(map[string]string{
+				"operated-prometheus-agent": "true",
+			}),
+		},
+		Spec: v1.ServiceSpec{
+			ClusterIP: "None",
+			Ports: []v1.ServicePort{
+				{
+					Name:       portName,
+					Port:       9090,
+					TargetPort: intstr.FromString(portName),
+				},
+			},
+			Selector: map[string]string{
+				"app.kubernetes.io/name": "prometheus-agent",
+			},
+		},
+	}
+}
+
+// makeAgentPodTemplate returns the pod template of the Prometheus agent and
+// the labels selecting its pods. The shard is nil in DaemonSet mode.
+func makeAgentPodTemplate(p monitoringv1.Prometheus, c *operator.Config, shard *int32) (*v1.PodTemplateSpec, map[string]string, error) {
+	version, err := semver.ParseTolerant(operator.StringValOrDefault(p.Spec.Version, operator.DefaultPrometheusAgentVersion))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to parse prometheus version")
+	}
+
+	prometheusImagePath, err := operator.BuildImagePath(
+		operator.StringPtrValOrDefault(p.Spec.Image, ""),
+		operator.StringValOrDefault(p.Spec.BaseImage, c.PrometheusDefaultBaseImage),
+		operator.StringValOrDefault(p.Spec.Version, operator.DefaultPrometheusAgentVersion),
+		p.Spec.Tag,
+		p.Spec.SHA,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	portName := defaultPortName
+	if p.Spec.PortName != "" {
+		portName = p.Spec.PortName
+	}
+
+	features := []string{"agent"}
+	for _, f := range p.Spec.EnableFeatures {
+		if f != "agent" {
+			features = append(features, f)
+		}
+	}
+
+	promArgs := []string{
+		fmt.Sprintf("--config.file=%s", path.Join(confOutDir, configEnvsubstFilename)),
+		"--enable-feature=" + strings.Join(features, ","),
+		fmt.Sprintf("--storage.agent.path=%s", storageDir),
+		"--web.enable-lifecycle",
+	}
+
+	if p.Spec.Web != nil && p.Spec.Web.PageTitle != nil {
+		promArgs = append(promArgs, fmt.Sprintf("--web.page-title=%s", *p.Spec.Web.PageTitle))
+	}
+
+	if p.Spec.ExternalURL != "" {
+		promArgs = append(promArgs, "--web.external-url="+p.Spec.ExternalURL)
+	}
+
+	webRoutePrefix := "/"
+	if p.Spec.RoutePrefix != "" {
+		webRoutePrefix = p.Spec.RoutePrefix
+	}
+	promArgs = append(promArgs, "--web.route-prefix="+webRoutePrefix)
+
+	if p.Spec.LogLevel != "" && p.Spec.LogLevel != "info" {
+		promArgs = append(promArgs, fmt.Sprintf("--log.level=%s", p.Spec.LogLevel))
+	}
+	if p.Spec.LogFormat != "" && p.Spec.LogFormat != "logfmt" {
+		promArgs = append(promArgs, fmt.Sprintf("--log.format=%s", p.Spec.LogFormat))
+	}
+
+	if p.Spec.WALCompression != nil {
+		if *p.Spec.WALCompression {
+			promArgs = append(promArgs, "--storage.agent.wal-compression")
+		} else {
+			promArgs = append(promArgs, "--no-storage.agent.wal-compression")
+		}
+	}
+
+	selectorLabels := map[string]string{
+		"app.kubernetes.io/name":       "prometheus-agent",
+		"app.kubernetes.io/managed-by": "prometheus-operator",
+		"app.kubernetes.io/instance":   p.Name,
+		agentNameLabelName:             p.Name,
+	}
+	if shard != nil {
+		selectorLabels[shardLabelName] = fmt.Sprintf("%d", *shard)
+	}
+
+	volName := agentVolumeName(p.Name)
+	if p.Spec.Storage != nil && p.Spec.Storage.VolumeClaimTemplate.Name != "" {
+		volName = p.Spec.Storage.VolumeClaimTemplate.Name
+	}
+
+	return makePodTemplate(p, c, webConfigFileFields(&p, version), podTemplateParams{
+		image:                 prometheusImagePath,
+		version:               version,
+		args:                  promArgs,
+		configSecretName:      agentConfigSecretName(p.Name),
+		tlsAssetsSecretName:   agentTLSAssetsSecretName(p.Name),
+		webConfigSecretName:   agentWebConfigSecretName(p.Name),
+		credentialsSecretName: agentCredentialsSecretName(p.Name),
+		volumeName:            volName,
+		portName:              portName,
+		listenLocal:           p.Spec.ListenLocal,
+		selectorLabels:        selectorLabels,
+		shard:                 shard,
+	})
+}
+
+func agentConfigSecretName(name string) string {
+	return agentPrefixedName(name)
+}
+
+func agentTLSAssetsSecretName(name string) string {
+	return fmt.Sprintf("%s-tls-assets", agentPrefixedName(name))
+}
+
+func agentWebConfigSecretName(name string) string {
+	return fmt.Sprintf("%s-web-config", agentPrefixedName(name))
+}
+
+func agentVolumeName(name string) string {
+	return fmt.Sprintf("%s-db", agentPrefixedName(name))
+}
+
+func agentPrefixedName(name string) string {
+	return fmt.Sprintf("prom-agent-%s", name)
+}
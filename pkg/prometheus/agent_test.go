@@ -0,0 +1,238 @@
+// Copyright 2021 The prometheus-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	monitoringv1alpha1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1alpha1"
+)
+
+func TestValidateAgent(t *testing.T) {
+	replicas := int32(2)
+
+	for _, tc := range []struct {
+		name string
+		spec monitoringv1alpha1.PrometheusAgentSpec
+		ok   bool
+	}{
+		{
+			name: "empty",
+			ok:   true,
+		},
+		{
+			name: "statefulset with replicas",
+			spec: monitoringv1alpha1.PrometheusAgentSpec{
+				Mode: monitoringv1alpha1.StatefulSetMode,
+				CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+					Replicas: &replicas,
+					Shards:   &replicas,
+				},
+			},
+			ok: true,
+		},
+		{
+			name: "old version",
+			spec: monitoringv1alpha1.PrometheusAgentSpec{
+				CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+					Version: "v2.31.0",
+				},
+			},
+		},
+		{
+			name: "daemonset",
+			spec: monitoringv1alpha1.PrometheusAgentSpec{
+				Mode: monitoringv1alpha1.DaemonSetMode,
+				CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+					ServiceMonitorSelector: &metav1.LabelSelector{},
+				},
+			},
+			ok: true,
+		},
+		{
+			name: "daemonset with replicas",
+			spec: monitoringv1alpha1.PrometheusAgentSpec{
+				Mode: monitoringv1alpha1.DaemonSetMode,
+				CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+					Replicas: &replicas,
+				},
+			},
+		},
+		{
+			name: "daemonset with probe selector",
+			spec: monitoringv1alpha1.PrometheusAgentSpec{
+				Mode: monitoringv1alpha1.DaemonSetMode,
+				CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+					ProbeSelector: &metav1.LabelSelector{},
+				},
+			},
+		},
+		{
+			name: "daemonset with scrapeconfig selector",
+			spec: monitoringv1alpha1.PrometheusAgentSpec{
+				Mode: monitoringv1alpha1.DaemonSetMode,
+				CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+					ScrapeConfigSelector: &metav1.LabelSelector{},
+				},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateAgent(&monitoringv1alpha1.PrometheusAgent{Spec: tc.spec})
+			if tc.ok && err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if !tc.ok && err == nil {
+				t.Fatal("expected an error, got none")
+			}
+		})
+	}
+}
+
+func TestAgentStatefulSet(t *testing.T) {
+	a := &monitoringv1alpha1.PrometheusAgent{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "default",
+		},
+	}
+	p := prometheusForAgent(a)
+
+	sset, err := makeAgentStatefulSet("prom-agent-test-shard-1", *p, defaultTestConfig, "", 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if sset.Spec.ServiceName != agentGoverningServiceName {
+		t.Fatalf("expected governing service %q, got %q", agentGoverningServiceName, sset.Spec.ServiceName)
+	}
+
+	if sset.Labels[agentNameLabelName] != "test" {
+		t.Fatalf("expected label %q to be %q, got %q", agentNameLabelName, "test", sset.Labels[agentNameLabelName])
+	}
+
+	if _, found := sset.Labels[prometheusNameLabelName]; found {
+		t.Fatalf("unexpected label %q", prometheusNameLabelName)
+	}
+
+	ref := sset.OwnerReferences[0]
+	if ref.Kind != monitoringv1alpha1.PrometheusAgentsKind || ref.APIVersion != monitoringv1alpha1.SchemeGroupVersion.String() {
+		t.Fatalf("unexpected owner reference %v", ref)
+	}
+
+	args := sset.Spec.Template.Spec.Containers[0].Args
+	for _, expected := range []string{
+		"--enable-feature=agent",
+		"--storage.agent.path=/prometheus",
+		"--web.config.file=/etc/prometheus/web_config/web-config.yaml",
+	} {
+		if !contains(args, expected) {
+			t.Fatalf("expected argument %q in %v", expected, args)
+		}
+	}
+
+	for _, c := range sset.Spec.Template.Spec.Containers {
+		if c.Name != "config-reloader" {
+			continue
+		}
+		if !hasEnvVar(c.Env, "SHARD", "1") {
+			t.Fatalf("expected SHARD environment variable set to 1, got %v", c.Env)
+		}
+	}
+}
+
+func TestAgentDaemonSet(t *testing.T) {
+	a := &monitoringv1alpha1.PrometheusAgent{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "default",
+		},
+		Spec: monitoringv1alpha1.PrometheusAgentSpec{
+			Mode: monitoringv1alpha1.DaemonSetMode,
+			CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+				EnableFeatures: []string{"agent", "exemplar-storage"},
+			},
+		},
+	}
+	p := prometheusForAgent(a)
+
+	dset, err := makeAgentDaemonSet(*p, defaultTestConfig, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if dset.Name != "prom-agent-test" {
+		t.Fatalf("expected name %q, got %q", "prom-agent-test", dset.Name)
+	}
+
+	if _, found := dset.Spec.Selector.MatchLabels[shardLabelName]; found {
+		t.Fatalf("unexpected selector label %q", shardLabelName)
+	}
+
+	args := dset.Spec.Template.Spec.Containers[0].Args
+	if !contains(args, "--enable-feature=agent,exemplar-storage") {
+		t.Fatalf("expected the agent feature to be enabled once, got %v", args)
+	}
+
+	var found bool
+	for _, vol := range dset.Spec.Template.Spec.Volumes {
+		if vol.Name == "prom-agent-test-db" {
+			found = vol.EmptyDir != nil
+		}
+	}
+	if !found {
+		t.Fatal("expected an emptyDir volume for the WAL")
+	}
+
+	for _, c := range dset.Spec.Template.Spec.Containers {
+		if c.Name != "config-reloader" {
+			continue
+		}
+		var nodeName bool
+		for _, env := range c.Env {
+			if env.Name == "NODE_NAME" && env.ValueFrom != nil && env.ValueFrom.FieldRef.FieldPath == "spec.nodeName" {
+				nodeName = true
+			}
+		}
+		if !nodeName {
+			t.Fatalf("expected NODE_NAME environment variable, got %v", c.Env)
+		}
+		if !hasEnvVar(c.Env, "SHARD", "0") {
+			t.Fatalf("expected SHARD environment variable set to 0, got %v", c.Env)
+		}
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func hasEnvVar(env []v1.EnvVar, name, value string) bool {
+	for _, e := range env {
+		if e.Name == name && e.Value == value {
+			return true
+		}
+	}
+	return false
+}
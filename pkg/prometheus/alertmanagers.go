@@ -0,0 +1,122 @@
+// Copyright 2023 The prometheus-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus
+
+import (
+	"strings"
+
+	"github.com/blang/semver/v4"
+	"github.com/go-kit/log/level"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/prometheus-operator/prometheus-operator/pkg/alertmanager"
+	v1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/prometheus-operator/prometheus-operator/pkg/assets"
+)
+
+// withAlertmanagers returns a copy of the generator configured to send the
+// alerts to the given Alertmanager endpoints too.
+func (cg *ConfigGenerator) withAlertmanagers(endpoints []alertmanager.Endpoint) *ConfigGenerator {
+	c := *cg
+	c.alertmanagers = endpoints
+	return &c
+}
+
+// generateSelectedAlertmanagerConfig returns the configuration discovering
+// the pods of an Alertmanager resource. The Secrets and ConfigMaps referenced
+// by the TLS configuration are read from the Prometheus namespace.
+func (cg *ConfigGenerator) generateSelectedAlertmanagerConfig(
+	version semver.Version,
+	am alertmanager.Endpoint,
+	namespace string,
+	tlsConfig *v1.TLSConfig,
+	apiserverConfig *v1.APIServerConfig,
+	store *assets.Store,
+) yaml.MapSlice {
+	cfg := yaml.MapSlice{
+		{Key: "path_prefix", Value: am.PathPrefix},
+		{Key: "scheme", Value: am.Scheme},
+	}
+
+	if am.Scheme == "https" {
+		cfg = addTLStoYaml(cfg, namespace, tlsConfig)
+	}
+
+	cfg = append(cfg, cg.generateK8SSDConfig(version, []string{am.Namespace}, apiserverConfig, store, kubernetesSDRoleEndpoint))
+
+	if version.GTE(semver.MustParse("2.11.0")) {
+		cfg = append(cfg, yaml.MapItem{Key: "api_version", Value: "v2"})
+	}
+
+	cfg = append(cfg, yaml.MapItem{Key: "relabel_configs", Value: []yaml.MapSlice{
+		{
+			{Key: "action", Value: "keep"},
+			{Key: "source_labels", Value: []string{"__meta_kubernetes_service_name"}},
+			{Key: "regex", Value: alertmanager.GoverningServiceName},
+		},
+		{
+			{Key: "action", Value: "keep"},
+			{Key: "source_labels", Value: []string{"__meta_kubernetes_pod_label_" + sanitizeLabelName(alertmanager.NameLabelName)}},
+			{Key: "regex", Value: am.Name},
+		},
+		{
+			{Key: "action", Value: "keep"},
+			{Key: "source_labels", Value: []string{"__meta_kubernetes_endpoint_port_name"}},
+			{Key: "regex", Value: am.PortName},
+		},
+	}})
+
+	return cfg
+}
+
+// selectAlertmanagers returns the endpoints of the Alertmanager resources
+// selected by the alerting section of the Prometheus object.
+func (c *Operator) selectAlertmanagers(p *v1.Prometheus) ([]alertmanager.Endpoint, error) {
+	if p.Spec.Alerting == nil || p.Spec.Alerting.AlertmanagerSelector == nil {
+		return nil, nil
+	}
+
+	// The feature is disabled when the operator isn't allowed to watch the
+	// Alertmanagers.
+	if c.amInfs == nil {
+		level.Warn(c.logger).Log("msg", "alertmanagerSelector is ignored because the operator isn't allowed to watch Alertmanagers", "namespace", p.Namespace, "prometheus", p.Name)
+		return nil, nil
+	}
+
+	amSelector, err := metav1.LabelSelectorAsSelector(p.Spec.Alerting.AlertmanagerSelector)
+	if err != nil {
+		return nil, errors.Wrap(err, "convert alertmanager label selector to selector")
+	}
+
+	// If 'AlertmanagerNamespaceSelector' is nil only check own namespace.
+	namespaces := []string{p.Namespace}
+	if p.Spec.Alerting.AlertmanagerNamespaceSelector != nil {
+		amNSSelector, err := metav1.LabelSelectorAsSelector(p.Spec.Alerting.AlertmanagerNamespaceSelector)
+		if err != nil {
+			return nil, errors.Wrap(err, "convert alertmanager namespace label selector to selector")
+		}
+
+		namespaces, err = c.listMatchingNamespaces(amNSSelector)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	level.Debug(c.logger).Log("msg", "filtering namespaces to select Alertmanagers from", "namespaces", strings.Join(namespaces, ","), "namespace", p.Namespace, "prometheus", p.Name)
+
+	return alertmanager.SelectEndpoints(c.logger, c.amInfs, namespaces, amSelector)
+}
@@ -16,8 +16,8 @@ package prometheus
 
 import (
 	v1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/prometheus-operator/prometheus-operator/pkg/informers"
 	"github.com/prometheus/client_golang/prometheus"
-	"k8s.io/client-go/tools/cache"
 )
 
 var (
@@ -48,11 +48,14 @@ var (
 )
 
 type prometheusCollector struct {
-	stores []cache.Store
+	informers *informers.ForResource
+	selected  func(interface{}) bool
 }
 
-func newPrometheusCollectorForStores(s ...cache.Store) *prometheusCollector {
-	return &prometheusCollector{stores: s}
+// newPrometheusCollectorForInformers returns a collector for the objects of
+// the informers which are accepted by the selected function.
+func newPrometheusCollectorForInformers(infs *informers.ForResource, selected func(interface{}) bool) *prometheusCollector {
+	return &prometheusCollector{informers: infs, selected: selected}
 }
 
 // Describe implements the prometheus.Collector interface.
@@ -64,8 +67,11 @@ func (c *prometheusCollector) Describe(ch chan<- *prometheus.Desc) {
 
 // Collect implements the prometheus.Collector interface.
 func (c *prometheusCollector) Collect(ch chan<- prometheus.Metric) {
-	for _, s := range c.stores {
-		for _, p := range s.List() {
+	for _, inf := range c.informers.GetInformers() {
+		for _, p := range inf.Informer().GetStore().List() {
+			if !c.selected(p) {
+				continue
+			}
 			c.collectPrometheus(ch, p.(*v1.Prometheus))
 		}
 	}
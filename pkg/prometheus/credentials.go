@@ -0,0 +1,120 @@
+// Copyright 2023 The prometheus-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+
+	"github.com/go-kit/log/level"
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+
+	v1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/prometheus-operator/prometheus-operator/pkg/assets"
+)
+
+const (
+	credentialsDir = "/etc/prometheus/credentials"
+
+	// Suffixes identifying the credentials stored under the same asset key.
+	tokenCredential        = "token"
+	passwordCredential     = "password"
+	clientSecretCredential = "client_secret"
+)
+
+var invalidCredentialKeyCharRE = regexp.MustCompile(`[^-._a-zA-Z0-9]`)
+
+func credentialsSecretName(name string) string {
+	return fmt.Sprintf("%s-credentials", prefixedName(name))
+}
+
+func agentCredentialsSecretName(name string) string {
+	return fmt.Sprintf("%s-credentials", agentPrefixedName(name))
+}
+
+// credentialsVolume returns the volume and the volume mount of the
+// credentials secret.
+func credentialsVolume(secretName string) (corev1.Volume, corev1.VolumeMount) {
+	return corev1.Volume{
+		Name: "credentials",
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: secretName,
+			},
+		},
+	}, corev1.VolumeMount{
+		Name:      "credentials",
+		ReadOnly:  true,
+		MountPath: credentialsDir,
+	}
+}
+
+// credentialKey returns the key of the credential in the credentials secret.
+// Kubernetes object names can't contain underscores hence replacing the
+// slashes of the asset key keeps the result unique.
+func credentialKey(assetKey, suffix string) string {
+	return invalidCredentialKeyCharRE.ReplaceAllString(assetKey, "_") + "_" + suffix
+}
+
+// withCredentialsAsFiles returns a copy of the generator configured to
+// render the credentials of the given Prometheus object.
+func (cg *ConfigGenerator) withCredentialsAsFiles(p *v1.Prometheus) *ConfigGenerator {
+	c := *cg
+	c.credentialsAsFiles = p.Spec.CredentialsAsFiles
+	return &c
+}
+
+// secretItem returns the configuration item holding the given secret value.
+// When credentials are mounted as files, the item references the file
+// holding the value instead, e.g. `password_file` instead of `password`.
+func (cg *ConfigGenerator) secretItem(name, assetKey, suffix, value string) yaml.MapItem {
+	if !cg.credentialsAsFiles {
+		return yaml.MapItem{Key: name, Value: value}
+	}
+	return yaml.MapItem{Key: name + "_file", Value: path.Join(credentialsDir, credentialKey(assetKey, suffix))}
+}
+
+// inlineSecretItem returns the configuration item holding a secret value
+// which Prometheus can't read from a file. The value is always inlined, a
+// warning is logged when credentials are supposed to be mounted as files.
+func (cg *ConfigGenerator) inlineSecretItem(name, value string, keyvals ...interface{}) yaml.MapItem {
+	if cg.credentialsAsFiles {
+		level.Warn(cg.logger).Log(append([]interface{}{
+			"msg", "credential can't be read from a file by Prometheus, writing it in the configuration despite credentialsAsFiles",
+			"field", name,
+		}, keyvals...)...)
+	}
+	return yaml.MapItem{Key: name, Value: value}
+}
+
+// makeCredentialsSecretData returns the content of the secret mounted into
+// the Prometheus pods when credentials are mounted as files.
+func makeCredentialsSecretData(store *assets.Store) map[string][]byte {
+	data := make(map[string][]byte, len(store.TokenAssets)+len(store.BasicAuthAssets)+len(store.OAuth2Assets))
+
+	for k, v := range store.TokenAssets {
+		data[credentialKey(k, tokenCredential)] = []byte(v)
+	}
+	for k, v := range store.BasicAuthAssets {
+		data[credentialKey(k, passwordCredential)] = []byte(v.Password)
+	}
+	for k, v := range store.OAuth2Assets {
+		data[credentialKey(k, clientSecretCredential)] = []byte(v.ClientSecret)
+	}
+
+	return data
+}
@@ -0,0 +1,173 @@
+// Copyright 2022 The prometheus-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+
+	"github.com/prometheus-operator/prometheus-operator/pkg/k8sutil"
+)
+
+const (
+	httpRouteGroup    = "gateway.networking.k8s.io"
+	httpRouteResource = "httproutes"
+)
+
+// httpRouteVersions are the versions of the HTTPRoute resource supported by
+// the operator, in order of preference.
+var httpRouteVersions = []string{"v1", "v1beta1"}
+
+// discoverHTTPRouteGVR returns the preferred version of the HTTPRoute resource
+// served by the API server. It returns false if no supported version is
+// served.
+func discoverHTTPRouteGVR(dc discovery.DiscoveryInterface) (schema.GroupVersionResource, bool, error) {
+	for _, version := range httpRouteVersions {
+		gvr := schema.GroupVersionResource{Group: httpRouteGroup, Version: version, Resource: httpRouteResource}
+		supported, err := k8sutil.IsAPIGroupVersionResourceSupported(dc, gvr.GroupVersion().String(), httpRouteResource)
+		if err != nil {
+			return schema.GroupVersionResource{}, false, err
+		}
+		if supported {
+			return gvr, true, nil
+		}
+	}
+	return schema.GroupVersionResource{}, false, nil
+}
+
+// targetGroup represents a group of targets sharing the same labels. It is
+// used for the targets resolved by the operator from Kubernetes objects that
+// Prometheus can't discover by itself.
+type targetGroup struct {
+	Targets []string
+	Labels  map[string]string
+}
+
+// withHTTPRouteTargets returns a copy of the generator configured with the
+// targets resolved from the HTTPRoutes selected by the probes. The targets
+// are indexed by the probe's key ("probe/<namespace>/<name>").
+func (cg *ConfigGenerator) withHTTPRouteTargets(targets map[string][]targetGroup) *ConfigGenerator {
+	c := *cg
+	c.httpRouteTargets = targets
+	return &c
+}
+
+// httpRoute is the subset of the Gateway API HTTPRoute resource needed to
+// build the probe targets.
+type httpRoute struct {
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              struct {
+		Hostnames []string `json:"hostnames,omitempty"`
+		Rules     []struct {
+			Matches []struct {
+				Path *struct {
+					Type  *string `json:"type,omitempty"`
+					Value *string `json:"value,omitempty"`
+				} `json:"path,omitempty"`
+			} `json:"matches,omitempty"`
+		} `json:"rules,omitempty"`
+	} `json:"spec,omitempty"`
+}
+
+// httpRouteTargetGroup returns the URLs to probe for the given HTTPRoute
+// object. A URL is generated for each hostname and path match of the route.
+// Wildcard hostnames and path matches of type RegularExpression are ignored
+// since they can't be turned into a URL. It returns an error when no URL can
+// be generated.
+func httpRouteTargetGroup(obj *unstructured.Unstructured, scheme string) (targetGroup, error) {
+	var route httpRoute
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), &route); err != nil {
+		return targetGroup{}, errors.Wrapf(err, "failed to decode HTTPRoute %s/%s", obj.GetNamespace(), obj.GetName())
+	}
+
+	// The route matches the hostnames of the Gateway listeners which aren't
+	// known to the operator.
+	if len(route.Spec.Hostnames) == 0 {
+		return targetGroup{}, errors.New("the HTTPRoute has no hostname")
+	}
+
+	var hostnames []string
+	for _, hostname := range route.Spec.Hostnames {
+		if strings.HasPrefix(hostname, "*") {
+			continue
+		}
+		hostnames = append(hostnames, hostname)
+	}
+	if len(hostnames) == 0 {
+		return targetGroup{}, errors.New("the HTTPRoute has only wildcard hostnames")
+	}
+
+	if scheme == "" {
+		scheme = "http"
+	}
+
+	var (
+		paths []string
+		seen  = map[string]struct{}{}
+	)
+	addPath := func(path string) {
+		if _, ok := seen[path]; ok {
+			return
+		}
+		seen[path] = struct{}{}
+		paths = append(paths, path)
+	}
+
+	// A route without rules or a rule without matches matches all the
+	// requests.
+	if len(route.Spec.Rules) == 0 {
+		addPath("/")
+	}
+	for _, rule := range route.Spec.Rules {
+		if len(rule.Matches) == 0 {
+			addPath("/")
+		}
+		for _, match := range rule.Matches {
+			if match.Path == nil || match.Path.Value == nil {
+				addPath("/")
+				continue
+			}
+			if match.Path.Type != nil && *match.Path.Type != "Exact" && *match.Path.Type != "PathPrefix" {
+				continue
+			}
+			addPath(*match.Path.Value)
+		}
+	}
+
+	if len(paths) == 0 {
+		return targetGroup{}, errors.New("the HTTPRoute has no path match of type Exact or PathPrefix")
+	}
+
+	tg := targetGroup{
+		Labels: map[string]string{
+			"namespace": route.Namespace,
+			"httproute": route.Name,
+		},
+	}
+	for _, hostname := range hostnames {
+		for _, path := range paths {
+			tg.Targets = append(tg.Targets, fmt.Sprintf("%s://%s%s", scheme, hostname, path))
+		}
+	}
+
+	return tg, nil
+}
@@ -0,0 +1,215 @@
+// Copyright 2022 The prometheus-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestHTTPRouteTargetGroup(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		spec     map[string]interface{}
+		scheme   string
+		expected []string
+		err      bool
+	}{
+		{
+			name: "no rules",
+			spec: map[string]interface{}{
+				"hostnames": []interface{}{"example.com"},
+			},
+			expected: []string{"http://example.com/"},
+		},
+		{
+			name: "no hostnames",
+			spec: map[string]interface{}{
+				"rules": []interface{}{
+					map[string]interface{}{},
+				},
+			},
+			err: true,
+		},
+		{
+			name: "only wildcard hostnames",
+			spec: map[string]interface{}{
+				"hostnames": []interface{}{"*.example.com"},
+			},
+			err: true,
+		},
+		{
+			name: "wildcard hostnames are skipped",
+			spec: map[string]interface{}{
+				"hostnames": []interface{}{"*.example.com", "www.example.com"},
+			},
+			expected: []string{"http://www.example.com/"},
+		},
+		{
+			name: "only regular expression path matches",
+			spec: map[string]interface{}{
+				"hostnames": []interface{}{"example.com"},
+				"rules": []interface{}{
+					map[string]interface{}{
+						"matches": []interface{}{
+							map[string]interface{}{
+								"path": map[string]interface{}{"type": "RegularExpression", "value": "/v[0-9]+"},
+							},
+						},
+					},
+				},
+			},
+			err: true,
+		},
+		{
+			name: "path matches",
+			spec: map[string]interface{}{
+				"hostnames": []interface{}{"a.example.com", "b.example.com"},
+				"rules": []interface{}{
+					map[string]interface{}{
+						"matches": []interface{}{
+							map[string]interface{}{
+								"path": map[string]interface{}{"type": "PathPrefix", "value": "/api"},
+							},
+							map[string]interface{}{
+								"path": map[string]interface{}{"type": "RegularExpression", "value": "/v[0-9]+"},
+							},
+						},
+					},
+					map[string]interface{}{
+						"matches": []interface{}{
+							map[string]interface{}{
+								"path": map[string]interface{}{"type": "Exact", "value": "/healthz"},
+							},
+							map[string]interface{}{
+								"path": map[string]interface{}{"type": "PathPrefix", "value": "/api"},
+							},
+						},
+					},
+				},
+			},
+			scheme: "https",
+			expected: []string{
+				"https://a.example.com/api",
+				"https://a.example.com/healthz",
+				"https://b.example.com/api",
+				"https://b.example.com/healthz",
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			obj := &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"apiVersion": httpRouteGroup + "/v1",
+					"kind":       "HTTPRoute",
+					"metadata": map[string]interface{}{
+						"name":      "route",
+						"namespace": "default",
+					},
+					"spec": tc.spec,
+				},
+			}
+
+			tg, err := httpRouteTargetGroup(obj, tc.scheme)
+			if tc.err {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(tg.Targets, tc.expected) {
+				t.Fatalf("expected targets %v, got %v", tc.expected, tg.Targets)
+			}
+
+			if tg.Labels["namespace"] != "default" || tg.Labels["httproute"] != "route" {
+				t.Fatalf("unexpected labels %v", tg.Labels)
+			}
+		})
+	}
+}
+
+func TestDiscoverHTTPRouteGVR(t *testing.T) {
+	resources := func(groupVersion string, names ...string) *metav1.APIResourceList {
+		l := &metav1.APIResourceList{GroupVersion: groupVersion}
+		for _, n := range names {
+			l.APIResources = append(l.APIResources, metav1.APIResource{Name: n})
+		}
+		return l
+	}
+
+	for _, tc := range []struct {
+		name      string
+		resources []*metav1.APIResourceList
+		expected  string
+		supported bool
+	}{
+		{
+			name: "v1 preferred",
+			resources: []*metav1.APIResourceList{
+				resources("gateway.networking.k8s.io/v1", "httproutes"),
+				resources("gateway.networking.k8s.io/v1beta1", "httproutes"),
+			},
+			expected:  "v1",
+			supported: true,
+		},
+		{
+			name: "fallback to v1beta1",
+			resources: []*metav1.APIResourceList{
+				resources("gateway.networking.k8s.io/v1", "gateways"),
+				resources("gateway.networking.k8s.io/v1beta1", "httproutes"),
+			},
+			expected:  "v1beta1",
+			supported: true,
+		},
+		{
+			name: "not served",
+			resources: []*metav1.APIResourceList{
+				resources("gateway.networking.k8s.io/v1"),
+				resources("gateway.networking.k8s.io/v1beta1"),
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := fake.NewSimpleClientset()
+			c.Discovery().(*fakediscovery.FakeDiscovery).Resources = tc.resources
+
+			gvr, supported, err := discoverHTTPRouteGVR(c.Discovery())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if supported != tc.supported {
+				t.Fatalf("expected supported %v, got %v", tc.supported, supported)
+			}
+			if !supported {
+				return
+			}
+
+			expected := schema.GroupVersionResource{Group: httpRouteGroup, Version: tc.expected, Resource: httpRouteResource}
+			if gvr != expected {
+				t.Fatalf("expected %v, got %v", expected, gvr)
+			}
+		})
+	}
+}
@@ -0,0 +1,246 @@
+// Copyright 2023 The prometheus-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	clientauthv1 "k8s.io/client-go/kubernetes/typed/authorization/v1"
+
+	"github.com/prometheus-operator/prometheus-operator/pkg/k8sutil"
+	"github.com/prometheus-operator/prometheus-operator/pkg/operator"
+)
+
+const (
+	// kubeletObjectsAnnotation lists the additional kubelet objects (per
+	// OS or node pool) managed along with the main kubelet object. It
+	// allows to delete the objects which aren't needed anymore without
+	// permission to list services.
+	kubeletObjectsAnnotation = "operator.prometheus.io/kubelet-objects"
+
+	kubeletNodeOSLabel   = "operator.prometheus.io/node-os"
+	kubeletNodePoolLabel = "operator.prometheus.io/node-pool"
+)
+
+var defaultKubeletPorts = []operator.KubeletPort{
+	{Name: "https-metrics", Port: 10250},
+	{Name: "http-metrics", Port: 10255},
+	{Name: "cadvisor", Port: 4194},
+}
+
+// kubeletSyncVerbs returns the verbs required on the kubelet services and
+// endpoints. Stale objects need to be deleted when the nodes are split into
+// several objects.
+func kubeletSyncVerbs(cfg operator.KubeletSyncConfig) []string {
+	if cfg.SplitByOS || cfg.NodePoolLabel != "" {
+		return []string{"get", "create", "update", "delete"}
+	}
+	return []string{"get", "create", "update"}
+}
+
+// checkKubeletSyncPermissions returns an error for each permission required by
+// the kubelet endpoints sync which isn't granted to the operator.
+func checkKubeletSyncPermissions(
+	ctx context.Context,
+	logger log.Logger,
+	ssarClient clientauthv1.SelfSubjectAccessReviewInterface,
+	namespace string,
+	cfg operator.KubeletSyncConfig,
+) []error {
+	return append(
+		operator.CheckPermissions(ctx, logger, ssarClient, nil, k8sutil.ResourceAttribute{
+			Resource: "nodes",
+			Verbs:    []string{"list"},
+		}),
+		operator.CheckPermissions(
+			ctx,
+			logger,
+			ssarClient,
+			map[string]struct{}{namespace: {}},
+			k8sutil.ResourceAttribute{Resource: "services", Verbs: kubeletSyncVerbs(cfg)},
+			k8sutil.ResourceAttribute{Resource: "endpoints", Verbs: kubeletSyncVerbs(cfg)},
+		)...,
+	)
+}
+
+// kubeletNodeGroup is a group of nodes published into the same kubelet
+// Service/Endpoints objects.
+type kubeletNodeGroup struct {
+	name   string
+	labels map[string]string
+	ports  []operator.KubeletPort
+	nodes  []v1.Node
+}
+
+// groupKubeletNodes splits the nodes into groups according to the
+// configuration. The first group is always the main group named after the
+// kubelet object, even when it has no nodes.
+func groupKubeletNodes(name string, cfg operator.KubeletSyncConfig, nodes []v1.Node) []*kubeletNodeGroup {
+	ports := cfg.Ports
+	if len(ports) == 0 {
+		ports = defaultKubeletPorts
+	}
+	windowsPorts := cfg.WindowsPorts
+	if len(windowsPorts) == 0 {
+		windowsPorts = ports
+	}
+
+	main := &kubeletNodeGroup{name: name, labels: map[string]string{}, ports: ports}
+	if cfg.SplitByOS {
+		main.labels[kubeletNodeOSLabel] = "linux"
+	}
+
+	groups := map[string]*kubeletNodeGroup{name: main}
+	for _, n := range nodes {
+		var (
+			suffixes []string
+			labels   = map[string]string{}
+			ports    = ports
+		)
+
+		if cfg.NodePoolLabel != "" {
+			if pool := n.Labels[cfg.NodePoolLabel]; pool != "" {
+				suffixes = append(suffixes, pool)
+				labels[kubeletNodePoolLabel] = pool
+			}
+		}
+
+		if cfg.SplitByOS {
+			os := "linux"
+			if n.Labels[v1.LabelOSStable] == "windows" {
+				os = "windows"
+				suffixes = append(suffixes, os)
+				ports = windowsPorts
+			}
+			labels[kubeletNodeOSLabel] = os
+		}
+
+		groupName := name
+		if len(suffixes) > 0 {
+			groupName = k8sutil.SanitizeVolumeName(strings.Join(append([]string{name}, suffixes...), "-"))
+		}
+
+		g, found := groups[groupName]
+		if !found {
+			g = &kubeletNodeGroup{name: groupName, labels: labels, ports: ports}
+			groups[groupName] = g
+		}
+		g.nodes = append(g.nodes, n)
+	}
+
+	ret := make([]*kubeletNodeGroup, 0, len(groups))
+	for _, g := range groups {
+		if g != main {
+			ret = append(ret, g)
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].name < ret[j].name })
+
+	return append([]*kubeletNodeGroup{main}, ret...)
+}
+
+// nodeEndpointAddress returns the endpoint address of the node for the given
+// address type.
+func nodeEndpointAddress(node v1.Node, addressType v1.NodeAddressType) (v1.EndpointAddress, error) {
+	address, m, err := nodeAddress(node)
+
+	switch addressType {
+	case "":
+	case v1.NodeHostName:
+		// Endpoints require an IP address, the hostname is published
+		// along with the default address.
+		hostnames := m[v1.NodeHostName]
+		if len(hostnames) == 0 {
+			return v1.EndpointAddress{}, fmt.Errorf("%s address unknown", addressType)
+		}
+		if errs := validation.IsDNS1123Label(hostnames[0]); len(errs) > 0 {
+			return v1.EndpointAddress{}, fmt.Errorf("invalid hostname %q: %s", hostnames[0], strings.Join(errs, ", "))
+		}
+		if err != nil {
+			return v1.EndpointAddress{}, err
+		}
+		return newNodeEndpointAddress(node, address, hostnames[0]), nil
+	default:
+		addresses := m[addressType]
+		if len(addresses) == 0 {
+			return v1.EndpointAddress{}, fmt.Errorf("%s address unknown", addressType)
+		}
+		return newNodeEndpointAddress(node, addresses[0], ""), nil
+	}
+
+	if err != nil {
+		return v1.EndpointAddress{}, err
+	}
+	return newNodeEndpointAddress(node, address, ""), nil
+}
+
+func newNodeEndpointAddress(node v1.Node, ip, hostname string) v1.EndpointAddress {
+	return v1.EndpointAddress{
+		IP:       ip,
+		Hostname: hostname,
+		TargetRef: &v1.ObjectReference{
+			Kind:       "Node",
+			Name:       node.Name,
+			UID:        node.UID,
+			APIVersion: node.APIVersion,
+		},
+	}
+}
+
+// kubeletObjects returns the names of the additional kubelet objects
+// recorded on the main kubelet service.
+func (c *Operator) kubeletObjects(ctx context.Context) ([]string, error) {
+	svc, err := c.kclient.CoreV1().Services(c.kubeletObjectNamespace).Get(ctx, c.kubeletObjectName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	v := svc.Annotations[kubeletObjectsAnnotation]
+	if v == "" {
+		return nil, nil
+	}
+	return strings.Split(v, ","), nil
+}
+
+// deleteKubeletObject deletes the Service/Endpoints objects of a node group
+// which doesn't exist anymore.
+func (c *Operator) deleteKubeletObject(ctx context.Context, logger log.Logger, name string) error {
+	level.Debug(logger).Log("msg", "Deleting stale kubelet objects", "name", name, "ns", c.kubeletObjectNamespace)
+
+	err := c.kclient.CoreV1().Services(c.kubeletObjectNamespace).Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "deleting kubelet service %q failed", name)
+	}
+
+	err = c.kclient.CoreV1().Endpoints(c.kubeletObjectNamespace).Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "deleting kubelet endpoints %q failed", name)
+	}
+
+	return nil
+}
@@ -21,12 +21,16 @@ import (
 	"fmt"
 	"reflect"
 	"regexp"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/prometheus-operator/prometheus-operator/pkg/webconfig"
 
+	"github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	monitoringv1alpha1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1alpha1"
 	"github.com/prometheus-operator/prometheus-operator/pkg/assets"
 	monitoringclient "github.com/prometheus-operator/prometheus-operator/pkg/client/versioned"
 	"github.com/prometheus-operator/prometheus-operator/pkg/informers"
@@ -39,15 +43,21 @@ import (
 	"github.com/mitchellh/hashstructure"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/yaml.v2"
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	clientauthv1 "k8s.io/client-go/kubernetes/typed/authorization/v1"
+	"k8s.io/client-go/metadata"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
 )
@@ -66,18 +76,53 @@ type Operator struct {
 	nsPromInf cache.SharedIndexInformer
 	nsMonInf  cache.SharedIndexInformer
 
-	promInfs  *informers.ForResource
+	nsSelector *informers.NamespaceSelector
+
+	promInfs *informers.ForResource
+	cmapInfs *informers.ForResource
+	secrInfs *informers.ForResource
+
+	// assetCmapInfs and assetSecrInfs provide the resource versions of the
+	// configmaps and secrets to the asset cache. Unlike cmapInfs, they aren't
+	// filtered by label and they cover the namespaces of the monitoring
+	// resources too.
+	assetCmapInfs *informers.ForResource
+	assetSecrInfs *informers.ForResource
+	ssetInfs      *informers.ForResource
+
+	// The informers of the optional resources are nil when the operator
+	// isn't allowed to list/watch them.
 	smonInfs  *informers.ForResource
 	pmonInfs  *informers.ForResource
 	probeInfs *informers.ForResource
+	sconInfs  *informers.ForResource
+	amInfs    *informers.ForResource
 	ruleInfs  *informers.ForResource
-	cmapInfs  *informers.ForResource
-	secrInfs  *informers.ForResource
-	ssetInfs  *informers.ForResource
 
-	queue workqueue.RateLimitingInterface
+	// httpRouteInfs is nil when the Gateway API isn't served by the
+	// cluster or when the operator isn't allowed to list/watch HTTPRoutes.
+	httpRouteInfs *informers.ForResource
+
+	// proberInfs is nil when the Prober CRD isn't installed or when the
+	// operator isn't allowed to list/watch Probers.
+	proberInfs *informers.ForResource
 
-	metrics *operator.Metrics
+	// PrometheusAgent objects are reconciled with their own queue and
+	// metrics. They share the monitor, secret and statefulset informers with
+	// the Prometheus objects. agentInfs and dsetInfs are nil when the
+	// operator isn't allowed to list/watch PrometheusAgents and DaemonSets.
+	agentInfs *informers.ForResource
+	dsetInfs  *informers.ForResource
+
+	// assetCache resolves the secrets and configmaps referenced by the
+	// monitoring resources. The informers only hold the objects' metadata.
+	assetCache *assets.Cache
+
+	queue      workqueue.RateLimitingInterface
+	agentQueue workqueue.RateLimitingInterface
+
+	metrics      *operator.Metrics
+	agentMetrics *operator.Metrics
 
 	nodeAddressLookupErrors prometheus.Counter
 	nodeEndpointSyncs       prometheus.Counter
@@ -87,7 +132,17 @@ type Operator struct {
 	kubeletObjectName      string
 	kubeletObjectNamespace string
 	kubeletSyncEnabled     bool
-	config                 operator.Config
+	// nodeSyncCh triggers a kubelet endpoints sync out of the periodic
+	// schedule.
+	nodeSyncCh chan struct{}
+
+	ssarClient clientauthv1.SelfSubjectAccessReviewInterface
+
+	// configMtx protects the settings which can be updated at runtime by
+	// ApplyConfig.
+	configMtx    sync.RWMutex
+	config       operator.Config
+	promSelector labels.Selector
 
 	configGenerator *ConfigGenerator
 }
@@ -109,7 +164,18 @@ func New(ctx context.Context, conf operator.Config, logger log.Logger, r prometh
 		return nil, errors.Wrap(err, "instantiating monitoring client failed")
 	}
 
-	if _, err := labels.Parse(conf.PromSelector); err != nil {
+	dclient, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "instantiating dynamic client failed")
+	}
+
+	mdClient, err := metadata.NewForConfig(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "instantiating metadata client failed")
+	}
+
+	promSelector, err := labels.Parse(conf.PromSelector)
+	if err != nil {
 		return nil, errors.Wrap(err, "can not parse prometheus selector value")
 	}
 
@@ -132,18 +198,92 @@ func New(ctx context.Context, conf operator.Config, logger log.Logger, r prometh
 		kubeletSyncEnabled = true
 	}
 
+	// The controller can't run without watching these resources.
+	ssarClient := client.AuthorizationV1().SelfSubjectAccessReviews()
+	namespaceLabels, err := operator.CheckControllerPermissions(
+		ctx,
+		logger,
+		ssarClient,
+		"prometheus",
+		operator.NamespacedAttributes{
+			AllowList:  conf.Namespaces.AllowList,
+			Attributes: operator.ListWatchAttributes("", "configmaps", "secrets"),
+		},
+		operator.NamespacedAttributes{
+			AllowList: conf.Namespaces.PrometheusAllowList,
+			Attributes: append(
+				operator.ListWatchAttributes(monitoring.GroupName, monitoringv1.PrometheusName),
+				append(
+					operator.ListWatchAttributes("apps", "statefulsets"),
+					operator.ListWatchAttributes("", "configmaps", "secrets")...,
+				)...,
+			),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// The other resources are optional: their informers aren't created and
+	// the features relying on them are disabled when the operator isn't
+	// allowed to watch them.
+	var disabledFeatures []string
+	allowed := func(feature string, allowList map[string]struct{}, attributes ...k8sutil.ResourceAttribute) bool {
+		missing := operator.CheckPermissions(ctx, logger, ssarClient, allowList, attributes...)
+		for _, err := range missing {
+			level.Warn(logger).Log("msg", "feature is disabled", "feature", feature, "err", err)
+		}
+		if len(missing) > 0 {
+			disabledFeatures = append(disabledFeatures, feature)
+			return false
+		}
+		return true
+	}
+	var (
+		smonAllowed  = allowed("servicemonitors", conf.Namespaces.AllowList, operator.ListWatchAttributes(monitoring.GroupName, monitoringv1.ServiceMonitorName)...)
+		pmonAllowed  = allowed("podmonitors", conf.Namespaces.AllowList, operator.ListWatchAttributes(monitoring.GroupName, monitoringv1.PodMonitorName)...)
+		probeAllowed = allowed("probes", conf.Namespaces.AllowList, operator.ListWatchAttributes(monitoring.GroupName, monitoringv1.ProbeName)...)
+		sconAllowed  = allowed("scrapeconfigs", conf.Namespaces.AllowList, operator.ListWatchAttributes(monitoring.GroupName, monitoringv1alpha1.ScrapeConfigName)...)
+		ruleAllowed  = allowed("prometheusrules", conf.Namespaces.AllowList, operator.ListWatchAttributes(monitoring.GroupName, monitoringv1.PrometheusRuleName)...)
+		amAllowed    = allowed("alertmanagers", conf.Namespaces.AllowList, operator.ListWatchAttributes(monitoring.GroupName, monitoringv1.AlertmanagerName)...)
+		agentAllowed = allowed(
+			"prometheusagents",
+			conf.Namespaces.PrometheusAllowList,
+			append(
+				operator.ListWatchAttributes(monitoring.GroupName, monitoringv1alpha1.PrometheusAgentName),
+				operator.ListWatchAttributes("apps", "daemonsets")...,
+			)...,
+		)
+	)
+
+	// The kubelet endpoints sync is disabled rather than failing
+	// continuously when the operator isn't allowed to perform it.
+	var kubeletSyncMissing []error
+	if kubeletSyncEnabled {
+		kubeletSyncMissing = checkKubeletSyncPermissions(ctx, logger, ssarClient, kubeletObjectNamespace, conf.KubeletSync)
+		for _, err := range kubeletSyncMissing {
+			level.Warn(logger).Log("msg", "kubelet endpoints sync is disabled", "err", err)
+		}
+		kubeletSyncEnabled = len(kubeletSyncMissing) == 0
+	}
+
 	c := &Operator{
 		kclient:                client,
 		mclient:                mclient,
 		logger:                 logger,
 		queue:                  workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "prometheus"),
+		agentQueue:             workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "prometheusagent"),
 		host:                   cfg.Host,
 		kubeletObjectName:      kubeletObjectName,
 		kubeletObjectNamespace: kubeletObjectNamespace,
 		kubeletSyncEnabled:     kubeletSyncEnabled,
+		nodeSyncCh:             make(chan struct{}, 1),
+		ssarClient:             ssarClient,
 		config:                 conf,
+		promSelector:           promSelector,
 		configGenerator:        NewConfigGenerator(logger),
 		metrics:                operator.NewMetrics("prometheus", r),
+		agentMetrics:           operator.NewMetrics("prometheusagent", r),
 		nodeAddressLookupErrors: prometheus.NewCounter(prometheus.CounterOpts{
 			Name: "prometheus_operator_node_address_lookup_errors_total",
 			Help: "Number of times a node IP address could not be determined",
@@ -158,16 +298,33 @@ func New(ctx context.Context, conf operator.Config, logger log.Logger, r prometh
 		}),
 	}
 	c.metrics.MustRegister(c.nodeAddressLookupErrors, c.nodeEndpointSyncs, c.nodeEndpointSyncErrors)
+	if !namespaceLabels {
+		c.metrics.DisableFeature("namespace-labels")
+	}
+	if len(kubeletSyncMissing) > 0 {
+		c.metrics.DisableFeature("kubelet-endpoints-sync")
+	}
+	for _, feature := range disabledFeatures {
+		c.metrics.DisableFeature(feature)
+	}
+	if !agentAllowed {
+		c.agentMetrics.DisableFeature("controller")
+	}
+
+	c.nsSelector, err = informers.NewNamespaceSelector(c.config.Namespaces.AllowSelector, c.config.Namespaces.DenySelector, c.logger)
+	if err != nil {
+		return nil, err
+	}
 
+	// The instance selector is evaluated by the controller instead of the
+	// API server so that it can be updated at runtime.
 	c.promInfs, err = informers.NewInformersForResource(
 		informers.NewMonitoringInformerFactories(
-			c.config.Namespaces.PrometheusAllowList,
+			c.nsSelector.AllowList(c.config.Namespaces.PrometheusAllowList),
 			c.config.Namespaces.DenyList,
 			mclient,
 			resyncPeriod,
-			func(options *metav1.ListOptions) {
-				options.LabelSelector = c.config.PromSelector
-			},
+			nil,
 		),
 		monitoringv1.SchemeGroupVersion.WithResource(monitoringv1.PrometheusName),
 	)
@@ -175,89 +332,229 @@ func New(ctx context.Context, conf operator.Config, logger log.Logger, r prometh
 		return nil, errors.Wrap(err, "error creating prometheus informers")
 	}
 
-	var promStores []cache.Store
-	for _, informer := range c.promInfs.GetInformers() {
-		promStores = append(promStores, informer.Informer().GetStore())
+	c.metrics.MustRegister(newPrometheusCollectorForInformers(c.promInfs, c.isSelected))
+
+	if agentAllowed {
+		c.agentInfs, err = informers.NewInformersForResource(
+			informers.NewMonitoringInformerFactories(
+				c.nsSelector.AllowList(c.config.Namespaces.PrometheusAllowList),
+				c.config.Namespaces.DenyList,
+				mclient,
+				resyncPeriod,
+				nil,
+			),
+			monitoringv1alpha1.SchemeGroupVersion.WithResource(monitoringv1alpha1.PrometheusAgentName),
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "error creating prometheusagent informers")
+		}
+	}
+
+	if smonAllowed {
+		c.smonInfs, err = informers.NewInformersForResource(
+			informers.NewMonitoringInformerFactories(
+				c.nsSelector.AllowList(c.config.Namespaces.AllowList),
+				c.config.Namespaces.DenyList,
+				mclient,
+				resyncPeriod,
+				nil,
+			),
+			monitoringv1.SchemeGroupVersion.WithResource(monitoringv1.ServiceMonitorName),
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "error creating servicemonitor informers")
+		}
 	}
-	c.metrics.MustRegister(newPrometheusCollectorForStores(promStores...))
 
-	c.smonInfs, err = informers.NewInformersForResource(
-		informers.NewMonitoringInformerFactories(
-			c.config.Namespaces.AllowList,
-			c.config.Namespaces.DenyList,
-			mclient,
-			resyncPeriod,
-			nil,
-		),
-		monitoringv1.SchemeGroupVersion.WithResource(monitoringv1.ServiceMonitorName),
-	)
+	if pmonAllowed {
+		c.pmonInfs, err = informers.NewInformersForResource(
+			informers.NewMonitoringInformerFactories(
+				c.nsSelector.AllowList(c.config.Namespaces.AllowList),
+				c.config.Namespaces.DenyList,
+				mclient,
+				resyncPeriod,
+				nil,
+			),
+			monitoringv1.SchemeGroupVersion.WithResource(monitoringv1.PodMonitorName),
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "error creating podmonitor informers")
+		}
+	}
+
+	if probeAllowed {
+		c.probeInfs, err = informers.NewInformersForResource(
+			informers.NewMonitoringInformerFactories(
+				c.nsSelector.AllowList(c.config.Namespaces.AllowList),
+				c.config.Namespaces.DenyList,
+				mclient,
+				resyncPeriod,
+				nil,
+			),
+			monitoringv1.SchemeGroupVersion.WithResource(monitoringv1.ProbeName),
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "error creating probe informers")
+		}
+	}
+
+	if sconAllowed {
+		c.sconInfs, err = informers.NewInformersForResource(
+			informers.NewMonitoringInformerFactories(
+				c.nsSelector.AllowList(c.config.Namespaces.AllowList),
+				c.config.Namespaces.DenyList,
+				mclient,
+				resyncPeriod,
+				nil,
+			),
+			monitoringv1alpha1.SchemeGroupVersion.WithResource(monitoringv1alpha1.ScrapeConfigName),
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "error creating scrapeconfig informers")
+		}
+	}
+
+	// Alertmanagers are selected like the other resources watched in the
+	// monitoring namespaces.
+	if amAllowed {
+		c.amInfs, err = informers.NewInformersForResource(
+			informers.NewMonitoringInformerFactories(
+				c.nsSelector.AllowList(c.config.Namespaces.AllowList),
+				c.config.Namespaces.DenyList,
+				mclient,
+				resyncPeriod,
+				nil,
+			),
+			monitoringv1.SchemeGroupVersion.WithResource(monitoringv1.AlertmanagerName),
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "error creating alertmanager informers")
+		}
+	}
+
+	httpRouteGVR, httpRouteSupported, err := discoverHTTPRouteGVR(c.kclient.Discovery())
 	if err != nil {
-		return nil, errors.Wrap(err, "error creating servicemonitor informers")
+		return nil, errors.Wrap(err, "failed to check if the HTTPRoute resource is supported")
+	}
+	switch {
+	case !httpRouteSupported:
+		level.Info(c.logger).Log("msg", "HTTPRoute resource not served by the API server, probe targets of type httpRoute are disabled", "group", httpRouteGroup, "versions", strings.Join(httpRouteVersions, ","))
+	case !allowed("httproutes", conf.Namespaces.AllowList, operator.ListWatchAttributes(httpRouteGroup, httpRouteResource)...):
+		// The informers would never sync without the list/watch permissions.
+		c.metrics.DisableFeature("httproutes")
+	default:
+		c.httpRouteInfs, err = informers.NewInformersForResource(
+			informers.NewDynamicInformerFactories(
+				c.nsSelector.AllowList(c.config.Namespaces.AllowList),
+				c.config.Namespaces.DenyList,
+				dclient,
+				resyncPeriod,
+				nil,
+			),
+			httpRouteGVR,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "error creating httproute informers")
+		}
+		level.Info(c.logger).Log("msg", "watching HTTPRoute resources", "groupVersion", httpRouteGVR.GroupVersion().String())
 	}
 
-	c.pmonInfs, err = informers.NewInformersForResource(
-		informers.NewMonitoringInformerFactories(
-			c.config.Namespaces.AllowList,
-			c.config.Namespaces.DenyList,
-			mclient,
-			resyncPeriod,
-			nil,
-		),
-		monitoringv1.SchemeGroupVersion.WithResource(monitoringv1.PodMonitorName),
-	)
+	proberSupported, err := k8sutil.IsAPIGroupVersionResourceSupported(c.kclient.Discovery(), monitoringv1alpha1.SchemeGroupVersion.String(), monitoringv1alpha1.ProberName)
 	if err != nil {
-		return nil, errors.Wrap(err, "error creating podmonitor informers")
+		return nil, errors.Wrap(err, "failed to check if the Prober resource is supported")
+	}
+	switch {
+	case !proberSupported:
+		level.Info(c.logger).Log("msg", "Prober resource not served by the API server, probes referencing a prober by name are rejected")
+	case !allowed("probers", conf.Namespaces.AllowList, operator.ListWatchAttributes(monitoring.GroupName, monitoringv1alpha1.ProberName)...):
+		// The informers would never sync without the list/watch permissions.
+		c.metrics.DisableFeature("probers")
+	default:
+		c.proberInfs, err = informers.NewInformersForResource(
+			informers.NewMonitoringInformerFactories(
+				c.nsSelector.AllowList(c.config.Namespaces.AllowList),
+				c.config.Namespaces.DenyList,
+				mclient,
+				resyncPeriod,
+				nil,
+			),
+			monitoringv1alpha1.SchemeGroupVersion.WithResource(monitoringv1alpha1.ProberName),
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "error creating prober informers")
+		}
 	}
 
-	c.probeInfs, err = informers.NewInformersForResource(
-		informers.NewMonitoringInformerFactories(
-			c.config.Namespaces.AllowList,
+	if ruleAllowed {
+		c.ruleInfs, err = informers.NewInformersForResource(
+			informers.NewMonitoringInformerFactories(
+				c.nsSelector.AllowList(c.config.Namespaces.AllowList),
+				c.config.Namespaces.DenyList,
+				mclient,
+				resyncPeriod,
+				nil,
+			),
+			monitoringv1.SchemeGroupVersion.WithResource(monitoringv1.PrometheusRuleName),
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "error creating prometheusrule informers")
+		}
+	}
+
+	// Only the metadata of configmaps and secrets is watched, the referenced
+	// objects are fetched on demand by the asset cache.
+	c.cmapInfs, err = informers.NewInformersForResource(
+		informers.NewMetadataInformerFactories(
+			c.nsSelector.AllowList(c.config.Namespaces.PrometheusAllowList),
 			c.config.Namespaces.DenyList,
-			mclient,
+			mdClient,
 			resyncPeriod,
-			nil,
+			func(options *metav1.ListOptions) {
+				options.LabelSelector = labelPrometheusName
+			},
 		),
-		monitoringv1.SchemeGroupVersion.WithResource(monitoringv1.ProbeName),
+		v1.SchemeGroupVersion.WithResource(string(v1.ResourceConfigMaps)),
 	)
 	if err != nil {
-		return nil, errors.Wrap(err, "error creating probe informers")
+		return nil, errors.Wrap(err, "error creating configmap informers")
 	}
 
-	c.ruleInfs, err = informers.NewInformersForResource(
-		informers.NewMonitoringInformerFactories(
-			c.config.Namespaces.AllowList,
+	c.secrInfs, err = informers.NewInformersForResource(
+		informers.NewMetadataInformerFactories(
+			c.nsSelector.AllowList(c.config.Namespaces.PrometheusAllowList),
 			c.config.Namespaces.DenyList,
-			mclient,
+			mdClient,
 			resyncPeriod,
-			nil,
+			func(options *metav1.ListOptions) {
+				options.FieldSelector = secretListWatchSelector.String()
+			},
 		),
-		monitoringv1.SchemeGroupVersion.WithResource(monitoringv1.PrometheusRuleName),
+		v1.SchemeGroupVersion.WithResource(string(v1.ResourceSecrets)),
 	)
 	if err != nil {
-		return nil, errors.Wrap(err, "error creating prometheusrule informers")
+		return nil, errors.Wrap(err, "error creating secrets informers")
 	}
 
-	c.cmapInfs, err = informers.NewInformersForResource(
-		informers.NewKubeInformerFactories(
-			c.config.Namespaces.PrometheusAllowList,
+	assetAllowList := mergeAllowLists(c.config.Namespaces.AllowList, c.config.Namespaces.PrometheusAllowList)
+	c.assetCmapInfs, err = informers.NewInformersForResource(
+		informers.NewMetadataInformerFactories(
+			assetAllowList,
 			c.config.Namespaces.DenyList,
-			c.kclient,
+			mdClient,
 			resyncPeriod,
-			func(options *metav1.ListOptions) {
-				options.LabelSelector = labelPrometheusName
-			},
+			nil,
 		),
 		v1.SchemeGroupVersion.WithResource(string(v1.ResourceConfigMaps)),
 	)
 	if err != nil {
-		return nil, errors.Wrap(err, "error creating configmap informers")
+		return nil, errors.Wrap(err, "error creating asset configmap informers")
 	}
 
-	c.secrInfs, err = informers.NewInformersForResource(
-		informers.NewKubeInformerFactories(
-			c.config.Namespaces.PrometheusAllowList,
+	c.assetSecrInfs, err = informers.NewInformersForResource(
+		informers.NewMetadataInformerFactories(
+			assetAllowList,
 			c.config.Namespaces.DenyList,
-			c.kclient,
+			mdClient,
 			resyncPeriod,
 			func(options *metav1.ListOptions) {
 				options.FieldSelector = secretListWatchSelector.String()
@@ -266,12 +563,15 @@ func New(ctx context.Context, conf operator.Config, logger log.Logger, r prometh
 		v1.SchemeGroupVersion.WithResource(string(v1.ResourceSecrets)),
 	)
 	if err != nil {
-		return nil, errors.Wrap(err, "error creating secrets informers")
+		return nil, errors.Wrap(err, "error creating asset secret informers")
 	}
 
+	c.assetCache = assets.NewCache(c.kclient.CoreV1(), c.kclient.CoreV1(), c.assetCmapInfs, c.assetSecrInfs)
+	c.metrics.MustRegister(c.assetCache)
+
 	c.ssetInfs, err = informers.NewInformersForResource(
 		informers.NewKubeInformerFactories(
-			c.config.Namespaces.PrometheusAllowList,
+			c.nsSelector.AllowList(c.config.Namespaces.PrometheusAllowList),
 			c.config.Namespaces.DenyList,
 			c.kclient,
 			resyncPeriod,
@@ -283,6 +583,24 @@ func New(ctx context.Context, conf operator.Config, logger log.Logger, r prometh
 		return nil, errors.Wrap(err, "error creating statefulset informers")
 	}
 
+	if agentAllowed {
+		c.dsetInfs, err = informers.NewInformersForResource(
+			informers.NewKubeInformerFactories(
+				c.nsSelector.AllowList(c.config.Namespaces.PrometheusAllowList),
+				c.config.Namespaces.DenyList,
+				c.kclient,
+				resyncPeriod,
+				func(options *metav1.ListOptions) {
+					options.LabelSelector = agentNameLabelName
+				},
+			),
+			appsv1.SchemeGroupVersion.WithResource("daemonsets"),
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "error creating daemonset informers")
+		}
+	}
+
 	newNamespaceInformer := func(o *Operator, allowList map[string]struct{}) cache.SharedIndexInformer {
 		// nsResyncPeriod is used to control how often the namespace informer
 		// should resync. If the unprivileged ListerWatcher is used, then the
@@ -311,6 +629,14 @@ func New(ctx context.Context, conf operator.Config, logger log.Logger, r prometh
 		c.nsPromInf = newNamespaceInformer(c, c.config.Namespaces.PrometheusAllowList)
 	}
 
+	// Informers for namespaces matching the namespace selector are created
+	// and removed at runtime.
+	c.nsSelector.Register(c.config.Namespaces.AllowList, c.smonInfs, c.pmonInfs, c.probeInfs, c.sconInfs, c.ruleInfs, c.amInfs, c.httpRouteInfs, c.proberInfs)
+	c.nsSelector.Register(c.config.Namespaces.PrometheusAllowList, c.promInfs, c.agentInfs, c.cmapInfs, c.secrInfs, c.ssetInfs, c.dsetInfs)
+	if c.nsSelector != nil {
+		c.nsMonInf.AddEventHandler(c.nsSelector)
+	}
+
 	return c, nil
 }
 
@@ -325,10 +651,24 @@ func (c *Operator) waitForCacheSync(ctx context.Context) error {
 		{"PodMonitor", c.pmonInfs},
 		{"PrometheusRule", c.ruleInfs},
 		{"Probe", c.probeInfs},
+		{"ScrapeConfig", c.sconInfs},
+		{"Alertmanager", c.amInfs},
 		{"ConfigMap", c.cmapInfs},
 		{"Secret", c.secrInfs},
+		{"AssetConfigMap", c.assetCmapInfs},
+		{"AssetSecret", c.assetSecrInfs},
 		{"StatefulSet", c.ssetInfs},
+		{"PrometheusAgent", c.agentInfs},
+		{"DaemonSet", c.dsetInfs},
+		{"HTTPRoute", c.httpRouteInfs},
+		{"Prober", c.proberInfs},
 	} {
+		// The informers of the optional resources are nil when the
+		// operator isn't allowed to watch them.
+		if infs.informersForResource == nil {
+			continue
+		}
+
 		for _, inf := range infs.informersForResource.GetInformers() {
 			if !operator.WaitForNamedCacheSync(ctx, "prometheus", log.With(c.logger, "informer", infs.name), inf.Informer()) {
 				return errors.Errorf("failed to sync cache for %s informer", infs.name)
@@ -360,43 +700,90 @@ func (c *Operator) addHandlers() {
 		UpdateFunc: c.handlePrometheusUpdate,
 	})
 
-	c.smonInfs.AddEventHandler(cache.ResourceEventHandlerFuncs{
-		AddFunc:    c.handleSmonAdd,
-		DeleteFunc: c.handleSmonDelete,
-		UpdateFunc: c.handleSmonUpdate,
-	})
+	if c.smonInfs != nil {
+		c.smonInfs.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    c.handleSmonAdd,
+			DeleteFunc: c.handleSmonDelete,
+			UpdateFunc: c.handleSmonUpdate,
+		})
+	}
 
-	c.pmonInfs.AddEventHandler(cache.ResourceEventHandlerFuncs{
-		AddFunc:    c.handlePmonAdd,
-		DeleteFunc: c.handlePmonDelete,
-		UpdateFunc: c.handlePmonUpdate,
-	})
-	c.probeInfs.AddEventHandler(cache.ResourceEventHandlerFuncs{
-		AddFunc:    c.handleBmonAdd,
-		UpdateFunc: c.handleBmonUpdate,
-		DeleteFunc: c.handleBmonDelete,
-	})
-	c.ruleInfs.AddEventHandler(cache.ResourceEventHandlerFuncs{
-		AddFunc:    c.handleRuleAdd,
-		DeleteFunc: c.handleRuleDelete,
-		UpdateFunc: c.handleRuleUpdate,
-	})
+	if c.pmonInfs != nil {
+		c.pmonInfs.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    c.handlePmonAdd,
+			DeleteFunc: c.handlePmonDelete,
+			UpdateFunc: c.handlePmonUpdate,
+		})
+	}
+	if c.probeInfs != nil {
+		c.probeInfs.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    c.handleBmonAdd,
+			UpdateFunc: c.handleBmonUpdate,
+			DeleteFunc: c.handleBmonDelete,
+		})
+	}
+	if c.sconInfs != nil {
+		c.sconInfs.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    c.handleScrapeConfigAdd,
+			UpdateFunc: c.handleScrapeConfigUpdate,
+			DeleteFunc: c.handleScrapeConfigDelete,
+		})
+	}
+	if c.amInfs != nil {
+		c.amInfs.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    c.handleAlertmanagerAdd,
+			UpdateFunc: c.handleAlertmanagerUpdate,
+			DeleteFunc: c.handleAlertmanagerDelete,
+		})
+	}
+	if c.httpRouteInfs != nil {
+		c.httpRouteInfs.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    c.handleHTTPRouteAdd,
+			UpdateFunc: c.handleHTTPRouteUpdate,
+			DeleteFunc: c.handleHTTPRouteDelete,
+		})
+	}
+	if c.proberInfs != nil {
+		c.proberInfs.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    c.handleProberAdd,
+			DeleteFunc: c.handleProberDelete,
+		})
+	}
+	if c.ruleInfs != nil {
+		c.ruleInfs.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    c.handleRuleAdd,
+			DeleteFunc: c.handleRuleDelete,
+			UpdateFunc: c.handleRuleUpdate,
+		})
+	}
 	c.cmapInfs.AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    c.handleConfigMapAdd,
 		DeleteFunc: c.handleConfigMapDelete,
 		UpdateFunc: c.handleConfigMapUpdate,
 	})
+	c.assetCmapInfs.AddEventHandler(c.assetCache.ConfigMapEventHandler())
 	c.secrInfs.AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    c.handleSecretAdd,
 		DeleteFunc: c.handleSecretDelete,
 		UpdateFunc: c.handleSecretUpdate,
 	})
+	c.assetSecrInfs.AddEventHandler(c.assetCache.SecretEventHandler())
 	c.ssetInfs.AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    c.handleStatefulSetAdd,
 		DeleteFunc: c.handleStatefulSetDelete,
 		UpdateFunc: c.handleStatefulSetUpdate,
 	})
 
+	if c.agentInfs != nil {
+		c.agentInfs.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    c.handleAgentAdd,
+			DeleteFunc: c.handleAgentDelete,
+			UpdateFunc: c.handleAgentUpdate,
+		})
+		c.ssetInfs.AddEventHandler(c.agentWorkloadHandlers("StatefulSet"))
+		c.dsetInfs.AddEventHandler(c.agentWorkloadHandlers("DaemonSet"))
+	}
+
 	// The controller needs to watch the namespaces in which the service/pod
 	// monitors and rules live because a label change on a namespace may
 	// trigger a configuration change.
@@ -410,6 +797,7 @@ func (c *Operator) addHandlers() {
 // Run the controller.
 func (c *Operator) Run(ctx context.Context) error {
 	defer c.queue.ShutDown()
+	defer c.agentQueue.ShutDown()
 
 	errChan := make(chan error)
 	go func() {
@@ -433,15 +821,32 @@ func (c *Operator) Run(ctx context.Context) error {
 	}
 
 	go c.worker(ctx)
-
-	go c.promInfs.Start(ctx.Done())
-	go c.smonInfs.Start(ctx.Done())
-	go c.pmonInfs.Start(ctx.Done())
-	go c.probeInfs.Start(ctx.Done())
-	go c.ruleInfs.Start(ctx.Done())
-	go c.cmapInfs.Start(ctx.Done())
-	go c.secrInfs.Start(ctx.Done())
-	go c.ssetInfs.Start(ctx.Done())
+	if c.agentInfs != nil {
+		go c.agentWorker(ctx)
+	}
+
+	for _, infs := range []*informers.ForResource{
+		c.promInfs,
+		c.smonInfs,
+		c.pmonInfs,
+		c.probeInfs,
+		c.sconInfs,
+		c.amInfs,
+		c.httpRouteInfs,
+		c.proberInfs,
+		c.ruleInfs,
+		c.cmapInfs,
+		c.secrInfs,
+		c.assetCmapInfs,
+		c.assetSecrInfs,
+		c.ssetInfs,
+		c.agentInfs,
+		c.dsetInfs,
+	} {
+		if infs != nil {
+			go infs.Start(ctx.Done())
+		}
+	}
 	go c.nsMonInf.Run(ctx.Done())
 	if c.nsPromInf != c.nsMonInf {
 		go c.nsPromInf.Run(ctx.Done())
@@ -456,10 +861,113 @@ func (c *Operator) Run(ctx context.Context) error {
 	}
 
 	c.metrics.Ready().Set(1)
+	if c.agentInfs != nil {
+		c.agentMetrics.Ready().Set(1)
+	}
 	<-ctx.Done()
 	return nil
 }
 
+// ApplyConfig updates the settings of the controller which can be changed at
+// runtime (instance selector, labels, config reloader, default base image and
+// kubelet sync) and enqueues all Prometheus objects for reconciliation. A
+// kubelet sync configuration requiring permissions which aren't granted to the
+// operator is rejected.
+func (c *Operator) ApplyConfig(conf operator.Config) error {
+	promSelector, err := labels.Parse(conf.PromSelector)
+	if err != nil {
+		return errors.Wrap(err, "can not parse prometheus selector value")
+	}
+
+	prev, _ := c.currentConfig()
+	kubeletSyncChanged := c.kubeletSyncEnabled && !reflect.DeepEqual(prev.KubeletSync, conf.KubeletSync)
+	if kubeletSyncChanged {
+		if missing := checkKubeletSyncPermissions(context.TODO(), c.logger, c.ssarClient, c.kubeletObjectNamespace, conf.KubeletSync); len(missing) > 0 {
+			return errors.Wrap(utilerrors.NewAggregate(missing), "kubelet sync configuration requires missing permissions")
+		}
+	}
+
+	c.configMtx.Lock()
+	c.config.PromSelector = conf.PromSelector
+	c.promSelector = promSelector
+	c.config.Labels = conf.Labels
+	c.config.ReloaderConfig = conf.ReloaderConfig
+	c.config.PrometheusDefaultBaseImage = conf.PrometheusDefaultBaseImage
+	c.config.KubeletSync = conf.KubeletSync
+	c.configMtx.Unlock()
+
+	if kubeletSyncChanged {
+		c.triggerNodeSync()
+	}
+
+	// Enqueue the keys of all the objects, the objects which don't match the
+	// new instance selector anymore are forgotten by the sync.
+	err = c.promInfs.ListAll(labels.Everything(), func(obj interface{}) {
+		if key, ok := c.keyFunc(obj); ok {
+			c.enqueue(key)
+		}
+	})
+	if err != nil {
+		return errors.Wrap(err, "listing all Prometheus instances from cache failed")
+	}
+
+	if c.agentInfs == nil {
+		return nil
+	}
+
+	err = c.agentInfs.ListAll(labels.Everything(), func(obj interface{}) {
+		if key, ok := c.keyFunc(obj); ok {
+			c.enqueueAgent(key)
+		}
+	})
+	if err != nil {
+		return errors.Wrap(err, "listing all PrometheusAgent instances from cache failed")
+	}
+
+	return nil
+}
+
+// mergeAllowLists returns the namespaces allowed by any of the given allow
+// lists.
+func mergeAllowLists(a, b map[string]struct{}) map[string]struct{} {
+	if listwatch.IsAllNamespaces(a) || listwatch.IsAllNamespaces(b) {
+		return map[string]struct{}{v1.NamespaceAll: {}}
+	}
+
+	merged := make(map[string]struct{}, len(a)+len(b))
+	for ns := range a {
+		merged[ns] = struct{}{}
+	}
+	for ns := range b {
+		merged[ns] = struct{}{}
+	}
+	return merged
+}
+
+// currentConfig returns a copy of the settings which can be updated at
+// runtime by ApplyConfig and the instance selector. The lock is only held
+// while copying so that a long sync doesn't block ApplyConfig.
+func (c *Operator) currentConfig() (operator.Config, labels.Selector) {
+	c.configMtx.RLock()
+	defer c.configMtx.RUnlock()
+
+	return c.config, c.promSelector
+}
+
+// isSelected returns true if the object matches the instance selector. The
+// informers watch all the objects in the namespaces so that the selector can
+// be updated at runtime, the objects managed by other operators are filtered
+// out here.
+func (c *Operator) isSelected(obj interface{}) bool {
+	o, ok := c.getObject(obj)
+	if !ok {
+		return false
+	}
+
+	_, promSelector := c.currentConfig()
+	return promSelector.Matches(labels.Set(o.GetLabels()))
+}
+
 func (c *Operator) keyFunc(obj interface{}) (string, bool) {
 	k, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
 	if err != nil {
@@ -470,6 +978,10 @@ func (c *Operator) keyFunc(obj interface{}) (string, bool) {
 }
 
 func (c *Operator) handlePrometheusAdd(obj interface{}) {
+	if !c.isSelected(obj) {
+		return
+	}
+
 	key, ok := c.keyFunc(obj)
 	if !ok {
 		return
@@ -482,6 +994,10 @@ func (c *Operator) handlePrometheusAdd(obj interface{}) {
 }
 
 func (c *Operator) handlePrometheusDelete(obj interface{}) {
+	if !c.isSelected(obj) {
+		return
+	}
+
 	key, ok := c.keyFunc(obj)
 	if !ok {
 		return
@@ -497,6 +1013,12 @@ func (c *Operator) handlePrometheusUpdate(old, cur interface{}) {
 		return
 	}
 
+	// An object which stops matching the instance selector is still enqueued
+	// to be forgotten by the sync.
+	if !c.isSelected(old) && !c.isSelected(cur) {
+		return
+	}
+
 	key, ok := c.keyFunc(cur)
 	if !ok {
 		return
@@ -518,10 +1040,21 @@ func (c *Operator) reconcileNodeEndpoints(ctx context.Context) {
 			return
 		case <-ticker.C:
 			c.syncNodeEndpointsWithLogError(ctx)
+		case <-c.nodeSyncCh:
+			c.syncNodeEndpointsWithLogError(ctx)
 		}
 	}
 }
 
+// triggerNodeSync requests a kubelet endpoints sync without waiting for the
+// next tick. It doesn't block when a sync is already pending.
+func (c *Operator) triggerNodeSync() {
+	select {
+	case c.nodeSyncCh <- struct{}{}:
+	default:
+	}
+}
+
 // nodeAddresses returns the provided node's address, based on the priority:
 // 1. NodeInternalIP
 // 2. NodeExternalIP
@@ -542,25 +1075,17 @@ func nodeAddress(node v1.Node) (string, map[v1.NodeAddressType][]string, error)
 	return "", m, fmt.Errorf("host address unknown")
 }
 
-func getNodeAddresses(nodes *v1.NodeList) ([]v1.EndpointAddress, []error) {
+func getNodeAddresses(nodes []v1.Node, addressType v1.NodeAddressType) ([]v1.EndpointAddress, []error) {
 	addresses := make([]v1.EndpointAddress, 0)
 	errs := make([]error, 0)
 
-	for _, n := range nodes.Items {
-		address, _, err := nodeAddress(n)
+	for _, n := range nodes {
+		address, err := nodeEndpointAddress(n, addressType)
 		if err != nil {
 			errs = append(errs, errors.Wrapf(err, "failed to determine hostname for node (%s)", n.Name))
 			continue
 		}
-		addresses = append(addresses, v1.EndpointAddress{
-			IP: address,
-			TargetRef: &v1.ObjectReference{
-				Kind:       "Node",
-				Name:       n.Name,
-				UID:        n.UID,
-				APIVersion: n.APIVersion,
-			},
-		})
+		addresses = append(addresses, address)
 	}
 
 	return addresses, errs
@@ -578,93 +1103,101 @@ func (c *Operator) syncNodeEndpointsWithLogError(ctx context.Context) {
 }
 
 func (c *Operator) syncNodeEndpoints(ctx context.Context) error {
+	config, _ := c.currentConfig()
+
 	logger := log.With(c.logger, "operation", "syncNodeEndpoints")
-	eps := &v1.Endpoints{
-		ObjectMeta: metav1.ObjectMeta{
-			Name: c.kubeletObjectName,
-			Labels: c.config.Labels.Merge(map[string]string{
-				"k8s-app":                      "kubelet",
-				"app.kubernetes.io/name":       "kubelet",
-				"app.kubernetes.io/managed-by": "prometheus-operator",
-			}),
-		},
-		Subsets: []v1.EndpointSubset{
-			{
-				Ports: []v1.EndpointPort{
-					{
-						Name: "https-metrics",
-						Port: 10250,
-					},
-					{
-						Name: "http-metrics",
-						Port: 10255,
-					},
-					{
-						Name: "cadvisor",
-						Port: 4194,
-					},
-				},
-			},
-		},
-	}
 
-	nodes, err := c.kclient.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	nodes, err := c.kclient.CoreV1().Nodes().List(ctx, metav1.ListOptions{LabelSelector: config.KubeletSync.NodeSelector})
 	if err != nil {
 		return errors.Wrap(err, "listing nodes failed")
 	}
 
 	level.Debug(logger).Log("msg", "Nodes retrieved from the Kubernetes API", "num_nodes", len(nodes.Items))
 
-	addresses, errs := getNodeAddresses(nodes)
-	if len(errs) > 0 {
-		for _, err := range errs {
-			level.Warn(logger).Log("err", err)
-		}
-		c.nodeAddressLookupErrors.Add(float64(len(errs)))
+	prevObjects, err := c.kubeletObjects(ctx)
+	if err != nil {
+		return errors.Wrap(err, "getting kubelet service object failed")
 	}
-	level.Debug(logger).Log("msg", "Nodes converted to endpoint addresses", "num_addresses", len(addresses))
 
-	eps.Subsets[0].Addresses = addresses
+	groups := groupKubeletNodes(c.kubeletObjectName, config.KubeletSync, nodes.Items)
 
-	svc := &v1.Service{
-		ObjectMeta: metav1.ObjectMeta{
-			Name: c.kubeletObjectName,
-			Labels: c.config.Labels.Merge(map[string]string{
-				"k8s-app":                      "kubelet",
-				"app.kubernetes.io/name":       "kubelet",
-				"app.kubernetes.io/managed-by": "prometheus-operator",
-			}),
-		},
-		Spec: v1.ServiceSpec{
-			Type:      v1.ServiceTypeClusterIP,
-			ClusterIP: "None",
-			Ports: []v1.ServicePort{
-				{
-					Name: "https-metrics",
-					Port: 10250,
-				},
-				{
-					Name: "http-metrics",
-					Port: 10255,
-				},
-				{
-					Name: "cadvisor",
-					Port: 4194,
-				},
-			},
-		},
+	// The main objects record the additional objects to clean them up once
+	// they aren't needed anymore.
+	objects := make([]string, 0, len(groups)-1)
+	for _, g := range groups[1:] {
+		objects = append(objects, g.name)
 	}
 
-	level.Debug(logger).Log("msg", "Updating Kubernetes service", "service", c.kubeletObjectName, "ns", c.kubeletObjectNamespace)
-	err = k8sutil.CreateOrUpdateService(ctx, c.kclient.CoreV1().Services(c.kubeletObjectNamespace), svc)
-	if err != nil {
-		return errors.Wrap(err, "synchronizing kubelet service object failed")
+	for _, g := range groups {
+		addresses, errs := getNodeAddresses(g.nodes, config.KubeletSync.AddressType)
+		if len(errs) > 0 {
+			for _, err := range errs {
+				level.Warn(logger).Log("err", err)
+			}
+			c.nodeAddressLookupErrors.Add(float64(len(errs)))
+		}
+		level.Debug(logger).Log("msg", "Nodes converted to endpoint addresses", "name", g.name, "num_addresses", len(addresses))
+
+		objLabels := map[string]string{
+			"k8s-app":                      "kubelet",
+			"app.kubernetes.io/name":       "kubelet",
+			"app.kubernetes.io/managed-by": "prometheus-operator",
+		}
+		for k, v := range g.labels {
+			objLabels[k] = v
+		}
+		objectMeta := metav1.ObjectMeta{
+			Name:   g.name,
+			Labels: config.Labels.Merge(objLabels),
+		}
+		if g.name == c.kubeletObjectName && (len(objects) > 0 || len(prevObjects) > 0) {
+			objectMeta.Annotations = map[string]string{
+				kubeletObjectsAnnotation: strings.Join(objects, ","),
+			}
+		}
+
+		eps := &v1.Endpoints{ObjectMeta: objectMeta}
+		svc := &v1.Service{
+			ObjectMeta: *objectMeta.DeepCopy(),
+			Spec: v1.ServiceSpec{
+				Type:      v1.ServiceTypeClusterIP,
+				ClusterIP: "None",
+			},
+		}
+		subset := v1.EndpointSubset{Addresses: addresses}
+		for _, p := range g.ports {
+			subset.Ports = append(subset.Ports, v1.EndpointPort{Name: p.Name, Port: p.Port})
+			svc.Spec.Ports = append(svc.Spec.Ports, v1.ServicePort{Name: p.Name, Port: p.Port})
+		}
+		// A subset requires at least one address.
+		if len(addresses) > 0 {
+			eps.Subsets = []v1.EndpointSubset{subset}
+		}
+
+		level.Debug(logger).Log("msg", "Updating Kubernetes service", "service", g.name, "ns", c.kubeletObjectNamespace)
+		err = k8sutil.CreateOrUpdateService(ctx, c.kclient.CoreV1().Services(c.kubeletObjectNamespace), svc)
+		if err != nil {
+			return errors.Wrap(err, "synchronizing kubelet service object failed")
+		}
+
+		level.Debug(logger).Log("msg", "Updating Kubernetes endpoint", "endpoint", g.name, "ns", c.kubeletObjectNamespace)
+		err = k8sutil.CreateOrUpdateEndpoints(ctx, c.kclient.CoreV1().Endpoints(c.kubeletObjectNamespace), eps)
+		if err != nil {
+			return errors.Wrap(err, "synchronizing kubelet endpoints object failed")
+		}
 	}
 
-	level.Debug(logger).Log("msg", "Updating Kubernetes endpoint", "endpoint", c.kubeletObjectName, "ns", c.kubeletObjectNamespace)
-	err = k8sutil.CreateOrUpdateEndpoints(ctx, c.kclient.CoreV1().Endpoints(c.kubeletObjectNamespace), eps)
-	if err != nil {
-		return errors.Wrap(err, "synchronizing kubelet endpoints object failed")
+	current := make(map[string]struct{}, len(groups))
+	for _, g := range groups {
+		current[g.name] = struct{}{}
+	}
+	for _, name := range prevObjects {
+		if _, found := current[name]; found {
+			continue
+		}
+		if err := c.deleteKubeletObject(ctx, logger, name); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -774,6 +1307,140 @@ func (c *Operator) handleBmonDelete(obj interface{}) {
 	}
 }
 
+// TODO: Don't enqueue just for the namespace
+func (c *Operator) handleScrapeConfigAdd(obj interface{}) {
+	if o, ok := c.getObject(obj); ok {
+		level.Debug(c.logger).Log("msg", "ScrapeConfig added")
+		c.metrics.TriggerByCounter(monitoringv1alpha1.ScrapeConfigsKind, "add").Inc()
+		c.enqueueForMonitorNamespace(o.GetNamespace())
+	}
+}
+
+// TODO: Don't enqueue just for the namespace
+func (c *Operator) handleScrapeConfigUpdate(old, cur interface{}) {
+	if old.(*monitoringv1alpha1.ScrapeConfig).ResourceVersion == cur.(*monitoringv1alpha1.ScrapeConfig).ResourceVersion {
+		return
+	}
+
+	if o, ok := c.getObject(cur); ok {
+		level.Debug(c.logger).Log("msg", "ScrapeConfig updated")
+		c.metrics.TriggerByCounter(monitoringv1alpha1.ScrapeConfigsKind, "update").Inc()
+		c.enqueueForMonitorNamespace(o.GetNamespace())
+	}
+}
+
+// TODO: Don't enqueue just for the namespace
+func (c *Operator) handleScrapeConfigDelete(obj interface{}) {
+	if o, ok := c.getObject(obj); ok {
+		level.Debug(c.logger).Log("msg", "ScrapeConfig delete")
+		c.metrics.TriggerByCounter(monitoringv1alpha1.ScrapeConfigsKind, "delete").Inc()
+		c.enqueueForMonitorNamespace(o.GetNamespace())
+	}
+}
+
+func (c *Operator) handleAlertmanagerAdd(obj interface{}) {
+	if o, ok := c.getObject(obj); ok {
+		level.Debug(c.logger).Log("msg", "Alertmanager added")
+		c.metrics.TriggerByCounter(monitoringv1.AlertmanagersKind, "add").Inc()
+		c.enqueueForMonitorNamespace(o.GetNamespace())
+	}
+}
+
+// The status of the Alertmanager resources changes frequently and doesn't
+// affect the configuration, only the labels and the spec are compared.
+func (c *Operator) handleAlertmanagerUpdate(old, cur interface{}) {
+	oldAm, curAm := old.(*monitoringv1.Alertmanager), cur.(*monitoringv1.Alertmanager)
+	if reflect.DeepEqual(oldAm.Labels, curAm.Labels) && reflect.DeepEqual(oldAm.Spec, curAm.Spec) {
+		return
+	}
+
+	if o, ok := c.getObject(cur); ok {
+		level.Debug(c.logger).Log("msg", "Alertmanager updated")
+		c.metrics.TriggerByCounter(monitoringv1.AlertmanagersKind, "update").Inc()
+		c.enqueueForMonitorNamespace(o.GetNamespace())
+	}
+}
+
+func (c *Operator) handleAlertmanagerDelete(obj interface{}) {
+	if o, ok := c.getObject(obj); ok {
+		level.Debug(c.logger).Log("msg", "Alertmanager delete")
+		c.metrics.TriggerByCounter(monitoringv1.AlertmanagersKind, "delete").Inc()
+		c.enqueueForMonitorNamespace(o.GetNamespace())
+	}
+}
+
+// Probes reference the Probers living in their namespace, only the creation
+// and the deletion of a Prober change the Probes being selected.
+func (c *Operator) handleProberAdd(obj interface{}) {
+	if o, ok := c.getObject(obj); ok {
+		level.Debug(c.logger).Log("msg", "Prober added")
+		c.metrics.TriggerByCounter(monitoringv1alpha1.ProberKind, "add").Inc()
+		c.enqueueForMonitorNamespace(o.GetNamespace())
+	}
+}
+
+func (c *Operator) handleProberDelete(obj interface{}) {
+	if o, ok := c.getObject(obj); ok {
+		level.Debug(c.logger).Log("msg", "Prober deleted")
+		c.metrics.TriggerByCounter(monitoringv1alpha1.ProberKind, "delete").Inc()
+		c.enqueueForMonitorNamespace(o.GetNamespace())
+	}
+}
+
+func (c *Operator) handleHTTPRouteAdd(obj interface{}) {
+	if _, ok := c.getObject(obj); ok {
+		level.Debug(c.logger).Log("msg", "HTTPRoute added")
+		c.enqueueForHTTPRoute("add")
+	}
+}
+
+func (c *Operator) handleHTTPRouteUpdate(old, cur interface{}) {
+	oldObj, ok := c.getObject(old)
+	if !ok {
+		return
+	}
+	curObj, ok := c.getObject(cur)
+	if !ok || oldObj.GetResourceVersion() == curObj.GetResourceVersion() {
+		return
+	}
+
+	level.Debug(c.logger).Log("msg", "HTTPRoute updated")
+	c.enqueueForHTTPRoute("update")
+}
+
+func (c *Operator) handleHTTPRouteDelete(obj interface{}) {
+	if _, ok := c.getObject(obj); ok {
+		level.Debug(c.logger).Log("msg", "HTTPRoute deleted")
+		c.enqueueForHTTPRoute("delete")
+	}
+}
+
+// enqueueForHTTPRoute enqueues all Prometheus and PrometheusAgent objects
+// since Probes in any namespace may select HTTPRoutes from other namespaces.
+func (c *Operator) enqueueForHTTPRoute(action string) {
+	c.metrics.TriggerByCounter("HTTPRoute", action).Inc()
+
+	err := c.promInfs.ListAll(labels.Everything(), func(obj interface{}) {
+		c.enqueue(obj)
+	})
+	if err != nil {
+		level.Error(c.logger).Log("msg", "listing all Prometheus instances from cache failed", "err", err)
+	}
+
+	if c.agentInfs == nil {
+		return
+	}
+
+	err = c.agentInfs.ListAll(labels.Everything(), func(obj interface{}) {
+		if key, ok := c.keyFunc(obj); ok {
+			c.enqueueAgent(key)
+		}
+	})
+	if err != nil {
+		level.Error(c.logger).Log("msg", "listing all PrometheusAgent instances from cache failed", "err", err)
+	}
+}
+
 // TODO: Don't enqueue just for the namespace
 func (c *Operator) handleRuleAdd(obj interface{}) {
 	o, ok := c.getObject(obj)
@@ -823,7 +1490,7 @@ func (c *Operator) handleSecretDelete(obj interface{}) {
 }
 
 func (c *Operator) handleSecretUpdate(old, cur interface{}) {
-	if old.(*v1.Secret).ResourceVersion == cur.(*v1.Secret).ResourceVersion {
+	if old.(*metav1.PartialObjectMetadata).ResourceVersion == cur.(*metav1.PartialObjectMetadata).ResourceVersion {
 		return
 	}
 
@@ -868,7 +1535,7 @@ func (c *Operator) handleConfigMapDelete(obj interface{}) {
 }
 
 func (c *Operator) handleConfigMapUpdate(old, cur interface{}) {
-	if old.(*v1.ConfigMap).ResourceVersion == cur.(*v1.ConfigMap).ResourceVersion {
+	if old.(*metav1.PartialObjectMetadata).ResourceVersion == cur.(*metav1.PartialObjectMetadata).ResourceVersion {
 		return
 	}
 
@@ -904,6 +1571,10 @@ func (c *Operator) enqueue(obj interface{}) {
 
 	key, ok := obj.(string)
 	if !ok {
+		if !c.isSelected(obj) {
+			return
+		}
+
 		key, ok = c.keyFunc(obj)
 		if !ok {
 			return
@@ -994,6 +1665,38 @@ func (c *Operator) enqueueForNamespace(store cache.Store, nsName string) {
 			return
 		}
 
+		// Check for Prometheus instances selecting ScrapeConfigs in the NS.
+		scNSSelector, err := metav1.LabelSelectorAsSelector(p.Spec.ScrapeConfigNamespaceSelector)
+		if err != nil {
+			level.Error(c.logger).Log(
+				"msg", fmt.Sprintf("failed to convert ScrapeConfigNamespaceSelector of %q to selector", p.Name),
+				"err", err,
+			)
+			return
+		}
+
+		if scNSSelector.Matches(labels.Set(ns.Labels)) {
+			c.enqueue(p)
+			return
+		}
+
+		// Check for Prometheus instances selecting Alertmanagers in the NS.
+		if p.Spec.Alerting != nil && p.Spec.Alerting.AlertmanagerSelector != nil {
+			amNSSelector, err := metav1.LabelSelectorAsSelector(p.Spec.Alerting.AlertmanagerNamespaceSelector)
+			if err != nil {
+				level.Error(c.logger).Log(
+					"msg", fmt.Sprintf("failed to convert AlertmanagerNamespaceSelector of %q to selector", p.Name),
+					"err", err,
+				)
+				return
+			}
+
+			if amNSSelector.Matches(labels.Set(ns.Labels)) {
+				c.enqueue(p)
+				return
+			}
+		}
+
 		// Check for Prometheus instances selecting PrometheusRules in
 		// the NS.
 		ruleNSSelector, err := metav1.LabelSelectorAsSelector(p.Spec.RuleNamespaceSelector)
@@ -1017,6 +1720,7 @@ func (c *Operator) enqueueForNamespace(store cache.Store, nsName string) {
 		)
 	}
 
+	c.enqueueAgentsForNamespace(ns)
 }
 
 // worker runs a worker thread that just dequeues items, processes them, and
@@ -1035,7 +1739,9 @@ func (c *Operator) processNextWorkItem(ctx context.Context) bool {
 	defer c.queue.Done(key)
 
 	c.metrics.ReconcileCounter().Inc()
+	startTime := time.Now()
 	err := c.sync(ctx, key.(string))
+	c.metrics.ReconcileDurationHistogram().Observe(time.Since(startTime).Seconds())
 	c.metrics.SetSyncStatus(key.(string), err == nil)
 	if err == nil {
 		c.queue.Forget(key)
@@ -1168,6 +1874,7 @@ func (c *Operator) handleMonitorNamespaceUpdate(oldo, curo interface{}) {
 			"PodMonitors":     p.Spec.PodMonitorNamespaceSelector,
 			"Probes":          p.Spec.ProbeNamespaceSelector,
 			"PrometheusRules": p.Spec.RuleNamespaceSelector,
+			"ScrapeConfigs":   p.Spec.ScrapeConfigNamespaceSelector,
 			"ServiceMonitors": p.Spec.ServiceMonitorNamespaceSelector,
 		} {
 
@@ -1194,6 +1901,8 @@ func (c *Operator) handleMonitorNamespaceUpdate(oldo, curo interface{}) {
 			"err", err,
 		)
 	}
+
+	c.enqueueAgentsForNamespaceUpdate(old, cur)
 }
 
 func (c *Operator) sync(ctx context.Context, key string) error {
@@ -1201,6 +1910,7 @@ func (c *Operator) sync(ctx context.Context, key string) error {
 
 	if apierrors.IsNotFound(err) {
 		c.metrics.ForgetObject(key)
+		c.configGenerator.forgetScrapeConfigs(monitoringv1.PrometheusesKind, key)
 		// Dependent resources are cleaned up by K8s via OwnerReferences
 		return nil
 	}
@@ -1208,7 +1918,18 @@ func (c *Operator) sync(ctx context.Context, key string) error {
 		return err
 	}
 
+	config, promSelector := c.currentConfig()
+
 	p := pobj.(*monitoringv1.Prometheus)
+	logger := log.With(c.logger, "key", key)
+
+	if !promSelector.Matches(labels.Set(p.Labels)) {
+		level.Debug(logger).Log("msg", "Prometheus not matching the instance selector, skipping")
+		c.metrics.ForgetObject(key)
+		c.configGenerator.forgetScrapeConfigs(monitoringv1.PrometheusesKind, key)
+		return nil
+	}
+
 	p = p.DeepCopy()
 	p.APIVersion = monitoringv1.SchemeGroupVersion.String()
 	p.Kind = monitoringv1.PrometheusesKind
@@ -1217,30 +1938,40 @@ func (c *Operator) sync(ctx context.Context, key string) error {
 		return nil
 	}
 
-	logger := log.With(c.logger, "key", key)
 	level.Info(logger).Log("msg", "sync prometheus")
-	ruleConfigMapNames, err := c.createOrUpdateRuleConfigMaps(ctx, p)
+	shardRuleConfigMapNames, err := c.createOrUpdateRuleConfigMaps(ctx, p)
 	if err != nil {
 		return err
 	}
 
-	assetStore := assets.NewStore(c.kclient.CoreV1(), c.kclient.CoreV1())
+	// The configuration is shared by all the shards, each shard only mounts
+	// its own rule ConfigMaps.
+	var ruleConfigMapNames []string
+	for _, names := range shardRuleConfigMapNames {
+		ruleConfigMapNames = append(ruleConfigMapNames, names...)
+	}
+
+	assetStore := c.assetCache.NewStore()
 
-	if err := c.createOrUpdateConfigurationSecret(ctx, p, ruleConfigMapNames, assetStore); err != nil {
+	if err := c.createOrUpdateConfigurationSecret(ctx, p, config, ruleConfigMapNames, assetStore); err != nil {
 		return errors.Wrap(err, "creating config failed")
 	}
 
-	if err := c.createOrUpdateTLSAssetSecret(ctx, p, assetStore); err != nil {
+	if err := c.createOrUpdateTLSAssetSecret(ctx, p, config, assetStore); err != nil {
 		return errors.Wrap(err, "creating tls asset secret failed")
 	}
 
-	if err := c.createOrUpdateWebConfigSecret(ctx, p); err != nil {
+	if err := c.createOrUpdateCredentialsSecret(ctx, p, config, assetStore); err != nil {
+		return errors.Wrap(err, "creating credentials secret failed")
+	}
+
+	if err := c.createOrUpdateWebConfigSecret(ctx, p, config, assetStore); err != nil {
 		return errors.Wrap(err, "synchronizing web config secret failed")
 	}
 
 	// Create governing service if it doesn't exist.
 	svcClient := c.kclient.CoreV1().Services(p.Namespace)
-	if err := k8sutil.CreateOrUpdateService(ctx, svcClient, makeStatefulSetService(p, c.config)); err != nil {
+	if err := k8sutil.CreateOrUpdateService(ctx, svcClient, makeStatefulSetService(p, config)); err != nil {
 		return errors.Wrap(err, "synchronizing governing service failed")
 	}
 
@@ -1263,12 +1994,13 @@ func (c *Operator) sync(ctx context.Context, key string) error {
 			ss := obj.(*appsv1.StatefulSet)
 			spec = ss.Spec
 		}
-		newSSetInputHash, err := createSSetInputHash(*p, c.config, ruleConfigMapNames, spec)
+		shardConfigMapNames := ruleConfigMapNamesForShard(shardRuleConfigMapNames, int32(shard))
+		newSSetInputHash, err := createSSetInputHash(*p, config, shardConfigMapNames, spec)
 		if err != nil {
 			return err
 		}
 
-		sset, err := makeStatefulSet(ssetName, *p, &c.config, ruleConfigMapNames, newSSetInputHash, int32(shard))
+		sset, err := makeStatefulSet(ssetName, *p, &config, shardConfigMapNames, newSSetInputHash, int32(shard))
 		if err != nil {
 			return errors.Wrap(err, "making statefulset failed")
 		}
@@ -1486,30 +2218,18 @@ func gzipConfig(buf *bytes.Buffer, conf []byte) error {
 	return nil
 }
 
-func (c *Operator) createOrUpdateConfigurationSecret(ctx context.Context, p *monitoringv1.Prometheus, ruleConfigMapNames []string, store *assets.Store) error {
+func (c *Operator) createOrUpdateConfigurationSecret(ctx context.Context, p *monitoringv1.Prometheus, config operator.Config, ruleConfigMapNames []string, store *assets.Store) error {
 	// If no service or pod monitor selectors are configured, the user wants to
 	// manage configuration themselves. Do create an empty Secret if it doesn't
 	// exist.
 	if p.Spec.ServiceMonitorSelector == nil && p.Spec.PodMonitorSelector == nil &&
-		p.Spec.ProbeSelector == nil {
-		level.Debug(c.logger).Log("msg", "neither ServiceMonitor nor PodMonitor, nor Probe selector specified, leaving configuration unmanaged", "prometheus", p.Name, "namespace", p.Namespace)
-
-		s, err := makeEmptyConfigurationSecret(p, c.config)
-		if err != nil {
-			return errors.Wrap(err, "generating empty config secret failed")
-		}
-		sClient := c.kclient.CoreV1().Secrets(p.Namespace)
-		_, err = sClient.Get(ctx, s.Name, metav1.GetOptions{})
-		if apierrors.IsNotFound(err) {
-			if _, err := c.kclient.CoreV1().Secrets(p.Namespace).Create(ctx, s, metav1.CreateOptions{}); err != nil && !apierrors.IsAlreadyExists(err) {
-				return errors.Wrap(err, "creating empty config file failed")
-			}
-		}
-		if !apierrors.IsNotFound(err) && err != nil {
-			return err
-		}
+		p.Spec.ProbeSelector == nil && p.Spec.ScrapeConfigSelector == nil {
+		level.Debug(c.logger).Log("msg", "neither ServiceMonitor nor PodMonitor, nor Probe, nor ScrapeConfig selector specified, leaving configuration unmanaged", "prometheus", p.Name, "namespace", p.Namespace)
+		return c.createEmptyConfigurationSecret(ctx, p, config)
+	}
 
-		return nil
+	if err := addScrapeClassAssets(ctx, p, store); err != nil {
+		return errors.Wrap(err, "invalid scrape classes")
 	}
 
 	smons, err := c.selectServiceMonitors(ctx, p, store)
@@ -1522,11 +2242,22 @@ func (c *Operator) createOrUpdateConfigurationSecret(ctx context.Context, p *mon
 		return errors.Wrap(err, "selecting PodMonitors failed")
 	}
 
-	bmons, err := c.selectProbes(ctx, p, store)
+	httpRouteTargets := map[string][]targetGroup{}
+	bmons, err := c.selectProbes(ctx, p, store, httpRouteTargets)
 	if err != nil {
 		return errors.Wrap(err, "selecting Probes failed")
 	}
 
+	scrapeConfigs, err := c.selectScrapeConfigs(ctx, p, store)
+	if err != nil {
+		return errors.Wrap(err, "selecting ScrapeConfigs failed")
+	}
+
+	alertmanagers, err := c.selectAlertmanagers(p)
+	if err != nil {
+		return errors.Wrap(err, "selecting Alertmanagers failed")
+	}
+
 	sClient := c.kclient.CoreV1().Secrets(p.Namespace)
 	SecretsInPromNS, err := sClient.List(ctx, metav1.ListOptions{})
 	if err != nil {
@@ -1548,28 +2279,8 @@ func (c *Operator) createOrUpdateConfigurationSecret(ctx context.Context, p *mon
 		}
 	}
 
-	for i, remote := range p.Spec.RemoteWrite {
-		if err := store.AddBasicAuth(ctx, p.GetNamespace(), remote.BasicAuth, fmt.Sprintf("remoteWrite/%d", i)); err != nil {
-			return errors.Wrapf(err, "remote write %d", i)
-		}
-		if err := store.AddOAuth2(ctx, p.GetNamespace(), remote.OAuth2, fmt.Sprintf("remoteWrite/%d", i)); err != nil {
-			return errors.Wrapf(err, "remote write %d", i)
-		}
-		if err := store.AddTLSConfig(ctx, p.GetNamespace(), remote.TLSConfig); err != nil {
-			return errors.Wrapf(err, "remote write %d", i)
-		}
-		if err := store.AddAuthorizationCredentials(ctx, p.GetNamespace(), remote.Authorization, fmt.Sprintf("remoteWrite/auth/%d", i)); err != nil {
-			return errors.Wrapf(err, "remote write %d", i)
-		}
-	}
-
-	if p.Spec.APIServerConfig != nil {
-		if err := store.AddBasicAuth(ctx, p.GetNamespace(), p.Spec.APIServerConfig.BasicAuth, "apiserver"); err != nil {
-			return errors.Wrap(err, "apiserver config")
-		}
-		if err := store.AddAuthorizationCredentials(ctx, p.GetNamespace(), p.Spec.APIServerConfig.Authorization, "apiserver/auth"); err != nil {
-			return errors.Wrapf(err, "apiserver config")
-		}
+	if err := addRemoteWriteAndAPIServerAssets(ctx, p, store); err != nil {
+		return err
 	}
 	if p.Spec.Alerting != nil {
 		for i, am := range p.Spec.Alerting.Alertmanagers {
@@ -1577,6 +2288,9 @@ func (c *Operator) createOrUpdateConfigurationSecret(ctx context.Context, p *mon
 				return errors.Wrapf(err, "apiserver config")
 			}
 		}
+		if err := store.AddTLSConfig(ctx, p.GetNamespace(), p.Spec.Alerting.AlertmanagerTLSConfig); err != nil {
+			return errors.Wrap(err, "alertmanager TLS config")
+		}
 	}
 
 	additionalScrapeConfigs, err := c.loadAdditionalScrapeConfigsSecret(p.Spec.AdditionalScrapeConfigs, SecretsInPromNS)
@@ -1593,11 +2307,12 @@ func (c *Operator) createOrUpdateConfigurationSecret(ctx context.Context, p *mon
 	}
 
 	// Update secret based on the most recent configuration.
-	conf, err := c.configGenerator.GenerateConfig(
+	conf, err := c.configGenerator.withAlertmanagers(alertmanagers).withHTTPRouteTargets(httpRouteTargets).GenerateConfig(
 		p,
 		smons,
 		pmons,
 		bmons,
+		scrapeConfigs,
 		store,
 		additionalScrapeConfigs,
 		additionalAlertRelabelConfigs,
@@ -1608,30 +2323,156 @@ func (c *Operator) createOrUpdateConfigurationSecret(ctx context.Context, p *mon
 		return errors.Wrap(err, "generating config failed")
 	}
 
-	s := makeConfigSecret(p, c.config)
+	return c.updateConfigurationSecret(ctx, p, config, conf, countScrapeJobs(smons, pmons, bmons, scrapeConfigs, additionalScrapeConfigs))
+}
+
+// createEmptyConfigurationSecret creates the configuration secret with an
+// empty configuration if it doesn't exist.
+func (c *Operator) createEmptyConfigurationSecret(ctx context.Context, p *monitoringv1.Prometheus, config operator.Config) error {
+	s, err := makeEmptyConfigurationSecret(p, config)
+	if err != nil {
+		return errors.Wrap(err, "generating empty config secret failed")
+	}
+	sClient := c.kclient.CoreV1().Secrets(p.Namespace)
+	_, err = sClient.Get(ctx, s.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		if _, err := sClient.Create(ctx, s, metav1.CreateOptions{}); err != nil && !apierrors.IsAlreadyExists(err) {
+			return errors.Wrap(err, "creating empty config file failed")
+		}
+	}
+	if !apierrors.IsNotFound(err) && err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// updateConfigurationSecret stores the compressed configuration into the
+// configuration secret.
+func (c *Operator) updateConfigurationSecret(ctx context.Context, p *monitoringv1.Prometheus, config operator.Config, conf []byte, scrapeJobs int) error {
+	s := makeConfigSecret(p, config)
 	s.ObjectMeta.Annotations = map[string]string{
 		"generated": "true",
 	}
 
 	// Compress config to avoid 1mb secret limit for a while
 	var buf bytes.Buffer
-	if err = gzipConfig(&buf, conf); err != nil {
+	if err := gzipConfig(&buf, conf); err != nil {
 		return errors.Wrap(err, "couldn't gzip config")
 	}
 	s.Data[configFilename] = buf.Bytes()
 
+	if pKey, ok := c.keyFunc(p); ok {
+		c.metricsFor(p).SetConfigSize(pKey, len(conf), buf.Len())
+		c.metricsFor(p).SetScrapeJobs(pKey, scrapeJobs)
+	}
+
 	level.Debug(c.logger).Log("msg", "updating Prometheus configuration secret")
-	return k8sutil.CreateOrUpdateSecret(ctx, sClient, s)
+	return k8sutil.CreateOrUpdateSecret(ctx, c.kclient.CoreV1().Secrets(p.Namespace), s)
+}
+
+// addRemoteWriteAndAPIServerAssets adds the credentials of the remote write
+// endpoints and of the API server to the store.
+func addRemoteWriteAndAPIServerAssets(ctx context.Context, p *monitoringv1.Prometheus, store *assets.Store) error {
+	for i, remote := range p.Spec.RemoteWrite {
+		if err := validateRemoteWriteSpec(remote); err != nil {
+			return errors.Wrapf(err, "remote write %d", i)
+		}
+		if err := store.AddBasicAuth(ctx, p.GetNamespace(), remote.BasicAuth, fmt.Sprintf("remoteWrite/%d", i)); err != nil {
+			return errors.Wrapf(err, "remote write %d", i)
+		}
+		if err := store.AddOAuth2(ctx, p.GetNamespace(), remote.OAuth2, fmt.Sprintf("remoteWrite/%d", i)); err != nil {
+			return errors.Wrapf(err, "remote write %d", i)
+		}
+		if err := store.AddTLSConfig(ctx, p.GetNamespace(), remote.TLSConfig); err != nil {
+			return errors.Wrapf(err, "remote write %d", i)
+		}
+		if err := store.AddAuthorizationCredentials(ctx, p.GetNamespace(), remote.Authorization, fmt.Sprintf("remoteWrite/auth/%d", i)); err != nil {
+			return errors.Wrapf(err, "remote write %d", i)
+		}
+		if err := store.AddSigV4(ctx, p.GetNamespace(), remote.Sigv4, fmt.Sprintf("remoteWrite/%d", i)); err != nil {
+			return errors.Wrapf(err, "remote write %d", i)
+		}
+	}
+
+	if p.Spec.APIServerConfig != nil {
+		if err := store.AddBasicAuth(ctx, p.GetNamespace(), p.Spec.APIServerConfig.BasicAuth, "apiserver"); err != nil {
+			return errors.Wrap(err, "apiserver config")
+		}
+		if err := store.AddAuthorizationCredentials(ctx, p.GetNamespace(), p.Spec.APIServerConfig.Authorization, "apiserver/auth"); err != nil {
+			return errors.Wrapf(err, "apiserver config")
+		}
+	}
+
+	return nil
 }
 
-func (c *Operator) createOrUpdateTLSAssetSecret(ctx context.Context, p *monitoringv1.Prometheus, store *assets.Store) error {
+// validateRemoteWriteSpec checks that at most one authentication method is
+// defined for the remote write endpoint.
+func validateRemoteWriteSpec(spec monitoringv1.RemoteWriteSpec) error {
+	var authMethods []string
+	for _, m := range []struct {
+		name string
+		set  bool
+	}{
+		{"basicAuth", spec.BasicAuth != nil},
+		{"oauth2", spec.OAuth2 != nil},
+		{"bearerToken", spec.BearerToken != "" || spec.BearerTokenFile != ""},
+		{"authorization", spec.Authorization != nil},
+		{"sigv4", spec.Sigv4 != nil},
+		{"azureAd", spec.AzureAD != nil},
+	} {
+		if m.set {
+			authMethods = append(authMethods, m.name)
+		}
+	}
+
+	if len(authMethods) > 1 {
+		return errors.Errorf("%s can't be set at the same time, at most one of them must be defined", strings.Join(authMethods, " and "))
+	}
+
+	if spec.AzureAD != nil && spec.AzureAD.ManagedIdentity.ClientID == "" {
+		return errors.New("azureAd.managedIdentity.clientId must be defined")
+	}
+
+	return nil
+}
+
+// countScrapeJobs returns the number of scrape jobs generated from the given
+// ServiceMonitors, PodMonitors, Probes and ScrapeConfigs plus the number of
+// additional scrape configs.
+func countScrapeJobs(smons map[string]*monitoringv1.ServiceMonitor, pmons map[string]*monitoringv1.PodMonitor, bmons map[string]*monitoringv1.Probe, scrapeConfigs map[string]*monitoringv1alpha1.ScrapeConfig, additionalScrapeConfigs []byte) int {
+	n := len(bmons) + len(scrapeConfigs)
+
+	// The additional scrape configs have already been validated by the
+	// configuration generator.
+	var additional []yaml.MapSlice
+	if err := yaml.Unmarshal(additionalScrapeConfigs, &additional); err == nil {
+		n += len(additional)
+	}
+
+	for _, sm := range smons {
+		n += len(sm.Spec.Endpoints)
+	}
+	for _, pm := range pmons {
+		n += len(pm.Spec.PodMetricsEndpoints)
+	}
+	return n
+}
+
+func (c *Operator) createOrUpdateTLSAssetSecret(ctx context.Context, p *monitoringv1.Prometheus, config operator.Config, store *assets.Store) error {
 	boolTrue := true
 	sClient := c.kclient.CoreV1().Secrets(p.Namespace)
 
+	name := tlsAssetsSecretName(p.Name)
+	if isAgent(p) {
+		name = agentTLSAssetsSecretName(p.Name)
+	}
+
 	tlsAssetsSecret := &v1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:   tlsAssetsSecretName(p.Name),
-			Labels: c.config.Labels.Merge(managedByOperatorLabels),
+			Name:   name,
+			Labels: config.Labels.Merge(managedByOperatorLabels),
 			OwnerReferences: []metav1.OwnerReference{
 				{
 					APIVersion:         p.APIVersion,
@@ -1658,19 +2499,61 @@ func (c *Operator) createOrUpdateTLSAssetSecret(ctx context.Context, p *monitori
 	return nil
 }
 
-func (c *Operator) createOrUpdateWebConfigSecret(ctx context.Context, p *monitoringv1.Prometheus) error {
+// createOrUpdateCredentialsSecret creates the secret holding the credentials
+// referenced by the generated configuration when they are mounted as files.
+func (c *Operator) createOrUpdateCredentialsSecret(ctx context.Context, p *monitoringv1.Prometheus, config operator.Config, store *assets.Store) error {
+	if !p.Spec.CredentialsAsFiles {
+		return nil
+	}
+
+	boolTrue := true
+	sClient := c.kclient.CoreV1().Secrets(p.Namespace)
+
+	name := credentialsSecretName(p.Name)
+	if isAgent(p) {
+		name = agentCredentialsSecretName(p.Name)
+	}
+
+	credentialsSecret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: config.Labels.Merge(managedByOperatorLabels),
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion:         p.APIVersion,
+					BlockOwnerDeletion: &boolTrue,
+					Controller:         &boolTrue,
+					Kind:               p.Kind,
+					Name:               p.Name,
+					UID:                p.UID,
+				},
+			},
+		},
+		Data: makeCredentialsSecretData(store),
+	}
+
+	if err := k8sutil.CreateOrUpdateSecret(ctx, sClient, credentialsSecret); err != nil {
+		return errors.Wrap(err, "failed to create credentials secret for Prometheus")
+	}
+
+	return nil
+}
+
+func (c *Operator) createOrUpdateWebConfigSecret(ctx context.Context, p *monitoringv1.Prometheus, config operator.Config, store *assets.Store) error {
 	boolTrue := true
 	client := c.kclient.CoreV1().Secrets(p.Namespace)
 
-	var tlsConfig *monitoringv1.WebTLSConfig
-	if p.Spec.Web != nil {
-		tlsConfig = p.Spec.Web.TLSConfig
+	fields := p.Spec.Web.ConfigFileFields()
+
+	name := WebConfigSecretName(p.Name)
+	if isAgent(p) {
+		name = agentWebConfigSecretName(p.Name)
 	}
 
 	webConfig, err := webconfig.New(
 		webConfigDir,
-		WebConfigSecretName(p.Name),
-		tlsConfig,
+		name,
+		fields,
 	)
 	if err != nil {
 		return err
@@ -1685,18 +2568,13 @@ func (c *Operator) createOrUpdateWebConfigSecret(ctx context.Context, p *monitor
 		UID:                p.UID,
 	}
 
-	secretLabels := c.config.Labels.Merge(managedByOperatorLabels)
-	secret, err := webConfig.MakeConfigFileSecret(secretLabels, ownerReference)
-	if err != nil {
-		return err
-	}
-
-	err = k8sutil.CreateOrUpdateSecret(ctx, client, secret)
+	secretLabels := config.Labels.Merge(managedByOperatorLabels)
+	err = webConfig.CreateOrUpdateSecret(ctx, client, store, p.Namespace, secretLabels, ownerReference)
 	if err != nil {
 		return errors.Wrap(err, "failed to create web config for Prometheus")
 	}
 
-	return err
+	return nil
 }
 
 func (c *Operator) selectServiceMonitors(ctx context.Context, p *monitoringv1.Prometheus, store *assets.Store) (map[string]*monitoringv1.ServiceMonitor, error) {
@@ -1704,6 +2582,12 @@ func (c *Operator) selectServiceMonitors(ctx context.Context, p *monitoringv1.Pr
 	// Selectors (<namespace>/<name>) might overlap. Deduplicate them along the keyFunc.
 	serviceMonitors := make(map[string]*monitoringv1.ServiceMonitor)
 
+	// The feature is disabled when the operator isn't allowed to watch the
+	// resources.
+	if c.smonInfs == nil {
+		return serviceMonitors, nil
+	}
+
 	servMonSelector, err := metav1.LabelSelectorAsSelector(p.Spec.ServiceMonitorSelector)
 	if err != nil {
 		return nil, err
@@ -1741,13 +2625,27 @@ func (c *Operator) selectServiceMonitors(ctx context.Context, p *monitoringv1.Pr
 	var rejected int
 	res := make(map[string]*monitoringv1.ServiceMonitor, len(serviceMonitors))
 	for namespaceAndName, sm := range serviceMonitors {
+		if _, err := getScrapeClass(p, sm.Spec.ScrapeClassName); err != nil {
+			rejected++
+			level.Warn(c.logger).Log(
+				"msg", "skipping servicemonitor",
+				"error", err.Error(),
+				"servicemonitor", namespaceAndName,
+				"namespace", p.Namespace,
+				"prometheus", p.Name,
+			)
+			continue
+		}
+
 		var err error
+		fsAccessDenied := fileSystemAccessDenied(p, sm.GetNamespace())
 
 		for i, endpoint := range sm.Spec.Endpoints {
 			// If denied by Prometheus spec, filter out all service monitors that access
 			// the file system.
-			if p.Spec.ArbitraryFSAccessThroughSMs.Deny {
+			if fsAccessDenied {
 				if err = testForArbitraryFSAccess(endpoint); err != nil {
+					err = fileSystemAccessError(p, sm.GetNamespace(), errors.Wrapf(err, "endpoints[%d]", i))
 					break
 				}
 			}
@@ -1800,8 +2698,8 @@ func (c *Operator) selectServiceMonitors(ctx context.Context, p *monitoringv1.Pr
 	level.Debug(c.logger).Log("msg", "selected ServiceMonitors", "servicemonitors", strings.Join(smKeys, ","), "namespace", p.Namespace, "prometheus", p.Name)
 
 	if pKey, ok := c.keyFunc(p); ok {
-		c.metrics.SetSelectedResources(pKey, monitoringv1.ServiceMonitorsKind, len(res))
-		c.metrics.SetRejectedResources(pKey, monitoringv1.ServiceMonitorsKind, rejected)
+		c.metricsFor(p).SetSelectedResources(pKey, monitoringv1.ServiceMonitorsKind, len(res))
+		c.metricsFor(p).SetRejectedResources(pKey, monitoringv1.ServiceMonitorsKind, rejected)
 	}
 
 	return res, nil
@@ -1812,6 +2710,12 @@ func (c *Operator) selectPodMonitors(ctx context.Context, p *monitoringv1.Promet
 	// Selectors (<namespace>/<name>) might overlap. Deduplicate them along the keyFunc.
 	podMonitors := make(map[string]*monitoringv1.PodMonitor)
 
+	// The feature is disabled when the operator isn't allowed to watch the
+	// resources.
+	if c.pmonInfs == nil {
+		return podMonitors, nil
+	}
+
 	podMonSelector, err := metav1.LabelSelectorAsSelector(p.Spec.PodMonitorSelector)
 	if err != nil {
 		return nil, err
@@ -1849,6 +2753,18 @@ func (c *Operator) selectPodMonitors(ctx context.Context, p *monitoringv1.Promet
 	var rejected int
 	res := make(map[string]*monitoringv1.PodMonitor, len(podMonitors))
 	for namespaceAndName, pm := range podMonitors {
+		if _, err := getScrapeClass(p, pm.Spec.ScrapeClassName); err != nil {
+			rejected++
+			level.Warn(c.logger).Log(
+				"msg", "skipping podmonitor",
+				"error", err.Error(),
+				"podmonitor", namespaceAndName,
+				"namespace", p.Namespace,
+				"prometheus", p.Name,
+			)
+			continue
+		}
+
 		var err error
 
 		for i, endpoint := range pm.Spec.PodMetricsEndpoints {
@@ -1900,18 +2816,27 @@ func (c *Operator) selectPodMonitors(ctx context.Context, p *monitoringv1.Promet
 	level.Debug(c.logger).Log("msg", "selected PodMonitors", "podmonitors", strings.Join(pmKeys, ","), "namespace", p.Namespace, "prometheus", p.Name)
 
 	if pKey, ok := c.keyFunc(p); ok {
-		c.metrics.SetSelectedResources(pKey, monitoringv1.PodMonitorsKind, len(res))
-		c.metrics.SetRejectedResources(pKey, monitoringv1.PodMonitorsKind, rejected)
+		c.metricsFor(p).SetSelectedResources(pKey, monitoringv1.PodMonitorsKind, len(res))
+		c.metricsFor(p).SetRejectedResources(pKey, monitoringv1.PodMonitorsKind, rejected)
 	}
 
 	return res, nil
 }
 
-func (c *Operator) selectProbes(ctx context.Context, p *monitoringv1.Prometheus, store *assets.Store) (map[string]*monitoringv1.Probe, error) {
+// selectProbes returns the Probe objects selected by the Prometheus object.
+// The targets resolved from the HTTPRoutes selected by the probes are added
+// to httpRouteTargets.
+func (c *Operator) selectProbes(ctx context.Context, p *monitoringv1.Prometheus, store *assets.Store, httpRouteTargets map[string][]targetGroup) (map[string]*monitoringv1.Probe, error) {
 	namespaces := []string{}
 	// Selectors might overlap. Deduplicate them along the keyFunc.
 	probes := make(map[string]*monitoringv1.Probe)
 
+	// The feature is disabled when the operator isn't allowed to watch the
+	// resources.
+	if c.probeInfs == nil {
+		return probes, nil
+	}
+
 	bMonSelector, err := metav1.LabelSelectorAsSelector(p.Spec.ProbeSelector)
 	if err != nil {
 		return nil, err
@@ -1948,18 +2873,72 @@ func (c *Operator) selectProbes(ctx context.Context, p *monitoringv1.Prometheus,
 	var rejected int
 	res := make(map[string]*monitoringv1.Probe, len(probes))
 	for probeName, probe := range probes {
-		if probe.Spec.Targets.StaticConfig == nil && probe.Spec.Targets.Ingress == nil {
+		if (probe.Spec.ProberSpec.URL == "") == (probe.Spec.ProberSpec.Name == "") {
 			rejected++
 			level.Warn(c.logger).Log(
 				"msg", "skipping probe",
-				"error", "Probe needs at least one target of type staticConfig or ingress",
+				"error", "Probe needs exactly one of prober.url or prober.name",
 				"probe", probeName,
 				"namespace", p.Namespace,
 				"prometheus", p.Name,
 			)
 			continue
 		}
+
+		if err := c.checkProber(probe); err != nil {
+			rejected++
+			level.Warn(c.logger).Log(
+				"msg", "skipping probe",
+				"error", err.Error(),
+				"probe", probeName,
+				"namespace", p.Namespace,
+				"prometheus", p.Name,
+			)
+			continue
+		}
+
+		targets := probe.Spec.Targets
+		if targets.StaticConfig == nil && targets.Ingress == nil && targets.Service == nil && targets.HTTPRoute == nil {
+			rejected++
+			level.Warn(c.logger).Log(
+				"msg", "skipping probe",
+				"error", "Probe needs at least one target of type staticConfig, ingress, service or httpRoute",
+				"probe", probeName,
+				"namespace", p.Namespace,
+				"prometheus", p.Name,
+			)
+			continue
+		}
+		if _, err := getScrapeClass(p, probe.Spec.ScrapeClassName); err != nil {
+			rejected++
+			level.Warn(c.logger).Log(
+				"msg", "skipping probe",
+				"error", err.Error(),
+				"probe", probeName,
+				"namespace", p.Namespace,
+				"prometheus", p.Name,
+			)
+			continue
+		}
+
 		pnKey := fmt.Sprintf("probe/%s/%s", probe.GetNamespace(), probe.GetName())
+
+		if targets.StaticConfig == nil && targets.Ingress == nil && targets.Service == nil {
+			tgs, err := c.selectHTTPRouteTargets(p, probe)
+			if err != nil {
+				rejected++
+				level.Warn(c.logger).Log(
+					"msg", "skipping probe",
+					"error", err.Error(),
+					"probe", probeName,
+					"namespace", p.Namespace,
+					"prometheus", p.Name,
+				)
+				continue
+			}
+			httpRouteTargets[pnKey] = tgs
+		}
+
 		if err = store.AddBearerToken(ctx, probe.GetNamespace(), probe.Spec.BearerTokenSecret, pnKey); err != nil {
 			break
 		}
@@ -1992,16 +2971,295 @@ func (c *Operator) selectProbes(ctx context.Context, p *monitoringv1.Prometheus,
 	level.Debug(c.logger).Log("msg", "selected Probes", "probes", strings.Join(probeKeys, ","), "namespace", p.Namespace, "prometheus", p.Name)
 
 	if pKey, ok := c.keyFunc(p); ok {
-		c.metrics.SetSelectedResources(pKey, monitoringv1.ProbesKind, len(res))
-		c.metrics.SetRejectedResources(pKey, monitoringv1.ProbesKind, rejected)
+		c.metricsFor(p).SetSelectedResources(pKey, monitoringv1.ProbesKind, len(res))
+		c.metricsFor(p).SetRejectedResources(pKey, monitoringv1.ProbesKind, rejected)
+	}
+
+	return res, nil
+}
+
+// checkProber returns an error if the probe references a Prober which doesn't
+// exist in its namespace.
+func (c *Operator) checkProber(probe *monitoringv1.Probe) error {
+	name := probe.Spec.ProberSpec.Name
+	if name == "" {
+		return nil
+	}
+
+	if c.proberInfs == nil {
+		return errors.Errorf("prober %q can't be resolved because the operator doesn't watch Prober resources", name)
+	}
+
+	_, err := c.proberInfs.Get(probe.GetNamespace() + "/" + name)
+	if apierrors.IsNotFound(err) {
+		return errors.Errorf("prober %q not found in namespace %q", name, probe.GetNamespace())
+	}
+
+	return err
+}
+
+// selectHTTPRouteTargets returns the target groups of the HTTPRoute objects
+// selected by the probe. The HTTPRoutes from which no target can be built are
+// skipped.
+func (c *Operator) selectHTTPRouteTargets(p *monitoringv1.Prometheus, probe *monitoringv1.Probe) ([]targetGroup, error) {
+	if c.httpRouteInfs == nil {
+		return nil, errors.Errorf("the %s/%s resource isn't served by the API server or the operator isn't allowed to watch it", httpRouteResource, httpRouteGroup)
+	}
+
+	route := probe.Spec.Targets.HTTPRoute
+	selector, err := metav1.LabelSelectorAsSelector(&route.Selector)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid HTTPRoute selector")
+	}
+
+	var tgs []targetGroup
+	appendFn := func(obj interface{}) {
+		u := obj.(*unstructured.Unstructured)
+		tg, err := httpRouteTargetGroup(u, route.Scheme)
+		if err != nil {
+			level.Warn(c.logger).Log(
+				"msg", "skipping httproute",
+				"error", err.Error(),
+				"httproute", u.GetName(),
+				"httproute_namespace", u.GetNamespace(),
+				"probe", probe.Name,
+				"namespace", probe.Namespace,
+				"prometheus", p.Name,
+			)
+			return
+		}
+		tgs = append(tgs, tg)
+	}
+
+	namespaces := getNamespacesFromNamespaceSelector(&route.NamespaceSelector, probe.Namespace, p.Spec.IgnoreNamespaceSelectors)
+	if len(namespaces) == 0 {
+		err = c.httpRouteInfs.ListAll(selector, appendFn)
+	} else {
+		for _, ns := range namespaces {
+			if err = c.httpRouteInfs.ListAllByNamespace(ns, selector, appendFn); err != nil {
+				break
+			}
+		}
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list HTTPRoutes")
+	}
+
+	// Keep the generated configuration stable.
+	sort.Slice(tgs, func(i, j int) bool {
+		if tgs[i].Labels["namespace"] != tgs[j].Labels["namespace"] {
+			return tgs[i].Labels["namespace"] < tgs[j].Labels["namespace"]
+		}
+		return tgs[i].Labels["httproute"] < tgs[j].Labels["httproute"]
+	})
+
+	return tgs, nil
+}
+
+func (c *Operator) selectScrapeConfigs(ctx context.Context, p *monitoringv1.Prometheus, store *assets.Store) (map[string]*monitoringv1alpha1.ScrapeConfig, error) {
+	namespaces := []string{}
+	// Selectors might overlap. Deduplicate them along the keyFunc.
+	scrapeConfigs := make(map[string]*monitoringv1alpha1.ScrapeConfig)
+
+	// The feature is disabled when the operator isn't allowed to watch the
+	// resources.
+	if c.sconInfs == nil {
+		return scrapeConfigs, nil
+	}
+
+	if p.Spec.ScrapeConfigSelector == nil {
+		return scrapeConfigs, nil
+	}
+
+	scSelector, err := metav1.LabelSelectorAsSelector(p.Spec.ScrapeConfigSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	// If 'ScrapeConfigNamespaceSelector' is nil only check own namespace.
+	if p.Spec.ScrapeConfigNamespaceSelector == nil {
+		namespaces = append(namespaces, p.Namespace)
+	} else {
+		scNSSelector, err := metav1.LabelSelectorAsSelector(p.Spec.ScrapeConfigNamespaceSelector)
+		if err != nil {
+			return nil, err
+		}
+
+		namespaces, err = c.listMatchingNamespaces(scNSSelector)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	level.Debug(c.logger).Log("msg", "filtering namespaces to select ScrapeConfigs from", "namespaces", strings.Join(namespaces, ","), "namespace", p.Namespace, "prometheus", p.Name)
+
+	for _, ns := range namespaces {
+		err := c.sconInfs.ListAllByNamespace(ns, scSelector, func(obj interface{}) {
+			if k, ok := c.keyFunc(obj); ok {
+				scrapeConfigs[k] = obj.(*monitoringv1alpha1.ScrapeConfig)
+			}
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list scrape configs in namespace %s", ns)
+		}
+	}
+
+	var rejected int
+	res := make(map[string]*monitoringv1alpha1.ScrapeConfig, len(scrapeConfigs))
+	for namespaceAndName, sc := range scrapeConfigs {
+		if fileSystemAccessDenied(p, sc.GetNamespace()) {
+			if err := testScrapeConfigForArbitraryFSAccess(sc); err != nil {
+				rejected++
+				level.Warn(c.logger).Log(
+					"msg", "skipping scrapeconfig",
+					"error", fileSystemAccessError(p, sc.GetNamespace(), err).Error(),
+					"scrapeconfig", namespaceAndName,
+					"namespace", p.Namespace,
+					"prometheus", p.Name,
+				)
+				continue
+			}
+		}
+
+		if err := addScrapeConfigAssets(ctx, sc, store); err != nil {
+			rejected++
+			level.Warn(c.logger).Log(
+				"msg", "skipping scrapeconfig",
+				"error", err.Error(),
+				"scrapeconfig", namespaceAndName,
+				"namespace", p.Namespace,
+				"prometheus", p.Name,
+			)
+			continue
+		}
+
+		res[namespaceAndName] = sc
+	}
+
+	scKeys := []string{}
+	for k := range res {
+		scKeys = append(scKeys, k)
+	}
+	level.Debug(c.logger).Log("msg", "selected ScrapeConfigs", "scrapeconfigs", strings.Join(scKeys, ","), "namespace", p.Namespace, "prometheus", p.Name)
+
+	if pKey, ok := c.keyFunc(p); ok {
+		c.metricsFor(p).SetSelectedResources(pKey, monitoringv1alpha1.ScrapeConfigsKind, len(res))
+		c.metricsFor(p).SetRejectedResources(pKey, monitoringv1alpha1.ScrapeConfigsKind, rejected)
 	}
 
 	return res, nil
 }
 
+// addScrapeConfigAssets adds the credentials and TLS assets referenced by
+// the ScrapeConfig to the store.
+func addScrapeConfigAssets(ctx context.Context, sc *monitoringv1alpha1.ScrapeConfig, store *assets.Store) error {
+	ns := sc.GetNamespace()
+
+	if err := store.AddBasicAuth(ctx, ns, sc.Spec.BasicAuth, scrapeConfigAssetKey(sc, "")); err != nil {
+		return err
+	}
+	if err := store.AddSafeAuthorizationCredentials(ctx, ns, sc.Spec.Authorization, scrapeConfigAuthKey(sc, "")); err != nil {
+		return err
+	}
+	if err := store.AddSafeTLSConfig(ctx, ns, sc.Spec.TLSConfig); err != nil {
+		return err
+	}
+
+	for i, config := range sc.Spec.HTTPSDConfigs {
+		suffix := fmt.Sprintf("httpsdconfig/%d", i)
+		if err := store.AddBasicAuth(ctx, ns, config.BasicAuth, scrapeConfigAssetKey(sc, suffix)); err != nil {
+			return errors.Wrapf(err, "httpSDConfigs[%d]", i)
+		}
+		if err := store.AddSafeAuthorizationCredentials(ctx, ns, config.Authorization, scrapeConfigAuthKey(sc, suffix)); err != nil {
+			return errors.Wrapf(err, "httpSDConfigs[%d]", i)
+		}
+		if err := store.AddSafeTLSConfig(ctx, ns, config.TLSConfig); err != nil {
+			return errors.Wrapf(err, "httpSDConfigs[%d]", i)
+		}
+	}
+
+	for i, config := range sc.Spec.ConsulSDConfigs {
+		suffix := fmt.Sprintf("consulsdconfig/%d", i)
+		if config.TokenRef != nil {
+			if err := store.AddSecretKey(ctx, ns, *config.TokenRef, scrapeConfigAssetKey(sc, suffix+"/token")); err != nil {
+				return errors.Wrapf(err, "consulSDConfigs[%d]", i)
+			}
+		}
+		if err := store.AddBasicAuth(ctx, ns, config.BasicAuth, scrapeConfigAssetKey(sc, suffix)); err != nil {
+			return errors.Wrapf(err, "consulSDConfigs[%d]", i)
+		}
+		if err := store.AddSafeAuthorizationCredentials(ctx, ns, config.Authorization, scrapeConfigAuthKey(sc, suffix)); err != nil {
+			return errors.Wrapf(err, "consulSDConfigs[%d]", i)
+		}
+		if err := store.AddSafeTLSConfig(ctx, ns, config.TLSConfig); err != nil {
+			return errors.Wrapf(err, "consulSDConfigs[%d]", i)
+		}
+	}
+
+	for i, config := range sc.Spec.EC2SDConfigs {
+		suffix := fmt.Sprintf("ec2sdconfig/%d", i)
+		if config.AccessKey != nil {
+			if err := store.AddSecretKey(ctx, ns, *config.AccessKey, scrapeConfigAssetKey(sc, suffix+"/accesskey")); err != nil {
+				return errors.Wrapf(err, "ec2SDConfigs[%d]", i)
+			}
+		}
+		if config.SecretKey != nil {
+			if err := store.AddSecretKey(ctx, ns, *config.SecretKey, scrapeConfigAssetKey(sc, suffix+"/secretkey")); err != nil {
+				return errors.Wrapf(err, "ec2SDConfigs[%d]", i)
+			}
+		}
+	}
+
+	for i, config := range sc.Spec.AzureSDConfigs {
+		suffix := fmt.Sprintf("azuresdconfig/%d", i)
+		if config.ClientSecret != nil {
+			if err := store.AddSecretKey(ctx, ns, *config.ClientSecret, scrapeConfigAssetKey(sc, suffix+"/clientsecret")); err != nil {
+				return errors.Wrapf(err, "azureSDConfigs[%d]", i)
+			}
+		}
+	}
+
+	return nil
+}
+
+// fileSystemAccessPolicy returns the effective file system access policy of
+// the Prometheus resource.
+func fileSystemAccessPolicy(p *monitoringv1.Prometheus) monitoringv1.FileSystemAccessPolicy {
+	if p.Spec.FileSystemAccess != "" {
+		return p.Spec.FileSystemAccess
+	}
+
+	if p.Spec.ArbitraryFSAccessThroughSMs.Deny {
+		return monitoringv1.DenyFileSystemAccess
+	}
+
+	return monitoringv1.DenyOtherNamespacesFileSystemAccess
+}
+
+// fileSystemAccessDenied returns true if the objects from the given namespace
+// can't reference files on the file system of the Prometheus container.
+func fileSystemAccessDenied(p *monitoringv1.Prometheus, namespace string) bool {
+	switch fileSystemAccessPolicy(p) {
+	case monitoringv1.AllowFileSystemAccess:
+		return false
+	case monitoringv1.DenyOtherNamespacesFileSystemAccess:
+		return namespace != p.Namespace
+	default:
+		return true
+	}
+}
+
+// fileSystemAccessError returns the reason why an object from the given
+// namespace is rejected for accessing the file system.
+func fileSystemAccessError(p *monitoringv1.Prometheus, namespace string, err error) error {
+	return errors.Wrapf(err, "file system access is denied to objects in namespace %q by the %s policy of the Prometheus resource", namespace, fileSystemAccessPolicy(p))
+}
+
+// testForArbitraryFSAccess returns an error if the endpoint references a file
+// on the file system of the Prometheus container.
 func testForArbitraryFSAccess(e monitoringv1.Endpoint) error {
 	if e.BearerTokenFile != "" {
-		return errors.New("it accesses file system via bearer token file which Prometheus specification prohibits")
+		return errors.Errorf("bearerTokenFile references the file %q", e.BearerTokenFile)
 	}
 
 	tlsConf := e.TLSConfig
@@ -2009,8 +3267,25 @@ func testForArbitraryFSAccess(e monitoringv1.Endpoint) error {
 		return nil
 	}
 
-	if tlsConf.CAFile != "" || tlsConf.CertFile != "" || tlsConf.KeyFile != "" {
-		return errors.New("it accesses file system via tls config which Prometheus specification prohibits")
+	switch {
+	case tlsConf.CAFile != "":
+		return errors.Errorf("tlsConfig.caFile references the file %q", tlsConf.CAFile)
+	case tlsConf.CertFile != "":
+		return errors.Errorf("tlsConfig.certFile references the file %q", tlsConf.CertFile)
+	case tlsConf.KeyFile != "":
+		return errors.Errorf("tlsConfig.keyFile references the file %q", tlsConf.KeyFile)
+	}
+
+	return nil
+}
+
+// testScrapeConfigForArbitraryFSAccess returns an error if the ScrapeConfig
+// references a file on the file system of the Prometheus container.
+func testScrapeConfigForArbitraryFSAccess(sc *monitoringv1alpha1.ScrapeConfig) error {
+	for i, config := range sc.Spec.FileSDConfigs {
+		if len(config.Files) > 0 {
+			return errors.Errorf("fileSDConfigs[%d].files references the file %q", i, config.Files[0])
+		}
 	}
 
 	return nil
@@ -15,10 +15,26 @@
 package prometheus
 
 import (
+	"context"
 	"reflect"
+	"sort"
 	"testing"
 
+	"github.com/go-kit/log"
+	"github.com/prometheus/client_golang/prometheus"
+	authv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	monitoringv1alpha1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1alpha1"
+	monitoringfake "github.com/prometheus-operator/prometheus-operator/pkg/client/versioned/fake"
+	"github.com/prometheus-operator/prometheus-operator/pkg/informers"
 	"github.com/prometheus-operator/prometheus-operator/pkg/operator"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -60,6 +76,7 @@ func TestGetNodeAddresses(t *testing.T) {
 	cases := []struct {
 		name              string
 		nodes             *v1.NodeList
+		addressType       v1.NodeAddressType
 		expectedAddresses []string
 		expectedErrors    int
 	}{
@@ -121,10 +138,77 @@ func TestGetNodeAddresses(t *testing.T) {
 			expectedAddresses: []string{"10.0.0.1"},
 			expectedErrors:    1,
 		},
+		{
+			name: "external ip",
+			nodes: &v1.NodeList{
+				Items: []v1.Node{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "node-0",
+						},
+						Status: v1.NodeStatus{
+							Addresses: []v1.NodeAddress{
+								{
+									Address: "10.0.0.1",
+									Type:    v1.NodeInternalIP,
+								},
+								{
+									Address: "192.168.0.1",
+									Type:    v1.NodeExternalIP,
+								},
+							},
+						},
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "node-1",
+						},
+						Status: v1.NodeStatus{
+							Addresses: []v1.NodeAddress{
+								{
+									Address: "10.0.0.2",
+									Type:    v1.NodeInternalIP,
+								},
+							},
+						},
+					},
+				},
+			},
+			addressType:       v1.NodeExternalIP,
+			expectedAddresses: []string{"192.168.0.1"},
+			expectedErrors:    1,
+		},
+		{
+			name: "hostname",
+			nodes: &v1.NodeList{
+				Items: []v1.Node{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "node-0",
+						},
+						Status: v1.NodeStatus{
+							Addresses: []v1.NodeAddress{
+								{
+									Address: "10.0.0.1",
+									Type:    v1.NodeInternalIP,
+								},
+								{
+									Address: "node-0",
+									Type:    v1.NodeHostName,
+								},
+							},
+						},
+					},
+				},
+			},
+			addressType:       v1.NodeHostName,
+			expectedAddresses: []string{"10.0.0.1"},
+			expectedErrors:    0,
+		},
 	}
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
-			addrs, errs := getNodeAddresses(c.nodes)
+			addrs, errs := getNodeAddresses(c.nodes.Items, c.addressType)
 			if len(errs) != c.expectedErrors {
 				t.Errorf("Expected %d errors, got %d. Errors: %v", c.expectedErrors, len(errs), errs)
 			}
@@ -135,10 +219,215 @@ func TestGetNodeAddresses(t *testing.T) {
 			if !reflect.DeepEqual(ips, c.expectedAddresses) {
 				t.Error(pretty.Compare(ips, c.expectedAddresses))
 			}
+			if c.addressType == v1.NodeHostName {
+				for _, addr := range addrs {
+					if addr.Hostname != addr.TargetRef.Name {
+						t.Errorf("expected hostname %q, got %q", addr.TargetRef.Name, addr.Hostname)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestGroupKubeletNodes(t *testing.T) {
+	newNode := func(name string, labels map[string]string) v1.Node {
+		return v1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+	}
+	nodes := []v1.Node{
+		newNode("node-0", nil),
+		newNode("node-1", map[string]string{"pool": "gpu"}),
+		newNode("node-2", map[string]string{"pool": "gpu", v1.LabelOSStable: "windows"}),
+		newNode("node-3", map[string]string{v1.LabelOSStable: "windows"}),
+	}
+	windowsPorts := []operator.KubeletPort{{Name: "https-metrics", Port: 10250}}
+
+	for _, tc := range []struct {
+		name     string
+		cfg      operator.KubeletSyncConfig
+		expected map[string][]string
+	}{
+		{
+			name: "default",
+			expected: map[string][]string{
+				"kubelet": {"node-0", "node-1", "node-2", "node-3"},
+			},
+		},
+		{
+			name: "split by os",
+			cfg:  operator.KubeletSyncConfig{SplitByOS: true, WindowsPorts: windowsPorts},
+			expected: map[string][]string{
+				"kubelet":         {"node-0", "node-1"},
+				"kubelet-windows": {"node-2", "node-3"},
+			},
+		},
+		{
+			name: "node pools",
+			cfg:  operator.KubeletSyncConfig{NodePoolLabel: "pool"},
+			expected: map[string][]string{
+				"kubelet":     {"node-0", "node-3"},
+				"kubelet-gpu": {"node-1", "node-2"},
+			},
+		},
+		{
+			name: "node pools split by os",
+			cfg:  operator.KubeletSyncConfig{NodePoolLabel: "pool", SplitByOS: true, WindowsPorts: windowsPorts},
+			expected: map[string][]string{
+				"kubelet":             {"node-0"},
+				"kubelet-windows":     {"node-3"},
+				"kubelet-gpu":         {"node-1"},
+				"kubelet-gpu-windows": {"node-2"},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			groups := groupKubeletNodes("kubelet", tc.cfg, nodes)
+			if groups[0].name != "kubelet" {
+				t.Fatalf("expected the main group first, got %q", groups[0].name)
+			}
+
+			got := map[string][]string{}
+			for _, g := range groups {
+				for _, n := range g.nodes {
+					got[g.name] = append(got[g.name], n.Name)
+				}
+
+				expectedPorts := defaultKubeletPorts
+				if g.labels[kubeletNodeOSLabel] == "windows" {
+					expectedPorts = windowsPorts
+				}
+				if !reflect.DeepEqual(g.ports, expectedPorts) {
+					t.Errorf("group %q: expected ports %v, got %v", g.name, expectedPorts, g.ports)
+				}
+			}
+
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Fatal(pretty.Compare(got, tc.expected))
+			}
 		})
 	}
 }
 
+func TestSyncNodeEndpoints(t *testing.T) {
+	newNode := func(name, ip string, labels map[string]string) *v1.Node {
+		return &v1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+			Status: v1.NodeStatus{
+				Addresses: []v1.NodeAddress{{Address: ip, Type: v1.NodeInternalIP}},
+			},
+		}
+	}
+
+	kclient := fake.NewSimpleClientset(
+		newNode("node-0", "10.0.0.1", map[string]string{"pool": "a"}),
+		newNode("node-1", "10.0.0.2", map[string]string{"pool": "b"}),
+	)
+	c := &Operator{
+		kclient:                 kclient,
+		logger:                  log.NewNopLogger(),
+		kubeletObjectName:       "kubelet",
+		kubeletObjectNamespace:  "kube-system",
+		nodeAddressLookupErrors: prometheus.NewCounter(prometheus.CounterOpts{Name: "errors"}),
+		config: operator.Config{
+			KubeletSync: operator.KubeletSyncConfig{NodePoolLabel: "pool"},
+		},
+	}
+
+	expectServices := func(t *testing.T, expected ...string) {
+		t.Helper()
+		svcs, err := kclient.CoreV1().Services("kube-system").List(context.Background(), metav1.ListOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := []string{}
+		for _, svc := range svcs.Items {
+			got = append(got, svc.Name)
+		}
+		sort.Strings(got)
+		if !reflect.DeepEqual(got, expected) {
+			t.Fatal(pretty.Compare(got, expected))
+		}
+	}
+
+	if err := c.syncNodeEndpoints(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	expectServices(t, "kubelet", "kubelet-a", "kubelet-b")
+
+	eps, err := kclient.CoreV1().Endpoints("kube-system").Get(context.Background(), "kubelet-a", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(eps.Subsets) != 1 || len(eps.Subsets[0].Addresses) != 1 || eps.Subsets[0].Addresses[0].IP != "10.0.0.1" {
+		t.Fatalf("unexpected endpoints subsets: %v", eps.Subsets)
+	}
+
+	// The objects of the removed node pool are deleted.
+	if err := kclient.CoreV1().Nodes().Delete(context.Background(), "node-1", metav1.DeleteOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.syncNodeEndpoints(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	expectServices(t, "kubelet", "kubelet-a")
+	if _, err := kclient.CoreV1().Endpoints("kube-system").Get(context.Background(), "kubelet-b", metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+		t.Fatalf("expected endpoints kubelet-b to be deleted, got %v", err)
+	}
+}
+
+func TestApplyConfigKubeletSync(t *testing.T) {
+	kclient := fake.NewSimpleClientset()
+	// The operator isn't allowed to delete the kubelet objects.
+	kclient.PrependReactor("create", "selfsubjectaccessreviews", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		ssar := action.(clienttesting.CreateAction).GetObject().(*authv1.SelfSubjectAccessReview)
+		ssar.Status.Allowed = ssar.Spec.ResourceAttributes.Verb != "delete"
+		return true, ssar, nil
+	})
+
+	promInfs, err := informers.NewInformersForResource(
+		informers.NewMonitoringInformerFactories(map[string]struct{}{v1.NamespaceAll: {}}, nil, monitoringfake.NewSimpleClientset(), 0, nil),
+		monitoringv1.SchemeGroupVersion.WithResource(monitoringv1.PrometheusName),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := &Operator{
+		logger:                 log.NewNopLogger(),
+		promInfs:               promInfs,
+		ssarClient:             kclient.AuthorizationV1().SelfSubjectAccessReviews(),
+		kubeletObjectName:      "kubelet",
+		kubeletObjectNamespace: "kube-system",
+		kubeletSyncEnabled:     true,
+		nodeSyncCh:             make(chan struct{}, 1),
+		promSelector:           labels.Everything(),
+	}
+
+	// Splitting the nodes requires the delete permission.
+	err = c.ApplyConfig(operator.Config{KubeletSync: operator.KubeletSyncConfig{SplitByOS: true}})
+	if err == nil {
+		t.Fatal("expected an error for the missing delete permission")
+	}
+	if c.config.KubeletSync.SplitByOS {
+		t.Fatal("expected the kubelet sync configuration to be unchanged")
+	}
+	if len(c.nodeSyncCh) != 0 {
+		t.Fatal("expected no node sync to be triggered")
+	}
+
+	// A change which doesn't require new permissions triggers a node sync.
+	err = c.ApplyConfig(operator.Config{KubeletSync: operator.KubeletSyncConfig{NodeSelector: "kubernetes.io/os=linux"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.config.KubeletSync.NodeSelector != "kubernetes.io/os=linux" {
+		t.Fatalf("expected the node selector to be updated, got %q", c.config.KubeletSync.NodeSelector)
+	}
+	if len(c.nodeSyncCh) != 1 {
+		t.Fatal("expected a node sync to be triggered")
+	}
+}
+
 func TestStatefulSetKeyToPrometheusKey(t *testing.T) {
 	cases := []struct {
 		input         string
@@ -202,3 +491,323 @@ func TestPrometheusKeyToStatefulSetKey(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateRemoteWriteSpec(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		spec      monitoringv1.RemoteWriteSpec
+		expectErr bool
+	}{
+		{
+			name: "no authentication",
+			spec: monitoringv1.RemoteWriteSpec{},
+		},
+		{
+			name: "sigv4 only",
+			spec: monitoringv1.RemoteWriteSpec{
+				Sigv4: &monitoringv1.Sigv4{Region: "us-east-1"},
+			},
+		},
+		{
+			name: "sigv4 and basic auth",
+			spec: monitoringv1.RemoteWriteSpec{
+				Sigv4:     &monitoringv1.Sigv4{},
+				BasicAuth: &monitoringv1.BasicAuth{},
+			},
+			expectErr: true,
+		},
+		{
+			name: "azure AD and bearer token",
+			spec: monitoringv1.RemoteWriteSpec{
+				AzureAD: &monitoringv1.AzureAD{
+					ManagedIdentity: monitoringv1.ManagedIdentity{ClientID: "client-id"},
+				},
+				BearerToken: "token",
+			},
+			expectErr: true,
+		},
+		{
+			name: "azure AD without client id",
+			spec: monitoringv1.RemoteWriteSpec{
+				AzureAD: &monitoringv1.AzureAD{},
+			},
+			expectErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateRemoteWriteSpec(tc.spec)
+			if tc.expectErr && err == nil {
+				t.Fatal("expected error, got nil")
+			}
+			if !tc.expectErr && err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestFileSystemAccessDenied(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		spec      monitoringv1.PrometheusSpec
+		namespace string
+		denied    bool
+	}{
+		{
+			name:      "default same namespace",
+			namespace: "monitoring",
+		},
+		{
+			name:      "default other namespace",
+			namespace: "team-a",
+			denied:    true,
+		},
+		{
+			name: "allow",
+			spec: monitoringv1.PrometheusSpec{
+				CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+					FileSystemAccess: monitoringv1.AllowFileSystemAccess,
+				},
+			},
+			namespace: "team-a",
+		},
+		{
+			name: "deny",
+			spec: monitoringv1.PrometheusSpec{
+				CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+					FileSystemAccess: monitoringv1.DenyFileSystemAccess,
+				},
+			},
+			namespace: "monitoring",
+			denied:    true,
+		},
+		{
+			name: "deny through service monitors config",
+			spec: monitoringv1.PrometheusSpec{
+				CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+					ArbitraryFSAccessThroughSMs: monitoringv1.ArbitraryFSAccessThroughSMsConfig{Deny: true},
+				},
+			},
+			namespace: "monitoring",
+			denied:    true,
+		},
+		{
+			name: "explicit policy takes precedence",
+			spec: monitoringv1.PrometheusSpec{
+				CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+					ArbitraryFSAccessThroughSMs: monitoringv1.ArbitraryFSAccessThroughSMsConfig{Deny: true},
+					FileSystemAccess:            monitoringv1.AllowFileSystemAccess,
+				},
+			},
+			namespace: "team-a",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p := &monitoringv1.Prometheus{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "monitoring"},
+				Spec:       tc.spec,
+			}
+			if denied := fileSystemAccessDenied(p, tc.namespace); denied != tc.denied {
+				t.Fatalf("expected denied to be %v, got %v", tc.denied, denied)
+			}
+		})
+	}
+}
+
+func TestTestForArbitraryFSAccess(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		endpoint monitoringv1.Endpoint
+		err      string
+	}{
+		{
+			name: "no file",
+			endpoint: monitoringv1.Endpoint{
+				TLSConfig: &monitoringv1.TLSConfig{
+					SafeTLSConfig: monitoringv1.SafeTLSConfig{ServerName: "foo"},
+				},
+			},
+		},
+		{
+			name:     "bearer token file",
+			endpoint: monitoringv1.Endpoint{BearerTokenFile: "/etc/token"},
+			err:      `bearerTokenFile references the file "/etc/token"`,
+		},
+		{
+			name: "tls key file",
+			endpoint: monitoringv1.Endpoint{
+				TLSConfig: &monitoringv1.TLSConfig{KeyFile: "/etc/tls.key"},
+			},
+			err: `tlsConfig.keyFile references the file "/etc/tls.key"`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := testForArbitraryFSAccess(tc.endpoint)
+			if tc.err == "" {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.err {
+				t.Fatalf("expected error %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestEnqueueInstanceSelector(t *testing.T) {
+	c := &Operator{
+		logger:       log.NewNopLogger(),
+		metrics:      operator.NewMetrics("prometheus", prometheus.NewRegistry()),
+		queue:        workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		promSelector: labels.SelectorFromSet(labels.Set{"shard": "a"}),
+	}
+	defer c.queue.ShutDown()
+
+	newPrometheus := func(name, shard string) *monitoringv1.Prometheus {
+		return &monitoringv1.Prometheus{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "ns",
+				Labels:    map[string]string{"shard": shard},
+			},
+		}
+	}
+
+	selected := newPrometheus("selected", "a")
+	other := newPrometheus("other", "b")
+
+	c.handlePrometheusAdd(other)
+	c.handlePrometheusDelete(cache.DeletedFinalStateUnknown{Key: "ns/other", Obj: other})
+	c.enqueue(other)
+	if c.queue.Len() != 0 {
+		t.Fatalf("expected no key for the unselected object, got %d", c.queue.Len())
+	}
+
+	c.handlePrometheusAdd(selected)
+	if c.queue.Len() != 1 {
+		t.Fatalf("expected 1 key for the selected object, got %d", c.queue.Len())
+	}
+	key, _ := c.queue.Get()
+	c.queue.Done(key)
+	c.queue.Forget(key)
+	if key != "ns/selected" {
+		t.Fatalf("expected key %q, got %q", "ns/selected", key)
+	}
+
+	// An object leaving the selection is enqueued to be forgotten.
+	updated := newPrometheus("selected", "b")
+	updated.ResourceVersion = "2"
+	c.handlePrometheusUpdate(selected, updated)
+	if c.queue.Len() != 1 {
+		t.Fatalf("expected 1 key for the object leaving the selection, got %d", c.queue.Len())
+	}
+}
+
+func TestCheckProber(t *testing.T) {
+	proberInfs, err := informers.NewInformersForResource(
+		informers.NewMonitoringInformerFactories(
+			map[string]struct{}{v1.NamespaceAll: {}},
+			nil,
+			monitoringfake.NewSimpleClientset(&monitoringv1alpha1.Prober{
+				ObjectMeta: metav1.ObjectMeta{Name: "blackbox", Namespace: "ns"},
+			}),
+			0,
+			nil,
+		),
+		monitoringv1alpha1.SchemeGroupVersion.WithResource(monitoringv1alpha1.ProberName),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	proberInfs.Start(ctx.Done())
+	for _, inf := range proberInfs.GetInformers() {
+		if !cache.WaitForCacheSync(ctx.Done(), inf.Informer().HasSynced) {
+			t.Fatal("failed to sync the prober informers")
+		}
+	}
+
+	for _, tc := range []struct {
+		name        string
+		proberInfs  *informers.ForResource
+		prober      monitoringv1.ProberSpec
+		expectedErr bool
+	}{
+		{
+			name:   "prober URL",
+			prober: monitoringv1.ProberSpec{URL: "blackbox:9115"},
+		},
+		{
+			name:       "existing prober",
+			proberInfs: proberInfs,
+			prober:     monitoringv1.ProberSpec{Name: "blackbox"},
+		},
+		{
+			name:        "missing prober",
+			proberInfs:  proberInfs,
+			prober:      monitoringv1.ProberSpec{Name: "other"},
+			expectedErr: true,
+		},
+		{
+			name:        "probers not watched",
+			prober:      monitoringv1.ProberSpec{Name: "blackbox"},
+			expectedErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Operator{proberInfs: tc.proberInfs}
+			probe := &monitoringv1.Probe{
+				ObjectMeta: metav1.ObjectMeta{Name: "probe", Namespace: "ns"},
+				Spec:       monitoringv1.ProbeSpec{ProberSpec: tc.prober},
+			}
+
+			err := c.checkProber(probe)
+			if tc.expectedErr && err == nil {
+				t.Fatal("expected an error")
+			}
+			if !tc.expectedErr && err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestCountScrapeJobs(t *testing.T) {
+	smons := map[string]*monitoringv1.ServiceMonitor{
+		"ns/sm": {Spec: monitoringv1.ServiceMonitorSpec{Endpoints: []monitoringv1.Endpoint{{Port: "a"}, {Port: "b"}}}},
+	}
+	pmons := map[string]*monitoringv1.PodMonitor{
+		"ns/pm": {Spec: monitoringv1.PodMonitorSpec{PodMetricsEndpoints: []monitoringv1.PodMetricsEndpoint{{Port: "a"}}}},
+	}
+	bmons := map[string]*monitoringv1.Probe{"ns/probe": {}}
+	scrapeConfigs := map[string]*monitoringv1alpha1.ScrapeConfig{"ns/sc": {}}
+
+	for _, tc := range []struct {
+		name       string
+		additional string
+		expected   int
+	}{
+		{
+			name:     "no additional scrape configs",
+			expected: 5,
+		},
+		{
+			name: "additional scrape configs",
+			additional: `
+- job_name: a
+- job_name: b
+`,
+			expected: 7,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if n := countScrapeJobs(smons, pmons, bmons, scrapeConfigs, []byte(tc.additional)); n != tc.expected {
+				t.Fatalf("expected %d scrape jobs, got %d", tc.expected, n)
+			}
+		})
+	}
+}
@@ -28,15 +28,20 @@ import (
 	"gopkg.in/yaml.v2"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/prometheus-operator/prometheus-operator/pkg/alertmanager"
 	v1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1alpha1"
 	"github.com/prometheus-operator/prometheus-operator/pkg/assets"
 	"github.com/prometheus-operator/prometheus-operator/pkg/operator"
+	"github.com/prometheus-operator/prometheus-operator/pkg/prober"
+	"github.com/prometheus-operator/prometheus-operator/pkg/webconfig"
 )
 
 const (
 	kubernetesSDRoleEndpoint = "endpoints"
 	kubernetesSDRolePod      = "pod"
 	kubernetesSDRoleIngress  = "ingress"
+	kubernetesSDRoleService  = "service"
 )
 
 var (
@@ -46,12 +51,29 @@ var (
 // ConfigGenerator is used to create Prometheus configurations from operator resources.
 type ConfigGenerator struct {
 	logger log.Logger
+
+	// credentialsAsFiles makes the generated configuration reference the
+	// credentials mounted from the credentials secret instead of inlining
+	// them.
+	credentialsAsFiles bool
+
+	// alertmanagers are the endpoints of the Alertmanager resources selected
+	// by the Prometheus object.
+	alertmanagers []alertmanager.Endpoint
+
+	// httpRouteTargets are the targets of the probes selecting HTTPRoutes.
+	httpRouteTargets map[string][]targetGroup
+
+	// scrapeConfigCache is shared by the copies of the generator, it is nil
+	// when caching is disabled.
+	scrapeConfigCache *scrapeConfigCache
 }
 
 // NewConfigGenerator creates a ConfigGenerator instance using the provided Logger.
 func NewConfigGenerator(logger log.Logger) *ConfigGenerator {
 	cg := &ConfigGenerator{
-		logger: logger,
+		logger:            logger,
+		scrapeConfigCache: newScrapeConfigCache(),
 	}
 	return cg
 }
@@ -116,13 +138,12 @@ func addTLStoYaml(cfg yaml.MapSlice, namespace string, tls *v1.TLSConfig) yaml.M
 	return cfg
 }
 
-func addSafeAuthorizationToYaml(
+func (cg *ConfigGenerator) addSafeAuthorizationToYaml(
 	cfg yaml.MapSlice,
 	version semver.Version,
 	assetStoreKey string,
 	store *assets.Store,
 	auth *v1.SafeAuthorization,
-	logger log.Logger,
 ) yaml.MapSlice {
 	if auth == nil {
 		return cfg
@@ -131,7 +152,7 @@ func addSafeAuthorizationToYaml(
 		// extract current cfg section from assetStoreKey, assuming
 		// "<component>/something..."
 		component := strings.Split(assetStoreKey, "/")[0]
-		level.Warn(logger).Log("msg", fmt.Sprintf("%s: found authorization section, but prometheus is < 2.26.0, ignoring", component),
+		level.Warn(cg.logger).Log("msg", fmt.Sprintf("%s: found authorization section, but prometheus is < 2.26.0, ignoring", component),
 			component)
 		return cfg
 	}
@@ -142,19 +163,18 @@ func addSafeAuthorizationToYaml(
 	authCfg = append(authCfg, yaml.MapItem{Key: "type", Value: strings.TrimSpace(auth.Type)})
 	if auth.Credentials != nil {
 		if s, ok := store.TokenAssets[assetStoreKey]; ok {
-			authCfg = append(authCfg, yaml.MapItem{Key: "credentials", Value: s})
+			authCfg = append(authCfg, cg.secretItem("credentials", assetStoreKey, tokenCredential, string(s)))
 		}
 	}
 	return append(cfg, yaml.MapItem{Key: "authorization", Value: authCfg})
 }
 
-func addAuthorizationToYaml(
+func (cg *ConfigGenerator) addAuthorizationToYaml(
 	cfg yaml.MapSlice,
 	version semver.Version,
 	assetStoreKey string,
 	store *assets.Store,
 	auth *v1.Authorization,
-	logger log.Logger,
 ) yaml.MapSlice {
 	if auth == nil {
 		return cfg
@@ -163,13 +183,13 @@ func addAuthorizationToYaml(
 		// extract current cfg section from assetStoreKey, assuming
 		// "<component>/something..."
 		component := strings.Split(assetStoreKey, "/")[0]
-		level.Warn(logger).Log("msg", fmt.Sprintf("%s: found authorization section, but prometheus is < 2.26.0, ignoring", component),
+		level.Warn(cg.logger).Log("msg", fmt.Sprintf("%s: found authorization section, but prometheus is < 2.26.0, ignoring", component),
 			component)
 		return cfg
 	}
 	// reuse addSafeAuthorizationToYaml and unpack the part we're interested
 	// in, namely the value under the "authorization" key
-	authCfg := addSafeAuthorizationToYaml(yaml.MapSlice{}, version, assetStoreKey, store, &auth.SafeAuthorization, logger)[0].Value.(yaml.MapSlice)
+	authCfg := cg.addSafeAuthorizationToYaml(yaml.MapSlice{}, version, assetStoreKey, store, &auth.SafeAuthorization)[0].Value.(yaml.MapSlice)
 	if auth.CredentialsFile != "" {
 		authCfg = append(authCfg, yaml.MapItem{Key: "credentials_file", Value: auth.CredentialsFile})
 	}
@@ -221,12 +241,15 @@ func (cg *ConfigGenerator) GenerateConfig(
 	sMons map[string]*v1.ServiceMonitor,
 	pMons map[string]*v1.PodMonitor,
 	probes map[string]*v1.Probe,
+	scrapeConfigs map[string]*v1alpha1.ScrapeConfig,
 	store *assets.Store,
 	additionalScrapeConfigs []byte,
 	additionalAlertRelabelConfigs []byte,
 	additionalAlertManagerConfigs []byte,
 	ruleConfigMapNames []string,
 ) ([]byte, error) {
+	cg = cg.withCredentialsAsFiles(p)
+
 	versionStr := p.Spec.Version
 	if versionStr == "" {
 		versionStr = operator.DefaultPrometheusVersion
@@ -261,6 +284,12 @@ func (cg *ConfigGenerator) GenerateConfig(
 		})
 	}
 
+	if len(p.Spec.ScrapeProtocols) > 0 && version.GTE(semver.MustParse("2.49.0")) {
+		globalItems = append(globalItems, yaml.MapItem{
+			Key: "scrape_protocols", Value: p.Spec.ScrapeProtocols,
+		})
+	}
+
 	if version.GTE(semver.MustParse("2.16.0")) && p.Spec.QueryLogFile != "" {
 		globalItems = append(globalItems, yaml.MapItem{
 			Key: "query_log_file", Value: p.Spec.QueryLogFile,
@@ -269,6 +298,16 @@ func (cg *ConfigGenerator) GenerateConfig(
 
 	cfg = append(cfg, yaml.MapItem{Key: "global", Value: globalItems})
 
+	if err := validateTSDB(p, version); err != nil {
+		return nil, err
+	}
+	if err := validateAlertRelabelConfigs(p.Spec.Alerting, version); err != nil {
+		return nil, err
+	}
+	if storage := generateStorageConfig(p, version); len(storage) > 0 {
+		cfg = append(cfg, yaml.MapItem{Key: "storage", Value: storage})
+	}
+
 	ruleFilePaths := []string{}
 	for _, name := range ruleConfigMapNames {
 		ruleFilePaths = append(ruleFilePaths, rulesDir+"/"+name+"/*.yaml")
@@ -278,6 +317,216 @@ func (cg *ConfigGenerator) GenerateConfig(
 		Value: ruleFilePaths,
 	})
 
+	generatedScrapeConfigs, err := cg.generateScrapeConfigs(version, p, sMons, pMons, probes, scrapeConfigs, store)
+	if err != nil {
+		return nil, err
+	}
+	apiserverConfig := p.Spec.APIServerConfig
+
+	var alertmanagerConfigs []yaml.MapSlice
+	alertmanagerConfigs = cg.generateAlertmanagerConfig(version, p.Namespace, p.Spec.Alerting, apiserverConfig, store)
+
+	var additionalScrapeConfigsYaml []yaml.MapSlice
+	err = yaml.Unmarshal([]byte(additionalScrapeConfigs), &additionalScrapeConfigsYaml)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshalling additional scrape configs failed")
+	}
+
+	if err := generatedScrapeConfigs.addConfigs(additionalScrapeConfigsYaml...); err != nil {
+		return nil, err
+	}
+	cfg = append(cfg, generatedScrapeConfigs.mapItem())
+
+	var additionalAlertManagerConfigsYaml []yaml.MapSlice
+	err = yaml.Unmarshal([]byte(additionalAlertManagerConfigs), &additionalAlertManagerConfigsYaml)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshalling additional alert manager configs failed")
+	}
+
+	alertmanagerConfigs = append(alertmanagerConfigs, additionalAlertManagerConfigsYaml...)
+
+	var alertRelabelConfigs []yaml.MapSlice
+
+	// Use defaultReplicaExternalLabelName constant by default if field is missing.
+	// Do not add external label if field is set to empty string.
+	replicaExternalLabelName := defaultReplicaExternalLabelName
+	if p.Spec.ReplicaExternalLabelName != nil {
+		if *p.Spec.ReplicaExternalLabelName != "" {
+			replicaExternalLabelName = *p.Spec.ReplicaExternalLabelName
+		} else {
+			replicaExternalLabelName = ""
+		}
+	}
+
+	// action 'labeldrop' is not supported <= v1.4.1
+	if replicaExternalLabelName != "" && version.GT(semver.MustParse("1.4.1")) {
+		// Drop replica label, to make alerts from multiple Prometheus replicas alike
+		alertRelabelConfigs = append(alertRelabelConfigs, yaml.MapSlice{
+			{Key: "action", Value: "labeldrop"},
+			{Key: "regex", Value: regexp.QuoteMeta(replicaExternalLabelName)},
+		})
+	}
+
+	if p.Spec.Alerting != nil {
+		alertRelabelConfigs = append(alertRelabelConfigs, generateRelabelConfigs(p.Spec.Alerting.AlertRelabelConfigs)...)
+	}
+
+	var additionalAlertRelabelConfigsYaml []yaml.MapSlice
+	err = yaml.Unmarshal([]byte(additionalAlertRelabelConfigs), &additionalAlertRelabelConfigsYaml)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshalling additional alerting relabel configs failed")
+	}
+
+	cfg = append(cfg, yaml.MapItem{
+		Key: "alerting",
+		Value: yaml.MapSlice{
+			{
+				Key:   "alert_relabel_configs",
+				Value: append(alertRelabelConfigs, additionalAlertRelabelConfigsYaml...),
+			},
+			{
+				Key:   "alertmanagers",
+				Value: alertmanagerConfigs,
+			},
+		},
+	})
+
+	if len(p.Spec.RemoteWrite) > 0 {
+		cfg = append(cfg, cg.generateRemoteWriteConfig(version, p, store))
+	}
+
+	if len(p.Spec.RemoteRead) > 0 {
+		cfg = append(cfg, cg.generateRemoteReadConfig(version, p, store))
+	}
+
+	return generatedScrapeConfigs.marshal(cfg)
+}
+
+// GenerateAgentConfig generates the configuration of a Prometheus agent.
+// Compared to GenerateConfig, it doesn't generate the rule files, alerting,
+// remote read and evaluation interval sections which aren't supported in
+// agent mode. When nodeLocal is true, the targets of the ServiceMonitors and
+// PodMonitors are restricted to the pods running on the same node as the
+// Prometheus agent.
+func (cg *ConfigGenerator) GenerateAgentConfig(
+	p *v1.Prometheus,
+	sMons map[string]*v1.ServiceMonitor,
+	pMons map[string]*v1.PodMonitor,
+	probes map[string]*v1.Probe,
+	scrapeConfigs map[string]*v1alpha1.ScrapeConfig,
+	store *assets.Store,
+	additionalScrapeConfigs []byte,
+	nodeLocal bool,
+) ([]byte, error) {
+	cg = cg.withCredentialsAsFiles(p)
+
+	versionStr := p.Spec.Version
+	if versionStr == "" {
+		versionStr = operator.DefaultPrometheusVersion
+	}
+
+	version, err := semver.ParseTolerant(versionStr)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse version")
+	}
+
+	scrapeInterval := "30s"
+	if p.Spec.ScrapeInterval != "" {
+		scrapeInterval = p.Spec.ScrapeInterval
+	}
+
+	globalItems := yaml.MapSlice{
+		{Key: "scrape_interval", Value: scrapeInterval},
+		{Key: "external_labels", Value: buildExternalLabels(p)},
+	}
+
+	if p.Spec.ScrapeTimeout != "" {
+		globalItems = append(globalItems, yaml.MapItem{
+			Key: "scrape_timeout", Value: p.Spec.ScrapeTimeout,
+		})
+	}
+
+	if len(p.Spec.ScrapeProtocols) > 0 && version.GTE(semver.MustParse("2.49.0")) {
+		globalItems = append(globalItems, yaml.MapItem{
+			Key: "scrape_protocols", Value: p.Spec.ScrapeProtocols,
+		})
+	}
+
+	cfg := yaml.MapSlice{
+		{Key: "global", Value: globalItems},
+	}
+
+	generatedScrapeConfigs, err := cg.generateScrapeConfigs(version, p, sMons, pMons, probes, scrapeConfigs, store)
+	if err != nil {
+		return nil, err
+	}
+	if nodeLocal {
+		nodeLocalScrapeConfigs := &scrapeConfigList{}
+		for _, sc := range generatedScrapeConfigs.cfgs {
+			if err := nodeLocalScrapeConfigs.addConfigs(addNodeLocalRelabeling(sc)); err != nil {
+				return nil, err
+			}
+		}
+		generatedScrapeConfigs = nodeLocalScrapeConfigs
+	}
+
+	var additionalScrapeConfigsYaml []yaml.MapSlice
+	err = yaml.Unmarshal([]byte(additionalScrapeConfigs), &additionalScrapeConfigsYaml)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshalling additional scrape configs failed")
+	}
+
+	if err := generatedScrapeConfigs.addConfigs(additionalScrapeConfigsYaml...); err != nil {
+		return nil, err
+	}
+	cfg = append(cfg, generatedScrapeConfigs.mapItem())
+
+	if len(p.Spec.RemoteWrite) > 0 {
+		cfg = append(cfg, cg.generateRemoteWriteConfig(version, p, store))
+	}
+
+	return generatedScrapeConfigs.marshal(cfg)
+}
+
+// addNodeLocalRelabeling returns a copy of the scrape configuration with a
+// relabeling rule which keeps only the targets running on the node given by
+// the NODE_NAME environment variable of the config-reloader. The given
+// configuration isn't modified since it may be cached.
+func addNodeLocalRelabeling(cfg yaml.MapSlice) yaml.MapSlice {
+	out := make(yaml.MapSlice, len(cfg))
+	copy(out, cfg)
+
+	for i, item := range out {
+		if item.Key != "relabel_configs" {
+			continue
+		}
+
+		relabelings, ok := item.Value.([]yaml.MapSlice)
+		if !ok {
+			continue
+		}
+
+		out[i].Value = append(relabelings[:len(relabelings):len(relabelings)], yaml.MapSlice{
+			{Key: "source_labels", Value: []string{"__meta_kubernetes_pod_node_name"}},
+			{Key: "regex", Value: "$(NODE_NAME)"},
+			{Key: "action", Value: "keep"},
+		})
+	}
+
+	return out
+}
+
+// generateScrapeConfigs returns the scrape configurations of the given
+// ServiceMonitors, PodMonitors, Probes and ScrapeConfigs.
+func (cg *ConfigGenerator) generateScrapeConfigs(
+	version semver.Version,
+	p *v1.Prometheus,
+	sMons map[string]*v1.ServiceMonitor,
+	pMons map[string]*v1.PodMonitor,
+	probes map[string]*v1.Probe,
+	scrapeConfigs map[string]*v1alpha1.ScrapeConfig,
+	store *assets.Store,
+) (*scrapeConfigList, error) {
 	sMonIdentifiers := make([]string, len(sMons))
 	i := 0
 	for k := range sMons {
@@ -314,36 +563,125 @@ func (cg *ConfigGenerator) GenerateConfig(
 		shards = *p.Spec.Shards
 	}
 
-	var scrapeConfigs []yaml.MapSlice
+	cache := cg.scrapeConfigCache.begin(version, p, shards, cg.credentialsAsFiles, store, cg.httpRouteTargets)
+	defer cache.commit()
+
+	cfgs := &scrapeConfigList{}
 	for _, identifier := range sMonIdentifiers {
-		for i, ep := range sMons[identifier].Spec.Endpoints {
-			scrapeConfigs = append(scrapeConfigs,
-				cg.generateServiceMonitorConfig(
-					version,
-					sMons[identifier],
-					ep, i,
-					apiserverConfig,
-					store,
-					p.Spec.OverrideHonorLabels,
-					p.Spec.OverrideHonorTimestamps,
-					p.Spec.IgnoreNamespaceSelectors,
-					p.Spec.EnforcedNamespaceLabel,
-					p.Spec.EnforcedSampleLimit,
-					p.Spec.EnforcedTargetLimit,
-					p.Spec.EnforcedLabelLimit,
-					p.Spec.EnforcedLabelNameLengthLimit,
-					p.Spec.EnforcedLabelValueLengthLimit,
-					shards,
-				),
+		m := sMons[identifier]
+		// Monitors selecting an unknown scrape class have been rejected
+		// already.
+		scrapeClass, _ := getScrapeClass(p, m.Spec.ScrapeClassName)
+
+		var keys []string
+		for i := range m.Spec.Endpoints {
+			keys = append(keys,
+				fmt.Sprintf("serviceMonitor/%s/%s/%d", m.Namespace, m.Name, i),
+				fmt.Sprintf("serviceMonitor/auth/%s/%s/%d", m.Namespace, m.Name, i),
 			)
 		}
+		if scrapeClass != nil {
+			keys = append(keys, scrapeClassAuthKey(scrapeClass))
+		}
+
+		e, err := cache.get(v1.ServiceMonitorsKind, m, keys, func() []yaml.MapSlice {
+			var cfgs []yaml.MapSlice
+			for i, ep := range m.Spec.Endpoints {
+				cfgs = append(cfgs,
+					cg.generateServiceMonitorConfig(
+						version,
+						m,
+						ep, i,
+						apiserverConfig,
+						store,
+						p.Spec.OverrideHonorLabels,
+						p.Spec.OverrideHonorTimestamps,
+						p.Spec.IgnoreNamespaceSelectors,
+						p.Spec.EnforcedNamespaceLabel,
+						p.Spec.EnforcedSampleLimit,
+						p.Spec.EnforcedTargetLimit,
+						p.Spec.EnforcedLabelLimit,
+						p.Spec.EnforcedLabelNameLengthLimit,
+						p.Spec.EnforcedLabelValueLengthLimit,
+						shards,
+						scrapeClass,
+						p.Namespace,
+						p.Spec.ScrapeOptions,
+					),
+				)
+			}
+			return cfgs
+		})
+		if err != nil {
+			return nil, err
+		}
+		cfgs.add(e)
 	}
 	for _, identifier := range pMonIdentifiers {
-		for i, ep := range pMons[identifier].Spec.PodMetricsEndpoints {
-			scrapeConfigs = append(scrapeConfigs,
-				cg.generatePodMonitorConfig(
+		m := pMons[identifier]
+		scrapeClass, _ := getScrapeClass(p, m.Spec.ScrapeClassName)
+
+		var keys []string
+		for i := range m.Spec.PodMetricsEndpoints {
+			keys = append(keys,
+				fmt.Sprintf("podMonitor/%s/%s/%d", m.Namespace, m.Name, i),
+				fmt.Sprintf("podMonitor/auth/%s/%s/%d", m.Namespace, m.Name, i),
+			)
+		}
+		if scrapeClass != nil {
+			keys = append(keys, scrapeClassAuthKey(scrapeClass))
+		}
+
+		e, err := cache.get(v1.PodMonitorsKind, m, keys, func() []yaml.MapSlice {
+			var cfgs []yaml.MapSlice
+			for i, ep := range m.Spec.PodMetricsEndpoints {
+				cfgs = append(cfgs,
+					cg.generatePodMonitorConfig(
+						version,
+						m, ep, i,
+						apiserverConfig,
+						store,
+						p.Spec.OverrideHonorLabels,
+						p.Spec.OverrideHonorTimestamps,
+						p.Spec.IgnoreNamespaceSelectors,
+						p.Spec.EnforcedNamespaceLabel,
+						p.Spec.EnforcedSampleLimit,
+						p.Spec.EnforcedTargetLimit,
+						p.Spec.EnforcedLabelLimit,
+						p.Spec.EnforcedLabelNameLengthLimit,
+						p.Spec.EnforcedLabelValueLengthLimit,
+						shards,
+						scrapeClass,
+						p.Namespace,
+						p.Spec.ScrapeOptions,
+					),
+				)
+			}
+			return cfgs
+		})
+		if err != nil {
+			return nil, err
+		}
+		cfgs.add(e)
+	}
+
+	for _, identifier := range probeIdentifiers {
+		m := probes[identifier]
+		scrapeClass, _ := getScrapeClass(p, m.Spec.ScrapeClassName)
+
+		keys := []string{
+			fmt.Sprintf("probe/%s/%s", m.Namespace, m.Name),
+			fmt.Sprintf("probe/auth/%s/%s", m.Namespace, m.Name),
+		}
+		if scrapeClass != nil {
+			keys = append(keys, scrapeClassAuthKey(scrapeClass))
+		}
+
+		e, err := cache.get(v1.ProbesKind, m, keys, func() []yaml.MapSlice {
+			return []yaml.MapSlice{
+				cg.generateProbeConfig(
 					version,
-					pMons[identifier], ep, i,
+					m,
 					apiserverConfig,
 					store,
 					p.Spec.OverrideHonorLabels,
@@ -355,105 +693,49 @@ func (cg *ConfigGenerator) GenerateConfig(
 					p.Spec.EnforcedLabelLimit,
 					p.Spec.EnforcedLabelNameLengthLimit,
 					p.Spec.EnforcedLabelValueLengthLimit,
-					shards,
+					scrapeClass,
+					p.Namespace,
+					p.Spec.ScrapeOptions,
 				),
-			)
+			}
+		})
+		if err != nil {
+			return nil, err
 		}
+		cfgs.add(e)
 	}
 
-	for _, identifier := range probeIdentifiers {
-		scrapeConfigs = append(scrapeConfigs,
-			cg.generateProbeConfig(
+	scrapeConfigIdentifiers := make([]string, 0, len(scrapeConfigs))
+	for k := range scrapeConfigs {
+		scrapeConfigIdentifiers = append(scrapeConfigIdentifiers, k)
+	}
+
+	// Sorting ensures, that we always generate the config in the same order.
+	sort.Strings(scrapeConfigIdentifiers)
+
+	for _, identifier := range scrapeConfigIdentifiers {
+		err := cfgs.addConfigs(
+			cg.generateScrapeConfig(
 				version,
-				probes[identifier],
-				apiserverConfig,
+				scrapeConfigs[identifier],
 				store,
 				p.Spec.OverrideHonorLabels,
 				p.Spec.OverrideHonorTimestamps,
-				p.Spec.IgnoreNamespaceSelectors,
 				p.Spec.EnforcedNamespaceLabel,
 				p.Spec.EnforcedSampleLimit,
 				p.Spec.EnforcedTargetLimit,
 				p.Spec.EnforcedLabelLimit,
 				p.Spec.EnforcedLabelNameLengthLimit,
 				p.Spec.EnforcedLabelValueLengthLimit,
+				shards,
 			),
 		)
-	}
-
-	var alertmanagerConfigs []yaml.MapSlice
-	alertmanagerConfigs = cg.generateAlertmanagerConfig(version, p.Spec.Alerting, apiserverConfig, store)
-
-	var additionalScrapeConfigsYaml []yaml.MapSlice
-	err = yaml.Unmarshal([]byte(additionalScrapeConfigs), &additionalScrapeConfigsYaml)
-	if err != nil {
-		return nil, errors.Wrap(err, "unmarshalling additional scrape configs failed")
-	}
-
-	cfg = append(cfg, yaml.MapItem{
-		Key:   "scrape_configs",
-		Value: append(scrapeConfigs, additionalScrapeConfigsYaml...),
-	})
-
-	var additionalAlertManagerConfigsYaml []yaml.MapSlice
-	err = yaml.Unmarshal([]byte(additionalAlertManagerConfigs), &additionalAlertManagerConfigsYaml)
-	if err != nil {
-		return nil, errors.Wrap(err, "unmarshalling additional alert manager configs failed")
-	}
-
-	alertmanagerConfigs = append(alertmanagerConfigs, additionalAlertManagerConfigsYaml...)
-
-	var alertRelabelConfigs []yaml.MapSlice
-
-	// Use defaultReplicaExternalLabelName constant by default if field is missing.
-	// Do not add external label if field is set to empty string.
-	replicaExternalLabelName := defaultReplicaExternalLabelName
-	if p.Spec.ReplicaExternalLabelName != nil {
-		if *p.Spec.ReplicaExternalLabelName != "" {
-			replicaExternalLabelName = *p.Spec.ReplicaExternalLabelName
-		} else {
-			replicaExternalLabelName = ""
+		if err != nil {
+			return nil, err
 		}
 	}
 
-	// action 'labeldrop' is not supported <= v1.4.1
-	if replicaExternalLabelName != "" && version.GT(semver.MustParse("1.4.1")) {
-		// Drop replica label, to make alerts from multiple Prometheus replicas alike
-		alertRelabelConfigs = append(alertRelabelConfigs, yaml.MapSlice{
-			{Key: "action", Value: "labeldrop"},
-			{Key: "regex", Value: regexp.QuoteMeta(replicaExternalLabelName)},
-		})
-	}
-
-	var additionalAlertRelabelConfigsYaml []yaml.MapSlice
-	err = yaml.Unmarshal([]byte(additionalAlertRelabelConfigs), &additionalAlertRelabelConfigsYaml)
-	if err != nil {
-		return nil, errors.Wrap(err, "unmarshalling additional alerting relabel configs failed")
-	}
-
-	cfg = append(cfg, yaml.MapItem{
-		Key: "alerting",
-		Value: yaml.MapSlice{
-			{
-				Key:   "alert_relabel_configs",
-				Value: append(alertRelabelConfigs, additionalAlertRelabelConfigsYaml...),
-			},
-			{
-				Key:   "alertmanagers",
-				Value: alertmanagerConfigs,
-			},
-		},
-	})
-
-	if len(p.Spec.RemoteWrite) > 0 {
-		cfg = append(cfg, cg.generateRemoteWriteConfig(version, p, store))
-	}
-
-	if len(p.Spec.RemoteRead) > 0 {
-		cfg = append(cfg, cg.generateRemoteReadConfig(version, p, store))
-	}
-
-	return yaml.Marshal(cfg)
+	return cfgs, nil
 }
 
 // honorLabels determines the value of honor_labels.
@@ -510,6 +792,9 @@ func (cg *ConfigGenerator) generatePodMonitorConfig(
 	enforcedLabelNameLengthLimit *uint64,
 	enforcedLabelValueLengthLimit *uint64,
 	shards int32,
+	scrapeClass *v1.ScrapeClass,
+	scrapeClassNamespace string,
+	defaultScrapeOptions v1.ScrapeOptions,
 ) yaml.MapSlice {
 	hl := honorLabels(ep.HonorLabels, ignoreHonorLabels)
 	cfg := yaml.MapSlice{
@@ -551,28 +836,22 @@ func (cg *ConfigGenerator) generatePodMonitorConfig(
 	if ep.TLSConfig != nil {
 		cfg = addSafeTLStoYaml(cfg, m.Namespace, ep.TLSConfig.SafeTLSConfig)
 	}
+	cfg = addScrapeClassTLSToYaml(cfg, scrapeClassNamespace, scrapeClass)
 
 	if ep.BearerTokenSecret.Name != "" {
-		if s, ok := store.TokenAssets[fmt.Sprintf("podMonitor/%s/%s/%d", m.Namespace, m.Name, i)]; ok {
-			cfg = append(cfg, yaml.MapItem{Key: "bearer_token", Value: s})
-		}
+		cfg = cg.addBearerTokenToYaml(cfg, fmt.Sprintf("podMonitor/%s/%s/%d", m.Namespace, m.Name, i), store)
 	}
 
-	if ep.BasicAuth != nil {
-		if s, ok := store.BasicAuthAssets[fmt.Sprintf("podMonitor/%s/%s/%d", m.Namespace, m.Name, i)]; ok {
-			cfg = append(cfg, yaml.MapItem{
-				Key: "basic_auth", Value: yaml.MapSlice{
-					{Key: "username", Value: s.Username},
-					{Key: "password", Value: s.Password},
-				},
-			})
-		}
-	}
+	cfg = cg.addBasicAuthToYaml(cfg, fmt.Sprintf("podMonitor/%s/%s/%d", m.Namespace, m.Name, i), store, ep.BasicAuth)
 
 	assetKey := fmt.Sprintf("podMonitor/%s/%s/%d", m.Namespace, m.Name, i)
-	cfg = addOAuth2ToYaml(cfg, version, ep.OAuth2, store.OAuth2Assets, assetKey)
+	cfg = cg.addOAuth2ToYaml(cfg, version, ep.OAuth2, store.OAuth2Assets, assetKey)
+
+	cfg = cg.addSafeAuthorizationToYaml(cfg, version, fmt.Sprintf("podMonitor/auth/%s/%s/%d", m.Namespace, m.Name, i), store, ep.Authorization)
 
-	cfg = addSafeAuthorizationToYaml(cfg, version, fmt.Sprintf("podMonitor/auth/%s/%s/%d", m.Namespace, m.Name, i), store, ep.Authorization, cg.logger)
+	if ep.BearerTokenSecret.Name == "" && ep.BasicAuth == nil && ep.OAuth2 == nil && ep.Authorization == nil {
+		cfg = cg.addScrapeClassAuthorizationToYaml(cfg, version, store, scrapeClass)
+	}
 
 	relabelings := initRelabelings()
 
@@ -705,6 +984,8 @@ func (cg *ConfigGenerator) generatePodMonitorConfig(
 		})
 	}
 
+	relabelings = appendScrapeClassRelabelings(relabelings, scrapeClass)
+
 	if ep.RelabelConfigs != nil {
 		for _, c := range ep.RelabelConfigs {
 			relabelings = append(relabelings, generateRelabelConfig(c))
@@ -757,22 +1038,79 @@ func (cg *ConfigGenerator) generatePodMonitorConfig(
 		}
 	}
 
-	if ep.MetricRelabelConfigs != nil {
-		var metricRelabelings []yaml.MapSlice
-		for _, c := range ep.MetricRelabelConfigs {
-			if c.TargetLabel != "" && enforcedNamespaceLabel != "" && c.TargetLabel == enforcedNamespaceLabel {
-				continue
-			}
-			relabeling := generateRelabelConfig(c)
+	cfg = cg.addScrapeOptions(cfg, version, ep.ScrapeOptions, defaultScrapeOptions, "PodMonitor", m.Name)
+
+	if ep.MetricRelabelConfigs != nil || (scrapeClass != nil && len(scrapeClass.MetricRelabelings) > 0) {
+		cfg = append(cfg, yaml.MapItem{Key: "metric_relabel_configs", Value: generateMetricRelabelings(scrapeClass, ep.MetricRelabelConfigs, enforcedNamespaceLabel)})
+	}
+
+	return cfg
+}
 
-			metricRelabelings = append(metricRelabelings, relabeling)
+// addScrapeOptions adds the scrape protocol and native histogram settings to
+// the scrape configuration. The settings of the Prometheus resource apply
+// when the monitor doesn't define them, except the scrape protocols which
+// are set in the global section.
+func (cg *ConfigGenerator) addScrapeOptions(cfg yaml.MapSlice, version semver.Version, opts, defaults v1.ScrapeOptions, kind, name string) yaml.MapSlice {
+	logKey := strings.ToLower(kind[:1]) + kind[1:]
+
+	if len(opts.ScrapeProtocols) > 0 {
+		if version.GTE(semver.MustParse("2.49.0")) {
+			cfg = append(cfg, yaml.MapItem{Key: "scrape_protocols", Value: opts.ScrapeProtocols})
+		} else {
+			level.Warn(cg.logger).Log("msg", fmt.Sprintf("%s 'scrapeProtocols' is only available from prometheus 2.49.", kind), logKey, name)
+		}
+	}
+
+	scrapeClassicHistograms := opts.ScrapeClassicHistograms
+	if scrapeClassicHistograms == nil {
+		scrapeClassicHistograms = defaults.ScrapeClassicHistograms
+	}
+	if scrapeClassicHistograms != nil {
+		if version.GTE(semver.MustParse("2.45.0")) {
+			cfg = append(cfg, yaml.MapItem{Key: "scrape_classic_histograms", Value: *scrapeClassicHistograms})
+		} else {
+			level.Warn(cg.logger).Log("msg", fmt.Sprintf("%s 'scrapeClassicHistograms' is only available from prometheus 2.45.", kind), logKey, name)
+		}
+	}
+
+	nativeHistogramBucketLimit := opts.NativeHistogramBucketLimit
+	if nativeHistogramBucketLimit == nil {
+		nativeHistogramBucketLimit = defaults.NativeHistogramBucketLimit
+	}
+	if nativeHistogramBucketLimit != nil {
+		if version.GTE(semver.MustParse("2.45.0")) {
+			cfg = append(cfg, yaml.MapItem{Key: "native_histogram_bucket_limit", Value: *nativeHistogramBucketLimit})
+		} else {
+			level.Warn(cg.logger).Log("msg", fmt.Sprintf("%s 'nativeHistogramBucketLimit' is only available from prometheus 2.45.", kind), logKey, name)
+		}
+	}
+
+	enableCompression := opts.EnableCompression
+	if enableCompression == nil {
+		enableCompression = defaults.EnableCompression
+	}
+	if enableCompression != nil {
+		if version.GTE(semver.MustParse("2.49.0")) {
+			cfg = append(cfg, yaml.MapItem{Key: "enable_compression", Value: *enableCompression})
+		} else {
+			level.Warn(cg.logger).Log("msg", fmt.Sprintf("%s 'enableCompression' is only available from prometheus 2.49.", kind), logKey, name)
 		}
-		cfg = append(cfg, yaml.MapItem{Key: "metric_relabel_configs", Value: metricRelabelings})
 	}
 
 	return cfg
 }
 
+// proberAddress returns the address of the prober used by the probe. A prober
+// referenced by name resolves to the service of the Prober object deployed
+// in the namespace of the probe.
+func proberAddress(m *v1.Probe) string {
+	if m.Spec.ProberSpec.Name != "" {
+		return prober.ServiceAddress(m.Spec.ProberSpec.Name, m.Namespace)
+	}
+	return m.Spec.ProberSpec.URL
+}
+
 func (cg *ConfigGenerator) generateProbeConfig(
 	version semver.Version,
 	m *v1.Probe,
@@ -786,7 +1124,10 @@ func (cg *ConfigGenerator) generateProbeConfig(
 	enforcedTargetLimit *uint64,
 	enforcedLabelLimit *uint64,
 	enforcedLabelNameLengthLimit *uint64,
-	enforcedLabelValueLengthLimit *uint64) yaml.MapSlice {
+	enforcedLabelValueLengthLimit *uint64,
+	scrapeClass *v1.ScrapeClass,
+	scrapeClassNamespace string,
+	defaultScrapeOptions v1.ScrapeOptions) yaml.MapSlice {
 
 	jobName := fmt.Sprintf("probe/%s/%s", m.Namespace, m.Name)
 	cfg := yaml.MapSlice{
@@ -864,6 +1205,8 @@ func (cg *ConfigGenerator) generateProbeConfig(
 		}
 	}
 
+	cfg = cg.addScrapeOptions(cfg, version, m.Spec.ScrapeOptions, defaultScrapeOptions, "Probe", m.Name)
+
 	relabelings := initRelabelings()
 
 	if m.Spec.JobName != "" {
@@ -909,10 +1252,12 @@ func (cg *ConfigGenerator) generateProbeConfig(
 			},
 			{
 				{Key: "target_label", Value: "__address__"},
-				{Key: "replacement", Value: m.Spec.ProberSpec.URL},
+				{Key: "replacement", Value: proberAddress(m)},
 			},
 		}...)
 
+		relabelings = appendScrapeClassRelabelings(relabelings, scrapeClass)
+
 		// Add configured relabelings.
 		if m.Spec.Targets.StaticConfig.RelabelConfigs != nil {
 			for _, r := range m.Spec.Targets.StaticConfig.RelabelConfigs {
@@ -924,54 +1269,9 @@ func (cg *ConfigGenerator) generateProbeConfig(
 	}
 
 	// Generate kubernetes_sd_config section for ingress resources.
-	if m.Spec.Targets.StaticConfig == nil {
-		labelKeys := make([]string, 0, len(m.Spec.Targets.Ingress.Selector.MatchLabels))
-
+	if m.Spec.Targets.StaticConfig == nil && m.Spec.Targets.Ingress != nil {
 		// Filter targets by ingresses selected by the monitor.
-		// Exact label matches.
-		for k := range m.Spec.Targets.Ingress.Selector.MatchLabels {
-			labelKeys = append(labelKeys, k)
-		}
-		sort.Strings(labelKeys)
-
-		for _, k := range labelKeys {
-			relabelings = append(relabelings, yaml.MapSlice{
-				{Key: "action", Value: "keep"},
-				{Key: "source_labels", Value: []string{"__meta_kubernetes_ingress_label_" + sanitizeLabelName(k)}},
-				{Key: "regex", Value: m.Spec.Targets.Ingress.Selector.MatchLabels[k]},
-			})
-		}
-
-		// Set based label matching. We have to map the valid relations
-		// `In`, `NotIn`, `Exists`, and `DoesNotExist`, into relabeling rules.
-		for _, exp := range m.Spec.Targets.Ingress.Selector.MatchExpressions {
-			switch exp.Operator {
-			case metav1.LabelSelectorOpIn:
-				relabelings = append(relabelings, yaml.MapSlice{
-					{Key: "action", Value: "keep"},
-					{Key: "source_labels", Value: []string{"__meta_kubernetes_ingress_label_" + sanitizeLabelName(exp.Key)}},
-					{Key: "regex", Value: strings.Join(exp.Values, "|")},
-				})
-			case metav1.LabelSelectorOpNotIn:
-				relabelings = append(relabelings, yaml.MapSlice{
-					{Key: "action", Value: "drop"},
-					{Key: "source_labels", Value: []string{"__meta_kubernetes_ingress_label_" + sanitizeLabelName(exp.Key)}},
-					{Key: "regex", Value: strings.Join(exp.Values, "|")},
-				})
-			case metav1.LabelSelectorOpExists:
-				relabelings = append(relabelings, yaml.MapSlice{
-					{Key: "action", Value: "keep"},
-					{Key: "source_labels", Value: []string{"__meta_kubernetes_ingress_labelpresent_" + sanitizeLabelName(exp.Key)}},
-					{Key: "regex", Value: "true"},
-				})
-			case metav1.LabelSelectorOpDoesNotExist:
-				relabelings = append(relabelings, yaml.MapSlice{
-					{Key: "action", Value: "drop"},
-					{Key: "source_labels", Value: []string{"__meta_kubernetes_ingress_labelpresent_" + sanitizeLabelName(exp.Key)}},
-					{Key: "regex", Value: "true"},
-				})
-			}
-		}
+		relabelings = append(relabelings, generateSelectorRelabelings(m.Spec.Targets.Ingress.Selector, kubernetesSDRoleIngress)...)
 
 		selectedNamespaces := getNamespacesFromNamespaceSelector(&m.Spec.Targets.Ingress.NamespaceSelector, m.Namespace, ignoreNamespaceSelectors)
 		cfg = append(cfg, cg.generateK8SSDConfig(version, selectedNamespaces, apiserverConfig, store, kubernetesSDRoleIngress))
@@ -1004,10 +1304,12 @@ func (cg *ConfigGenerator) generateProbeConfig(
 			},
 			{
 				{Key: "target_label", Value: "__address__"},
-				{Key: "replacement", Value: m.Spec.ProberSpec.URL},
+				{Key: "replacement", Value: proberAddress(m)},
 			},
 		}...)
 
+		relabelings = appendScrapeClassRelabelings(relabelings, scrapeClass)
+
 		// Add configured relabelings.
 		if m.Spec.Targets.Ingress.RelabelConfigs != nil {
 			for _, r := range m.Spec.Targets.Ingress.RelabelConfigs {
@@ -1020,44 +1322,132 @@ func (cg *ConfigGenerator) generateProbeConfig(
 
 	}
 
-	if m.Spec.TLSConfig != nil {
-		cfg = addSafeTLStoYaml(cfg, m.Namespace, m.Spec.TLSConfig.SafeTLSConfig)
-	}
+	// Generate kubernetes_sd_config section for service resources.
+	if m.Spec.Targets.StaticConfig == nil && m.Spec.Targets.Ingress == nil && m.Spec.Targets.Service != nil {
+		svc := m.Spec.Targets.Service
 
-	if m.Spec.BearerTokenSecret.Name != "" {
-		pnKey := fmt.Sprintf("probe/%s/%s", m.GetNamespace(), m.GetName())
-		if s, ok := store.TokenAssets[pnKey]; ok {
-			cfg = append(cfg, yaml.MapItem{Key: "bearer_token", Value: s})
+		// Filter targets by services selected by the monitor.
+		relabelings = append(relabelings, generateSelectorRelabelings(svc.Selector, kubernetesSDRoleService)...)
+
+		if svc.Port != "" {
+			relabelings = append(relabelings, yaml.MapSlice{
+				{Key: "action", Value: "keep"},
+				{Key: "source_labels", Value: []string{"__meta_kubernetes_service_port_name"}},
+				{Key: "regex", Value: svc.Port},
+			})
+		}
+
+		selectedNamespaces := getNamespacesFromNamespaceSelector(&svc.NamespaceSelector, m.Namespace, ignoreNamespaceSelectors)
+		cfg = append(cfg, cg.generateK8SSDConfig(version, selectedNamespaces, apiserverConfig, store, kubernetesSDRoleService))
+
+		scheme := "http"
+		if svc.Scheme != "" {
+			scheme = svc.Scheme
+		}
+
+		// Relabelings for service SD. The address is the DNS name of the
+		// service followed by the port.
+		relabelings = append(relabelings, []yaml.MapSlice{
+			{
+				{Key: "source_labels", Value: []string{"__address__"}},
+				{Key: "target_label", Value: "__param_target"},
+				{Key: "replacement", Value: fmt.Sprintf("%s://${1}%s", scheme, svc.Path)},
+			},
+			{
+				{Key: "source_labels", Value: []string{"__meta_kubernetes_namespace"}},
+				{Key: "target_label", Value: "namespace"},
+			},
+			{
+				{Key: "source_labels", Value: []string{"__meta_kubernetes_service_name"}},
+				{Key: "target_label", Value: "service"},
+			},
+		}...)
+
+		// Relabelings for prober.
+		relabelings = append(relabelings, []yaml.MapSlice{
+			{
+				{Key: "source_labels", Value: []string{"__param_target"}},
+				{Key: "target_label", Value: "instance"},
+			},
+			{
+				{Key: "target_label", Value: "__address__"},
+				{Key: "replacement", Value: proberAddress(m)},
+			},
+		}...)
+
+		relabelings = appendScrapeClassRelabelings(relabelings, scrapeClass)
+
+		// Add configured relabelings.
+		for _, r := range svc.RelabelConfigs {
+			relabelings = append(relabelings, generateRelabelConfig(r))
 		}
+
+		relabelings = enforceNamespaceLabel(relabelings, m.Namespace, enforcedNamespaceLabel)
+		cfg = append(cfg, yaml.MapItem{Key: "relabel_configs", Value: relabelings})
 	}
 
-	if m.Spec.BasicAuth != nil {
-		if s, ok := store.BasicAuthAssets[fmt.Sprintf("probe/%s/%s", m.Namespace, m.Name)]; ok {
-			cfg = append(cfg, yaml.MapItem{
-				Key: "basic_auth", Value: yaml.MapSlice{
-					{Key: "username", Value: s.Username},
-					{Key: "password", Value: s.Password},
-				},
+	// Generate static_configs section for HTTPRoute resources. Prometheus
+	// can't discover HTTPRoutes hence the targets are resolved by the
+	// operator.
+	if m.Spec.Targets.StaticConfig == nil && m.Spec.Targets.Ingress == nil && m.Spec.Targets.Service == nil && m.Spec.Targets.HTTPRoute != nil {
+		var staticConfigs []yaml.MapSlice
+		for _, tg := range cg.httpRouteTargets[fmt.Sprintf("probe/%s/%s", m.Namespace, m.Name)] {
+			staticConfigs = append(staticConfigs, yaml.MapSlice{
+				{Key: "targets", Value: tg.Targets},
+				{Key: "labels", Value: stringMapToMapSlice(tg.Labels)},
 			})
 		}
+		cfg = append(cfg, yaml.MapItem{Key: "static_configs", Value: staticConfigs})
+
+		// Relabelings for prober.
+		relabelings = append(relabelings, []yaml.MapSlice{
+			{
+				{Key: "source_labels", Value: []string{"__address__"}},
+				{Key: "target_label", Value: "__param_target"},
+			},
+			{
+				{Key: "source_labels", Value: []string{"__param_target"}},
+				{Key: "target_label", Value: "instance"},
+			},
+			{
+				{Key: "target_label", Value: "__address__"},
+				{Key: "replacement", Value: proberAddress(m)},
+			},
+		}...)
+
+		relabelings = appendScrapeClassRelabelings(relabelings, scrapeClass)
+
+		// Add configured relabelings.
+		for _, r := range m.Spec.Targets.HTTPRoute.RelabelConfigs {
+			relabelings = append(relabelings, generateRelabelConfig(r))
+		}
+
+		relabelings = enforceNamespaceLabel(relabelings, m.Namespace, enforcedNamespaceLabel)
+		cfg = append(cfg, yaml.MapItem{Key: "relabel_configs", Value: relabelings})
 	}
 
-	assetKey := fmt.Sprintf("probe/%s/%s", m.Namespace, m.Name)
-	cfg = addOAuth2ToYaml(cfg, version, m.Spec.OAuth2, store.OAuth2Assets, assetKey)
+	if m.Spec.TLSConfig != nil {
+		cfg = addSafeTLStoYaml(cfg, m.Namespace, m.Spec.TLSConfig.SafeTLSConfig)
+	}
+	cfg = addScrapeClassTLSToYaml(cfg, scrapeClassNamespace, scrapeClass)
+
+	if m.Spec.BearerTokenSecret.Name != "" {
+		cfg = cg.addBearerTokenToYaml(cfg, fmt.Sprintf("probe/%s/%s", m.GetNamespace(), m.GetName()), store)
+	}
 
-	cfg = addSafeAuthorizationToYaml(cfg, version, fmt.Sprintf("probe/auth/%s/%s", m.Namespace, m.Name), store, m.Spec.Authorization, cg.logger)
+	cfg = cg.addBasicAuthToYaml(cfg, fmt.Sprintf("probe/%s/%s", m.Namespace, m.Name), store, m.Spec.BasicAuth)
 
-	if m.Spec.MetricRelabelConfigs != nil {
-		var metricRelabelings []yaml.MapSlice
-		for _, c := range m.Spec.MetricRelabelConfigs {
-			if c.TargetLabel != "" && enforcedNamespaceLabel != "" && c.TargetLabel == enforcedNamespaceLabel {
-				continue
-			}
-			relabeling := generateRelabelConfig(c)
+	assetKey := fmt.Sprintf("probe/%s/%s", m.Namespace, m.Name)
+	cfg = cg.addOAuth2ToYaml(cfg, version, m.Spec.OAuth2, store.OAuth2Assets, assetKey)
 
-			metricRelabelings = append(metricRelabelings, relabeling)
-		}
-		cfg = append(cfg, yaml.MapItem{Key: "metric_relabel_configs", Value: metricRelabelings})
+	cfg = cg.addSafeAuthorizationToYaml(cfg, version, fmt.Sprintf("probe/auth/%s/%s", m.Namespace, m.Name), store, m.Spec.Authorization)
+
+	if m.Spec.BearerTokenSecret.Name == "" && m.Spec.BasicAuth == nil && m.Spec.OAuth2 == nil && m.Spec.Authorization == nil {
+		cfg = cg.addScrapeClassAuthorizationToYaml(cfg, version, store, scrapeClass)
+	}
+
+	if m.Spec.MetricRelabelConfigs != nil || (scrapeClass != nil && len(scrapeClass.MetricRelabelings) > 0) {
+		cfg = append(cfg, yaml.MapItem{Key: "metric_relabel_configs", Value: generateMetricRelabelings(scrapeClass, m.Spec.MetricRelabelConfigs, enforcedNamespaceLabel)})
 	}
 
 	return cfg
@@ -1080,6 +1470,9 @@ func (cg *ConfigGenerator) generateServiceMonitorConfig(
 	enforcedLabelNameLengthLimit *uint64,
 	enforcedLabelValueLengthLimit *uint64,
 	shards int32,
+	scrapeClass *v1.ScrapeClass,
+	scrapeClassNamespace string,
+	defaultScrapeOptions v1.ScrapeOptions,
 ) yaml.MapSlice {
 	hl := honorLabels(ep.HonorLabels, overrideHonorLabels)
 	cfg := yaml.MapSlice{
@@ -1119,32 +1512,28 @@ func (cg *ConfigGenerator) generateServiceMonitorConfig(
 	}
 
 	assetKey := fmt.Sprintf("serviceMonitor/%s/%s/%d", m.Namespace, m.Name, i)
-	cfg = addOAuth2ToYaml(cfg, version, ep.OAuth2, store.OAuth2Assets, assetKey)
+	cfg = cg.addOAuth2ToYaml(cfg, version, ep.OAuth2, store.OAuth2Assets, assetKey)
 
-	cfg = addTLStoYaml(cfg, m.Namespace, ep.TLSConfig)
+	if ep.TLSConfig != nil {
+		cfg = addTLStoYaml(cfg, m.Namespace, ep.TLSConfig)
+	}
+	cfg = addScrapeClassTLSToYaml(cfg, scrapeClassNamespace, scrapeClass)
 
 	if ep.BearerTokenFile != "" {
 		cfg = append(cfg, yaml.MapItem{Key: "bearer_token_file", Value: ep.BearerTokenFile})
 	}
 
 	if ep.BearerTokenSecret.Name != "" {
-		if s, ok := store.TokenAssets[fmt.Sprintf("serviceMonitor/%s/%s/%d", m.Namespace, m.Name, i)]; ok {
-			cfg = append(cfg, yaml.MapItem{Key: "bearer_token", Value: s})
-		}
+		cfg = cg.addBearerTokenToYaml(cfg, fmt.Sprintf("serviceMonitor/%s/%s/%d", m.Namespace, m.Name, i), store)
 	}
 
-	if ep.BasicAuth != nil {
-		if s, ok := store.BasicAuthAssets[fmt.Sprintf("serviceMonitor/%s/%s/%d", m.Namespace, m.Name, i)]; ok {
-			cfg = append(cfg, yaml.MapItem{
-				Key: "basic_auth", Value: yaml.MapSlice{
-					{Key: "username", Value: s.Username},
-					{Key: "password", Value: s.Password},
-				},
-			})
-		}
-	}
+	cfg = cg.addBasicAuthToYaml(cfg, fmt.Sprintf("serviceMonitor/%s/%s/%d", m.Namespace, m.Name, i), store, ep.BasicAuth)
 
-	cfg = addSafeAuthorizationToYaml(cfg, version, fmt.Sprintf("serviceMonitor/auth/%s/%s/%d", m.Namespace, m.Name, i), store, ep.Authorization, cg.logger)
+	cfg = cg.addSafeAuthorizationToYaml(cfg, version, fmt.Sprintf("serviceMonitor/auth/%s/%s/%d", m.Namespace, m.Name, i), store, ep.Authorization)
+
+	if ep.BearerTokenFile == "" && ep.BearerTokenSecret.Name == "" && ep.BasicAuth == nil && ep.OAuth2 == nil && ep.Authorization == nil {
+		cfg = cg.addScrapeClassAuthorizationToYaml(cfg, version, store, scrapeClass)
+	}
 
 	relabelings := initRelabelings()
 
@@ -1303,6 +1692,8 @@ func (cg *ConfigGenerator) generateServiceMonitorConfig(
 		})
 	}
 
+	relabelings = appendScrapeClassRelabelings(relabelings, scrapeClass)
+
 	if ep.RelabelConfigs != nil {
 		for _, c := range ep.RelabelConfigs {
 			relabelings = append(relabelings, generateRelabelConfig(c))
@@ -1355,15 +1746,366 @@ func (cg *ConfigGenerator) generateServiceMonitorConfig(
 		}
 	}
 
-	if ep.MetricRelabelConfigs != nil {
+	cfg = cg.addScrapeOptions(cfg, version, ep.ScrapeOptions, defaultScrapeOptions, "ServiceMonitor", m.Name)
+
+	if ep.MetricRelabelConfigs != nil || (scrapeClass != nil && len(scrapeClass.MetricRelabelings) > 0) {
+		cfg = append(cfg, yaml.MapItem{Key: "metric_relabel_configs", Value: generateMetricRelabelings(scrapeClass, ep.MetricRelabelConfigs, enforcedNamespaceLabel)})
+	}
+
+	return cfg
+}
+
+// scrapeConfigAssetKey returns the key of the assets referenced by the given
+// ScrapeConfig in the store. The suffix identifies the service discovery
+// section, it is empty for the scrape-level assets.
+func scrapeConfigAssetKey(sc *v1alpha1.ScrapeConfig, suffix string) string {
+	key := fmt.Sprintf("scrapeConfig/%s/%s", sc.Namespace, sc.Name)
+	if suffix != "" {
+		key += "/" + suffix
+	}
+	return key
+}
+
+// scrapeConfigAuthKey returns the key of the authorization credentials
+// referenced by the given ScrapeConfig in the store.
+func scrapeConfigAuthKey(sc *v1alpha1.ScrapeConfig, suffix string) string {
+	key := fmt.Sprintf("scrapeConfig/auth/%s/%s", sc.Namespace, sc.Name)
+	if suffix != "" {
+		key += "/" + suffix
+	}
+	return key
+}
+
+func (cg *ConfigGenerator) addBasicAuthToYaml(cfg yaml.MapSlice, assetStoreKey string, store *assets.Store, ba *v1.BasicAuth) yaml.MapSlice {
+	if ba == nil {
+		return cfg
+	}
+
+	s, ok := store.BasicAuthAssets[assetStoreKey]
+	if !ok {
+		return cfg
+	}
+
+	return append(cfg, yaml.MapItem{
+		Key: "basic_auth", Value: yaml.MapSlice{
+			{Key: "username", Value: s.Username},
+			cg.secretItem("password", assetStoreKey, passwordCredential, s.Password),
+		},
+	})
+}
+
+func (cg *ConfigGenerator) addBearerTokenToYaml(cfg yaml.MapSlice, assetStoreKey string, store *assets.Store) yaml.MapSlice {
+	s, ok := store.TokenAssets[assetStoreKey]
+	if !ok {
+		return cfg
+	}
+
+	return append(cfg, cg.secretItem("bearer_token", assetStoreKey, tokenCredential, string(s)))
+}
+
+func (cg *ConfigGenerator) generateScrapeConfig(
+	version semver.Version,
+	sc *v1alpha1.ScrapeConfig,
+	store *assets.Store,
+	overrideHonorLabels bool,
+	overrideHonorTimestamps bool,
+	enforcedNamespaceLabel string,
+	enforcedSampleLimit *uint64,
+	enforcedTargetLimit *uint64,
+	enforcedLabelLimit *uint64,
+	enforcedLabelNameLengthLimit *uint64,
+	enforcedLabelValueLengthLimit *uint64,
+	shards int32,
+) yaml.MapSlice {
+	jobName := fmt.Sprintf("scrapeConfig/%s/%s", sc.Namespace, sc.Name)
+	cfg := yaml.MapSlice{
+		{
+			Key:   "job_name",
+			Value: jobName,
+		},
+	}
+
+	if sc.Spec.HonorLabels != nil {
+		cfg = append(cfg, yaml.MapItem{Key: "honor_labels", Value: honorLabels(*sc.Spec.HonorLabels, overrideHonorLabels)})
+	}
+	cfg = honorTimestamps(cfg, sc.Spec.HonorTimestamps, overrideHonorTimestamps)
+
+	if sc.Spec.MetricsPath != nil {
+		cfg = append(cfg, yaml.MapItem{Key: "metrics_path", Value: *sc.Spec.MetricsPath})
+	}
+	if sc.Spec.ScrapeInterval != "" {
+		cfg = append(cfg, yaml.MapItem{Key: "scrape_interval", Value: sc.Spec.ScrapeInterval})
+	}
+	if sc.Spec.ScrapeTimeout != "" {
+		cfg = append(cfg, yaml.MapItem{Key: "scrape_timeout", Value: sc.Spec.ScrapeTimeout})
+	}
+	if sc.Spec.Params != nil {
+		cfg = append(cfg, yaml.MapItem{Key: "params", Value: sc.Spec.Params})
+	}
+	if sc.Spec.Scheme != nil {
+		cfg = append(cfg, yaml.MapItem{Key: "scheme", Value: strings.ToLower(*sc.Spec.Scheme)})
+	}
+
+	cfg = cg.addBasicAuthToYaml(cfg, scrapeConfigAssetKey(sc, ""), store, sc.Spec.BasicAuth)
+	cfg = cg.addSafeAuthorizationToYaml(cfg, version, scrapeConfigAuthKey(sc, ""), store, sc.Spec.Authorization)
+	if sc.Spec.TLSConfig != nil {
+		cfg = addSafeTLStoYaml(cfg, sc.Namespace, *sc.Spec.TLSConfig)
+	}
+
+	if len(sc.Spec.StaticConfigs) > 0 {
+		configs := make([]yaml.MapSlice, len(sc.Spec.StaticConfigs))
+		for i, config := range sc.Spec.StaticConfigs {
+			configs[i] = yaml.MapSlice{
+				{Key: "targets", Value: config.Targets},
+			}
+			if len(config.Labels) > 0 {
+				configs[i] = append(configs[i], yaml.MapItem{Key: "labels", Value: stringMapToMapSlice(config.Labels)})
+			}
+		}
+		cfg = append(cfg, yaml.MapItem{Key: "static_configs", Value: configs})
+	}
+
+	if len(sc.Spec.FileSDConfigs) > 0 {
+		configs := make([]yaml.MapSlice, len(sc.Spec.FileSDConfigs))
+		for i, config := range sc.Spec.FileSDConfigs {
+			files := make([]string, len(config.Files))
+			for j, f := range config.Files {
+				files[j] = string(f)
+			}
+			configs[i] = yaml.MapSlice{
+				{Key: "files", Value: files},
+			}
+			if config.RefreshInterval != "" {
+				configs[i] = append(configs[i], yaml.MapItem{Key: "refresh_interval", Value: config.RefreshInterval})
+			}
+		}
+		cfg = append(cfg, yaml.MapItem{Key: "file_sd_configs", Value: configs})
+	}
+
+	if len(sc.Spec.HTTPSDConfigs) > 0 {
+		configs := make([]yaml.MapSlice, len(sc.Spec.HTTPSDConfigs))
+		for i, config := range sc.Spec.HTTPSDConfigs {
+			suffix := fmt.Sprintf("httpsdconfig/%d", i)
+			configs[i] = yaml.MapSlice{
+				{Key: "url", Value: config.URL},
+			}
+			if config.RefreshInterval != "" {
+				configs[i] = append(configs[i], yaml.MapItem{Key: "refresh_interval", Value: config.RefreshInterval})
+			}
+			configs[i] = cg.addBasicAuthToYaml(configs[i], scrapeConfigAssetKey(sc, suffix), store, config.BasicAuth)
+			configs[i] = cg.addSafeAuthorizationToYaml(configs[i], version, scrapeConfigAuthKey(sc, suffix), store, config.Authorization)
+			if config.TLSConfig != nil {
+				configs[i] = addSafeTLStoYaml(configs[i], sc.Namespace, *config.TLSConfig)
+			}
+		}
+		cfg = append(cfg, yaml.MapItem{Key: "http_sd_configs", Value: configs})
+	}
+
+	if len(sc.Spec.DNSSDConfigs) > 0 {
+		configs := make([]yaml.MapSlice, len(sc.Spec.DNSSDConfigs))
+		for i, config := range sc.Spec.DNSSDConfigs {
+			configs[i] = yaml.MapSlice{
+				{Key: "names", Value: config.Names},
+			}
+			if config.RefreshInterval != "" {
+				configs[i] = append(configs[i], yaml.MapItem{Key: "refresh_interval", Value: config.RefreshInterval})
+			}
+			if config.Type != nil {
+				configs[i] = append(configs[i], yaml.MapItem{Key: "type", Value: *config.Type})
+			}
+			if config.Port != nil {
+				configs[i] = append(configs[i], yaml.MapItem{Key: "port", Value: *config.Port})
+			}
+		}
+		cfg = append(cfg, yaml.MapItem{Key: "dns_sd_configs", Value: configs})
+	}
+
+	if len(sc.Spec.ConsulSDConfigs) > 0 {
+		configs := make([]yaml.MapSlice, len(sc.Spec.ConsulSDConfigs))
+		for i, config := range sc.Spec.ConsulSDConfigs {
+			suffix := fmt.Sprintf("consulsdconfig/%d", i)
+			configs[i] = yaml.MapSlice{
+				{Key: "server", Value: config.Server},
+			}
+			if config.TokenRef != nil {
+				if s, ok := store.TokenAssets[scrapeConfigAssetKey(sc, suffix+"/token")]; ok {
+					configs[i] = append(configs[i], cg.inlineSecretItem("token", string(s), "scrapeconfig", sc.Namespace+"/"+sc.Name))
+				}
+			}
+			if config.Datacenter != nil {
+				configs[i] = append(configs[i], yaml.MapItem{Key: "datacenter", Value: *config.Datacenter})
+			}
+			if config.Namespace != nil {
+				configs[i] = append(configs[i], yaml.MapItem{Key: "namespace", Value: *config.Namespace})
+			}
+			if config.Partition != nil {
+				configs[i] = append(configs[i], yaml.MapItem{Key: "partition", Value: *config.Partition})
+			}
+			if config.Scheme != nil {
+				configs[i] = append(configs[i], yaml.MapItem{Key: "scheme", Value: strings.ToLower(*config.Scheme)})
+			}
+			if len(config.Services) > 0 {
+				configs[i] = append(configs[i], yaml.MapItem{Key: "services", Value: config.Services})
+			}
+			if len(config.Tags) > 0 {
+				configs[i] = append(configs[i], yaml.MapItem{Key: "tags", Value: config.Tags})
+			}
+			if config.TagSeparator != nil {
+				configs[i] = append(configs[i], yaml.MapItem{Key: "tag_separator", Value: *config.TagSeparator})
+			}
+			if len(config.NodeMeta) > 0 {
+				configs[i] = append(configs[i], yaml.MapItem{Key: "node_meta", Value: stringMapToMapSlice(config.NodeMeta)})
+			}
+			if config.AllowStale != nil {
+				configs[i] = append(configs[i], yaml.MapItem{Key: "allow_stale", Value: *config.AllowStale})
+			}
+			if config.RefreshInterval != "" {
+				configs[i] = append(configs[i], yaml.MapItem{Key: "refresh_interval", Value: config.RefreshInterval})
+			}
+			configs[i] = cg.addBasicAuthToYaml(configs[i], scrapeConfigAssetKey(sc, suffix), store, config.BasicAuth)
+			configs[i] = cg.addSafeAuthorizationToYaml(configs[i], version, scrapeConfigAuthKey(sc, suffix), store, config.Authorization)
+			if config.TLSConfig != nil {
+				configs[i] = addSafeTLStoYaml(configs[i], sc.Namespace, *config.TLSConfig)
+			}
+		}
+		cfg = append(cfg, yaml.MapItem{Key: "consul_sd_configs", Value: configs})
+	}
+
+	if len(sc.Spec.EC2SDConfigs) > 0 {
+		configs := make([]yaml.MapSlice, len(sc.Spec.EC2SDConfigs))
+		for i, config := range sc.Spec.EC2SDConfigs {
+			suffix := fmt.Sprintf("ec2sdconfig/%d", i)
+			configs[i] = yaml.MapSlice{}
+			if config.Region != nil {
+				configs[i] = append(configs[i], yaml.MapItem{Key: "region", Value: *config.Region})
+			}
+			if config.AccessKey != nil {
+				if s, ok := store.TokenAssets[scrapeConfigAssetKey(sc, suffix+"/accesskey")]; ok {
+					configs[i] = append(configs[i], cg.inlineSecretItem("access_key", string(s), "scrapeconfig", sc.Namespace+"/"+sc.Name))
+				}
+			}
+			if config.SecretKey != nil {
+				if s, ok := store.TokenAssets[scrapeConfigAssetKey(sc, suffix+"/secretkey")]; ok {
+					configs[i] = append(configs[i], cg.inlineSecretItem("secret_key", string(s), "scrapeconfig", sc.Namespace+"/"+sc.Name))
+				}
+			}
+			if config.RoleARN != nil {
+				configs[i] = append(configs[i], yaml.MapItem{Key: "role_arn", Value: *config.RoleARN})
+			}
+			if config.RefreshInterval != "" {
+				configs[i] = append(configs[i], yaml.MapItem{Key: "refresh_interval", Value: config.RefreshInterval})
+			}
+			if config.Port != nil {
+				configs[i] = append(configs[i], yaml.MapItem{Key: "port", Value: *config.Port})
+			}
+			if len(config.Filters) > 0 {
+				filters := make([]yaml.MapSlice, len(config.Filters))
+				for j, f := range config.Filters {
+					filters[j] = yaml.MapSlice{
+						{Key: "name", Value: f.Name},
+						{Key: "values", Value: f.Values},
+					}
+				}
+				configs[i] = append(configs[i], yaml.MapItem{Key: "filters", Value: filters})
+			}
+		}
+		cfg = append(cfg, yaml.MapItem{Key: "ec2_sd_configs", Value: configs})
+	}
+
+	if len(sc.Spec.AzureSDConfigs) > 0 {
+		configs := make([]yaml.MapSlice, len(sc.Spec.AzureSDConfigs))
+		for i, config := range sc.Spec.AzureSDConfigs {
+			suffix := fmt.Sprintf("azuresdconfig/%d", i)
+			configs[i] = yaml.MapSlice{}
+			if config.Environment != nil {
+				configs[i] = append(configs[i], yaml.MapItem{Key: "environment", Value: *config.Environment})
+			}
+			if config.AuthenticationMethod != nil {
+				configs[i] = append(configs[i], yaml.MapItem{Key: "authentication_method", Value: *config.AuthenticationMethod})
+			}
+			configs[i] = append(configs[i], yaml.MapItem{Key: "subscription_id", Value: config.SubscriptionID})
+			if config.TenantID != nil {
+				configs[i] = append(configs[i], yaml.MapItem{Key: "tenant_id", Value: *config.TenantID})
+			}
+			if config.ClientID != nil {
+				configs[i] = append(configs[i], yaml.MapItem{Key: "client_id", Value: *config.ClientID})
+			}
+			if config.ClientSecret != nil {
+				if s, ok := store.TokenAssets[scrapeConfigAssetKey(sc, suffix+"/clientsecret")]; ok {
+					configs[i] = append(configs[i], cg.inlineSecretItem("client_secret", string(s), "scrapeconfig", sc.Namespace+"/"+sc.Name))
+				}
+			}
+			if config.ResourceGroup != nil {
+				configs[i] = append(configs[i], yaml.MapItem{Key: "resource_group", Value: *config.ResourceGroup})
+			}
+			if config.RefreshInterval != "" {
+				configs[i] = append(configs[i], yaml.MapItem{Key: "refresh_interval", Value: config.RefreshInterval})
+			}
+			if config.Port != nil {
+				configs[i] = append(configs[i], yaml.MapItem{Key: "port", Value: *config.Port})
+			}
+		}
+		cfg = append(cfg, yaml.MapItem{Key: "azure_sd_configs", Value: configs})
+	}
+
+	relabelings := initRelabelings()
+	for _, c := range sc.Spec.RelabelConfigs {
+		relabelings = append(relabelings, generateRelabelConfig(c))
+	}
+	// Because of security risks, whenever enforcedNamespaceLabel is set, we want to append it to the
+	// relabel_configs as the last relabeling, to ensure it overrides any other relabelings.
+	relabelings = enforceNamespaceLabel(relabelings, sc.Namespace, enforcedNamespaceLabel)
+
+	relabelings = generateAddressShardingRelabelingRules(relabelings, shards)
+	cfg = append(cfg, yaml.MapItem{Key: "relabel_configs", Value: relabelings})
+
+	if sc.Spec.SampleLimit > 0 || enforcedSampleLimit != nil {
+		cfg = append(cfg, yaml.MapItem{Key: "sample_limit", Value: getLimit(sc.Spec.SampleLimit, enforcedSampleLimit)})
+	}
+
+	if sc.Spec.TargetLimit > 0 || enforcedTargetLimit != nil {
+		if version.Major == 2 && version.Minor >= 21 {
+			cfg = append(cfg, yaml.MapItem{Key: "target_limit", Value: getLimit(sc.Spec.TargetLimit, enforcedTargetLimit)})
+		} else {
+			level.Warn(cg.logger).Log("msg", "ScrapeConfig 'targetLimit' is only available from prometheus 2.21.",
+				"scrapeConfig", sc.Name)
+		}
+	}
+
+	if sc.Spec.LabelLimit > 0 || enforcedLabelLimit != nil {
+		if version.Major == 2 && version.Minor >= 27 {
+			cfg = append(cfg, yaml.MapItem{Key: "label_limit", Value: getLimit(sc.Spec.LabelLimit, enforcedLabelLimit)})
+		} else {
+			level.Warn(cg.logger).Log("msg", "ScrapeConfig 'labelLimit' is only available from prometheus 2.27.",
+				"scrapeConfig", sc.Name)
+		}
+	}
+
+	if sc.Spec.LabelNameLengthLimit > 0 || enforcedLabelNameLengthLimit != nil {
+		if version.Major == 2 && version.Minor >= 27 {
+			cfg = append(cfg, yaml.MapItem{Key: "label_name_length_limit", Value: getLimit(sc.Spec.LabelNameLengthLimit, enforcedLabelNameLengthLimit)})
+		} else {
+			level.Warn(cg.logger).Log("msg", "ScrapeConfig 'labelNameLengthLimit' is only available from prometheus 2.27.",
+				"scrapeConfig", sc.Name)
+		}
+	}
+
+	if sc.Spec.LabelValueLengthLimit > 0 || enforcedLabelValueLengthLimit != nil {
+		if version.Major == 2 && version.Minor >= 27 {
+			cfg = append(cfg, yaml.MapItem{Key: "label_value_length_limit", Value: getLimit(sc.Spec.LabelValueLengthLimit, enforcedLabelValueLengthLimit)})
+		} else {
+			level.Warn(cg.logger).Log("msg", "ScrapeConfig 'labelValueLengthLimit' is only available from prometheus 2.27.",
+				"scrapeConfig", sc.Name)
+		}
+	}
+
+	if sc.Spec.MetricRelabelConfigs != nil {
 		var metricRelabelings []yaml.MapSlice
-		for _, c := range ep.MetricRelabelConfigs {
+		for _, c := range sc.Spec.MetricRelabelConfigs {
 			if c.TargetLabel != "" && enforcedNamespaceLabel != "" && c.TargetLabel == enforcedNamespaceLabel {
 				continue
 			}
-			relabeling := generateRelabelConfig(c)
-
-			metricRelabelings = append(metricRelabelings, relabeling)
+			metricRelabelings = append(metricRelabelings, generateRelabelConfig(c))
 		}
 		cfg = append(cfg, yaml.MapItem{Key: "metric_relabel_configs", Value: metricRelabelings})
 	}
@@ -1371,6 +2113,63 @@ func (cg *ConfigGenerator) generateServiceMonitorConfig(
 	return cfg
 }
 
+// generateSelectorRelabelings returns the relabeling rules keeping only the
+// targets whose Kubernetes object matches the label selector. role is the
+// Kubernetes SD role of the targets.
+func generateSelectorRelabelings(selector metav1.LabelSelector, role string) []yaml.MapSlice {
+	var (
+		relabelings []yaml.MapSlice
+		labelKeys   = make([]string, 0, len(selector.MatchLabels))
+	)
+
+	// Exact label matches.
+	for k := range selector.MatchLabels {
+		labelKeys = append(labelKeys, k)
+	}
+	sort.Strings(labelKeys)
+
+	for _, k := range labelKeys {
+		relabelings = append(relabelings, yaml.MapSlice{
+			{Key: "action", Value: "keep"},
+			{Key: "source_labels", Value: []string{"__meta_kubernetes_" + role + "_label_" + sanitizeLabelName(k)}},
+			{Key: "regex", Value: selector.MatchLabels[k]},
+		})
+	}
+
+	// Set based label matching. We have to map the valid relations
+	// `In`, `NotIn`, `Exists`, and `DoesNotExist`, into relabeling rules.
+	for _, exp := range selector.MatchExpressions {
+		switch exp.Operator {
+		case metav1.LabelSelectorOpIn:
+			relabelings = append(relabelings, yaml.MapSlice{
+				{Key: "action", Value: "keep"},
+				{Key: "source_labels", Value: []string{"__meta_kubernetes_" + role + "_label_" + sanitizeLabelName(exp.Key)}},
+				{Key: "regex", Value: strings.Join(exp.Values, "|")},
+			})
+		case metav1.LabelSelectorOpNotIn:
+			relabelings = append(relabelings, yaml.MapSlice{
+				{Key: "action", Value: "drop"},
+				{Key: "source_labels", Value: []string{"__meta_kubernetes_" + role + "_label_" + sanitizeLabelName(exp.Key)}},
+				{Key: "regex", Value: strings.Join(exp.Values, "|")},
+			})
+		case metav1.LabelSelectorOpExists:
+			relabelings = append(relabelings, yaml.MapSlice{
+				{Key: "action", Value: "keep"},
+				{Key: "source_labels", Value: []string{"__meta_kubernetes_" + role + "_labelpresent_" + sanitizeLabelName(exp.Key)}},
+				{Key: "regex", Value: "true"},
+			})
+		case metav1.LabelSelectorOpDoesNotExist:
+			relabelings = append(relabelings, yaml.MapSlice{
+				{Key: "action", Value: "drop"},
+				{Key: "source_labels", Value: []string{"__meta_kubernetes_" + role + "_labelpresent_" + sanitizeLabelName(exp.Key)}},
+				{Key: "regex", Value: "true"},
+			})
+		}
+	}
+
+	return relabelings
+}
+
 func getLimit(user uint64, enforced *uint64) uint64 {
 	if enforced != nil {
 		if user < *enforced && user != 0 || *enforced == 0 {
@@ -1437,6 +2236,16 @@ func generateRelabelConfig(c *v1.RelabelConfig) yaml.MapSlice {
 	return relabeling
 }
 
+// generateRelabelConfigs returns the relabeling configurations of the given
+// RelabelConfigs.
+func generateRelabelConfigs(configs []v1.RelabelConfig) []yaml.MapSlice {
+	relabelings := make([]yaml.MapSlice, 0, len(configs))
+	for i := range configs {
+		relabelings = append(relabelings, generateRelabelConfig(&configs[i]))
+	}
+	return relabelings
+}
+
 // getNamespacesFromNamespaceSelector gets a list of namespaces to select based on
 // the given namespace selector, the given default namespace, and whether to ignore namespace selectors
 func getNamespacesFromNamespaceSelector(nsel *v1.NamespaceSelector, namespace string, ignoreNamespaceSelectors bool) []string {
@@ -1475,16 +2284,7 @@ func (cg *ConfigGenerator) generateK8SSDConfig(version semver.Version, namespace
 			Key: "api_server", Value: apiserverConfig.Host,
 		})
 
-		if apiserverConfig.BasicAuth != nil && store.BasicAuthAssets != nil {
-			if s, ok := store.BasicAuthAssets["apiserver"]; ok {
-				k8sSDConfig = append(k8sSDConfig, yaml.MapItem{
-					Key: "basic_auth", Value: yaml.MapSlice{
-						{Key: "username", Value: s.Username},
-						{Key: "password", Value: s.Password},
-					},
-				})
-			}
-		}
+		k8sSDConfig = cg.addBasicAuthToYaml(k8sSDConfig, "apiserver", store, apiserverConfig.BasicAuth)
 
 		if apiserverConfig.BearerToken != "" {
 			k8sSDConfig = append(k8sSDConfig, yaml.MapItem{Key: "bearer_token", Value: apiserverConfig.BearerToken})
@@ -1494,7 +2294,7 @@ func (cg *ConfigGenerator) generateK8SSDConfig(version semver.Version, namespace
 			k8sSDConfig = append(k8sSDConfig, yaml.MapItem{Key: "bearer_token_file", Value: apiserverConfig.BearerTokenFile})
 		}
 
-		k8sSDConfig = addAuthorizationToYaml(k8sSDConfig, version, "apiserver/auth", store, apiserverConfig.Authorization, cg.logger)
+		k8sSDConfig = cg.addAuthorizationToYaml(k8sSDConfig, version, "apiserver/auth", store, apiserverConfig.Authorization)
 
 		// TODO: If we want to support secret refs for k8s service discovery tls
 		// config as well, make sure to path the right namespace here.
@@ -1509,12 +2309,15 @@ func (cg *ConfigGenerator) generateK8SSDConfig(version semver.Version, namespace
 	}
 }
 
-func (cg *ConfigGenerator) generateAlertmanagerConfig(version semver.Version, alerting *v1.AlertingSpec, apiserverConfig *v1.APIServerConfig, store *assets.Store) []yaml.MapSlice {
+func (cg *ConfigGenerator) generateAlertmanagerConfig(version semver.Version, namespace string, alerting *v1.AlertingSpec, apiserverConfig *v1.APIServerConfig, store *assets.Store) []yaml.MapSlice {
 	var alertmanagerConfigs []yaml.MapSlice
 	if alerting == nil {
 		return alertmanagerConfigs
 	}
 	for i, am := range alerting.Alertmanagers {
+		// When the scheme isn't set, it is discovered from the annotation
+		// of the Alertmanager pods managed by the operator.
+		discoverScheme := am.Scheme == ""
 		if am.Scheme == "" {
 			am.Scheme = "http"
 		}
@@ -1542,7 +2345,7 @@ func (cg *ConfigGenerator) generateAlertmanagerConfig(version semver.Version, al
 			cfg = append(cfg, yaml.MapItem{Key: "bearer_token_file", Value: am.BearerTokenFile})
 		}
 
-		cfg = addSafeAuthorizationToYaml(cfg, version, fmt.Sprintf("alertmanager/auth/%d", i), store, am.Authorization, cg.logger)
+		cfg = cg.addSafeAuthorizationToYaml(cfg, version, fmt.Sprintf("alertmanager/auth/%d", i), store, am.Authorization)
 
 		if version.Major > 2 || (version.Major == 2 && version.Minor >= 11) {
 			if am.APIVersion == "v1" || am.APIVersion == "v2" {
@@ -1572,10 +2375,29 @@ func (cg *ConfigGenerator) generateAlertmanagerConfig(version semver.Version, al
 			})
 		}
 
+		if discoverScheme {
+			relabelings = append(relabelings, yaml.MapSlice{
+				{Key: "action", Value: "replace"},
+				{Key: "source_labels", Value: []string{"__meta_kubernetes_pod_annotation_" + sanitizeLabelName(webconfig.SchemeAnnotation)}},
+				{Key: "regex", Value: "(.+)"},
+				{Key: "target_label", Value: "__scheme__"},
+			})
+		}
+
 		cfg = append(cfg, yaml.MapItem{Key: "relabel_configs", Value: relabelings})
+
+		// The version is checked by validateAlertRelabelConfigs.
+		if len(am.AlertRelabelConfigs) > 0 {
+			cfg = append(cfg, yaml.MapItem{Key: "alert_relabel_configs", Value: generateRelabelConfigs(am.AlertRelabelConfigs)})
+		}
+
 		alertmanagerConfigs = append(alertmanagerConfigs, cfg)
 	}
 
+	for _, am := range cg.alertmanagers {
+		alertmanagerConfigs = append(alertmanagerConfigs, cg.generateSelectedAlertmanagerConfig(version, am, namespace, alerting.AlertmanagerTLSConfig, apiserverConfig, store))
+	}
+
 	return alertmanagerConfigs
 }
 
@@ -1609,16 +2431,7 @@ func (cg *ConfigGenerator) generateRemoteReadConfig(
 			cfg = append(cfg, yaml.MapItem{Key: "read_recent", Value: spec.ReadRecent})
 		}
 
-		if spec.BasicAuth != nil {
-			if s, ok := store.BasicAuthAssets[fmt.Sprintf("remoteRead/%d", i)]; ok {
-				cfg = append(cfg, yaml.MapItem{
-					Key: "basic_auth", Value: yaml.MapSlice{
-						{Key: "username", Value: s.Username},
-						{Key: "password", Value: s.Password},
-					},
-				})
-			}
-		}
+		cfg = cg.addBasicAuthToYaml(cfg, fmt.Sprintf("remoteRead/%d", i), store, spec.BasicAuth)
 
 		if spec.BearerToken != "" {
 			cfg = append(cfg, yaml.MapItem{Key: "bearer_token", Value: spec.BearerToken})
@@ -1628,11 +2441,11 @@ func (cg *ConfigGenerator) generateRemoteReadConfig(
 			cfg = append(cfg, yaml.MapItem{Key: "bearer_token_file", Value: spec.BearerTokenFile})
 		}
 
-		cfg = addOAuth2ToYaml(cfg, version, spec.OAuth2, store.OAuth2Assets, fmt.Sprintf("remoteRead/%d", i))
+		cfg = cg.addOAuth2ToYaml(cfg, version, spec.OAuth2, store.OAuth2Assets, fmt.Sprintf("remoteRead/%d", i))
 
 		cfg = addTLStoYaml(cfg, p.ObjectMeta.Namespace, spec.TLSConfig)
 
-		cfg = addAuthorizationToYaml(cfg, version, fmt.Sprintf("remoteRead/auth/%d", i), store, spec.Authorization, cg.logger)
+		cfg = cg.addAuthorizationToYaml(cfg, version, fmt.Sprintf("remoteRead/auth/%d", i), store, spec.Authorization)
 
 		if spec.ProxyURL != "" {
 			cfg = append(cfg, yaml.MapItem{Key: "proxy_url", Value: spec.ProxyURL})
@@ -1647,7 +2460,7 @@ func (cg *ConfigGenerator) generateRemoteReadConfig(
 	}
 }
 
-func addOAuth2ToYaml(
+func (cg *ConfigGenerator) addOAuth2ToYaml(
 	cfg yaml.MapSlice,
 	version semver.Version,
 	oauth2 *v1.OAuth2,
@@ -1666,7 +2479,7 @@ func addOAuth2ToYaml(
 	oauth2Cfg := yaml.MapSlice{}
 	oauth2Cfg = append(oauth2Cfg,
 		yaml.MapItem{Key: "client_id", Value: tlsAsset.ClientID},
-		yaml.MapItem{Key: "client_secret", Value: tlsAsset.ClientSecret},
+		cg.secretItem("client_secret", assetKey, clientSecretCredential, tlsAsset.ClientSecret),
 		yaml.MapItem{Key: "token_url", Value: oauth2.TokenURL},
 	)
 
@@ -1681,6 +2494,43 @@ func addOAuth2ToYaml(
 	return append(cfg, yaml.MapItem{Key: "oauth2", Value: oauth2Cfg})
 }
 
+func (cg *ConfigGenerator) generateSigv4Config(sigv4 *v1.Sigv4, creds assets.SigV4Credentials, remoteWrite int) yaml.MapSlice {
+	cfg := yaml.MapSlice{}
+
+	if sigv4.Region != "" {
+		cfg = append(cfg, yaml.MapItem{Key: "region", Value: sigv4.Region})
+	}
+	if creds.AccessKeyID != "" {
+		cfg = append(cfg, cg.inlineSecretItem("access_key", creds.AccessKeyID, "remoteWrite", remoteWrite))
+	}
+	if creds.SecretKeyID != "" {
+		cfg = append(cfg, cg.inlineSecretItem("secret_key", creds.SecretKeyID, "remoteWrite", remoteWrite))
+	}
+	if sigv4.Profile != "" {
+		cfg = append(cfg, yaml.MapItem{Key: "profile", Value: sigv4.Profile})
+	}
+	if sigv4.RoleArn != "" {
+		cfg = append(cfg, yaml.MapItem{Key: "role_arn", Value: sigv4.RoleArn})
+	}
+
+	return cfg
+}
+
+func generateAzureADConfig(azureAD *v1.AzureAD) yaml.MapSlice {
+	cfg := yaml.MapSlice{}
+
+	if azureAD.Cloud != nil {
+		cfg = append(cfg, yaml.MapItem{Key: "cloud", Value: *azureAD.Cloud})
+	}
+
+	return append(cfg, yaml.MapItem{
+		Key: "managed_identity",
+		Value: yaml.MapSlice{
+			{Key: "client_id", Value: azureAD.ManagedIdentity.ClientID},
+		},
+	})
+}
+
 func (cg *ConfigGenerator) generateRemoteWriteConfig(
 	version semver.Version,
 	p *v1.Prometheus,
@@ -1751,16 +2601,7 @@ func (cg *ConfigGenerator) generateRemoteWriteConfig(
 
 		}
 
-		if spec.BasicAuth != nil {
-			if s, ok := store.BasicAuthAssets[fmt.Sprintf("remoteWrite/%d", i)]; ok {
-				cfg = append(cfg, yaml.MapItem{
-					Key: "basic_auth", Value: yaml.MapSlice{
-						{Key: "username", Value: s.Username},
-						{Key: "password", Value: s.Password},
-					},
-				})
-			}
-		}
+		cfg = cg.addBasicAuthToYaml(cfg, fmt.Sprintf("remoteWrite/%d", i), store, spec.BasicAuth)
 
 		if spec.BearerToken != "" {
 			cfg = append(cfg, yaml.MapItem{Key: "bearer_token", Value: spec.BearerToken})
@@ -1770,11 +2611,27 @@ func (cg *ConfigGenerator) generateRemoteWriteConfig(
 			cfg = append(cfg, yaml.MapItem{Key: "bearer_token_file", Value: spec.BearerTokenFile})
 		}
 
-		cfg = addOAuth2ToYaml(cfg, version, spec.OAuth2, store.OAuth2Assets, fmt.Sprintf("remoteWrite/%d", i))
+		cfg = cg.addOAuth2ToYaml(cfg, version, spec.OAuth2, store.OAuth2Assets, fmt.Sprintf("remoteWrite/%d", i))
 
 		cfg = addTLStoYaml(cfg, p.ObjectMeta.Namespace, spec.TLSConfig)
 
-		cfg = addAuthorizationToYaml(cfg, version, fmt.Sprintf("remoteWrite/auth/%d", i), store, spec.Authorization, cg.logger)
+		cfg = cg.addAuthorizationToYaml(cfg, version, fmt.Sprintf("remoteWrite/auth/%d", i), store, spec.Authorization)
+
+		if spec.Sigv4 != nil {
+			if version.GTE(semver.MustParse("2.26.0")) {
+				cfg = append(cfg, yaml.MapItem{Key: "sigv4", Value: cg.generateSigv4Config(spec.Sigv4, store.SigV4Assets[fmt.Sprintf("remoteWrite/%d", i)], i)})
+			} else {
+				level.Warn(cg.logger).Log("msg", "sigv4 configuration is only supported with Prometheus >= 2.26.0, ignoring", "remoteWrite", i)
+			}
+		}
+
+		if spec.AzureAD != nil {
+			if version.GTE(semver.MustParse("2.45.0")) {
+				cfg = append(cfg, yaml.MapItem{Key: "azuread", Value: generateAzureADConfig(spec.AzureAD)})
+			} else {
+				level.Warn(cg.logger).Log("msg", "azureAd configuration is only supported with Prometheus >= 2.45.0, ignoring", "remoteWrite", i)
+			}
+		}
 
 		if spec.ProxyURL != "" {
 			cfg = append(cfg, yaml.MapItem{Key: "proxy_url", Value: spec.ProxyURL})
@@ -26,7 +26,9 @@ import (
 	"github.com/go-openapi/swag"
 	"github.com/google/go-cmp/cmp"
 	"github.com/kylelemons/godebug/pretty"
+	"github.com/prometheus-operator/prometheus-operator/pkg/alertmanager"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	monitoringv1alpha1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1alpha1"
 	"github.com/prometheus-operator/prometheus-operator/pkg/assets"
 	"github.com/prometheus-operator/prometheus-operator/pkg/operator"
 	"gopkg.in/yaml.v2"
@@ -72,6 +74,8 @@ func TestGlobalSettings(t *testing.T) {
 		ScrapeTimeout      string
 		ExternalLabels     map[string]string
 		QueryLogFile       string
+		TSDB               *monitoringv1.TSDBSpec
+		ScrapeProtocols    []monitoringv1.ScrapeProtocol
 		Version            string
 		Expected           string
 	}
@@ -184,6 +188,91 @@ alerting:
   query_log_file: test.log
 rule_files: []
 scrape_configs: []
+alerting:
+  alert_relabel_configs:
+  - action: labeldrop
+    regex: prometheus_replica
+  alertmanagers: []
+`,
+		},
+		{
+			Version: "v2.39.0",
+			TSDB: &monitoringv1.TSDBSpec{
+				OutOfOrderTimeWindow: "10m",
+				MaxExemplars:         func(i int64) *int64 { return &i }(100000),
+			},
+			Expected: `global:
+  evaluation_interval: 30s
+  scrape_interval: 30s
+  external_labels:
+    prometheus: /
+    prometheus_replica: $(POD_NAME)
+storage:
+  tsdb:
+    out_of_order_time_window: 10m
+  exemplars:
+    max_exemplars: 100000
+rule_files: []
+scrape_configs: []
+alerting:
+  alert_relabel_configs:
+  - action: labeldrop
+    regex: prometheus_replica
+  alertmanagers: []
+`,
+		},
+		{
+			Version:         "v2.49.0",
+			ScrapeProtocols: []monitoringv1.ScrapeProtocol{"PrometheusProto", "OpenMetricsText1.0.0"},
+			Expected: `global:
+  evaluation_interval: 30s
+  scrape_interval: 30s
+  external_labels:
+    prometheus: /
+    prometheus_replica: $(POD_NAME)
+  scrape_protocols:
+  - PrometheusProto
+  - OpenMetricsText1.0.0
+rule_files: []
+scrape_configs: []
+alerting:
+  alert_relabel_configs:
+  - action: labeldrop
+    regex: prometheus_replica
+  alertmanagers: []
+`,
+		},
+		{
+			Version:         "v2.48.0",
+			ScrapeProtocols: []monitoringv1.ScrapeProtocol{"PrometheusProto"},
+			Expected: `global:
+  evaluation_interval: 30s
+  scrape_interval: 30s
+  external_labels:
+    prometheus: /
+    prometheus_replica: $(POD_NAME)
+rule_files: []
+scrape_configs: []
+alerting:
+  alert_relabel_configs:
+  - action: labeldrop
+    regex: prometheus_replica
+  alertmanagers: []
+`,
+		},
+		{
+			Version: "v2.39.0",
+			TSDB: &monitoringv1.TSDBSpec{
+				MinBlockDuration: "1h",
+			},
+			Expected: `global:
+  evaluation_interval: 30s
+  scrape_interval: 30s
+  external_labels:
+    prometheus: /
+    prometheus_replica: $(POD_NAME)
+rule_files: []
+scrape_configs: []
 alerting:
   alert_relabel_configs:
   - action: labeldrop
@@ -199,17 +288,24 @@ alerting:
 			&monitoringv1.Prometheus{
 				ObjectMeta: metav1.ObjectMeta{},
 				Spec: monitoringv1.PrometheusSpec{
+					CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+						ScrapeInterval: tc.ScrapeInterval,
+						ScrapeTimeout:  tc.ScrapeTimeout,
+						ExternalLabels: tc.ExternalLabels,
+						ScrapeOptions: monitoringv1.ScrapeOptions{
+							ScrapeProtocols: tc.ScrapeProtocols,
+						},
+						Version: tc.Version,
+					},
 					EvaluationInterval: tc.EvaluationInterval,
-					ScrapeInterval:     tc.ScrapeInterval,
-					ScrapeTimeout:      tc.ScrapeTimeout,
-					ExternalLabels:     tc.ExternalLabels,
 					QueryLogFile:       tc.QueryLogFile,
-					Version:            tc.Version,
+					TSDB:               tc.TSDB,
 				},
 			},
 			map[string]*monitoringv1.ServiceMonitor{},
 			nil,
 			nil,
+			nil,
 			&assets.Store{},
 			nil,
 			nil,
@@ -390,9 +486,11 @@ func TestProbeStaticTargetsConfigGeneration(t *testing.T) {
 				Namespace: "default",
 			},
 			Spec: monitoringv1.PrometheusSpec{
-				ProbeSelector: &metav1.LabelSelector{
-					MatchLabels: map[string]string{
-						"group": "group1",
+				CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+					ProbeSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							"group": "group1",
+						},
 					},
 				},
 			},
@@ -437,6 +535,7 @@ func TestProbeStaticTargetsConfigGeneration(t *testing.T) {
 				},
 			},
 		},
+		nil,
 		&assets.Store{},
 		nil,
 		nil,
@@ -499,7 +598,7 @@ alerting:
 	}
 }
 
-func TestProbeStaticTargetsConfigGenerationWithLabelEnforce(t *testing.T) {
+func TestProbeNamedProberConfigGeneration(t *testing.T) {
 	cg := &ConfigGenerator{}
 	cfg, err := cg.GenerateConfig(
 		&monitoringv1.Prometheus{
@@ -508,12 +607,114 @@ func TestProbeStaticTargetsConfigGenerationWithLabelEnforce(t *testing.T) {
 				Namespace: "default",
 			},
 			Spec: monitoringv1.PrometheusSpec{
-				EnforcedNamespaceLabel: "namespace",
-				ProbeSelector: &metav1.LabelSelector{
-					MatchLabels: map[string]string{
+				CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+					ProbeSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							"group": "group1",
+						},
+					},
+				},
+			},
+		},
+		nil,
+		nil,
+		map[string]*monitoringv1.Probe{
+			"probe1": {
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "testprobe1",
+					Namespace: "monitoring",
+					Labels: map[string]string{
 						"group": "group1",
 					},
 				},
+				Spec: monitoringv1.ProbeSpec{
+					ProberSpec: monitoringv1.ProberSpec{
+						Name: "blackbox",
+					},
+					Module: "http_2xx",
+					Targets: monitoringv1.ProbeTargets{
+						StaticConfig: &monitoringv1.ProbeTargetStaticConfig{
+							Targets: []string{
+								"prometheus.io",
+							},
+						},
+					},
+				},
+			},
+		},
+		nil,
+		&assets.Store{},
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `global:
+  evaluation_interval: 30s
+  scrape_interval: 30s
+  external_labels:
+    prometheus: default/test
+    prometheus_replica: $(POD_NAME)
+rule_files: []
+scrape_configs:
+- job_name: probe/monitoring/testprobe1
+  honor_timestamps: true
+  metrics_path: /probe
+  params:
+    module:
+    - http_2xx
+  static_configs:
+  - targets:
+    - prometheus.io
+    labels:
+      namespace: monitoring
+  relabel_configs:
+  - source_labels:
+    - job
+    target_label: __tmp_prometheus_job_name
+  - source_labels:
+    - __address__
+    target_label: __param_target
+  - source_labels:
+    - __param_target
+    target_label: instance
+  - target_label: __address__
+    replacement: prober-blackbox.monitoring.svc:9115
+alerting:
+  alert_relabel_configs:
+  - action: labeldrop
+    regex: prometheus_replica
+  alertmanagers: []
+`
+
+	result := string(cfg)
+	if diff := cmp.Diff(expected, result); diff != "" {
+		t.Fatalf("Unexpected result got(-) want(+)\n%s\n", diff)
+	}
+}
+
+func TestProbeStaticTargetsConfigGenerationWithLabelEnforce(t *testing.T) {
+	cg := &ConfigGenerator{}
+	cfg, err := cg.GenerateConfig(
+		&monitoringv1.Prometheus{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "default",
+			},
+			Spec: monitoringv1.PrometheusSpec{
+				CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+					EnforcedNamespaceLabel: "namespace",
+					ProbeSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							"group": "group1",
+						},
+					},
+				},
 			},
 		},
 		nil,
@@ -555,6 +756,7 @@ func TestProbeStaticTargetsConfigGenerationWithLabelEnforce(t *testing.T) {
 				},
 			},
 		},
+		nil,
 		&assets.Store{},
 		nil,
 		nil,
@@ -627,9 +829,11 @@ func TestProbeStaticTargetsConfigGenerationWithJobName(t *testing.T) {
 				Namespace: "default",
 			},
 			Spec: monitoringv1.PrometheusSpec{
-				ProbeSelector: &metav1.LabelSelector{
-					MatchLabels: map[string]string{
-						"group": "group1",
+				CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+					ProbeSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							"group": "group1",
+						},
 					},
 				},
 			},
@@ -664,6 +868,7 @@ func TestProbeStaticTargetsConfigGenerationWithJobName(t *testing.T) {
 				},
 			},
 		},
+		nil,
 		&assets.Store{},
 		nil,
 		nil,
@@ -732,9 +937,11 @@ func TestProbeStaticTargetsConfigGenerationWithoutModule(t *testing.T) {
 				Namespace: "default",
 			},
 			Spec: monitoringv1.PrometheusSpec{
-				ProbeSelector: &metav1.LabelSelector{
-					MatchLabels: map[string]string{
-						"group": "group1",
+				CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+					ProbeSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							"group": "group1",
+						},
 					},
 				},
 			},
@@ -768,6 +975,7 @@ func TestProbeStaticTargetsConfigGenerationWithoutModule(t *testing.T) {
 				},
 			},
 		},
+		nil,
 		&assets.Store{},
 		nil,
 		nil,
@@ -833,9 +1041,11 @@ func TestProbeIngressSDConfigGeneration(t *testing.T) {
 				Namespace: "default",
 			},
 			Spec: monitoringv1.PrometheusSpec{
-				ProbeSelector: &metav1.LabelSelector{
-					MatchLabels: map[string]string{
-						"group": "group1",
+				CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+					ProbeSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							"group": "group1",
+						},
 					},
 				},
 			},
@@ -880,6 +1090,7 @@ func TestProbeIngressSDConfigGeneration(t *testing.T) {
 				},
 			},
 		},
+		nil,
 		&assets.Store{},
 		nil,
 		nil,
@@ -952,7 +1163,7 @@ alerting:
 	}
 }
 
-func TestProbeIngressSDConfigGenerationWithLabelEnforce(t *testing.T) {
+func TestProbeServiceSDConfigGeneration(t *testing.T) {
 	cg := &ConfigGenerator{}
 	cfg, err := cg.GenerateConfig(
 		&monitoringv1.Prometheus{
@@ -961,10 +1172,11 @@ func TestProbeIngressSDConfigGenerationWithLabelEnforce(t *testing.T) {
 				Namespace: "default",
 			},
 			Spec: monitoringv1.PrometheusSpec{
-				EnforcedNamespaceLabel: "namespace",
-				ProbeSelector: &metav1.LabelSelector{
-					MatchLabels: map[string]string{
-						"group": "group1",
+				CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+					ProbeSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							"group": "group1",
+						},
 					},
 				},
 			},
@@ -982,33 +1194,25 @@ func TestProbeIngressSDConfigGenerationWithLabelEnforce(t *testing.T) {
 				},
 				Spec: monitoringv1.ProbeSpec{
 					ProberSpec: monitoringv1.ProberSpec{
-						Scheme: "http",
-						URL:    "blackbox.exporter.io",
-						Path:   "/probe",
+						URL: "blackbox.exporter.io",
 					},
 					Module: "http_2xx",
 					Targets: monitoringv1.ProbeTargets{
-						Ingress: &monitoringv1.ProbeTargetIngress{
+						Service: &monitoringv1.ProbeTargetService{
 							Selector: metav1.LabelSelector{
 								MatchLabels: map[string]string{
 									"prometheus.io/probe": "true",
 								},
 							},
-							NamespaceSelector: monitoringv1.NamespaceSelector{
-								Any: true,
-							},
-							RelabelConfigs: []*monitoringv1.RelabelConfig{
-								{
-									TargetLabel: "foo",
-									Replacement: "bar",
-									Action:      "replace",
-								},
-							},
+							Port:   "web",
+							Scheme: "https",
+							Path:   "/healthz",
 						},
 					},
 				},
 			},
 		},
+		nil,
 		&assets.Store{},
 		nil,
 		nil,
@@ -1031,45 +1235,41 @@ scrape_configs:
 - job_name: probe/default/testprobe1
   honor_timestamps: true
   metrics_path: /probe
-  scheme: http
   params:
     module:
     - http_2xx
   kubernetes_sd_configs:
-  - role: ingress
+  - role: service
+    namespaces:
+      names:
+      - default
   relabel_configs:
   - source_labels:
     - job
     target_label: __tmp_prometheus_job_name
   - action: keep
     source_labels:
-    - __meta_kubernetes_ingress_label_prometheus_io_probe
+    - __meta_kubernetes_service_label_prometheus_io_probe
     regex: "true"
+  - action: keep
+    source_labels:
+    - __meta_kubernetes_service_port_name
+    regex: web
   - source_labels:
-    - __meta_kubernetes_ingress_scheme
     - __address__
-    - __meta_kubernetes_ingress_path
-    separator: ;
-    regex: (.+);(.+);(.+)
     target_label: __param_target
-    replacement: ${1}://${2}${3}
-    action: replace
+    replacement: https://${1}/healthz
   - source_labels:
     - __meta_kubernetes_namespace
     target_label: namespace
   - source_labels:
-    - __meta_kubernetes_ingress_name
-    target_label: ingress
+    - __meta_kubernetes_service_name
+    target_label: service
   - source_labels:
     - __param_target
     target_label: instance
   - target_label: __address__
     replacement: blackbox.exporter.io
-  - target_label: foo
-    replacement: bar
-    action: replace
-  - target_label: namespace
-    replacement: default
 alerting:
   alert_relabel_configs:
   - action: labeldrop
@@ -1083,23 +1283,271 @@ alerting:
 	}
 }
 
-func TestK8SSDConfigGeneration(t *testing.T) {
-	sm := &monitoringv1.ServiceMonitor{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      "testservicemonitor1",
-			Namespace: "default",
-			Labels: map[string]string{
-				"group": "group1",
+func TestProbeHTTPRouteConfigGeneration(t *testing.T) {
+	cg := &ConfigGenerator{}
+	cfg, err := cg.withHTTPRouteTargets(map[string][]targetGroup{
+		"probe/default/testprobe1": {
+			{
+				Targets: []string{"http://example.com/", "http://example.com/api"},
+				Labels: map[string]string{
+					"namespace": "default",
+					"httproute": "example",
+				},
 			},
 		},
-		Spec: monitoringv1.ServiceMonitorSpec{
-			NamespaceSelector: monitoringv1.NamespaceSelector{
-				MatchNames: []string{"test"},
+	}).GenerateConfig(
+		&monitoringv1.Prometheus{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "default",
 			},
-		},
-	}
-
-	cg := &ConfigGenerator{}
+			Spec: monitoringv1.PrometheusSpec{
+				CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+					ProbeSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							"group": "group1",
+						},
+					},
+				},
+			},
+		},
+		nil,
+		nil,
+		map[string]*monitoringv1.Probe{
+			"probe1": {
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "testprobe1",
+					Namespace: "default",
+					Labels: map[string]string{
+						"group": "group1",
+					},
+				},
+				Spec: monitoringv1.ProbeSpec{
+					ProberSpec: monitoringv1.ProberSpec{
+						URL: "blackbox.exporter.io",
+					},
+					Module: "http_2xx",
+					Targets: monitoringv1.ProbeTargets{
+						HTTPRoute: &monitoringv1.ProbeTargetHTTPRoute{
+							Selector: metav1.LabelSelector{
+								MatchLabels: map[string]string{
+									"prometheus.io/probe": "true",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		nil,
+		&assets.Store{},
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `global:
+  evaluation_interval: 30s
+  scrape_interval: 30s
+  external_labels:
+    prometheus: default/test
+    prometheus_replica: $(POD_NAME)
+rule_files: []
+scrape_configs:
+- job_name: probe/default/testprobe1
+  honor_timestamps: true
+  metrics_path: /probe
+  params:
+    module:
+    - http_2xx
+  static_configs:
+  - targets:
+    - http://example.com/
+    - http://example.com/api
+    labels:
+      httproute: example
+      namespace: default
+  relabel_configs:
+  - source_labels:
+    - job
+    target_label: __tmp_prometheus_job_name
+  - source_labels:
+    - __address__
+    target_label: __param_target
+  - source_labels:
+    - __param_target
+    target_label: instance
+  - target_label: __address__
+    replacement: blackbox.exporter.io
+alerting:
+  alert_relabel_configs:
+  - action: labeldrop
+    regex: prometheus_replica
+  alertmanagers: []
+`
+
+	result := string(cfg)
+	if expected != result {
+		t.Fatalf("Unexpected result.\n\nGot:\n\n%s\n\nExpected:\n\n%s\n\n", result, expected)
+	}
+}
+
+func TestProbeIngressSDConfigGenerationWithLabelEnforce(t *testing.T) {
+	cg := &ConfigGenerator{}
+	cfg, err := cg.GenerateConfig(
+		&monitoringv1.Prometheus{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "default",
+			},
+			Spec: monitoringv1.PrometheusSpec{
+				CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+					EnforcedNamespaceLabel: "namespace",
+					ProbeSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							"group": "group1",
+						},
+					},
+				},
+			},
+		},
+		nil,
+		nil,
+		map[string]*monitoringv1.Probe{
+			"probe1": {
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "testprobe1",
+					Namespace: "default",
+					Labels: map[string]string{
+						"group": "group1",
+					},
+				},
+				Spec: monitoringv1.ProbeSpec{
+					ProberSpec: monitoringv1.ProberSpec{
+						Scheme: "http",
+						URL:    "blackbox.exporter.io",
+						Path:   "/probe",
+					},
+					Module: "http_2xx",
+					Targets: monitoringv1.ProbeTargets{
+						Ingress: &monitoringv1.ProbeTargetIngress{
+							Selector: metav1.LabelSelector{
+								MatchLabels: map[string]string{
+									"prometheus.io/probe": "true",
+								},
+							},
+							NamespaceSelector: monitoringv1.NamespaceSelector{
+								Any: true,
+							},
+							RelabelConfigs: []*monitoringv1.RelabelConfig{
+								{
+									TargetLabel: "foo",
+									Replacement: "bar",
+									Action:      "replace",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		nil,
+		&assets.Store{},
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `global:
+  evaluation_interval: 30s
+  scrape_interval: 30s
+  external_labels:
+    prometheus: default/test
+    prometheus_replica: $(POD_NAME)
+rule_files: []
+scrape_configs:
+- job_name: probe/default/testprobe1
+  honor_timestamps: true
+  metrics_path: /probe
+  scheme: http
+  params:
+    module:
+    - http_2xx
+  kubernetes_sd_configs:
+  - role: ingress
+  relabel_configs:
+  - source_labels:
+    - job
+    target_label: __tmp_prometheus_job_name
+  - action: keep
+    source_labels:
+    - __meta_kubernetes_ingress_label_prometheus_io_probe
+    regex: "true"
+  - source_labels:
+    - __meta_kubernetes_ingress_scheme
+    - __address__
+    - __meta_kubernetes_ingress_path
+    separator: ;
+    regex: (.+);(.+);(.+)
+    target_label: __param_target
+    replacement: ${1}://${2}${3}
+    action: replace
+  - source_labels:
+    - __meta_kubernetes_namespace
+    target_label: namespace
+  - source_labels:
+    - __meta_kubernetes_ingress_name
+    target_label: ingress
+  - source_labels:
+    - __param_target
+    target_label: instance
+  - target_label: __address__
+    replacement: blackbox.exporter.io
+  - target_label: foo
+    replacement: bar
+    action: replace
+  - target_label: namespace
+    replacement: default
+alerting:
+  alert_relabel_configs:
+  - action: labeldrop
+    regex: prometheus_replica
+  alertmanagers: []
+`
+
+	result := string(cfg)
+	if expected != result {
+		t.Fatalf("Unexpected result.\n\nGot:\n\n%s\n\nExpected:\n\n%s\n\n", result, expected)
+	}
+}
+
+func TestK8SSDConfigGeneration(t *testing.T) {
+	sm := &monitoringv1.ServiceMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "testservicemonitor1",
+			Namespace: "default",
+			Labels: map[string]string{
+				"group": "group1",
+			},
+		},
+		Spec: monitoringv1.ServiceMonitorSpec{
+			NamespaceSelector: monitoringv1.NamespaceSelector{
+				MatchNames: []string{"test"},
+			},
+		},
+	}
+
+	cg := &ConfigGenerator{}
 
 	testcases := []struct {
 		apiserverConfig *monitoringv1.APIServerConfig
@@ -1193,6 +1641,7 @@ func TestAlertmanagerBearerToken(t *testing.T) {
 		nil,
 		nil,
 		nil,
+		nil,
 		&assets.Store{},
 		nil,
 		nil,
@@ -1236,6 +1685,11 @@ alerting:
       source_labels:
       - __meta_kubernetes_endpoint_port_name
       regex: web
+    - action: replace
+      source_labels:
+      - __meta_kubernetes_pod_annotation_operator_prometheus_io_web_scheme
+      regex: (.+)
+      target_label: __scheme__
 `
 
 	result := string(cfg)
@@ -1255,7 +1709,9 @@ func TestAlertmanagerAPIVersion(t *testing.T) {
 				Namespace: "default",
 			},
 			Spec: monitoringv1.PrometheusSpec{
-				Version: "v2.11.0",
+				CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+					Version: "v2.11.0",
+				},
 				Alerting: &monitoringv1.AlertingSpec{
 					Alertmanagers: []monitoringv1.AlertmanagerEndpoints{
 						{
@@ -1271,6 +1727,7 @@ func TestAlertmanagerAPIVersion(t *testing.T) {
 		nil,
 		nil,
 		nil,
+		nil,
 		&assets.Store{},
 		nil,
 		nil,
@@ -1314,6 +1771,11 @@ alerting:
       source_labels:
       - __meta_kubernetes_endpoint_port_name
       regex: web
+    - action: replace
+      source_labels:
+      - __meta_kubernetes_pod_annotation_operator_prometheus_io_web_scheme
+      regex: (.+)
+      target_label: __scheme__
 `
 
 	result := string(cfg)
@@ -1333,7 +1795,9 @@ func TestAlertmanagerTimeoutConfig(t *testing.T) {
 				Namespace: "default",
 			},
 			Spec: monitoringv1.PrometheusSpec{
-				Version: "v2.11.0",
+				CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+					Version: "v2.11.0",
+				},
 				Alerting: &monitoringv1.AlertingSpec{
 					Alertmanagers: []monitoringv1.AlertmanagerEndpoints{
 						{
@@ -1350,6 +1814,7 @@ func TestAlertmanagerTimeoutConfig(t *testing.T) {
 		nil,
 		nil,
 		nil,
+		nil,
 		&assets.Store{},
 		nil,
 		nil,
@@ -1394,6 +1859,11 @@ alerting:
       source_labels:
       - __meta_kubernetes_endpoint_port_name
       regex: web
+    - action: replace
+      source_labels:
+      - __meta_kubernetes_pod_annotation_operator_prometheus_io_web_scheme
+      regex: (.+)
+      target_label: __scheme__
 `
 
 	result := string(cfg)
@@ -1427,6 +1897,7 @@ func TestAdditionalAlertRelabelConfigs(t *testing.T) {
 		nil,
 		nil,
 		nil,
+		nil,
 		&assets.Store{},
 		nil,
 		[]byte(`- action: drop
@@ -1474,6 +1945,11 @@ alerting:
       source_labels:
       - __meta_kubernetes_endpoint_port_name
       regex: web
+    - action: replace
+      source_labels:
+      - __meta_kubernetes_pod_annotation_operator_prometheus_io_web_scheme
+      regex: (.+)
+      target_label: __scheme__
 `
 
 	result := string(cfg)
@@ -1484,20 +1960,349 @@ alerting:
 	}
 }
 
-func TestNoEnforcedNamespaceLabelServiceMonitor(t *testing.T) {
+func TestAlertRelabelConfigs(t *testing.T) {
 	cg := &ConfigGenerator{}
 	cfg, err := cg.GenerateConfig(
 		&monitoringv1.Prometheus{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      "test",
-				Namespace: "ns-value",
+				Namespace: "default",
 			},
-			Spec: monitoringv1.PrometheusSpec{},
-		},
-		map[string]*monitoringv1.ServiceMonitor{
-			"test": {
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      "test",
+			Spec: monitoringv1.PrometheusSpec{
+				CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+					Version: "v2.51.0",
+				},
+				Alerting: &monitoringv1.AlertingSpec{
+					AlertRelabelConfigs: []monitoringv1.RelabelConfig{
+						{
+							SourceLabels: []string{"team"},
+							Regex:        "infra",
+							TargetLabel:  "team",
+							Replacement:  "platform",
+						},
+					},
+					Alertmanagers: []monitoringv1.AlertmanagerEndpoints{
+						{
+							Name:      "alertmanager-main",
+							Namespace: "default",
+							Port:      intstr.FromString("web"),
+							AlertRelabelConfigs: []monitoringv1.RelabelConfig{
+								{
+									Action: "labeldrop",
+									Regex:  "cluster",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		nil,
+		nil,
+		nil,
+		nil,
+		&assets.Store{},
+		nil,
+		[]byte(`- action: drop
+  source_labels: [severity]
+  regex: none
+`),
+		nil,
+		nil,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `global:
+  evaluation_interval: 30s
+  scrape_interval: 30s
+  external_labels:
+    prometheus: default/test
+    prometheus_replica: $(POD_NAME)
+rule_files: []
+scrape_configs: []
+alerting:
+  alert_relabel_configs:
+  - action: labeldrop
+    regex: prometheus_replica
+  - source_labels:
+    - team
+    target_label: team
+    regex: infra
+    replacement: platform
+  - action: drop
+    source_labels:
+    - severity
+    regex: none
+  alertmanagers:
+  - path_prefix: /
+    scheme: http
+    kubernetes_sd_configs:
+    - role: endpoints
+      namespaces:
+        names:
+        - default
+    relabel_configs:
+    - action: keep
+      source_labels:
+      - __meta_kubernetes_service_name
+      regex: alertmanager-main
+    - action: keep
+      source_labels:
+      - __meta_kubernetes_endpoint_port_name
+      regex: web
+    - action: replace
+      source_labels:
+      - __meta_kubernetes_pod_annotation_operator_prometheus_io_web_scheme
+      regex: (.+)
+      target_label: __scheme__
+    alert_relabel_configs:
+    - regex: cluster
+      action: labeldrop
+`
+
+	result := string(cfg)
+
+	if expected != result {
+		fmt.Println(pretty.Compare(expected, result))
+		t.Fatal("expected Prometheus configuration and actual configuration do not match")
+	}
+}
+
+func TestSelectedAlertmanagers(t *testing.T) {
+	var endpoints []alertmanager.Endpoint
+	for _, am := range []*monitoringv1.Alertmanager{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "main",
+				Namespace: "monitoring",
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "secure",
+				Namespace: "default",
+			},
+			Spec: monitoringv1.AlertmanagerSpec{
+				PortName:    "api",
+				RoutePrefix: "/alertmanager",
+				Web: &monitoringv1.AlertmanagerWebSpec{
+					WebConfigFileFields: monitoringv1.WebConfigFileFields{
+						TLSConfig: &monitoringv1.WebTLSConfig{},
+					},
+				},
+			},
+		},
+	} {
+		e, err := alertmanager.NewEndpoint(am)
+		if err != nil {
+			t.Fatal(err)
+		}
+		endpoints = append(endpoints, e)
+	}
+
+	cg := &ConfigGenerator{}
+	cfg, err := cg.withAlertmanagers(endpoints).GenerateConfig(
+		&monitoringv1.Prometheus{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "default",
+			},
+			Spec: monitoringv1.PrometheusSpec{
+				CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+					Version: "v2.45.0",
+				},
+				Alerting: &monitoringv1.AlertingSpec{
+					AlertmanagerSelector: &metav1.LabelSelector{},
+					AlertmanagerTLSConfig: &monitoringv1.TLSConfig{
+						SafeTLSConfig: monitoringv1.SafeTLSConfig{
+							CA: monitoringv1.SecretOrConfigMap{
+								Secret: &v1.SecretKeySelector{
+									LocalObjectReference: v1.LocalObjectReference{Name: "alertmanager-tls"},
+									Key:                  "ca.crt",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		nil,
+		nil,
+		nil,
+		nil,
+		&assets.Store{},
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `global:
+  evaluation_interval: 30s
+  scrape_interval: 30s
+  external_labels:
+    prometheus: default/test
+    prometheus_replica: $(POD_NAME)
+rule_files: []
+scrape_configs: []
+alerting:
+  alert_relabel_configs:
+  - action: labeldrop
+    regex: prometheus_replica
+  alertmanagers:
+  - path_prefix: /
+    scheme: http
+    kubernetes_sd_configs:
+    - role: endpoints
+      namespaces:
+        names:
+        - monitoring
+    api_version: v2
+    relabel_configs:
+    - action: keep
+      source_labels:
+      - __meta_kubernetes_service_name
+      regex: alertmanager-operated
+    - action: keep
+      source_labels:
+      - __meta_kubernetes_pod_label_alertmanager
+      regex: main
+    - action: keep
+      source_labels:
+      - __meta_kubernetes_endpoint_port_name
+      regex: web
+  - path_prefix: /alertmanager
+    scheme: https
+    tls_config:
+      insecure_skip_verify: false
+      ca_file: /etc/prometheus/certs/secret_default_alertmanager-tls_ca.crt
+    kubernetes_sd_configs:
+    - role: endpoints
+      namespaces:
+        names:
+        - default
+    api_version: v2
+    relabel_configs:
+    - action: keep
+      source_labels:
+      - __meta_kubernetes_service_name
+      regex: alertmanager-operated
+    - action: keep
+      source_labels:
+      - __meta_kubernetes_pod_label_alertmanager
+      regex: secure
+    - action: keep
+      source_labels:
+      - __meta_kubernetes_endpoint_port_name
+      regex: api
+`
+
+	result := string(cfg)
+
+	if expected != result {
+		fmt.Println(pretty.Compare(expected, result))
+		t.Fatal("expected Prometheus configuration and actual configuration do not match")
+	}
+}
+
+func TestValidateAlertRelabelConfigs(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		version string
+		rc      monitoringv1.RelabelConfig
+		ok      bool
+	}{
+		{
+			name:    "default action",
+			version: "2.30.0",
+			rc:      monitoringv1.RelabelConfig{SourceLabels: []string{"team"}, TargetLabel: "owner"},
+			ok:      true,
+		},
+		{
+			name:    "unknown action",
+			version: "2.30.0",
+			rc:      monitoringv1.RelabelConfig{Action: "rewrite"},
+		},
+		{
+			name:    "invalid regex",
+			version: "2.30.0",
+			rc:      monitoringv1.RelabelConfig{Action: "drop", Regex: "(foo"},
+		},
+		{
+			name:    "replace without target label",
+			version: "2.30.0",
+			rc:      monitoringv1.RelabelConfig{Action: "Replace", SourceLabels: []string{"team"}},
+		},
+		{
+			name:    "hashmod without modulus",
+			version: "2.30.0",
+			rc:      monitoringv1.RelabelConfig{Action: "hashmod", TargetLabel: "shard"},
+		},
+		{
+			name:    "lowercase unsupported",
+			version: "2.35.0",
+			rc:      monitoringv1.RelabelConfig{Action: "lowercase", TargetLabel: "team"},
+		},
+		{
+			name:    "lowercase",
+			version: "2.36.0",
+			rc:      monitoringv1.RelabelConfig{Action: "lowercase", TargetLabel: "team"},
+			ok:      true,
+		},
+		{
+			name:    "labeldrop",
+			version: "2.51.0",
+			rc:      monitoringv1.RelabelConfig{Action: "labeldrop", Regex: "cluster"},
+			ok:      true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			version := semver.MustParse(tc.version)
+			for _, c := range []struct {
+				alerting *monitoringv1.AlertingSpec
+				ok       bool
+			}{
+				{
+					alerting: &monitoringv1.AlertingSpec{AlertRelabelConfigs: []monitoringv1.RelabelConfig{tc.rc}},
+					ok:       tc.ok,
+				},
+				{
+					// The per-Alertmanager relabeling requires Prometheus >= 2.51.0.
+					alerting: &monitoringv1.AlertingSpec{Alertmanagers: []monitoringv1.AlertmanagerEndpoints{{AlertRelabelConfigs: []monitoringv1.RelabelConfig{tc.rc}}}},
+					ok:       tc.ok && version.GTE(semver.MustParse("2.51.0")),
+				},
+			} {
+				err := validateAlertRelabelConfigs(c.alerting, version)
+				if c.ok && err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				if !c.ok && err == nil {
+					t.Fatal("expected error, got none")
+				}
+			}
+		})
+	}
+}
+
+func TestNoEnforcedNamespaceLabelServiceMonitor(t *testing.T) {
+	cg := &ConfigGenerator{}
+	cfg, err := cg.GenerateConfig(
+		&monitoringv1.Prometheus{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "ns-value",
+			},
+			Spec: monitoringv1.PrometheusSpec{},
+		},
+		map[string]*monitoringv1.ServiceMonitor{
+			"test": {
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test",
 					Namespace: "default",
 				},
 				Spec: monitoringv1.ServiceMonitorSpec{
@@ -1539,6 +2344,7 @@ func TestNoEnforcedNamespaceLabelServiceMonitor(t *testing.T) {
 		},
 		nil,
 		nil,
+		nil,
 		&assets.Store{},
 		nil,
 		nil,
@@ -1654,7 +2460,9 @@ func TestEnforcedNamespaceLabelPodMonitor(t *testing.T) {
 				Namespace: "ns-value",
 			},
 			Spec: monitoringv1.PrometheusSpec{
-				EnforcedNamespaceLabel: "ns-key",
+				CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+					EnforcedNamespaceLabel: "ns-key",
+				},
 			},
 		},
 		nil,
@@ -1695,6 +2503,7 @@ func TestEnforcedNamespaceLabelPodMonitor(t *testing.T) {
 			},
 		},
 		nil,
+		nil,
 		&assets.Store{},
 		nil,
 		nil,
@@ -1797,7 +2606,9 @@ func TestEnforcedNamespaceLabelServiceMonitor(t *testing.T) {
 				Namespace: "ns-value",
 			},
 			Spec: monitoringv1.PrometheusSpec{
-				EnforcedNamespaceLabel: "ns-key",
+				CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+					EnforcedNamespaceLabel: "ns-key",
+				},
 			},
 		},
 		map[string]*monitoringv1.ServiceMonitor{
@@ -1839,6 +2650,7 @@ func TestEnforcedNamespaceLabelServiceMonitor(t *testing.T) {
 		},
 		nil,
 		nil,
+		nil,
 		&assets.Store{},
 		nil,
 		nil,
@@ -1963,6 +2775,7 @@ func TestAdditionalAlertmanagers(t *testing.T) {
 		nil,
 		nil,
 		nil,
+		nil,
 		&assets.Store{},
 		nil,
 		nil,
@@ -2005,6 +2818,11 @@ alerting:
       source_labels:
       - __meta_kubernetes_endpoint_port_name
       regex: web
+    - action: replace
+      source_labels:
+      - __meta_kubernetes_pod_annotation_operator_prometheus_io_web_scheme
+      regex: (.+)
+      target_label: __scheme__
   - static_configs:
     - targets:
       - localhost
@@ -2027,10 +2845,12 @@ func TestSettingHonorTimestampsInServiceMonitor(t *testing.T) {
 				Namespace: "default",
 			},
 			Spec: monitoringv1.PrometheusSpec{
-				Version: "v2.9.0",
-				ServiceMonitorSelector: &metav1.LabelSelector{
-					MatchLabels: map[string]string{
-						"group": "group1",
+				CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+					Version: "v2.9.0",
+					ServiceMonitorSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							"group": "group1",
+						},
 					},
 				},
 			},
@@ -2058,6 +2878,7 @@ func TestSettingHonorTimestampsInServiceMonitor(t *testing.T) {
 		},
 		nil,
 		nil,
+		nil,
 		&assets.Store{},
 		nil,
 		nil,
@@ -2168,10 +2989,12 @@ func TestSettingHonorTimestampsInPodMonitor(t *testing.T) {
 				Namespace: "default",
 			},
 			Spec: monitoringv1.PrometheusSpec{
-				Version: "v2.9.0",
-				ServiceMonitorSelector: &metav1.LabelSelector{
-					MatchLabels: map[string]string{
-						"group": "group1",
+				CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+					Version: "v2.9.0",
+					ServiceMonitorSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							"group": "group1",
+						},
 					},
 				},
 			},
@@ -2199,6 +3022,7 @@ func TestSettingHonorTimestampsInPodMonitor(t *testing.T) {
 			},
 		},
 		nil,
+		nil,
 		&assets.Store{},
 		nil,
 		nil,
@@ -2290,11 +3114,13 @@ func TestHonorTimestampsOverriding(t *testing.T) {
 				Namespace: "default",
 			},
 			Spec: monitoringv1.PrometheusSpec{
-				Version:                 "v2.9.0",
-				OverrideHonorTimestamps: true,
-				ServiceMonitorSelector: &metav1.LabelSelector{
-					MatchLabels: map[string]string{
-						"group": "group1",
+				CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+					Version:                 "v2.9.0",
+					OverrideHonorTimestamps: true,
+					ServiceMonitorSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							"group": "group1",
+						},
 					},
 				},
 			},
@@ -2322,6 +3148,7 @@ func TestHonorTimestampsOverriding(t *testing.T) {
 		},
 		nil,
 		nil,
+		nil,
 		&assets.Store{},
 		nil,
 		nil,
@@ -2432,12 +3259,14 @@ func TestSettingHonorLabels(t *testing.T) {
 				Namespace: "default",
 			},
 			Spec: monitoringv1.PrometheusSpec{
-				ServiceMonitorSelector: &metav1.LabelSelector{
-					MatchLabels: map[string]string{
-						"group": "group1",
-					},
-				},
-			},
+				CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+					ServiceMonitorSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							"group": "group1",
+						},
+					},
+				},
+			},
 		},
 		map[string]*monitoringv1.ServiceMonitor{
 			"testservicemonitor1": {
@@ -2462,6 +3291,7 @@ func TestSettingHonorLabels(t *testing.T) {
 		},
 		nil,
 		nil,
+		nil,
 		&assets.Store{},
 		nil,
 		nil,
@@ -2571,10 +3401,12 @@ func TestHonorLabelsOverriding(t *testing.T) {
 				Namespace: "default",
 			},
 			Spec: monitoringv1.PrometheusSpec{
-				OverrideHonorLabels: true,
-				ServiceMonitorSelector: &metav1.LabelSelector{
-					MatchLabels: map[string]string{
-						"group": "group1",
+				CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+					OverrideHonorLabels: true,
+					ServiceMonitorSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							"group": "group1",
+						},
 					},
 				},
 			},
@@ -2602,6 +3434,7 @@ func TestHonorLabelsOverriding(t *testing.T) {
 		},
 		nil,
 		nil,
+		nil,
 		&assets.Store{},
 		nil,
 		nil,
@@ -2711,10 +3544,12 @@ func TestTargetLabels(t *testing.T) {
 				Namespace: "default",
 			},
 			Spec: monitoringv1.PrometheusSpec{
-				OverrideHonorLabels: false,
-				ServiceMonitorSelector: &metav1.LabelSelector{
-					MatchLabels: map[string]string{
-						"group": "group1",
+				CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+					OverrideHonorLabels: false,
+					ServiceMonitorSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							"group": "group1",
+						},
 					},
 				},
 			},
@@ -2741,6 +3576,7 @@ func TestTargetLabels(t *testing.T) {
 		},
 		nil,
 		nil,
+		nil,
 		&assets.Store{},
 		nil,
 		nil,
@@ -2894,10 +3730,12 @@ oauth2:
 					Namespace: "default",
 				},
 				Spec: monitoringv1.PrometheusSpec{
-					OverrideHonorLabels: false,
-					ServiceMonitorSelector: &metav1.LabelSelector{
-						MatchLabels: map[string]string{
-							"group": "group1",
+					CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+						OverrideHonorLabels: false,
+						ServiceMonitorSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								"group": "group1",
+							},
 						},
 					},
 				},
@@ -2937,10 +3775,12 @@ oauth2:
 					Namespace: "default",
 				},
 				Spec: monitoringv1.PrometheusSpec{
-					OverrideHonorLabels: false,
-					ServiceMonitorSelector: &metav1.LabelSelector{
-						MatchLabels: map[string]string{
-							"group": "group1",
+					CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+						OverrideHonorLabels: false,
+						ServiceMonitorSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								"group": "group1",
+							},
 						},
 					},
 				},
@@ -2980,10 +3820,12 @@ oauth2:
 					Namespace: "default",
 				},
 				Spec: monitoringv1.PrometheusSpec{
-					OverrideHonorLabels: false,
-					ServiceMonitorSelector: &metav1.LabelSelector{
-						MatchLabels: map[string]string{
-							"group": "group1",
+					CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+						OverrideHonorLabels: false,
+						ServiceMonitorSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								"group": "group1",
+							},
 						},
 					},
 				},
@@ -3026,6 +3868,7 @@ oauth2:
 				tt.sMons,
 				tt.pMons,
 				tt.probes,
+				nil,
 				&assets.Store{
 					BasicAuthAssets: map[string]assets.BasicAuthCredentials{},
 					OAuth2Assets:    tt.oauth2Credentials,
@@ -3058,9 +3901,11 @@ func TestPodTargetLabels(t *testing.T) {
 				Namespace: "default",
 			},
 			Spec: monitoringv1.PrometheusSpec{
-				ServiceMonitorSelector: &metav1.LabelSelector{
-					MatchLabels: map[string]string{
-						"group": "group1",
+				CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+					ServiceMonitorSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							"group": "group1",
+						},
 					},
 				},
 			},
@@ -3087,6 +3932,7 @@ func TestPodTargetLabels(t *testing.T) {
 		},
 		nil,
 		nil,
+		nil,
 		&assets.Store{},
 		nil,
 		nil,
@@ -3196,9 +4042,11 @@ func TestPodTargetLabelsFromPodMonitor(t *testing.T) {
 				Namespace: "default",
 			},
 			Spec: monitoringv1.PrometheusSpec{
-				ServiceMonitorSelector: &metav1.LabelSelector{
-					MatchLabels: map[string]string{
-						"group": "group1",
+				CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+					ServiceMonitorSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							"group": "group1",
+						},
 					},
 				},
 			},
@@ -3225,6 +4073,7 @@ func TestPodTargetLabelsFromPodMonitor(t *testing.T) {
 			},
 		},
 		nil,
+		nil,
 		&assets.Store{},
 		nil,
 		nil,
@@ -3336,6 +4185,7 @@ func TestEmptyEndointPorts(t *testing.T) {
 		},
 		nil,
 		nil,
+		nil,
 		&assets.Store{},
 		nil,
 		nil,
@@ -3435,6 +4285,32 @@ func generateTestConfig(version string) ([]byte, error) {
 				Namespace: "default",
 			},
 			Spec: monitoringv1.PrometheusSpec{
+				CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+					ExternalLabels: map[string]string{
+						"label1": "value1",
+						"label2": "value2",
+					},
+					Version:  version,
+					Replicas: &replicas,
+					ServiceMonitorSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							"group": "group1",
+						},
+					},
+					PodMonitorSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							"group": "group1",
+						},
+					},
+					Resources: v1.ResourceRequirements{
+						Requests: v1.ResourceList{
+							v1.ResourceMemory: resource.MustParse("400Mi"),
+						},
+					},
+					RemoteWrite: []monitoringv1.RemoteWriteSpec{{
+						URL: "https://example.com/remote_write",
+					}},
+				},
 				Alerting: &monitoringv1.AlertingSpec{
 					Alertmanagers: []monitoringv1.AlertmanagerEndpoints{
 						{
@@ -3444,43 +4320,20 @@ func generateTestConfig(version string) ([]byte, error) {
 						},
 					},
 				},
-				ExternalLabels: map[string]string{
-					"label1": "value1",
-					"label2": "value2",
-				},
-				Version:  version,
-				Replicas: &replicas,
-				ServiceMonitorSelector: &metav1.LabelSelector{
-					MatchLabels: map[string]string{
-						"group": "group1",
-					},
-				},
-				PodMonitorSelector: &metav1.LabelSelector{
-					MatchLabels: map[string]string{
-						"group": "group1",
-					},
-				},
 				RuleSelector: &metav1.LabelSelector{
 					MatchLabels: map[string]string{
 						"role": "rulefile",
 					},
 				},
-				Resources: v1.ResourceRequirements{
-					Requests: v1.ResourceList{
-						v1.ResourceMemory: resource.MustParse("400Mi"),
-					},
-				},
 				RemoteRead: []monitoringv1.RemoteReadSpec{{
 					URL: "https://example.com/remote_read",
 				}},
-				RemoteWrite: []monitoringv1.RemoteWriteSpec{{
-					URL: "https://example.com/remote_write",
-				}},
 			},
 		},
 		makeServiceMonitors(),
 		makePodMonitors(),
 		nil,
+		nil,
 		&assets.Store{},
 		nil,
 		nil,
@@ -4067,10 +4920,12 @@ alerting:
 					Namespace: "default",
 				},
 				Spec: monitoringv1.PrometheusSpec{
-					Version: "v2.20.0",
-					ServiceMonitorSelector: &metav1.LabelSelector{
-						MatchLabels: map[string]string{
-							"group": "group1",
+					CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+						Version: "v2.20.0",
+						ServiceMonitorSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								"group": "group1",
+							},
 						},
 					},
 				},
@@ -4108,6 +4963,7 @@ alerting:
 				},
 				nil,
 				nil,
+				nil,
 				&assets.Store{},
 				nil,
 				nil,
@@ -4337,10 +5193,12 @@ alerting:
 					Namespace: "default",
 				},
 				Spec: monitoringv1.PrometheusSpec{
-					Version: tc.version,
-					ServiceMonitorSelector: &metav1.LabelSelector{
-						MatchLabels: map[string]string{
-							"group": "group1",
+					CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+						Version: tc.version,
+						ServiceMonitorSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								"group": "group1",
+							},
 						},
 					},
 				},
@@ -4378,6 +5236,7 @@ alerting:
 				},
 				nil,
 				nil,
+				nil,
 				&assets.Store{},
 				nil,
 				nil,
@@ -4512,10 +5371,12 @@ remote_read:
 					Namespace: "default",
 				},
 				Spec: monitoringv1.PrometheusSpec{
-					Version: tc.version,
-					ServiceMonitorSelector: &metav1.LabelSelector{
-						MatchLabels: map[string]string{
-							"group": "group1",
+					CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+						Version: tc.version,
+						ServiceMonitorSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								"group": "group1",
+							},
 						},
 					},
 					RemoteRead: []monitoringv1.RemoteReadSpec{tc.remoteRead},
@@ -4527,6 +5388,7 @@ remote_read:
 				nil,
 				nil,
 				nil,
+				nil,
 				&assets.Store{
 					BasicAuthAssets: map[string]assets.BasicAuthCredentials{},
 					OAuth2Assets: map[string]assets.OAuth2Credentials{
@@ -4557,6 +5419,8 @@ remote_read:
 }
 
 func TestRemoteWriteConfig(t *testing.T) {
+	azureCloud := "AzureGovernment"
+
 	for _, tc := range []struct {
 		version     string
 		remoteWrite monitoringv1.RemoteWriteSpec
@@ -4817,11 +5681,142 @@ remote_write:
   authorization:
     type: Bearer
     credentials: secret
+`,
+		},
+		{
+			version: "v2.26.0",
+			remoteWrite: monitoringv1.RemoteWriteSpec{
+				URL: "http://example.com",
+				Sigv4: &monitoringv1.Sigv4{
+					Region: "us-central-0",
+					AccessKey: &v1.SecretKeySelector{
+						LocalObjectReference: v1.LocalObjectReference{
+							Name: "sigv4-secret",
+						},
+						Key: "access-key",
+					},
+					SecretKey: &v1.SecretKeySelector{
+						LocalObjectReference: v1.LocalObjectReference{
+							Name: "sigv4-secret",
+						},
+						Key: "secret-key",
+					},
+					Profile: "profilename",
+					RoleArn: "arn:aws:iam::123456789012:instance-profile/prometheus",
+				},
+			},
+			expected: `global:
+  evaluation_interval: 30s
+  scrape_interval: 30s
+  external_labels:
+    prometheus: default/test
+    prometheus_replica: $(POD_NAME)
+rule_files: []
+scrape_configs: []
+alerting:
+  alert_relabel_configs:
+  - action: labeldrop
+    regex: prometheus_replica
+  alertmanagers: []
+remote_write:
+- url: http://example.com
+  remote_timeout: 30s
+  sigv4:
+    region: us-central-0
+    access_key: access-key
+    secret_key: secret-key
+    profile: profilename
+    role_arn: arn:aws:iam::123456789012:instance-profile/prometheus
+`,
+		},
+		{
+			version: "v2.25.0",
+			remoteWrite: monitoringv1.RemoteWriteSpec{
+				URL:   "http://example.com",
+				Sigv4: &monitoringv1.Sigv4{Region: "us-central-0"},
+			},
+			expected: `global:
+  evaluation_interval: 30s
+  scrape_interval: 30s
+  external_labels:
+    prometheus: default/test
+    prometheus_replica: $(POD_NAME)
+rule_files: []
+scrape_configs: []
+alerting:
+  alert_relabel_configs:
+  - action: labeldrop
+    regex: prometheus_replica
+  alertmanagers: []
+remote_write:
+- url: http://example.com
+  remote_timeout: 30s
+`,
+		},
+		{
+			version: "v2.45.0",
+			remoteWrite: monitoringv1.RemoteWriteSpec{
+				URL: "http://example.com",
+				AzureAD: &monitoringv1.AzureAD{
+					Cloud: &azureCloud,
+					ManagedIdentity: monitoringv1.ManagedIdentity{
+						ClientID: "00000000-a12b-3cd4-e56f-000000000000",
+					},
+				},
+			},
+			expected: `global:
+  evaluation_interval: 30s
+  scrape_interval: 30s
+  external_labels:
+    prometheus: default/test
+    prometheus_replica: $(POD_NAME)
+rule_files: []
+scrape_configs: []
+alerting:
+  alert_relabel_configs:
+  - action: labeldrop
+    regex: prometheus_replica
+  alertmanagers: []
+remote_write:
+- url: http://example.com
+  remote_timeout: 30s
+  azuread:
+    cloud: AzureGovernment
+    managed_identity:
+      client_id: 00000000-a12b-3cd4-e56f-000000000000
+`,
+		},
+		{
+			version: "v2.44.0",
+			remoteWrite: monitoringv1.RemoteWriteSpec{
+				URL: "http://example.com",
+				AzureAD: &monitoringv1.AzureAD{
+					ManagedIdentity: monitoringv1.ManagedIdentity{
+						ClientID: "00000000-a12b-3cd4-e56f-000000000000",
+					},
+				},
+			},
+			expected: `global:
+  evaluation_interval: 30s
+  scrape_interval: 30s
+  external_labels:
+    prometheus: default/test
+    prometheus_replica: $(POD_NAME)
+rule_files: []
+scrape_configs: []
+alerting:
+  alert_relabel_configs:
+  - action: labeldrop
+    regex: prometheus_replica
+  alertmanagers: []
+remote_write:
+- url: http://example.com
+  remote_timeout: 30s
 `,
 		},
 	} {
 		t.Run(fmt.Sprintf("version=%s", tc.version), func(t *testing.T) {
-			cg := &ConfigGenerator{}
+			cg := NewConfigGenerator(log.NewNopLogger())
 
 			prometheus := monitoringv1.Prometheus{
 				ObjectMeta: metav1.ObjectMeta{
@@ -4829,13 +5824,15 @@ remote_write:
 					Namespace: "default",
 				},
 				Spec: monitoringv1.PrometheusSpec{
-					Version: tc.version,
-					ServiceMonitorSelector: &metav1.LabelSelector{
-						MatchLabels: map[string]string{
-							"group": "group1",
+					CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+						Version: tc.version,
+						ServiceMonitorSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								"group": "group1",
+							},
 						},
+						RemoteWrite: []monitoringv1.RemoteWriteSpec{tc.remoteWrite},
 					},
-					RemoteWrite: []monitoringv1.RemoteWriteSpec{tc.remoteWrite},
 				},
 			}
 
@@ -4844,6 +5841,7 @@ remote_write:
 				nil,
 				nil,
 				nil,
+				nil,
 				&assets.Store{
 					BasicAuthAssets: map[string]assets.BasicAuthCredentials{},
 					OAuth2Assets: map[string]assets.OAuth2Credentials{
@@ -4854,6 +5852,12 @@ remote_write:
 					},
 					TokenAssets: map[string]assets.Token{
 						"remoteWrite/auth/0": assets.Token("secret"),
+					},
+					SigV4Assets: map[string]assets.SigV4Credentials{
+						"remoteWrite/0": {
+							AccessKeyID: "access-key",
+							SecretKeyID: "secret-key",
+						},
 					}},
 				nil,
 				nil,
@@ -5083,10 +6087,12 @@ alerting:
 					Namespace: "default",
 				},
 				Spec: monitoringv1.PrometheusSpec{
-					Version: tc.version,
-					ServiceMonitorSelector: &metav1.LabelSelector{
-						MatchLabels: map[string]string{
-							"group": "group1",
+					CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+						Version: tc.version,
+						ServiceMonitorSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								"group": "group1",
+							},
 						},
 					},
 				},
@@ -5124,6 +6130,7 @@ alerting:
 				},
 				nil,
 				nil,
+				nil,
 				&assets.Store{},
 				nil,
 				nil,
@@ -5315,10 +6322,12 @@ alerting:
 					Namespace: "default",
 				},
 				Spec: monitoringv1.PrometheusSpec{
-					Version: tc.version,
-					ServiceMonitorSelector: &metav1.LabelSelector{
-						MatchLabels: map[string]string{
-							"group": "group1",
+					CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+						Version: tc.version,
+						ServiceMonitorSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								"group": "group1",
+							},
 						},
 					},
 				},
@@ -5356,6 +6365,7 @@ alerting:
 					"testpodmonitor1": &podMonitor,
 				},
 				nil,
+				nil,
 				&assets.Store{},
 				nil,
 				nil,
@@ -5525,10 +6535,12 @@ alerting:
 					Namespace: "default",
 				},
 				Spec: monitoringv1.PrometheusSpec{
-					Version: tc.version,
-					ServiceMonitorSelector: &metav1.LabelSelector{
-						MatchLabels: map[string]string{
-							"group": "group1",
+					CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+						Version: tc.version,
+						ServiceMonitorSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								"group": "group1",
+							},
 						},
 					},
 				},
@@ -5578,6 +6590,7 @@ alerting:
 				map[string]*monitoringv1.Probe{
 					"testprobe1": &probe,
 				},
+				nil,
 				&assets.Store{},
 				nil,
 				nil,
@@ -5596,3 +6609,984 @@ alerting:
 		})
 	}
 }
+
+func TestGenerateAgentConfig(t *testing.T) {
+	cg := &ConfigGenerator{}
+	cfg, err := cg.GenerateAgentConfig(
+		&monitoringv1.Prometheus{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "default",
+			},
+			Spec: monitoringv1.PrometheusSpec{
+				CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+					RemoteWrite: []monitoringv1.RemoteWriteSpec{
+						{
+							URL: "http://example.com",
+						},
+					},
+				},
+			},
+		},
+		nil,
+		map[string]*monitoringv1.PodMonitor{
+			"default/pm": {
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pm",
+					Namespace: "default",
+				},
+				Spec: monitoringv1.PodMonitorSpec{
+					Selector: metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							"app": "x",
+						},
+					},
+					PodMetricsEndpoints: []monitoringv1.PodMetricsEndpoint{
+						{
+							Port: "web",
+						},
+					},
+				},
+			},
+		},
+		nil,
+		nil,
+		&assets.Store{},
+		nil,
+		true,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `global:
+  scrape_interval: 30s
+  external_labels:
+    prometheus: default/test
+    prometheus_replica: $(POD_NAME)
+scrape_configs:
+- job_name: podMonitor/default/pm/0
+  honor_labels: false
+  kubernetes_sd_configs:
+  - role: pod
+    namespaces:
+      names:
+      - default
+  relabel_configs:
+  - source_labels:
+    - job
+    target_label: __tmp_prometheus_job_name
+  - action: keep
+    source_labels:
+    - __meta_kubernetes_pod_label_app
+    regex: x
+  - action: keep
+    source_labels:
+    - __meta_kubernetes_pod_container_port_name
+    regex: web
+  - source_labels:
+    - __meta_kubernetes_namespace
+    target_label: namespace
+  - source_labels:
+    - __meta_kubernetes_pod_container_name
+    target_label: container
+  - source_labels:
+    - __meta_kubernetes_pod_name
+    target_label: pod
+  - target_label: job
+    replacement: default/pm
+  - target_label: endpoint
+    replacement: web
+  - source_labels:
+    - __address__
+    target_label: __tmp_hash
+    modulus: 1
+    action: hashmod
+  - source_labels:
+    - __tmp_hash
+    regex: $(SHARD)
+    action: keep
+  - source_labels:
+    - __meta_kubernetes_pod_node_name
+    regex: $(NODE_NAME)
+    action: keep
+remote_write:
+- url: http://example.com
+  remote_timeout: 30s
+`
+
+	result := string(cfg)
+
+	if expected != result {
+		fmt.Println(pretty.Compare(expected, result))
+		t.Fatal("expected Prometheus agent configuration and actual configuration do not match")
+	}
+}
+
+func TestScrapeConfigGeneration(t *testing.T) {
+	region := "us-east-1"
+	scheme := "HTTPS"
+	sc := &monitoringv1alpha1.ScrapeConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "testscrapeconfig1",
+			Namespace: "default",
+		},
+		Spec: monitoringv1alpha1.ScrapeConfigSpec{
+			Scheme: &scheme,
+			StaticConfigs: []monitoringv1alpha1.StaticConfig{
+				{
+					Targets: []string{"http://localhost:9100"},
+					Labels: map[string]string{
+						"static": "label",
+					},
+				},
+			},
+			ConsulSDConfigs: []monitoringv1alpha1.ConsulSDConfig{
+				{
+					Server: "localhost:8500",
+					TokenRef: &v1.SecretKeySelector{
+						LocalObjectReference: v1.LocalObjectReference{Name: "consul"},
+						Key:                  "token",
+					},
+					Services: []string{"web"},
+				},
+			},
+			EC2SDConfigs: []monitoringv1alpha1.EC2SDConfig{
+				{
+					Region: &region,
+					AccessKey: &v1.SecretKeySelector{
+						LocalObjectReference: v1.LocalObjectReference{Name: "aws"},
+						Key:                  "access",
+					},
+					SecretKey: &v1.SecretKeySelector{
+						LocalObjectReference: v1.LocalObjectReference{Name: "aws"},
+						Key:                  "secret",
+					},
+					Filters: []monitoringv1alpha1.EC2Filter{
+						{
+							Name:   "tag:env",
+							Values: []string{"prod"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	cg := &ConfigGenerator{}
+	cfg, err := cg.GenerateConfig(
+		&monitoringv1.Prometheus{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "default",
+			},
+		},
+		nil,
+		nil,
+		nil,
+		map[string]*monitoringv1alpha1.ScrapeConfig{
+			"default/testscrapeconfig1": sc,
+		},
+		&assets.Store{
+			TokenAssets: map[string]assets.Token{
+				"scrapeConfig/default/testscrapeconfig1/consulsdconfig/0/token":  "consul-token",
+				"scrapeConfig/default/testscrapeconfig1/ec2sdconfig/0/accesskey": "access-key",
+				"scrapeConfig/default/testscrapeconfig1/ec2sdconfig/0/secretkey": "secret-key",
+			},
+		},
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `global:
+  evaluation_interval: 30s
+  scrape_interval: 30s
+  external_labels:
+    prometheus: default/test
+    prometheus_replica: $(POD_NAME)
+rule_files: []
+scrape_configs:
+- job_name: scrapeConfig/default/testscrapeconfig1
+  scheme: https
+  static_configs:
+  - targets:
+    - http://localhost:9100
+    labels:
+      static: label
+  consul_sd_configs:
+  - server: localhost:8500
+    token: consul-token
+    services:
+    - web
+  ec2_sd_configs:
+  - region: us-east-1
+    access_key: access-key
+    secret_key: secret-key
+    filters:
+    - name: tag:env
+      values:
+      - prod
+  relabel_configs:
+  - source_labels:
+    - job
+    target_label: __tmp_prometheus_job_name
+  - source_labels:
+    - __address__
+    target_label: __tmp_hash
+    modulus: 1
+    action: hashmod
+  - source_labels:
+    - __tmp_hash
+    regex: $(SHARD)
+    action: keep
+alerting:
+  alert_relabel_configs:
+  - action: labeldrop
+    regex: prometheus_replica
+  alertmanagers: []
+`
+
+	result := string(cfg)
+	if expected != result {
+		t.Logf("\n%s", pretty.Compare(expected, result))
+		t.Fatal("expected Prometheus configuration and actual configuration do not match")
+	}
+}
+
+func TestScrapeClasses(t *testing.T) {
+	p := &monitoringv1.Prometheus{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "monitoring",
+		},
+		Spec: monitoringv1.PrometheusSpec{
+			CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+				ScrapeClasses: []monitoringv1.ScrapeClass{
+					{
+						Name:    "default",
+						Default: pointer.Bool(true),
+						TLSConfig: &monitoringv1.TLSConfig{
+							SafeTLSConfig: monitoringv1.SafeTLSConfig{
+								CA: monitoringv1.SecretOrConfigMap{
+									Secret: &v1.SecretKeySelector{
+										LocalObjectReference: v1.LocalObjectReference{Name: "tls"},
+										Key:                  "ca.crt",
+									},
+								},
+							},
+						},
+						Authorization: &monitoringv1.Authorization{
+							SafeAuthorization: monitoringv1.SafeAuthorization{
+								Credentials: &v1.SecretKeySelector{
+									LocalObjectReference: v1.LocalObjectReference{Name: "auth"},
+									Key:                  "token",
+								},
+							},
+						},
+						Relabelings: []*monitoringv1.RelabelConfig{
+							{TargetLabel: "cluster", Replacement: "prod"},
+						},
+						MetricRelabelings: []*monitoringv1.RelabelConfig{
+							{Action: "drop", SourceLabels: []string{"__name__"}, Regex: "go_.*"},
+						},
+					},
+					{
+						Name:          "files",
+						TLSConfig:     &monitoringv1.TLSConfig{CAFile: "/etc/ca.crt"},
+						Authorization: &monitoringv1.Authorization{CredentialsFile: "/etc/token"},
+					},
+				},
+			},
+		},
+	}
+
+	store := &assets.Store{
+		BasicAuthAssets: map[string]assets.BasicAuthCredentials{
+			"serviceMonitor/default/basicauth/0": {Username: "user", Password: "pass"},
+		},
+		OAuth2Assets: map[string]assets.OAuth2Credentials{},
+		TokenAssets: map[string]assets.Token{
+			"scrapeClass/auth/default": "class-token",
+		},
+	}
+
+	for _, tc := range []struct {
+		name        string
+		sMons       map[string]*monitoringv1.ServiceMonitor
+		pMons       map[string]*monitoringv1.PodMonitor
+		probes      map[string]*monitoringv1.Probe
+		expected    []string
+		notExpected []string
+	}{
+		{
+			name: "service monitor using the default class",
+			sMons: map[string]*monitoringv1.ServiceMonitor{
+				"default/sm": {
+					ObjectMeta: metav1.ObjectMeta{Name: "sm", Namespace: "default"},
+					Spec: monitoringv1.ServiceMonitorSpec{
+						Endpoints: []monitoringv1.Endpoint{
+							{
+								Port: "web",
+								RelabelConfigs: []*monitoringv1.RelabelConfig{
+									{TargetLabel: "team", Replacement: "a"},
+								},
+								MetricRelabelConfigs: []*monitoringv1.RelabelConfig{
+									{Action: "drop", SourceLabels: []string{"__name__"}, Regex: "up"},
+								},
+							},
+						},
+					},
+				},
+			},
+			expected: []string{`
+  tls_config:
+    insecure_skip_verify: false
+    ca_file: /etc/prometheus/certs/secret_monitoring_tls_ca.crt
+  authorization:
+    type: Bearer
+    credentials: class-token`, `
+  - target_label: cluster
+    replacement: prod
+  - target_label: team
+    replacement: a`, `
+  metric_relabel_configs:
+  - source_labels:
+    - __name__
+    regex: go_.*
+    action: drop
+  - source_labels:
+    - __name__
+    regex: up
+    action: drop`,
+			},
+		},
+		{
+			name: "service monitor with its own TLS and authentication",
+			sMons: map[string]*monitoringv1.ServiceMonitor{
+				"default/basicauth": {
+					ObjectMeta: metav1.ObjectMeta{Name: "basicauth", Namespace: "default"},
+					Spec: monitoringv1.ServiceMonitorSpec{
+						Endpoints: []monitoringv1.Endpoint{
+							{
+								Port:      "web",
+								TLSConfig: &monitoringv1.TLSConfig{CAFile: "/etc/own-ca.crt"},
+								BasicAuth: &monitoringv1.BasicAuth{},
+							},
+						},
+					},
+				},
+			},
+			expected: []string{`
+  tls_config:
+    insecure_skip_verify: false
+    ca_file: /etc/own-ca.crt
+  basic_auth:
+    username: user
+    password: pass`,
+			},
+			notExpected: []string{"secret_monitoring_tls_ca.crt", "class-token"},
+		},
+		{
+			name: "service monitor merging its TLS config with the default class",
+			sMons: map[string]*monitoringv1.ServiceMonitor{
+				"default/merge": {
+					ObjectMeta: metav1.ObjectMeta{Name: "merge", Namespace: "default"},
+					Spec: monitoringv1.ServiceMonitorSpec{
+						Endpoints: []monitoringv1.Endpoint{
+							{
+								Port: "web",
+								TLSConfig: &monitoringv1.TLSConfig{
+									SafeTLSConfig: monitoringv1.SafeTLSConfig{
+										ServerName:         "example.com",
+										InsecureSkipVerify: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expected: []string{`
+  tls_config:
+    insecure_skip_verify: true
+    server_name: example.com
+    ca_file: /etc/prometheus/certs/secret_monitoring_tls_ca.crt`,
+			},
+		},
+		{
+			name: "pod monitor selecting a class",
+			pMons: map[string]*monitoringv1.PodMonitor{
+				"default/pm": {
+					ObjectMeta: metav1.ObjectMeta{Name: "pm", Namespace: "default"},
+					Spec: monitoringv1.PodMonitorSpec{
+						ScrapeClassName: pointer.String("files"),
+						PodMetricsEndpoints: []monitoringv1.PodMetricsEndpoint{
+							{Port: "web"},
+						},
+					},
+				},
+			},
+			expected: []string{`
+  tls_config:
+    insecure_skip_verify: false
+    ca_file: /etc/ca.crt
+  authorization:
+    type: Bearer
+    credentials_file: /etc/token`,
+			},
+			notExpected: []string{"cluster", "metric_relabel_configs"},
+		},
+		{
+			name: "probe using the default class",
+			probes: map[string]*monitoringv1.Probe{
+				"default/probe": {
+					ObjectMeta: metav1.ObjectMeta{Name: "probe", Namespace: "default"},
+					Spec: monitoringv1.ProbeSpec{
+						ProberSpec: monitoringv1.ProberSpec{URL: "blackbox:9115"},
+						Targets: monitoringv1.ProbeTargets{
+							StaticConfig: &monitoringv1.ProbeTargetStaticConfig{
+								Targets: []string{"example.com"},
+							},
+						},
+					},
+				},
+			},
+			expected: []string{`
+  - target_label: cluster
+    replacement: prod`, `
+  tls_config:
+    insecure_skip_verify: false
+    ca_file: /etc/prometheus/certs/secret_monitoring_tls_ca.crt
+  authorization:
+    type: Bearer
+    credentials: class-token`, `
+  metric_relabel_configs:
+  - source_labels:
+    - __name__
+    regex: go_.*
+    action: drop`,
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cg := NewConfigGenerator(log.NewNopLogger())
+			cfg, err := cg.GenerateConfig(p, tc.sMons, tc.pMons, tc.probes, nil, store, nil, nil, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			result := string(cfg)
+			for _, s := range tc.expected {
+				if !strings.Contains(result, s) {
+					t.Fatalf("expected Prometheus configuration to contain:\n%s\nFull config:\n%s", s, result)
+				}
+			}
+			for _, s := range tc.notExpected {
+				if strings.Contains(result, s) {
+					t.Fatalf("expected Prometheus configuration not to contain %q\nFull config:\n%s", s, result)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateScrapeClasses(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		classes []monitoringv1.ScrapeClass
+		err     bool
+	}{
+		{
+			name: "valid",
+			classes: []monitoringv1.ScrapeClass{
+				{Name: "a", Default: pointer.Bool(true)},
+				{Name: "b", Default: pointer.Bool(false)},
+				{Name: "c"},
+			},
+		},
+		{
+			name:    "empty name",
+			classes: []monitoringv1.ScrapeClass{{Name: ""}},
+			err:     true,
+		},
+		{
+			name:    "duplicate name",
+			classes: []monitoringv1.ScrapeClass{{Name: "a"}, {Name: "a"}},
+			err:     true,
+		},
+		{
+			name: "several defaults",
+			classes: []monitoringv1.ScrapeClass{
+				{Name: "a", Default: pointer.Bool(true)},
+				{Name: "b", Default: pointer.Bool(true)},
+			},
+			err: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p := &monitoringv1.Prometheus{Spec: monitoringv1.PrometheusSpec{
+				CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+					ScrapeClasses: tc.classes,
+				},
+			}}
+			err := validateScrapeClasses(p)
+			if tc.err && err == nil {
+				t.Fatal("expected error, got none")
+			}
+			if !tc.err && err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestCredentialsAsFiles(t *testing.T) {
+	sMons := map[string]*monitoringv1.ServiceMonitor{
+		"default/sm": {
+			ObjectMeta: metav1.ObjectMeta{Name: "sm", Namespace: "default"},
+			Spec: monitoringv1.ServiceMonitorSpec{
+				Endpoints: []monitoringv1.Endpoint{
+					{
+						Port:      "web",
+						BasicAuth: &monitoringv1.BasicAuth{},
+					},
+					{
+						Port: "web",
+						BearerTokenSecret: v1.SecretKeySelector{
+							LocalObjectReference: v1.LocalObjectReference{Name: "token"},
+							Key:                  "token",
+						},
+					},
+					{
+						Port: "web",
+						Authorization: &monitoringv1.SafeAuthorization{
+							Credentials: &v1.SecretKeySelector{
+								LocalObjectReference: v1.LocalObjectReference{Name: "auth"},
+								Key:                  "credentials",
+							},
+						},
+					},
+					{
+						Port: "web",
+						OAuth2: &monitoringv1.OAuth2{
+							TokenURL: "http://example.com/token",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	store := &assets.Store{
+		BasicAuthAssets: map[string]assets.BasicAuthCredentials{
+			"serviceMonitor/default/sm/0": {Username: "user", Password: "pass"},
+		},
+		OAuth2Assets: map[string]assets.OAuth2Credentials{
+			"serviceMonitor/default/sm/3": {ClientID: "client", ClientSecret: "client-secret"},
+		},
+		TokenAssets: map[string]assets.Token{
+			"serviceMonitor/default/sm/1":      "bearer-token",
+			"serviceMonitor/auth/default/sm/2": "auth-token",
+		},
+	}
+
+	for _, tc := range []struct {
+		name               string
+		credentialsAsFiles bool
+		expected           []string
+		notExpected        []string
+	}{
+		{
+			name: "inlined credentials",
+			expected: []string{`
+  basic_auth:
+    username: user
+    password: pass`, `
+  bearer_token: bearer-token`, `
+  authorization:
+    type: Bearer
+    credentials: auth-token`, `
+    client_secret: client-secret`,
+			},
+			notExpected: []string{credentialsDir},
+		},
+		{
+			name:               "credentials as files",
+			credentialsAsFiles: true,
+			expected: []string{`
+  basic_auth:
+    username: user
+    password_file: /etc/prometheus/credentials/serviceMonitor_default_sm_0_password`, `
+  bearer_token_file: /etc/prometheus/credentials/serviceMonitor_default_sm_1_token`, `
+  authorization:
+    type: Bearer
+    credentials_file: /etc/prometheus/credentials/serviceMonitor_auth_default_sm_2_token`, `
+    client_secret_file: /etc/prometheus/credentials/serviceMonitor_default_sm_3_client_secret`,
+			},
+			notExpected: []string{"pass\n", "bearer-token", "auth-token", "client-secret"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p := &monitoringv1.Prometheus{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+				Spec: monitoringv1.PrometheusSpec{
+					CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+						CredentialsAsFiles: tc.credentialsAsFiles,
+					},
+				},
+			}
+
+			cg := NewConfigGenerator(log.NewNopLogger())
+			cfg, err := cg.GenerateConfig(p, sMons, nil, nil, nil, store, nil, nil, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			result := string(cfg)
+			for _, s := range tc.expected {
+				if !strings.Contains(result, s) {
+					t.Fatalf("expected Prometheus configuration to contain:\n%s\nFull config:\n%s", s, result)
+				}
+			}
+			for _, s := range tc.notExpected {
+				if strings.Contains(result, s) {
+					t.Fatalf("expected Prometheus configuration not to contain %q\nFull config:\n%s", s, result)
+				}
+			}
+		})
+	}
+
+	expected := map[string][]byte{
+		"serviceMonitor_default_sm_0_password":      []byte("pass"),
+		"serviceMonitor_default_sm_1_token":         []byte("bearer-token"),
+		"serviceMonitor_auth_default_sm_2_token":    []byte("auth-token"),
+		"serviceMonitor_default_sm_3_client_secret": []byte("client-secret"),
+	}
+	if diff := cmp.Diff(expected, makeCredentialsSecretData(store)); diff != "" {
+		t.Fatalf("unexpected credentials secret data (-want +got):\n%s", diff)
+	}
+}
+
+func TestCredentialsAsFilesInlinedSigv4(t *testing.T) {
+	p := &monitoringv1.Prometheus{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: monitoringv1.PrometheusSpec{
+			CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+				CredentialsAsFiles: true,
+				RemoteWrite: []monitoringv1.RemoteWriteSpec{
+					{
+						URL: "http://example.com",
+						Sigv4: &monitoringv1.Sigv4{
+							Region: "us-central-0",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	store := &assets.Store{
+		SigV4Assets: map[string]assets.SigV4Credentials{
+			"remoteWrite/0": {AccessKeyID: "access-key", SecretKeyID: "secret-key"},
+		},
+	}
+
+	var buf bytes.Buffer
+	cg := NewConfigGenerator(log.NewLogfmtLogger(&buf))
+	cfg, err := cg.GenerateConfig(p, nil, nil, nil, nil, store, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Prometheus can't read the SigV4 keys from files.
+	expected := `
+  sigv4:
+    region: us-central-0
+    access_key: access-key
+    secret_key: secret-key`
+	if !strings.Contains(string(cfg), expected) {
+		t.Fatalf("expected Prometheus configuration to contain:\n%s\nFull config:\n%s", expected, cfg)
+	}
+
+	for _, field := range []string{"access_key", "secret_key"} {
+		if !strings.Contains(buf.String(), "field="+field) {
+			t.Fatalf("expected a warning about %q, got:\n%s", field, buf.String())
+		}
+	}
+}
+
+func TestScrapeConfigCache(t *testing.T) {
+	p := &monitoringv1.Prometheus{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+	}
+
+	sm := &monitoringv1.ServiceMonitor{
+		ObjectMeta: metav1.ObjectMeta{Name: "sm", Namespace: "default", ResourceVersion: "1"},
+		Spec: monitoringv1.ServiceMonitorSpec{
+			Endpoints: []monitoringv1.Endpoint{
+				{
+					Port:      "web",
+					BasicAuth: &monitoringv1.BasicAuth{},
+				},
+			},
+		},
+	}
+
+	store := &assets.Store{
+		BasicAuthAssets: map[string]assets.BasicAuthCredentials{
+			"serviceMonitor/default/sm/0": {Username: "user", Password: "pass"},
+		},
+	}
+
+	cg := NewConfigGenerator(log.NewNopLogger())
+	generate := func() string {
+		t.Helper()
+		cfg, err := cg.GenerateConfig(p, map[string]*monitoringv1.ServiceMonitor{"default/sm": sm}, nil, nil, nil, store, nil, nil, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(cfg)
+	}
+	contains := func(cfg, s string) {
+		t.Helper()
+		if !strings.Contains(cfg, s) {
+			t.Fatalf("expected Prometheus configuration to contain %q\nFull config:\n%s", s, cfg)
+		}
+	}
+
+	contains(generate(), "scrape_interval: 30s")
+
+	// The cached configuration is used as long as the resource version
+	// doesn't change.
+	sm.Spec.Endpoints[0].Interval = "10s"
+	contains(generate(), "scrape_interval: 30s")
+
+	sm.ResourceVersion = "2"
+	contains(generate(), "scrape_interval: 10s")
+
+	// Changes of the referenced credentials invalidate the cache.
+	store.BasicAuthAssets["serviceMonitor/default/sm/0"] = assets.BasicAuthCredentials{Username: "user", Password: "newpass"}
+	contains(generate(), "password: newpass")
+
+	// Changes of the Prometheus settings invalidate the cache.
+	sampleLimit := uint64(100)
+	p.Spec.EnforcedSampleLimit = &sampleLimit
+	contains(generate(), "sample_limit: 100")
+
+	p.Spec.Shards = pointer.Int32(2)
+	contains(generate(), "modulus: 2")
+
+	// The cached configuration is identical to the generated one.
+	cached := generate()
+	uncached, err := NewConfigGenerator(log.NewNopLogger()).GenerateConfig(p, map[string]*monitoringv1.ServiceMonitor{"default/sm": sm}, nil, nil, nil, store, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(string(uncached), cached); diff != "" {
+		t.Fatalf("unexpected cached configuration (-want +got):\n%s", diff)
+	}
+
+	// Monitors which aren't selected anymore are dropped from the cache.
+	if _, err := cg.GenerateConfig(p, nil, nil, nil, nil, store, nil, nil, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(cg.scrapeConfigCache.entries[scrapeConfigCacheKey(p)]); n != 0 {
+		t.Fatalf("expected no cached entry, got %d", n)
+	}
+
+	cg.forgetScrapeConfigs(monitoringv1.PrometheusesKind, "default/test")
+	if n := len(cg.scrapeConfigCache.entries); n != 0 {
+		t.Fatalf("expected no cached Prometheus, got %d", n)
+	}
+}
+
+func TestScrapeConfigCacheNodeLocal(t *testing.T) {
+	p := &monitoringv1.Prometheus{
+		TypeMeta:   metav1.TypeMeta{Kind: monitoringv1alpha1.PrometheusAgentsKind},
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+	}
+
+	sMons := map[string]*monitoringv1.ServiceMonitor{
+		"default/sm": {
+			ObjectMeta: metav1.ObjectMeta{Name: "sm", Namespace: "default", ResourceVersion: "1"},
+			Spec: monitoringv1.ServiceMonitorSpec{
+				Endpoints: []monitoringv1.Endpoint{{Port: "web"}},
+			},
+		},
+	}
+
+	cg := NewConfigGenerator(log.NewNopLogger())
+
+	var prev []byte
+	for i := 0; i < 2; i++ {
+		cfg, err := cg.GenerateAgentConfig(p, sMons, nil, nil, nil, &assets.Store{}, nil, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if n := strings.Count(string(cfg), "$(NODE_NAME)"); n != 1 {
+			t.Fatalf("expected 1 node-local relabeling, got %d\nFull config:\n%s", n, string(cfg))
+		}
+
+		if prev != nil && !bytes.Equal(prev, cfg) {
+			t.Fatalf("expected identical configurations, got:\n%s\nand:\n%s", string(prev), string(cfg))
+		}
+		prev = cfg
+	}
+}
+
+func makeServiceMonitorsForBenchmark(n int) map[string]*monitoringv1.ServiceMonitor {
+	sMons := make(map[string]*monitoringv1.ServiceMonitor, n)
+	for i := 0; i < n; i++ {
+		name := fmt.Sprintf("sm%d", i)
+		sMons["default/"+name] = &monitoringv1.ServiceMonitor{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Namespace:       "default",
+				ResourceVersion: "1",
+			},
+			Spec: monitoringv1.ServiceMonitorSpec{
+				Selector: metav1.LabelSelector{
+					MatchLabels: map[string]string{"app": name},
+				},
+				Endpoints: []monitoringv1.Endpoint{
+					{
+						Port:     "web",
+						Interval: "30s",
+						RelabelConfigs: []*monitoringv1.RelabelConfig{
+							{TargetLabel: "team", Replacement: "a"},
+						},
+						MetricRelabelConfigs: []*monitoringv1.RelabelConfig{
+							{Action: "drop", SourceLabels: []string{"__name__"}, Regex: "go_.*"},
+						},
+					},
+				},
+			},
+		}
+	}
+	return sMons
+}
+
+func BenchmarkGenerateConfig(b *testing.B) {
+	p := &monitoringv1.Prometheus{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+	}
+	sMons := makeServiceMonitorsForBenchmark(5000)
+
+	generate := func(b *testing.B, cg *ConfigGenerator) {
+		if _, err := cg.GenerateConfig(p, sMons, nil, nil, nil, &assets.Store{}, nil, nil, nil, nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	b.Run("uncached", func(b *testing.B) {
+		cg := NewConfigGenerator(log.NewNopLogger())
+		cg.scrapeConfigCache = nil
+
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			generate(b, cg)
+		}
+	})
+
+	b.Run("cached", func(b *testing.B) {
+		cg := NewConfigGenerator(log.NewNopLogger())
+		generate(b, cg)
+
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			generate(b, cg)
+		}
+	})
+
+	b.Run("cached with one changed monitor", func(b *testing.B) {
+		cg := NewConfigGenerator(log.NewNopLogger())
+		generate(b, cg)
+
+		sm := sMons["default/sm0"]
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			sm.ResourceVersion = fmt.Sprintf("%d", i+2)
+			generate(b, cg)
+		}
+	})
+}
+
+func TestScrapeOptions(t *testing.T) {
+	var (
+		boolTrue           = true
+		boolFalse          = false
+		bucketLimit        = uint64(160)
+		defaultBucketLimit = uint64(80)
+	)
+
+	for _, tc := range []struct {
+		name     string
+		version  string
+		opts     monitoringv1.ScrapeOptions
+		defaults monitoringv1.ScrapeOptions
+		expected yaml.MapSlice
+	}{
+		{
+			name:    "monitor settings",
+			version: "v2.49.0",
+			opts: monitoringv1.ScrapeOptions{
+				ScrapeProtocols:            []monitoringv1.ScrapeProtocol{"PrometheusProto"},
+				ScrapeClassicHistograms:    &boolTrue,
+				NativeHistogramBucketLimit: &bucketLimit,
+				EnableCompression:          &boolFalse,
+			},
+			defaults: monitoringv1.ScrapeOptions{
+				ScrapeProtocols:            []monitoringv1.ScrapeProtocol{"OpenMetricsText1.0.0"},
+				NativeHistogramBucketLimit: &defaultBucketLimit,
+			},
+			expected: yaml.MapSlice{
+				{Key: "scrape_protocols", Value: []monitoringv1.ScrapeProtocol{"PrometheusProto"}},
+				{Key: "scrape_classic_histograms", Value: true},
+				{Key: "native_histogram_bucket_limit", Value: bucketLimit},
+				{Key: "enable_compression", Value: false},
+			},
+		},
+		{
+			name:    "prometheus defaults",
+			version: "v2.49.0",
+			defaults: monitoringv1.ScrapeOptions{
+				ScrapeProtocols:            []monitoringv1.ScrapeProtocol{"OpenMetricsText1.0.0"},
+				NativeHistogramBucketLimit: &defaultBucketLimit,
+				EnableCompression:          &boolTrue,
+			},
+			expected: yaml.MapSlice{
+				{Key: "native_histogram_bucket_limit", Value: defaultBucketLimit},
+				{Key: "enable_compression", Value: true},
+			},
+		},
+		{
+			name:    "unsupported version",
+			version: "v2.45.0",
+			opts: monitoringv1.ScrapeOptions{
+				ScrapeProtocols:         []monitoringv1.ScrapeProtocol{"PrometheusProto"},
+				ScrapeClassicHistograms: &boolTrue,
+				EnableCompression:       &boolFalse,
+			},
+			expected: yaml.MapSlice{
+				{Key: "scrape_classic_histograms", Value: true},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cg := NewConfigGenerator(log.NewNopLogger())
+			cfg := cg.addScrapeOptions(nil, semver.MustParse(strings.TrimPrefix(tc.version, "v")), tc.opts, tc.defaults, "ServiceMonitor", "test")
+			if diff := pretty.Compare(cfg, tc.expected); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2023 The prometheus-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	"github.com/pkg/errors"
+	"github.com/prometheus/common/model"
+
+	v1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+)
+
+// relabelActionsMinVersion maps the relabeling actions to the minimum
+// Prometheus version supporting them.
+var relabelActionsMinVersion = map[string]string{
+	"replace":   "2.0.0",
+	"keep":      "2.0.0",
+	"drop":      "2.0.0",
+	"hashmod":   "2.0.0",
+	"labelmap":  "2.0.0",
+	"labeldrop": "2.0.0",
+	"labelkeep": "2.0.0",
+	"lowercase": "2.36.0",
+	"uppercase": "2.36.0",
+	"keepequal": "2.41.0",
+	"dropequal": "2.41.0",
+}
+
+// validateRelabelConfig returns an error if the relabeling configuration
+// would be rejected by the given Prometheus version.
+func validateRelabelConfig(rc v1.RelabelConfig, version semver.Version) error {
+	action := strings.ToLower(rc.Action)
+	if action == "" {
+		action = "replace"
+	}
+
+	minVersion, found := relabelActionsMinVersion[action]
+	if !found {
+		return errors.Errorf("unknown relabel action %q", rc.Action)
+	}
+	if version.LT(semver.MustParse(minVersion)) {
+		return errors.Errorf("relabel action %q requires Prometheus >= %s, got %s", action, minVersion, version)
+	}
+
+	if rc.Regex != "" {
+		if _, err := regexp.Compile("^(?:" + rc.Regex + ")$"); err != nil {
+			return errors.Wrapf(err, "invalid regex %q", rc.Regex)
+		}
+	}
+
+	switch action {
+	case "replace", "hashmod", "lowercase", "uppercase", "keepequal", "dropequal":
+		if rc.TargetLabel == "" {
+			return errors.Errorf("relabel action %q requires targetLabel", action)
+		}
+		if !strings.Contains(rc.TargetLabel, "$") && !model.LabelName(rc.TargetLabel).IsValid() {
+			return errors.Errorf("invalid targetLabel %q for relabel action %q", rc.TargetLabel, action)
+		}
+	}
+
+	if action == "hashmod" && rc.Modulus == 0 {
+		return errors.New("relabel action \"hashmod\" requires a non-zero modulus")
+	}
+
+	return nil
+}
+
+// validateAlertRelabelConfigs checks the alert relabeling configurations of
+// the alerting section.
+func validateAlertRelabelConfigs(alerting *v1.AlertingSpec, version semver.Version) error {
+	if alerting == nil {
+		return nil
+	}
+
+	for i, rc := range alerting.AlertRelabelConfigs {
+		if err := validateRelabelConfig(rc, version); err != nil {
+			return errors.Wrapf(err, "alerting.alertRelabelConfigs[%d]", i)
+		}
+	}
+
+	for i, am := range alerting.Alertmanagers {
+		if len(am.AlertRelabelConfigs) > 0 && version.LT(semver.MustParse("2.51.0")) {
+			return errors.Errorf("alerting.alertmanagers[%d].alertRelabelConfigs requires Prometheus >= 2.51.0, got %s", i, version)
+		}
+
+		for j, rc := range am.AlertRelabelConfigs {
+			if err := validateRelabelConfig(rc, version); err != nil {
+				return errors.Wrapf(err, "alerting.alertmanagers[%d].alertRelabelConfigs[%d]", i, j)
+			}
+		}
+	}
+
+	return nil
+}
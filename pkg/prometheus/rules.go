@@ -16,6 +16,7 @@ package prometheus
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"sort"
@@ -37,7 +38,17 @@ import (
 	"github.com/pkg/errors"
 )
 
-const labelPrometheusName = "prometheus-name"
+const (
+	labelPrometheusName = "prometheus-name"
+
+	// ruleShardAnnotation is the PrometheusRule annotation assigning the
+	// rules to a shard when rule sharding is enabled.
+	ruleShardAnnotation = "operator.prometheus.io/shard"
+	// ruleGroupShardsAnnotation is the PrometheusRule annotation assigning
+	// individual rule groups to shards when rule sharding is enabled. Its
+	// value is a JSON object mapping the group names to the shards.
+	ruleGroupShardsAnnotation = "operator.prometheus.io/group-shards"
+)
 
 // The maximum `Data` size of a ConfigMap seems to differ between
 // environments. This is probably due to different meta data sizes which count
@@ -45,7 +56,14 @@ const labelPrometheusName = "prometheus-name"
 // large buffer.
 var maxConfigMapDataSize = int(float64(v1.MaxSecretSize) * 0.5)
 
-func (c *Operator) createOrUpdateRuleConfigMaps(ctx context.Context, p *monitoringv1.Prometheus) ([]string, error) {
+// createOrUpdateRuleConfigMaps returns the names of the rule ConfigMaps
+// for each shard. It returns a single set of names shared by all the shards
+// when rule sharding is disabled.
+func (c *Operator) createOrUpdateRuleConfigMaps(ctx context.Context, p *monitoringv1.Prometheus) ([][]string, error) {
+	if err := validateRuleSharding(p); err != nil {
+		return nil, err
+	}
+
 	cClient := c.kclient.CoreV1().ConfigMaps(p.Namespace)
 
 	namespaces, err := c.selectRuleNamespaces(p)
@@ -53,7 +71,7 @@ func (c *Operator) createOrUpdateRuleConfigMaps(ctx context.Context, p *monitori
 		return nil, err
 	}
 
-	newRules, err := c.selectRules(p, namespaces)
+	newRules, ruleShards, err := c.selectRules(p, namespaces)
 	if err != nil {
 		return nil, err
 	}
@@ -64,35 +82,45 @@ func (c *Operator) createOrUpdateRuleConfigMaps(ctx context.Context, p *monitori
 	}
 	currentConfigMaps := currentConfigMapList.Items
 
-	currentRules := map[string]string{}
-	for _, cm := range currentConfigMaps {
-		for ruleFileName, ruleFile := range cm.Data {
-			currentRules[ruleFileName] = ruleFile
+	shardConfigMaps, err := makeRulesConfigMaps(p, newRules, ruleShards)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to make rules ConfigMaps")
+	}
+
+	var (
+		newConfigMaps     []v1.ConfigMap
+		newConfigMapNames []string
+		shardNames        = make([][]string, 0, len(shardConfigMaps))
+	)
+	for _, configMaps := range shardConfigMaps {
+		names := []string{}
+		for _, cm := range configMaps {
+			newConfigMaps = append(newConfigMaps, cm)
+			names = append(names, cm.Name)
 		}
+		newConfigMapNames = append(newConfigMapNames, names...)
+		shardNames = append(shardNames, names)
 	}
 
-	equal := reflect.DeepEqual(newRules, currentRules)
-	if equal && len(currentConfigMaps) != 0 {
+	// The ConfigMaps are compared by name because the same rule files may be
+	// assigned to different shards.
+	currentData := map[string]map[string]string{}
+	for _, cm := range currentConfigMaps {
+		currentData[cm.Name] = cm.Data
+	}
+	newData := map[string]map[string]string{}
+	for _, cm := range newConfigMaps {
+		newData[cm.Name] = cm.Data
+	}
+
+	if len(currentConfigMaps) != 0 && equalRulesConfigMapData(currentData, newData) {
 		level.Debug(c.logger).Log(
 			"msg", "no PrometheusRule changes",
 			"namespace", p.Namespace,
 			"prometheus", p.Name,
 		)
-		currentConfigMapNames := []string{}
-		for _, cm := range currentConfigMaps {
-			currentConfigMapNames = append(currentConfigMapNames, cm.Name)
-		}
-		return currentConfigMapNames, nil
-	}
-
-	newConfigMaps, err := makeRulesConfigMaps(p, newRules)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to make rules ConfigMaps")
-	}
-
-	newConfigMapNames := []string{}
-	for _, cm := range newConfigMaps {
-		newConfigMapNames = append(newConfigMapNames, cm.Name)
+		c.updateRuleMetrics(p, newConfigMapNames, newRules)
+		return shardNames, nil
 	}
 
 	if len(currentConfigMaps) == 0 {
@@ -107,7 +135,8 @@ func (c *Operator) createOrUpdateRuleConfigMaps(ctx context.Context, p *monitori
 				return nil, errors.Wrapf(err, "failed to create ConfigMap '%v'", cm.Name)
 			}
 		}
-		return newConfigMapNames, nil
+		c.updateRuleMetrics(p, newConfigMapNames, newRules)
+		return shardNames, nil
 	}
 
 	// Simply deleting old ConfigMaps and creating new ones for now. Could be
@@ -131,7 +160,49 @@ func (c *Operator) createOrUpdateRuleConfigMaps(ctx context.Context, p *monitori
 		}
 	}
 
-	return newConfigMapNames, nil
+	c.updateRuleMetrics(p, newConfigMapNames, newRules)
+	return shardNames, nil
+}
+
+// equalRulesConfigMapData returns true if both maps hold the same
+// ConfigMaps. The nil and empty data are considered equal.
+func equalRulesConfigMapData(a, b map[string]map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for name, data := range a {
+		other, found := b[name]
+		if !found {
+			return false
+		}
+		if len(data) == 0 && len(other) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(data, other) {
+			return false
+		}
+	}
+	return true
+}
+
+// ruleConfigMapNamesForShard returns the names of the rule ConfigMaps
+// mounted by the given shard.
+func ruleConfigMapNamesForShard(names [][]string, shard int32) []string {
+	if len(names) == 0 {
+		return nil
+	}
+	if int(shard) >= len(names) {
+		return names[0]
+	}
+	return names[shard]
+}
+
+// updateRuleMetrics tracks the number of rule ConfigMaps and the total size
+// of the rule files generated for the given Prometheus object.
+func (c *Operator) updateRuleMetrics(p *monitoringv1.Prometheus, configMapNames []string, ruleFiles map[string]string) {
+	if key, ok := c.keyFunc(p); ok {
+		c.metrics.SetRuleConfigMaps(key, len(configMapNames), bucketSize(ruleFiles))
+	}
 }
 
 func prometheusRulesConfigMapSelector(prometheusName string) metav1.ListOptions {
@@ -166,12 +237,21 @@ func (c *Operator) selectRuleNamespaces(p *monitoringv1.Prometheus) ([]string, e
 	return namespaces, nil
 }
 
-func (c *Operator) selectRules(p *monitoringv1.Prometheus, namespaces []string) (map[string]string, error) {
+// selectRules returns the rule files of the selected PrometheusRules and,
+// when rule sharding is enabled, the shard evaluating each rule file.
+func (c *Operator) selectRules(p *monitoringv1.Prometheus, namespaces []string) (map[string]string, map[string]int32, error) {
 	rules := map[string]string{}
+	ruleShards := map[string]int32{}
+
+	// The feature is disabled when the operator isn't allowed to watch the
+	// PrometheusRules.
+	if c.ruleInfs == nil {
+		return rules, ruleShards, nil
+	}
 
 	ruleSelector, err := metav1.LabelSelectorAsSelector(p.Spec.RuleSelector)
 	if err != nil {
-		return rules, errors.Wrap(err, "convert rule label selector to selector")
+		return rules, ruleShards, errors.Wrap(err, "convert rule label selector to selector")
 	}
 
 	nsLabeler := namespacelabeler.New(
@@ -180,6 +260,7 @@ func (c *Operator) selectRules(p *monitoringv1.Prometheus, namespaces []string)
 		true,
 	)
 
+	var rejected int
 	for _, ns := range namespaces {
 		var marshalErr error
 		err := c.ruleInfs.ListAllByNamespace(ns, ruleSelector, func(obj interface{}) {
@@ -190,18 +271,53 @@ func (c *Operator) selectRules(p *monitoringv1.Prometheus, namespaces []string)
 				return
 			}
 
-			content, err := GenerateContent(promRule.Spec, c.logger)
+			filename := fmt.Sprintf("%v-%v.yaml", promRule.Namespace, promRule.Name)
+			if p.Spec.RuleSharding == nil {
+				content, err := GenerateContent(promRule.Spec, c.logger)
+				if err != nil {
+					marshalErr = err
+					return
+				}
+				rules[filename] = content
+				return
+			}
+
+			shardSpecs, err := ruleShardSpecs(p, promRule)
 			if err != nil {
-				marshalErr = err
+				rejected++
+				level.Warn(c.logger).Log(
+					"msg", "skipping prometheusrule",
+					"error", err.Error(),
+					"prometheusrule", promRule.Name,
+					"prometheusrule_namespace", promRule.Namespace,
+					"namespace", p.Namespace,
+					"prometheus", p.Name,
+				)
 				return
 			}
-			rules[fmt.Sprintf("%v-%v.yaml", promRule.Namespace, promRule.Name)] = content
+
+			defaultShard := shardSpecs[0].shard
+			for _, ss := range shardSpecs {
+				content, err := GenerateContent(ss.spec, c.logger)
+				if err != nil {
+					marshalErr = err
+					return
+				}
+				// The groups assigned to another shard than the one of the
+				// PrometheusRule are written to a dedicated file.
+				shardFilename := filename
+				if ss.shard != defaultShard {
+					shardFilename = fmt.Sprintf("%v-%v-shard-%d.yaml", promRule.Namespace, promRule.Name, ss.shard)
+				}
+				rules[shardFilename] = content
+				ruleShards[shardFilename] = ss.shard
+			}
 		})
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		if marshalErr != nil {
-			return nil, marshalErr
+			return nil, nil, marshalErr
 		}
 	}
 
@@ -219,21 +335,179 @@ func (c *Operator) selectRules(p *monitoringv1.Prometheus, namespaces []string)
 
 	if pKey, ok := c.keyFunc(p); ok {
 		c.metrics.SetSelectedResources(pKey, monitoringv1.PrometheusRuleKind, len(rules))
-		c.metrics.SetRejectedResources(pKey, monitoringv1.PrometheusRuleKind, 0)
+		c.metrics.SetRejectedResources(pKey, monitoringv1.PrometheusRuleKind, rejected)
+	}
+
+	return rules, ruleShards, nil
+}
+
+// validateRuleSharding returns an error if the default shard of the rule
+// sharding doesn't exist.
+func validateRuleSharding(p *monitoringv1.Prometheus) error {
+	if p.Spec.RuleSharding == nil {
+		return nil
+	}
+
+	shards := shardCount(p)
+	if defaultShard := p.Spec.RuleSharding.DefaultShard; defaultShard < 0 || defaultShard >= shards {
+		return errors.Errorf("ruleSharding.defaultShard must be between 0 and %d, got %d", shards-1, defaultShard)
+	}
+
+	return nil
+}
+
+// ruleShard returns the shard evaluating the given PrometheusRule: the one
+// set by its annotation or the default shard when the annotation is missing.
+// The default shard is checked by validateRuleSharding.
+func ruleShard(p *monitoringv1.Prometheus, promRule *monitoringv1.PrometheusRule) (int32, error) {
+	value, found := promRule.Annotations[ruleShardAnnotation]
+	if !found {
+		return p.Spec.RuleSharding.DefaultShard, nil
+	}
+
+	shard, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid %s annotation %q", ruleShardAnnotation, value)
+	}
+	if shards := shardCount(p); shard < 0 || int32(shard) >= shards {
+		return 0, errors.Errorf("invalid %s annotation %q: shard must be between 0 and %d", ruleShardAnnotation, value, shards-1)
 	}
 
-	return rules, nil
+	return int32(shard), nil
+}
+
+// ruleGroupShards returns the shards of the rule groups listed in the group
+// shards annotation of the given PrometheusRule.
+func ruleGroupShards(p *monitoringv1.Prometheus, promRule *monitoringv1.PrometheusRule) (map[string]int32, error) {
+	value, found := promRule.Annotations[ruleGroupShardsAnnotation]
+	if !found {
+		return nil, nil
+	}
+
+	var groupShards map[string]int32
+	if err := json.Unmarshal([]byte(value), &groupShards); err != nil {
+		return nil, errors.Wrapf(err, "invalid %s annotation", ruleGroupShardsAnnotation)
+	}
+
+	groups := make(map[string]struct{}, len(promRule.Spec.Groups))
+	for _, g := range promRule.Spec.Groups {
+		groups[g.Name] = struct{}{}
+	}
+
+	shards := shardCount(p)
+	for name, shard := range groupShards {
+		if _, found := groups[name]; !found {
+			return nil, errors.Errorf("invalid %s annotation: unknown rule group %q", ruleGroupShardsAnnotation, name)
+		}
+		if shard < 0 || shard >= shards {
+			return nil, errors.Errorf("invalid %s annotation: shard of rule group %q must be between 0 and %d", ruleGroupShardsAnnotation, name, shards-1)
+		}
+	}
+
+	return groupShards, nil
+}
+
+type shardRuleSpec struct {
+	shard int32
+	spec  monitoringv1.PrometheusRuleSpec
+}
+
+// ruleShardSpecs splits the rule groups of the given PrometheusRule by shard.
+// The first item holds the groups evaluated by the shard of the
+// PrometheusRule, it is always present even if all the groups are assigned to
+// other shards. The other items are sorted by shard.
+func ruleShardSpecs(p *monitoringv1.Prometheus, promRule *monitoringv1.PrometheusRule) ([]shardRuleSpec, error) {
+	defaultShard, err := ruleShard(p, promRule)
+	if err != nil {
+		return nil, err
+	}
+
+	groupShards, err := ruleGroupShards(p, promRule)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(groupShards) == 0 {
+		return []shardRuleSpec{{shard: defaultShard, spec: promRule.Spec}}, nil
+	}
+
+	specs := map[int32]*monitoringv1.PrometheusRuleSpec{
+		defaultShard: {Groups: []monitoringv1.RuleGroup{}},
+	}
+	for _, g := range promRule.Spec.Groups {
+		shard, found := groupShards[g.Name]
+		if !found {
+			shard = defaultShard
+		}
+		if specs[shard] == nil {
+			specs[shard] = &monitoringv1.PrometheusRuleSpec{}
+		}
+		specs[shard].Groups = append(specs[shard].Groups, g)
+	}
+
+	res := []shardRuleSpec{{shard: defaultShard, spec: *specs[defaultShard]}}
+	delete(specs, defaultShard)
+
+	others := make([]int, 0, len(specs))
+	for shard := range specs {
+		others = append(others, int(shard))
+	}
+	sort.Ints(others)
+	for _, shard := range others {
+		res = append(res, shardRuleSpec{shard: int32(shard), spec: *specs[int32(shard)]})
+	}
+
+	return res, nil
 }
 
 // makeRulesConfigMaps takes a Prometheus configuration and rule files and
-// returns a list of Kubernetes ConfigMaps to be later on mounted into the
-// Prometheus instance.
+// returns the lists of Kubernetes ConfigMaps to be later on mounted into the
+// Prometheus instances of each shard.
+// When rule sharding is disabled, a single list shared by all the shards is
+// returned. Otherwise the rule files are grouped by the shards given in
+// ruleShards.
+func makeRulesConfigMaps(p *monitoringv1.Prometheus, ruleFiles map[string]string, ruleShards map[string]int32) ([][]v1.ConfigMap, error) {
+	if p.Spec.RuleSharding == nil {
+		configMaps, err := makeShardRulesConfigMaps(p, 0, ruleFiles)
+		if err != nil {
+			return nil, err
+		}
+		return [][]v1.ConfigMap{configMaps}, nil
+	}
+
+	shards := shardCount(p)
+	shardFiles := make([]map[string]string, shards)
+	for i := range shardFiles {
+		shardFiles[i] = map[string]string{}
+	}
+	for filename, file := range ruleFiles {
+		shard := ruleShards[filename]
+		if shard < 0 || shard >= shards {
+			shard = 0
+		}
+		shardFiles[shard][filename] = file
+	}
+
+	res := make([][]v1.ConfigMap, 0, shards)
+	for shard, files := range shardFiles {
+		configMaps, err := makeShardRulesConfigMaps(p, int32(shard), files)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, configMaps)
+	}
+
+	return res, nil
+}
+
+// makeShardRulesConfigMaps returns the ConfigMaps holding the rule files of a
+// shard.
 // If the total size of rule files exceeds the Kubernetes ConfigMap limit,
 // they are split up via the simple first-fit [1] bin packing algorithm. In the
 // future this can be replaced by a more sophisticated algorithm, but for now
 // simplicity should be sufficient.
 // [1] https://en.wikipedia.org/wiki/Bin_packing_problem#First-fit_algorithm
-func makeRulesConfigMaps(p *monitoringv1.Prometheus, ruleFiles map[string]string) ([]v1.ConfigMap, error) {
+func makeShardRulesConfigMaps(p *monitoringv1.Prometheus, shard int32, ruleFiles map[string]string) ([]v1.ConfigMap, error) {
 	//check if none of the rule files is too large for a single ConfigMap
 	for filename, file := range ruleFiles {
 		if len(file) > maxConfigMapDataSize {
@@ -269,7 +543,7 @@ func makeRulesConfigMaps(p *monitoringv1.Prometheus, ruleFiles map[string]string
 	ruleFileConfigMaps := []v1.ConfigMap{}
 	for i, bucket := range buckets {
 		cm := makeRulesConfigMap(p, bucket)
-		cm.Name = cm.Name + "-" + strconv.Itoa(i)
+		cm.Name = prometheusNameByShard(p.Name, shard) + "-rulefiles-" + strconv.Itoa(i)
 		ruleFileConfigMaps = append(ruleFileConfigMaps, cm)
 	}
 
@@ -20,6 +20,8 @@ import (
 	"testing"
 
 	"github.com/go-kit/log"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
@@ -30,6 +32,7 @@ func TestMakeRulesConfigMaps(t *testing.T) {
 	t.Run("ShouldReturnAtLeastOneConfigMap", shouldReturnAtLeastOneConfigMap)
 	t.Run("ShouldErrorOnTooLargeRuleFile", shouldErrorOnTooLargeRuleFile)
 	t.Run("ShouldSplitUpLargeSmallIntoTwo", shouldSplitUpLargeSmallIntoTwo)
+	t.Run("ShouldGroupRuleFilesByShard", shouldGroupRuleFilesByShard)
 	t.Run("ShouldAcceptValidRule", shouldAcceptValidRule)
 	t.Run("shouldAcceptRuleWithValidPartialResponseStrategyValue", shouldAcceptRuleWithValidPartialResponseStrategyValue)
 	t.Run("shouldRejectRuleWithInvalidLabels", shouldRejectRuleWithInvalidLabels)
@@ -147,10 +150,11 @@ func shouldReturnAtLeastOneConfigMap(t *testing.T) {
 	p := &monitoringv1.Prometheus{}
 	ruleFiles := map[string]string{}
 
-	configMaps, err := makeRulesConfigMaps(p, ruleFiles)
+	shardConfigMaps, err := makeRulesConfigMaps(p, ruleFiles, nil)
 	if err != nil {
 		t.Fatalf("expected no error but got: %v", err.Error())
 	}
+	configMaps := shardConfigMaps[0]
 
 	if len(configMaps) != 1 {
 		t.Fatalf("expected one ConfigMaps but got %v", len(configMaps))
@@ -164,7 +168,7 @@ func shouldErrorOnTooLargeRuleFile(t *testing.T) {
 
 	ruleFiles["my-rule-file"] = strings.Repeat("a", v1.MaxSecretSize+1)
 
-	_, err := makeRulesConfigMaps(p, ruleFiles)
+	_, err := makeRulesConfigMaps(p, ruleFiles, nil)
 	if err == nil || err.Error() != expectedError {
 		t.Fatalf("expected makeRulesConfigMaps to return error '%v' but got '%v'", expectedError, err)
 	}
@@ -177,10 +181,11 @@ func shouldSplitUpLargeSmallIntoTwo(t *testing.T) {
 	ruleFiles["first"] = strings.Repeat("a", maxConfigMapDataSize)
 	ruleFiles["second"] = "a"
 
-	configMaps, err := makeRulesConfigMaps(p, ruleFiles)
+	shardConfigMaps, err := makeRulesConfigMaps(p, ruleFiles, nil)
 	if err != nil {
 		t.Fatalf("expected no error but got: %v", err)
 	}
+	configMaps := shardConfigMaps[0]
 
 	if len(configMaps) != 2 {
 		t.Fatalf("expected rule files to be split up into two ConfigMaps, but got '%v' instead", len(configMaps))
@@ -190,3 +195,201 @@ func shouldSplitUpLargeSmallIntoTwo(t *testing.T) {
 		t.Fatal("expected ConfigMap data to match rule file content")
 	}
 }
+
+func shouldGroupRuleFilesByShard(t *testing.T) {
+	shards := int32(3)
+	p := &monitoringv1.Prometheus{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec: monitoringv1.PrometheusSpec{
+			CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+				Shards: &shards,
+			},
+			RuleSharding: &monitoringv1.RuleShardingSpec{},
+		},
+	}
+	ruleFiles := map[string]string{
+		"default-a.yaml": "a",
+		"default-b.yaml": "b",
+		"default-c.yaml": "c",
+	}
+	ruleShards := map[string]int32{
+		"default-a.yaml": 0,
+		"default-b.yaml": 2,
+		"default-c.yaml": 2,
+	}
+
+	shardConfigMaps, err := makeRulesConfigMaps(p, ruleFiles, ruleShards)
+	require.NoError(t, err)
+	require.Len(t, shardConfigMaps, 3)
+
+	for shard, expected := range []struct {
+		name string
+		data map[string]string
+	}{
+		{
+			name: "prometheus-test-rulefiles-0",
+			data: map[string]string{"default-a.yaml": "a"},
+		},
+		{
+			name: "prometheus-test-shard-1-rulefiles-0",
+			data: map[string]string{},
+		},
+		{
+			name: "prometheus-test-shard-2-rulefiles-0",
+			data: map[string]string{"default-b.yaml": "b", "default-c.yaml": "c"},
+		},
+	} {
+		require.Len(t, shardConfigMaps[shard], 1)
+		require.Equal(t, expected.name, shardConfigMaps[shard][0].Name)
+		require.Equal(t, expected.data, shardConfigMaps[shard][0].Data)
+	}
+}
+
+func TestRuleShardSpecs(t *testing.T) {
+	shards := int32(2)
+	groups := []monitoringv1.RuleGroup{{Name: "a"}, {Name: "b"}}
+
+	for _, tc := range []struct {
+		name         string
+		defaultShard int32
+		annotations  map[string]string
+		expected     []shardRuleSpec
+		expectedErr  bool
+	}{
+		{
+			name:     "no annotation",
+			expected: []shardRuleSpec{{shard: 0, spec: monitoringv1.PrometheusRuleSpec{Groups: groups}}},
+		},
+		{
+			name:         "no annotation with default shard",
+			defaultShard: 1,
+			expected:     []shardRuleSpec{{shard: 1, spec: monitoringv1.PrometheusRuleSpec{Groups: groups}}},
+		},
+		{
+			name:        "annotation",
+			annotations: map[string]string{ruleShardAnnotation: "1"},
+			expected:    []shardRuleSpec{{shard: 1, spec: monitoringv1.PrometheusRuleSpec{Groups: groups}}},
+		},
+		{
+			name:        "annotation out of range",
+			annotations: map[string]string{ruleShardAnnotation: "2"},
+			expectedErr: true,
+		},
+		{
+			name:        "invalid annotation",
+			annotations: map[string]string{ruleShardAnnotation: "first"},
+			expectedErr: true,
+		},
+		{
+			name:        "group annotation",
+			annotations: map[string]string{ruleGroupShardsAnnotation: `{"b": 1}`},
+			expected: []shardRuleSpec{
+				{shard: 0, spec: monitoringv1.PrometheusRuleSpec{Groups: []monitoringv1.RuleGroup{{Name: "a"}}}},
+				{shard: 1, spec: monitoringv1.PrometheusRuleSpec{Groups: []monitoringv1.RuleGroup{{Name: "b"}}}},
+			},
+		},
+		{
+			name: "group annotation assigning all the groups",
+			annotations: map[string]string{
+				ruleShardAnnotation:       "1",
+				ruleGroupShardsAnnotation: `{"a": 0, "b": 0}`,
+			},
+			expected: []shardRuleSpec{
+				{shard: 1, spec: monitoringv1.PrometheusRuleSpec{Groups: []monitoringv1.RuleGroup{}}},
+				{shard: 0, spec: monitoringv1.PrometheusRuleSpec{Groups: groups}},
+			},
+		},
+		{
+			name:        "invalid group annotation",
+			annotations: map[string]string{ruleGroupShardsAnnotation: "b=1"},
+			expectedErr: true,
+		},
+		{
+			name:        "group annotation with unknown group",
+			annotations: map[string]string{ruleGroupShardsAnnotation: `{"c": 1}`},
+			expectedErr: true,
+		},
+		{
+			name:        "group annotation out of range",
+			annotations: map[string]string{ruleGroupShardsAnnotation: `{"b": 2}`},
+			expectedErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p := &monitoringv1.Prometheus{
+				Spec: monitoringv1.PrometheusSpec{
+					CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+						Shards: &shards,
+					},
+					RuleSharding: &monitoringv1.RuleShardingSpec{DefaultShard: tc.defaultShard},
+				},
+			}
+			promRule := &monitoringv1.PrometheusRule{
+				ObjectMeta: metav1.ObjectMeta{Name: "rules", Namespace: "default", Annotations: tc.annotations},
+				Spec:       monitoringv1.PrometheusRuleSpec{Groups: groups},
+			}
+
+			specs, err := ruleShardSpecs(p, promRule)
+			if tc.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, specs)
+		})
+	}
+}
+
+func TestValidateRuleSharding(t *testing.T) {
+	shards := int32(2)
+
+	for _, tc := range []struct {
+		name         string
+		ruleSharding *monitoringv1.RuleShardingSpec
+		ok           bool
+	}{
+		{
+			name: "no rule sharding",
+			ok:   true,
+		},
+		{
+			name:         "last shard",
+			ruleSharding: &monitoringv1.RuleShardingSpec{DefaultShard: 1},
+			ok:           true,
+		},
+		{
+			name:         "default shard out of range",
+			ruleSharding: &monitoringv1.RuleShardingSpec{DefaultShard: 2},
+		},
+		{
+			name:         "negative default shard",
+			ruleSharding: &monitoringv1.RuleShardingSpec{DefaultShard: -1},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p := &monitoringv1.Prometheus{
+				Spec: monitoringv1.PrometheusSpec{
+					CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+						Shards: &shards,
+					},
+					RuleSharding: tc.ruleSharding,
+				},
+			}
+
+			err := validateRuleSharding(p)
+			if tc.ok {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestRuleConfigMapNamesForShard(t *testing.T) {
+	shared := [][]string{{"prometheus-test-rulefiles-0"}}
+	require.Equal(t, []string{"prometheus-test-rulefiles-0"}, ruleConfigMapNamesForShard(shared, 1))
+
+	sharded := [][]string{{"prometheus-test-rulefiles-0"}, {"prometheus-test-shard-1-rulefiles-0"}}
+	require.Equal(t, []string{"prometheus-test-shard-1-rulefiles-0"}, ruleConfigMapNamesForShard(sharded, 1))
+}
@@ -0,0 +1,301 @@
+// Copyright 2023 The prometheus-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+
+	"github.com/blang/semver/v4"
+	"github.com/mitchellh/hashstructure"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/prometheus-operator/prometheus-operator/pkg/assets"
+)
+
+// scrapeConfigCache holds the scrape configurations generated for the
+// ServiceMonitor, PodMonitor and Probe objects selected by each Prometheus
+// object. An entry is reused as long as the monitor's resource version, the
+// settings of the Prometheus object affecting the generation and the
+// credentials referenced by the monitor don't change.
+type scrapeConfigCache struct {
+	mtx sync.Mutex
+	// entries are indexed by the key of the Prometheus object, then by the
+	// key of the monitor. The maps are never modified once they have been
+	// stored.
+	entries map[string]map[string]scrapeConfigCacheEntry
+}
+
+type scrapeConfigCacheEntry struct {
+	hash uint64
+	cfgs []yaml.MapSlice
+	// yaml is the YAML representation of cfgs.
+	yaml []byte
+}
+
+func newScrapeConfigCache() *scrapeConfigCache {
+	return &scrapeConfigCache{
+		entries: map[string]map[string]scrapeConfigCacheEntry{},
+	}
+}
+
+// scrapeConfigCacheGeneration tracks the entries used while generating the
+// configuration of a Prometheus object. The entries which aren't used are
+// dropped when the generation is committed.
+type scrapeConfigCacheGeneration struct {
+	cache            *scrapeConfigCache
+	key              string
+	store            *assets.Store
+	httpRouteTargets map[string][]targetGroup
+
+	// base is the hash of the Prometheus settings which apply to all the
+	// monitors, it's zero when the settings can't be hashed and caching is
+	// disabled.
+	base uint64
+	prev map[string]scrapeConfigCacheEntry
+	next map[string]scrapeConfigCacheEntry
+}
+
+// begin starts the generation of the scrape configurations for the given
+// Prometheus object.
+func (c *scrapeConfigCache) begin(
+	version semver.Version,
+	p *v1.Prometheus,
+	shards int32,
+	credentialsAsFiles bool,
+	store *assets.Store,
+	httpRouteTargets map[string][]targetGroup,
+) *scrapeConfigCacheGeneration {
+	g := &scrapeConfigCacheGeneration{
+		cache:            c,
+		key:              scrapeConfigCacheKey(p),
+		store:            store,
+		httpRouteTargets: httpRouteTargets,
+		next:             map[string]scrapeConfigCacheEntry{},
+	}
+
+	if c == nil || store == nil {
+		return g
+	}
+
+	base, err := hashstructure.Hash(struct {
+		Version                       semver.Version
+		Namespace                     string
+		APIServerConfig               *v1.APIServerConfig
+		APIServerAssets               []interface{}
+		OverrideHonorLabels           bool
+		OverrideHonorTimestamps       bool
+		IgnoreNamespaceSelectors      bool
+		EnforcedNamespaceLabel        string
+		EnforcedSampleLimit           *uint64
+		EnforcedTargetLimit           *uint64
+		EnforcedLabelLimit            *uint64
+		EnforcedLabelNameLengthLimit  *uint64
+		EnforcedLabelValueLengthLimit *uint64
+		ScrapeClasses                 []v1.ScrapeClass
+		ScrapeOptions                 v1.ScrapeOptions
+		Shards                        int32
+		CredentialsAsFiles            bool
+	}{
+		Version:                       version,
+		Namespace:                     p.Namespace,
+		APIServerConfig:               p.Spec.APIServerConfig,
+		APIServerAssets:               g.assets("apiserver", "apiserver/auth"),
+		OverrideHonorLabels:           p.Spec.OverrideHonorLabels,
+		OverrideHonorTimestamps:       p.Spec.OverrideHonorTimestamps,
+		IgnoreNamespaceSelectors:      p.Spec.IgnoreNamespaceSelectors,
+		EnforcedNamespaceLabel:        p.Spec.EnforcedNamespaceLabel,
+		EnforcedSampleLimit:           p.Spec.EnforcedSampleLimit,
+		EnforcedTargetLimit:           p.Spec.EnforcedTargetLimit,
+		EnforcedLabelLimit:            p.Spec.EnforcedLabelLimit,
+		EnforcedLabelNameLengthLimit:  p.Spec.EnforcedLabelNameLengthLimit,
+		EnforcedLabelValueLengthLimit: p.Spec.EnforcedLabelValueLengthLimit,
+		ScrapeClasses:                 p.Spec.ScrapeClasses,
+		ScrapeOptions:                 p.Spec.ScrapeOptions,
+		Shards:                        shards,
+		CredentialsAsFiles:            credentialsAsFiles,
+	}, nil)
+	if err != nil {
+		return g
+	}
+	g.base = base
+
+	c.mtx.Lock()
+	g.prev = c.entries[g.key]
+	c.mtx.Unlock()
+
+	return g
+}
+
+// assets returns the credentials and the resolved targets stored under the
+// given keys.
+func (g *scrapeConfigCacheGeneration) assets(keys ...string) []interface{} {
+	var ret []interface{}
+	for _, k := range keys {
+		ret = append(ret,
+			g.store.TokenAssets[k],
+			g.store.BasicAuthAssets[k],
+			g.store.OAuth2Assets[k],
+			g.httpRouteTargets[k],
+		)
+	}
+	return ret
+}
+
+// get returns the cached scrape configurations of the monitor if they are
+// still valid, otherwise it generates and caches them. The keys identify the
+// entries of the store referenced by the monitor.
+func (g *scrapeConfigCacheGeneration) get(kind string, m metav1.Object, keys []string, generate func() []yaml.MapSlice) (scrapeConfigCacheEntry, error) {
+	// Objects without resource version (e.g. in unit tests) can't be
+	// tracked.
+	if g.base == 0 || m.GetResourceVersion() == "" {
+		return newScrapeConfigCacheEntry(0, generate())
+	}
+
+	hash, err := hashstructure.Hash(struct {
+		Base            uint64
+		ResourceVersion string
+		Assets          []interface{}
+	}{
+		Base:            g.base,
+		ResourceVersion: m.GetResourceVersion(),
+		Assets:          g.assets(keys...),
+	}, nil)
+	if err != nil {
+		return newScrapeConfigCacheEntry(0, generate())
+	}
+
+	key := fmt.Sprintf("%s/%s/%s", kind, m.GetNamespace(), m.GetName())
+	if e, found := g.prev[key]; found && e.hash == hash {
+		g.next[key] = e
+		return e, nil
+	}
+
+	e, err := newScrapeConfigCacheEntry(hash, generate())
+	if err != nil {
+		return e, err
+	}
+	g.next[key] = e
+
+	return e, nil
+}
+
+func newScrapeConfigCacheEntry(hash uint64, cfgs []yaml.MapSlice) (scrapeConfigCacheEntry, error) {
+	e := scrapeConfigCacheEntry{hash: hash, cfgs: cfgs}
+	if len(cfgs) == 0 {
+		return e, nil
+	}
+
+	b, err := yaml.Marshal(cfgs)
+	if err != nil {
+		return e, errors.Wrap(err, "failed to marshal scrape configurations")
+	}
+	e.yaml = b
+
+	return e, nil
+}
+
+// commit replaces the cached entries of the Prometheus object by the entries
+// used during the generation.
+func (g *scrapeConfigCacheGeneration) commit() {
+	if g.base == 0 {
+		return
+	}
+
+	g.cache.mtx.Lock()
+	defer g.cache.mtx.Unlock()
+	g.cache.entries[g.key] = g.next
+}
+
+// forget drops the cached entries of the object identified by its kind and
+// its "<namespace>/<name>" key.
+func (c *scrapeConfigCache) forget(kind, key string) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	delete(c.entries, kind+"/"+key)
+}
+
+// forgetScrapeConfigs drops the cached scrape configurations of the object
+// identified by its kind and its "<namespace>/<name>" key.
+func (cg *ConfigGenerator) forgetScrapeConfigs(kind, key string) {
+	if cg.scrapeConfigCache == nil {
+		return
+	}
+	cg.scrapeConfigCache.forget(kind, key)
+}
+
+func scrapeConfigCacheKey(p *v1.Prometheus) string {
+	kind := v1.PrometheusesKind
+	if isAgent(p) {
+		kind = p.Kind
+	}
+	return fmt.Sprintf("%s/%s/%s", kind, p.Namespace, p.Name)
+}
+
+// scrapeConfigsPlaceholder is replaced by the rendered scrape configurations
+// in the marshaled configuration.
+const scrapeConfigsPlaceholder = "__prometheus_operator_scrape_configs__"
+
+// scrapeConfigList holds scrape configurations along with their YAML
+// representation. Marshaling the full configuration dominates the generation
+// time hence the cached YAML representations are spliced into the marshaled
+// configuration rather than marshaled again.
+type scrapeConfigList struct {
+	cfgs []yaml.MapSlice
+	yaml bytes.Buffer
+}
+
+func (l *scrapeConfigList) add(e scrapeConfigCacheEntry) {
+	l.cfgs = append(l.cfgs, e.cfgs...)
+	l.yaml.Write(e.yaml)
+}
+
+// addConfigs appends the given configurations to the list.
+func (l *scrapeConfigList) addConfigs(cfgs ...yaml.MapSlice) error {
+	e, err := newScrapeConfigCacheEntry(0, cfgs)
+	if err != nil {
+		return err
+	}
+	l.add(e)
+	return nil
+}
+
+// mapItem returns the scrape_configs item of the configuration.
+func (l *scrapeConfigList) mapItem() yaml.MapItem {
+	if len(l.cfgs) == 0 {
+		return yaml.MapItem{Key: "scrape_configs", Value: l.cfgs}
+	}
+	return yaml.MapItem{Key: "scrape_configs", Value: scrapeConfigsPlaceholder}
+}
+
+// marshal marshals the configuration which contains the item returned by
+// mapItem.
+func (l *scrapeConfigList) marshal(cfg yaml.MapSlice) ([]byte, error) {
+	b, err := yaml.Marshal(cfg)
+	if err != nil || len(l.cfgs) == 0 {
+		return b, err
+	}
+
+	return bytes.Replace(
+		b,
+		[]byte("scrape_configs: "+scrapeConfigsPlaceholder+"\n"),
+		append([]byte("scrape_configs:\n"), l.yaml.Bytes()...),
+		1,
+	), nil
+}
@@ -0,0 +1,195 @@
+// Copyright 2023 The prometheus-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/blang/semver/v4"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+
+	v1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/prometheus-operator/prometheus-operator/pkg/assets"
+)
+
+// validateScrapeClasses checks that the scrape classes have unique and
+// non-empty names and that at most one of them is the default class.
+func validateScrapeClasses(p *v1.Prometheus) error {
+	var (
+		names        = make(map[string]struct{}, len(p.Spec.ScrapeClasses))
+		defaultClass string
+	)
+
+	for _, sc := range p.Spec.ScrapeClasses {
+		if sc.Name == "" {
+			return errors.New("scrape class name must not be empty")
+		}
+
+		if _, found := names[sc.Name]; found {
+			return errors.Errorf("duplicate scrape class name %q", sc.Name)
+		}
+		names[sc.Name] = struct{}{}
+
+		if sc.Default != nil && *sc.Default {
+			if defaultClass != "" {
+				return errors.Errorf("scrape classes %q and %q are both marked as default", defaultClass, sc.Name)
+			}
+			defaultClass = sc.Name
+		}
+	}
+
+	return nil
+}
+
+// getScrapeClass returns the scrape class selected by the given name. When
+// the name is nil, it returns the default scrape class or nil if there is
+// none.
+func getScrapeClass(p *v1.Prometheus, name *string) (*v1.ScrapeClass, error) {
+	for i := range p.Spec.ScrapeClasses {
+		sc := &p.Spec.ScrapeClasses[i]
+
+		if name == nil {
+			if sc.Default != nil && *sc.Default {
+				return sc, nil
+			}
+			continue
+		}
+
+		if sc.Name == *name {
+			return sc, nil
+		}
+	}
+
+	if name != nil {
+		return nil, errors.Errorf("unknown scrape class %q", *name)
+	}
+
+	return nil, nil
+}
+
+// scrapeClassAuthKey returns the key of the authorization credentials of the
+// scrape class in the store.
+func scrapeClassAuthKey(sc *v1.ScrapeClass) string {
+	return fmt.Sprintf("scrapeClass/auth/%s", sc.Name)
+}
+
+// addScrapeClassAssets validates the scrape classes and adds the credentials
+// they reference to the store.
+func addScrapeClassAssets(ctx context.Context, p *v1.Prometheus, store *assets.Store) error {
+	if err := validateScrapeClasses(p); err != nil {
+		return err
+	}
+
+	for i := range p.Spec.ScrapeClasses {
+		sc := &p.Spec.ScrapeClasses[i]
+		if err := store.AddTLSConfig(ctx, p.GetNamespace(), sc.TLSConfig); err != nil {
+			return errors.Wrapf(err, "scrape class %q", sc.Name)
+		}
+		if err := store.AddAuthorizationCredentials(ctx, p.GetNamespace(), sc.Authorization, scrapeClassAuthKey(sc)); err != nil {
+			return errors.Wrapf(err, "scrape class %q", sc.Name)
+		}
+	}
+
+	return nil
+}
+
+// addScrapeClassTLSToYaml merges the TLS configuration of the scrape class
+// into the TLS configuration already defined by the endpoint, if any. The
+// fields set by the endpoint take precedence over the ones of the scrape
+// class. The assets of the scrape class are located in the namespace of the
+// Prometheus object.
+func addScrapeClassTLSToYaml(cfg yaml.MapSlice, namespace string, sc *v1.ScrapeClass) yaml.MapSlice {
+	if sc == nil || sc.TLSConfig == nil {
+		return cfg
+	}
+
+	classTLS := addTLStoYaml(yaml.MapSlice{}, namespace, sc.TLSConfig)[0].Value.(yaml.MapSlice)
+
+	idx := -1
+	for i, item := range cfg {
+		if item.Key == "tls_config" {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		return append(cfg, yaml.MapItem{Key: "tls_config", Value: classTLS})
+	}
+
+	tlsConfig := cfg[idx].Value.(yaml.MapSlice)
+	for _, classItem := range classTLS {
+		found := false
+		for i, item := range tlsConfig {
+			if item.Key != classItem.Key {
+				continue
+			}
+			found = true
+			// insecure_skip_verify is always set and false is its zero
+			// value, the endpoint can only override it by enabling it.
+			if item.Key == "insecure_skip_verify" && item.Value == false {
+				tlsConfig[i].Value = classItem.Value
+			}
+			break
+		}
+		if !found {
+			tlsConfig = append(tlsConfig, classItem)
+		}
+	}
+	cfg[idx].Value = tlsConfig
+
+	return cfg
+}
+
+// addScrapeClassAuthorizationToYaml adds the authorization section of the
+// scrape class.
+func (cg *ConfigGenerator) addScrapeClassAuthorizationToYaml(cfg yaml.MapSlice, version semver.Version, store *assets.Store, sc *v1.ScrapeClass) yaml.MapSlice {
+	if sc == nil {
+		return cfg
+	}
+	return cg.addAuthorizationToYaml(cfg, version, scrapeClassAuthKey(sc), store, sc.Authorization)
+}
+
+// appendScrapeClassRelabelings appends the relabelings of the scrape class.
+func appendScrapeClassRelabelings(relabelings []yaml.MapSlice, sc *v1.ScrapeClass) []yaml.MapSlice {
+	if sc == nil {
+		return relabelings
+	}
+	for _, c := range sc.Relabelings {
+		relabelings = append(relabelings, generateRelabelConfig(c))
+	}
+	return relabelings
+}
+
+// generateMetricRelabelings returns the metric relabelings of the scrape
+// class followed by the given ones. The relabelings overriding the enforced
+// namespace label are dropped.
+func generateMetricRelabelings(sc *v1.ScrapeClass, configs []*v1.RelabelConfig, enforcedNamespaceLabel string) []yaml.MapSlice {
+	var all []*v1.RelabelConfig
+	if sc != nil {
+		all = append(all, sc.MetricRelabelings...)
+	}
+	all = append(all, configs...)
+
+	var metricRelabelings []yaml.MapSlice
+	for _, c := range all {
+		if c.TargetLabel != "" && enforcedNamespaceLabel != "" && c.TargetLabel == enforcedNamespaceLabel {
+			continue
+		}
+		metricRelabelings = append(metricRelabelings, generateRelabelConfig(c))
+	}
+	return metricRelabelings
+}
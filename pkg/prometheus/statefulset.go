@@ -51,6 +51,10 @@ const (
 	configEnvsubstFilename          = "prometheus.env.yaml"
 	sSetInputHashName               = "prometheus-operator-input-hash"
 	defaultPortName                 = "web"
+	queryProxyPortName              = "query-proxy"
+	queryProxyPort                  = 9095
+	defaultQueryTenancyLabel        = "namespace"
+	defaultQueryTenancyHeader       = "X-Namespace"
 )
 
 var (
@@ -67,14 +71,19 @@ var (
 	probeTimeoutSeconds     int32 = 3
 )
 
+// shardCount returns the number of shards of the Prometheus deployment.
+func shardCount(p *monitoringv1.Prometheus) int32 {
+	if p.Spec.Shards != nil && *p.Spec.Shards > 1 {
+		return *p.Spec.Shards
+	}
+	return minShards
+}
+
 func expectedStatefulSetShardNames(
 	p *monitoringv1.Prometheus,
 ) []string {
 	res := []string{}
-	shards := minShards
-	if p.Spec.Shards != nil && *p.Spec.Shards > 1 {
-		shards = *p.Spec.Shards
-	}
+	shards := shardCount(p)
 
 	for i := int32(0); i < shards; i++ {
 		res = append(res, prometheusNameByShard(p.Name, i))
@@ -149,72 +158,86 @@ func makeStatefulSet(
 		return nil, errors.Wrap(err, "make StatefulSet spec")
 	}
 
+	statefulset := &appsv1.StatefulSet{
+		ObjectMeta: makeWorkloadObjectMeta(name, p, config, inputHash, map[string]string{
+			shardLabelName:          fmt.Sprintf("%d", shard),
+			prometheusNameLabelName: p.Name,
+		}),
+		Spec: *spec,
+	}
+
+	addStorage(&statefulset.Spec, p.Spec.Storage, volumeName(p.Name))
+	statefulset.Spec.Template.Spec.Volumes = append(statefulset.Spec.Template.Spec.Volumes, p.Spec.Volumes...)
+
+	if err := operator.ApplyPodTemplateOverride(&statefulset.Spec.Template, p.Spec.PodTemplateOverride); err != nil {
+		return nil, err
+	}
+
+	return statefulset, nil
+}
+
+// makeWorkloadObjectMeta returns the metadata of the StatefulSet or DaemonSet
+// running the pods of p.
+func makeWorkloadObjectMeta(name string, p monitoringv1.Prometheus, config *operator.Config, inputHash string, extraLabels map[string]string) metav1.ObjectMeta {
 	boolTrue := true
-	// do not transfer kubectl annotations to the statefulset so it is not
+
+	// do not transfer kubectl annotations to the workload so it is not
 	// pruned by kubectl
-	annotations := make(map[string]string)
+	annotations := map[string]string{}
 	for key, value := range p.ObjectMeta.Annotations {
 		if !strings.HasPrefix(key, "kubectl.kubernetes.io/") {
 			annotations[key] = value
 		}
 	}
-	labels := make(map[string]string)
+	annotations[sSetInputHashName] = inputHash
+
+	labels := map[string]string{}
 	for key, value := range p.ObjectMeta.Labels {
 		labels[key] = value
 	}
-	labels[shardLabelName] = fmt.Sprintf("%d", shard)
-	labels[prometheusNameLabelName] = p.Name
+	for key, value := range extraLabels {
+		labels[key] = value
+	}
 
-	statefulset := &appsv1.StatefulSet{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:        name,
-			Labels:      config.Labels.Merge(labels),
-			Annotations: annotations,
-			OwnerReferences: []metav1.OwnerReference{
-				{
-					APIVersion:         p.APIVersion,
-					BlockOwnerDeletion: &boolTrue,
-					Controller:         &boolTrue,
-					Kind:               p.Kind,
-					Name:               p.Name,
-					UID:                p.UID,
-				},
+	return metav1.ObjectMeta{
+		Name:        name,
+		Labels:      config.Labels.Merge(labels),
+		Annotations: annotations,
+		OwnerReferences: []metav1.OwnerReference{
+			{
+				APIVersion:         p.APIVersion,
+				BlockOwnerDeletion: &boolTrue,
+				Controller:         &boolTrue,
+				Kind:               p.Kind,
+				Name:               p.Name,
+				UID:                p.UID,
 			},
 		},
-		Spec: *spec,
-	}
-
-	if statefulset.ObjectMeta.Annotations == nil {
-		statefulset.ObjectMeta.Annotations = map[string]string{
-			sSetInputHashName: inputHash,
-		}
-	} else {
-		statefulset.ObjectMeta.Annotations[sSetInputHashName] = inputHash
 	}
+}
 
-	if p.Spec.ImagePullSecrets != nil && len(p.Spec.ImagePullSecrets) > 0 {
-		statefulset.Spec.Template.Spec.ImagePullSecrets = p.Spec.ImagePullSecrets
-	}
-	storageSpec := p.Spec.Storage
-	if storageSpec == nil {
-		statefulset.Spec.Template.Spec.Volumes = append(statefulset.Spec.Template.Spec.Volumes, v1.Volume{
-			Name: volumeName(p.Name),
+// addStorage adds the volume or the volume claim template storing the data
+// of Prometheus to the StatefulSet spec.
+func addStorage(spec *appsv1.StatefulSetSpec, storageSpec *monitoringv1.StorageSpec, defaultVolumeName string) {
+	switch {
+	case storageSpec == nil:
+		spec.Template.Spec.Volumes = append(spec.Template.Spec.Volumes, v1.Volume{
+			Name: defaultVolumeName,
 			VolumeSource: v1.VolumeSource{
 				EmptyDir: &v1.EmptyDirVolumeSource{},
 			},
 		})
-	} else if storageSpec.EmptyDir != nil {
-		emptyDir := storageSpec.EmptyDir
-		statefulset.Spec.Template.Spec.Volumes = append(statefulset.Spec.Template.Spec.Volumes, v1.Volume{
-			Name: volumeName(p.Name),
+	case storageSpec.EmptyDir != nil:
+		spec.Template.Spec.Volumes = append(spec.Template.Spec.Volumes, v1.Volume{
+			Name: defaultVolumeName,
 			VolumeSource: v1.VolumeSource{
-				EmptyDir: emptyDir,
+				EmptyDir: storageSpec.EmptyDir,
 			},
 		})
-	} else {
+	default:
 		pvcTemplate := operator.MakeVolumeClaimTemplate(storageSpec.VolumeClaimTemplate)
 		if pvcTemplate.Name == "" {
-			pvcTemplate.Name = volumeName(p.Name)
+			pvcTemplate.Name = defaultVolumeName
 		}
 		if storageSpec.VolumeClaimTemplate.Spec.AccessModes == nil {
 			pvcTemplate.Spec.AccessModes = []v1.PersistentVolumeAccessMode{v1.ReadWriteOnce}
@@ -223,12 +246,8 @@ func makeStatefulSet(
 		}
 		pvcTemplate.Spec.Resources = storageSpec.VolumeClaimTemplate.Spec.Resources
 		pvcTemplate.Spec.Selector = storageSpec.VolumeClaimTemplate.Spec.Selector
-		statefulset.Spec.VolumeClaimTemplates = append(statefulset.Spec.VolumeClaimTemplates, *pvcTemplate)
+		spec.VolumeClaimTemplates = append(spec.VolumeClaimTemplates, *pvcTemplate)
 	}
-
-	statefulset.Spec.Template.Spec.Volumes = append(statefulset.Spec.Template.Spec.Volumes, p.Spec.Volumes...)
-
-	return statefulset, nil
 }
 
 func makeEmptyConfigurationSecret(p *monitoringv1.Prometheus, config operator.Config) (*v1.Secret, error) {
@@ -243,9 +262,14 @@ func makeEmptyConfigurationSecret(p *monitoringv1.Prometheus, config operator.Co
 
 func makeConfigSecret(p *monitoringv1.Prometheus, config operator.Config) *v1.Secret {
 	boolTrue := true
+	name := configSecretName(p.Name)
+	if isAgent(p) {
+		name = agentConfigSecretName(p.Name)
+	}
+
 	return &v1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:   configSecretName(p.Name),
+			Name:   name,
 			Labels: config.Labels.Merge(managedByOperatorLabels),
 			OwnerReferences: []metav1.OwnerReference{
 				{
@@ -292,7 +316,7 @@ func makeStatefulSetService(p *monitoringv1.Prometheus, config operator.Config)
 				{
 					Name:       p.Spec.PortName,
 					Port:       9090,
-					TargetPort: intstr.FromString(p.Spec.PortName),
+					TargetPort: intstr.FromString(webTargetPortName(p)),
 				},
 			},
 			Selector: map[string]string{
@@ -312,12 +336,17 @@ func makeStatefulSetService(p *monitoringv1.Prometheus, config operator.Config)
 	return svc
 }
 
+// webTargetPortName returns the name of the container port receiving the
+// web traffic of the governing service.
+func webTargetPortName(p *monitoringv1.Prometheus) string {
+	if p.Spec.QueryTenancy != nil {
+		return queryProxyPortName
+	}
+	return p.Spec.PortName
+}
+
 func makeStatefulSetSpec(p monitoringv1.Prometheus, c *operator.Config, shard int32, ruleConfigMapNames []string,
 	version semver.Version) (*appsv1.StatefulSetSpec, error) {
-	// Prometheus may take quite long to shut down to checkpoint existing data.
-	// Allow up to 10 minutes for clean termination.
-	terminationGracePeriod := int64(600)
-
 	prometheusImagePath, err := operator.BuildImagePath(
 		operator.StringPtrValOrDefault(p.Spec.Image, ""),
 		operator.StringValOrDefault(p.Spec.BaseImage, c.PrometheusDefaultBaseImage),
@@ -441,29 +470,292 @@ func makeStatefulSetSpec(p monitoringv1.Prometheus, c *operator.Config, shard in
 		promArgs = append(promArgs, "-storage.tsdb.allow-overlapping-blocks")
 	}
 
+	if err := validateTSDB(&p, version); err != nil {
+		return nil, err
+	}
+	promArgs = append(promArgs, tsdbArgs(&p)...)
+
+	// With query tenancy, the queries can only reach Prometheus through the
+	// proxy. The Thanos sidecar and the config reloader use the loopback
+	// interface.
+	listenLocal := p.Spec.ListenLocal || p.Spec.QueryTenancy != nil
+
+	for i, a := range promArgs {
+		promArgs[i] = "-" + a
+	}
+
+	volName := volumeName(p.Name)
+	if p.Spec.Storage != nil {
+		if p.Spec.Storage.VolumeClaimTemplate.Name != "" {
+			volName = p.Spec.Storage.VolumeClaimTemplate.Name
+		}
+	}
+
+	webFields := webConfigFileFields(&p, version)
+
+	var (
+		additionalContainers []v1.Container
+		additionalVolumes    []v1.Volume
+	)
+
+	disableCompaction := p.Spec.DisableCompaction
+	if p.Spec.Thanos != nil {
+		thanosImage, err := operator.BuildImagePath(
+			operator.StringPtrValOrDefault(p.Spec.Thanos.Image, ""),
+			operator.StringPtrValOrDefault(p.Spec.Thanos.BaseImage, c.ThanosDefaultBaseImage),
+			operator.StringPtrValOrDefault(p.Spec.Thanos.Version, operator.DefaultThanosVersion),
+			operator.StringPtrValOrDefault(p.Spec.Thanos.Tag, ""),
+			operator.StringPtrValOrDefault(p.Spec.Thanos.SHA, ""),
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to build image path")
+		}
+
+		bindAddress := "" // Listen to all available IP addresses by default
+		if p.Spec.Thanos.ListenLocal {
+			bindAddress = "127.0.0.1"
+		}
+
+		// The sidecar can only be configured to access the web server over
+		// HTTPS or with basic auth from Thanos v0.24.0 on. Older versions
+		// keep using HTTP.
+		thanosWebFields := webFields
+		thanosVersion, err := semver.ParseTolerant(operator.StringPtrValOrDefault(p.Spec.Thanos.Version, operator.DefaultThanosVersion))
+		if err == nil && thanosVersion.LT(semver.MustParse("0.24.0")) {
+			if len(webFields.BasicAuthUsers) > 0 {
+				return nil, errors.Errorf("basic auth for the web server requires Thanos >= 0.24.0, got %s", thanosVersion)
+			}
+			thanosWebFields = monitoringv1.WebConfigFileFields{}
+		}
+
+		thanosArgs := []string{"sidecar",
+			fmt.Sprintf("--prometheus.url=%s://%s:9090%s", webconfig.Scheme(thanosWebFields), c.LocalHost, path.Clean(webRoutePrefix)),
+			fmt.Sprintf("--grpc-address=%s:10901", bindAddress),
+			fmt.Sprintf("--http-address=%s:10902", bindAddress),
+		}
+
+		if p.Spec.Thanos.GRPCServerTLSConfig != nil {
+			tls := p.Spec.Thanos.GRPCServerTLSConfig
+			if tls.CertFile != "" {
+				thanosArgs = append(thanosArgs, "--grpc-server-tls-cert="+tls.CertFile)
+			}
+			if tls.KeyFile != "" {
+				thanosArgs = append(thanosArgs, "--grpc-server-tls-key="+tls.KeyFile)
+			}
+			if tls.CAFile != "" {
+				thanosArgs = append(thanosArgs, "--grpc-server-tls-client-ca="+tls.CAFile)
+			}
+		}
+
+		container := v1.Container{
+			Name:                     "thanos-sidecar",
+			Image:                    thanosImage,
+			TerminationMessagePolicy: v1.TerminationMessageFallbackToLogsOnError,
+			Args:                     thanosArgs,
+			Ports: []v1.ContainerPort{
+				{
+					Name:          "http",
+					ContainerPort: 10902,
+				},
+				{
+					Name:          "grpc",
+					ContainerPort: 10901,
+				},
+			},
+			Resources: p.Spec.Thanos.Resources,
+		}
+
+		if p.Spec.Thanos.ObjectStorageConfig != nil || p.Spec.Thanos.ObjectStorageConfigFile != nil {
+			if p.Spec.Thanos.ObjectStorageConfigFile != nil {
+				container.Args = append(container.Args, "--objstore.config-file="+*p.Spec.Thanos.ObjectStorageConfigFile)
+			} else {
+				container.Args = append(container.Args, "--objstore.config=$(OBJSTORE_CONFIG)")
+				container.Env = append(container.Env, v1.EnvVar{
+					Name: "OBJSTORE_CONFIG",
+					ValueFrom: &v1.EnvVarSource{
+						SecretKeyRef: p.Spec.Thanos.ObjectStorageConfig,
+					},
+				})
+			}
+			container.Args = append(container.Args, fmt.Sprintf("--tsdb.path=%s", storageDir))
+			container.VolumeMounts = append(
+				container.VolumeMounts,
+				v1.VolumeMount{
+					Name:      volName,
+					MountPath: storageDir,
+					SubPath:   subPathForStorage(p.Spec.Storage),
+				},
+			)
+
+			// NOTE(bwplotka): As described in https://thanos.io/components/sidecar.md/ we have to turn off compaction of Prometheus
+			// to avoid races during upload, if the uploads are configured.
+			disableCompaction = true
+		}
+
+		if p.Spec.Thanos.TracingConfig != nil || len(p.Spec.Thanos.TracingConfigFile) > 0 {
+			if len(p.Spec.Thanos.TracingConfigFile) > 0 {
+				container.Args = append(container.Args, "--tracing.config-file="+p.Spec.Thanos.TracingConfigFile)
+			} else {
+				container.Args = append(container.Args, "--tracing.config=$(TRACING_CONFIG)")
+				container.Env = append(container.Env, v1.EnvVar{
+					Name: "TRACING_CONFIG",
+					ValueFrom: &v1.EnvVarSource{
+						SecretKeyRef: p.Spec.Thanos.TracingConfig,
+					},
+				})
+			}
+		}
+
+		if p.Spec.Thanos.LogLevel != "" {
+			container.Args = append(container.Args, "--log.level="+p.Spec.Thanos.LogLevel)
+		} else if p.Spec.LogLevel != "" {
+			container.Args = append(container.Args, "--log.level="+p.Spec.LogLevel)
+		}
+		if p.Spec.Thanos.LogFormat != "" {
+			container.Args = append(container.Args, "--log.format="+p.Spec.Thanos.LogFormat)
+		} else if p.Spec.LogFormat != "" {
+			container.Args = append(container.Args, "--log.format="+p.Spec.LogFormat)
+		}
+
+		if p.Spec.Thanos.MinTime != "" {
+			container.Args = append(container.Args, "--min-time="+p.Spec.Thanos.MinTime)
+		}
+
+		if p.Spec.Thanos.ReadyTimeout != "" {
+			container.Args = append(container.Args, "--prometheus.ready_timeout="+p.Spec.Thanos.ReadyTimeout)
+		}
+
+		if thanosWebFields.TLSConfig != nil || len(thanosWebFields.BasicAuthUsers) > 0 {
+			arg, vols, mounts := makeThanosHTTPClientArg(thanosWebFields)
+			container.Args = append(container.Args, arg)
+			container.VolumeMounts = append(container.VolumeMounts, mounts...)
+			additionalVolumes = append(additionalVolumes, vols...)
+		}
+		additionalContainers = append(additionalContainers, container)
+	}
+	if p.Spec.QueryTenancy != nil {
+		container, err := makeQueryTenancyContainer(p, c, webFields, webRoutePrefix)
+		if err != nil {
+			return nil, err
+		}
+		additionalContainers = append(additionalContainers, *container)
+	}
+	blockDurationArgs, err := tsdbBlockDurationArgs(&p, disableCompaction)
+	if err != nil {
+		return nil, err
+	}
+
+	shardPtr := &shard
+	template, selectorLabels, err := makePodTemplate(p, c, webFields, podTemplateParams{
+		image:                 prometheusImagePath,
+		version:               version,
+		args:                  promArgs,
+		trailingArgs:          blockDurationArgs,
+		configSecretName:      configSecretName(p.Name),
+		tlsAssetsSecretName:   tlsAssetsSecretName(p.Name),
+		webConfigSecretName:   WebConfigSecretName(p.Name),
+		credentialsSecretName: credentialsSecretName(p.Name),
+		volumeName:            volName,
+		portName:              p.Spec.PortName,
+		listenLocal:           listenLocal,
+		ruleConfigMapNames:    ruleConfigMapNames,
+		additionalVolumes:     additionalVolumes,
+		additionalContainers:  additionalContainers,
+		selectorLabels: map[string]string{
+			// TODO(fpetkovski): remove `app` label after 0.50 release
+			"app":                          "prometheus",
+			"app.kubernetes.io/name":       "prometheus",
+			"app.kubernetes.io/managed-by": "prometheus-operator",
+			"app.kubernetes.io/instance":   p.Name,
+			"prometheus":                   p.Name,
+			shardLabelName:                 fmt.Sprintf("%d", shard),
+			prometheusNameLabelName:        p.Name,
+		},
+		shard: shardPtr,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// PodManagementPolicy is set to Parallel to mitigate issues in kubernetes: https://github.com/kubernetes/kubernetes/issues/60164
+	// This is also mentioned as one of limitations of StatefulSets: https://kubernetes.io/docs/concepts/workloads/controllers/statefulset/#limitations
+	return &appsv1.StatefulSetSpec{
+		ServiceName:         governingServiceName,
+		Replicas:            p.Spec.Replicas,
+		PodManagementPolicy: appsv1.ParallelPodManagement,
+		UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
+			Type: appsv1.RollingUpdateStatefulSetStrategyType,
+		},
+		Selector: &metav1.LabelSelector{
+			MatchLabels: selectorLabels,
+		},
+		Template: *template,
+	}, nil
+}
+
+// podTemplateParams holds the settings of the pod template which differ
+// between Prometheus and the Prometheus agent.
+type podTemplateParams struct {
+	image   string
+	version semver.Version
+	// args are the arguments of the prometheus container. The listen address
+	// and web config flags are appended to them, followed by trailingArgs.
+	args         []string
+	trailingArgs []string
+
+	configSecretName      string
+	tlsAssetsSecretName   string
+	webConfigSecretName   string
+	credentialsSecretName string
+	volumeName            string
+
+	portName    string
+	listenLocal bool
+
+	// ruleConfigMapNames are mounted into the prometheus and config reloader
+	// containers.
+	ruleConfigMapNames   []string
+	additionalVolumes    []v1.Volume
+	additionalContainers []v1.Container
+	selectorLabels       map[string]string
+	// shard is nil when one pod runs on each node, the config reloader then
+	// keeps only the targets running on the node of the pod.
+	shard *int32
+}
+
+// makePodTemplate returns the pod template shared by Prometheus and the
+// Prometheus agent and the labels selecting its pods.
+func makePodTemplate(p monitoringv1.Prometheus, c *operator.Config, webFields monitoringv1.WebConfigFileFields, params podTemplateParams) (*v1.PodTemplateSpec, map[string]string, error) {
+	// Prometheus may take quite long to shut down to checkpoint existing data.
+	// Allow up to 10 minutes for clean termination.
+	terminationGracePeriod := int64(600)
+
+	webRoutePrefix := "/"
+	if p.Spec.RoutePrefix != "" {
+		webRoutePrefix = p.Spec.RoutePrefix
+	}
+
+	promArgs := params.args
+
 	var ports []v1.ContainerPort
-	if p.Spec.ListenLocal {
-		promArgs = append(promArgs, "-web.listen-address=127.0.0.1:9090")
+	if params.listenLocal {
+		promArgs = append(promArgs, "--web.listen-address=127.0.0.1:9090")
 	} else {
 		ports = []v1.ContainerPort{
 			{
-				Name:          p.Spec.PortName,
+				Name:          params.portName,
 				ContainerPort: 9090,
 				Protocol:      v1.ProtocolTCP,
 			},
 		}
 	}
 
-	for i, a := range promArgs {
-		promArgs[i] = "-" + a
-	}
-
 	volumes := []v1.Volume{
 		{
 			Name: "config",
 			VolumeSource: v1.VolumeSource{
 				Secret: &v1.SecretVolumeSource{
-					SecretName: configSecretName(p.Name),
+					SecretName: params.configSecretName,
 				},
 			},
 		},
@@ -471,7 +763,7 @@ func makeStatefulSetSpec(p monitoringv1.Prometheus, c *operator.Config, shard in
 			Name: "tls-assets",
 			VolumeSource: v1.VolumeSource{
 				Secret: &v1.SecretVolumeSource{
-					SecretName: tlsAssetsSecretName(p.Name),
+					SecretName: params.tlsAssetsSecretName,
 				},
 			},
 		},
@@ -483,7 +775,7 @@ func makeStatefulSetSpec(p monitoringv1.Prometheus, c *operator.Config, shard in
 		},
 	}
 
-	for _, name := range ruleConfigMapNames {
+	for _, name := range params.ruleConfigMapNames {
 		volumes = append(volumes, v1.Volume{
 			Name: name,
 			VolumeSource: v1.VolumeSource{
@@ -496,13 +788,6 @@ func makeStatefulSetSpec(p monitoringv1.Prometheus, c *operator.Config, shard in
 		})
 	}
 
-	volName := volumeName(p.Name)
-	if p.Spec.Storage != nil {
-		if p.Spec.Storage.VolumeClaimTemplate.Name != "" {
-			volName = p.Spec.Storage.VolumeClaimTemplate.Name
-		}
-	}
-
 	promVolumeMounts := []v1.VolumeMount{
 		{
 			Name:      "config-out",
@@ -515,14 +800,14 @@ func makeStatefulSetSpec(p monitoringv1.Prometheus, c *operator.Config, shard in
 			MountPath: tlsAssetsDir,
 		},
 		{
-			Name:      volName,
+			Name:      params.volumeName,
 			MountPath: storageDir,
 			SubPath:   subPathForStorage(p.Spec.Storage),
 		},
 	}
 
 	promVolumeMounts = append(promVolumeMounts, p.Spec.VolumeMounts...)
-	for _, name := range ruleConfigMapNames {
+	for _, name := range params.ruleConfigMapNames {
 		promVolumeMounts = append(promVolumeMounts, v1.VolumeMount{
 			Name:      name,
 			MountPath: rulesDir + "/" + name,
@@ -533,15 +818,10 @@ func makeStatefulSetSpec(p monitoringv1.Prometheus, c *operator.Config, shard in
 	// We always mount the web config file for versions greater than 2.24.0.
 	// With this we avoid redeploying prometheus when reconfiguring between
 	// HTTP and HTTPS and vice-versa.
-	if version.GTE(semver.MustParse("2.24.0")) {
-		var webTLSConfig *monitoringv1.WebTLSConfig
-		if p.Spec.Web != nil {
-			webTLSConfig = p.Spec.Web.TLSConfig
-		}
-
-		webConfig, err := webconfig.New(webConfigDir, WebConfigSecretName(p.Name), webTLSConfig)
+	if params.version.GTE(semver.MustParse("2.24.0")) {
+		webConfig, err := webconfig.New(webConfigDir, params.webConfigSecretName, webFields)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		confArg, configVol, configMount := webConfig.GetMountParameters()
@@ -549,6 +829,13 @@ func makeStatefulSetSpec(p monitoringv1.Prometheus, c *operator.Config, shard in
 		volumes = append(volumes, configVol...)
 		promVolumeMounts = append(promVolumeMounts, configMount...)
 	}
+	promArgs = append(promArgs, params.trailingArgs...)
+
+	if p.Spec.CredentialsAsFiles {
+		vol, mount := credentialsVolume(params.credentialsSecretName)
+		volumes = append(volumes, vol)
+		promVolumeMounts = append(promVolumeMounts, mount)
+	}
 
 	// Mount related secrets
 
@@ -586,30 +873,9 @@ func makeStatefulSetSpec(p monitoringv1.Prometheus, c *operator.Config, shard in
 		})
 	}
 
-	const localProbe = `if [ -x "$(command -v curl)" ]; then exec curl %s; elif [ -x "$(command -v wget)" ]; then exec wget -q -O /dev/null %s; else exit 1; fi`
+	volumes = append(volumes, params.additionalVolumes...)
 
-	var readinessProbeHandler v1.Handler
-	{
-		readyPath := path.Clean(webRoutePrefix + "/-/ready")
-		if p.Spec.ListenLocal {
-			localReadyPath := fmt.Sprintf("http://localhost:9090%s", readyPath)
-			readinessProbeHandler.Exec = &v1.ExecAction{
-				Command: []string{
-					"sh",
-					"-c",
-					fmt.Sprintf(localProbe, localReadyPath, localReadyPath),
-				},
-			}
-		} else {
-			readinessProbeHandler.HTTPGet = &v1.HTTPGetAction{
-				Path: readyPath,
-				Port: intstr.FromString(p.Spec.PortName),
-			}
-			if p.Spec.Web != nil && p.Spec.Web.TLSConfig != nil && version.GTE(semver.MustParse("2.24.0")) {
-				readinessProbeHandler.HTTPGet.Scheme = v1.URISchemeHTTPS
-			}
-		}
-	}
+	readinessProbeHandler := webconfig.ProbeHandler(webFields, path.Clean(webRoutePrefix+"/-/ready"), 9090, params.portName, params.listenLocal)
 
 	// TODO(paulfantom): Re-add livenessProbe and add startupProbe when kubernetes 1.21 is available.
 	// This would be a follow-up to https://github.com/prometheus-operator/prometheus-operator/pull/3502
@@ -622,17 +888,7 @@ func makeStatefulSetSpec(p monitoringv1.Prometheus, c *operator.Config, shard in
 
 	podAnnotations := map[string]string{}
 	podLabels := map[string]string{
-		"app.kubernetes.io/version": version.String(),
-	}
-	podSelectorLabels := map[string]string{
-		// TODO(fpetkovski): remove `app` label after 0.50 release
-		"app":                          "prometheus",
-		"app.kubernetes.io/name":       "prometheus",
-		"app.kubernetes.io/managed-by": "prometheus-operator",
-		"app.kubernetes.io/instance":   p.Name,
-		"prometheus":                   p.Name,
-		shardLabelName:                 fmt.Sprintf("%d", shard),
-		prometheusNameLabelName:        p.Name,
+		"app.kubernetes.io/version": params.version.String(),
 	}
 	if p.Spec.PodMetadata != nil {
 		if p.Spec.PodMetadata.Labels != nil {
@@ -647,138 +903,12 @@ func makeStatefulSetSpec(p monitoringv1.Prometheus, c *operator.Config, shard in
 		}
 	}
 
-	for k, v := range podSelectorLabels {
+	for k, v := range params.selectorLabels {
 		podLabels[k] = v
 	}
 
 	podAnnotations["kubectl.kubernetes.io/default-container"] = "prometheus"
 
-	finalSelectorLabels := c.Labels.Merge(podSelectorLabels)
-	finalLabels := c.Labels.Merge(podLabels)
-
-	var additionalContainers, operatorInitContainers []v1.Container
-
-	disableCompaction := p.Spec.DisableCompaction
-	if p.Spec.Thanos != nil {
-		thanosImage, err := operator.BuildImagePath(
-			operator.StringPtrValOrDefault(p.Spec.Thanos.Image, ""),
-			operator.StringPtrValOrDefault(p.Spec.Thanos.BaseImage, c.ThanosDefaultBaseImage),
-			operator.StringPtrValOrDefault(p.Spec.Thanos.Version, operator.DefaultThanosVersion),
-			operator.StringPtrValOrDefault(p.Spec.Thanos.Tag, ""),
-			operator.StringPtrValOrDefault(p.Spec.Thanos.SHA, ""),
-		)
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to build image path")
-		}
-
-		bindAddress := "" // Listen to all available IP addresses by default
-		if p.Spec.Thanos.ListenLocal {
-			bindAddress = "127.0.0.1"
-		}
-
-		thanosArgs := []string{"sidecar",
-			fmt.Sprintf("--prometheus.url=http://%s:9090%s", c.LocalHost, path.Clean(webRoutePrefix)),
-			fmt.Sprintf("--grpc-address=%s:10901", bindAddress),
-			fmt.Sprintf("--http-address=%s:10902", bindAddress),
-		}
-
-		if p.Spec.Thanos.GRPCServerTLSConfig != nil {
-			tls := p.Spec.Thanos.GRPCServerTLSConfig
-			if tls.CertFile != "" {
-				thanosArgs = append(thanosArgs, "--grpc-server-tls-cert="+tls.CertFile)
-			}
-			if tls.KeyFile != "" {
-				thanosArgs = append(thanosArgs, "--grpc-server-tls-key="+tls.KeyFile)
-			}
-			if tls.CAFile != "" {
-				thanosArgs = append(thanosArgs, "--grpc-server-tls-client-ca="+tls.CAFile)
-			}
-		}
-
-		container := v1.Container{
-			Name:                     "thanos-sidecar",
-			Image:                    thanosImage,
-			TerminationMessagePolicy: v1.TerminationMessageFallbackToLogsOnError,
-			Args:                     thanosArgs,
-			Ports: []v1.ContainerPort{
-				{
-					Name:          "http",
-					ContainerPort: 10902,
-				},
-				{
-					Name:          "grpc",
-					ContainerPort: 10901,
-				},
-			},
-			Resources: p.Spec.Thanos.Resources,
-		}
-
-		if p.Spec.Thanos.ObjectStorageConfig != nil || p.Spec.Thanos.ObjectStorageConfigFile != nil {
-			if p.Spec.Thanos.ObjectStorageConfigFile != nil {
-				container.Args = append(container.Args, "--objstore.config-file="+*p.Spec.Thanos.ObjectStorageConfigFile)
-			} else {
-				container.Args = append(container.Args, "--objstore.config=$(OBJSTORE_CONFIG)")
-				container.Env = append(container.Env, v1.EnvVar{
-					Name: "OBJSTORE_CONFIG",
-					ValueFrom: &v1.EnvVarSource{
-						SecretKeyRef: p.Spec.Thanos.ObjectStorageConfig,
-					},
-				})
-			}
-			container.Args = append(container.Args, fmt.Sprintf("--tsdb.path=%s", storageDir))
-			container.VolumeMounts = append(
-				container.VolumeMounts,
-				v1.VolumeMount{
-					Name:      volName,
-					MountPath: storageDir,
-					SubPath:   subPathForStorage(p.Spec.Storage),
-				},
-			)
-
-			// NOTE(bwplotka): As described in https://thanos.io/components/sidecar.md/ we have to turn off compaction of Prometheus
-			// to avoid races during upload, if the uploads are configured.
-			disableCompaction = true
-		}
-
-		if p.Spec.Thanos.TracingConfig != nil || len(p.Spec.Thanos.TracingConfigFile) > 0 {
-			if len(p.Spec.Thanos.TracingConfigFile) > 0 {
-				container.Args = append(container.Args, "--tracing.config-file="+p.Spec.Thanos.TracingConfigFile)
-			} else {
-				container.Args = append(container.Args, "--tracing.config=$(TRACING_CONFIG)")
-				container.Env = append(container.Env, v1.EnvVar{
-					Name: "TRACING_CONFIG",
-					ValueFrom: &v1.EnvVarSource{
-						SecretKeyRef: p.Spec.Thanos.TracingConfig,
-					},
-				})
-			}
-		}
-
-		if p.Spec.Thanos.LogLevel != "" {
-			container.Args = append(container.Args, "--log.level="+p.Spec.Thanos.LogLevel)
-		} else if p.Spec.LogLevel != "" {
-			container.Args = append(container.Args, "--log.level="+p.Spec.LogLevel)
-		}
-		if p.Spec.Thanos.LogFormat != "" {
-			container.Args = append(container.Args, "--log.format="+p.Spec.Thanos.LogFormat)
-		} else if p.Spec.LogFormat != "" {
-			container.Args = append(container.Args, "--log.format="+p.Spec.LogFormat)
-		}
-
-		if p.Spec.Thanos.MinTime != "" {
-			container.Args = append(container.Args, "--min-time="+p.Spec.Thanos.MinTime)
-		}
-
-		if p.Spec.Thanos.ReadyTimeout != "" {
-			container.Args = append(container.Args, "--prometheus.ready_timeout="+p.Spec.Thanos.ReadyTimeout)
-		}
-		additionalContainers = append(additionalContainers, container)
-	}
-	if disableCompaction {
-		promArgs = append(promArgs, "--storage.tsdb.max-block-duration=2h")
-		promArgs = append(promArgs, "--storage.tsdb.min-block-duration=2h")
-	}
-
 	var watchedDirectories []string
 	configReloaderVolumeMounts := []v1.VolumeMount{
 		{
@@ -791,103 +921,104 @@ func makeStatefulSetSpec(p monitoringv1.Prometheus, c *operator.Config, shard in
 		},
 	}
 
-	if len(ruleConfigMapNames) != 0 {
-		for _, name := range ruleConfigMapNames {
-			mountPath := rulesDir + "/" + name
-			configReloaderVolumeMounts = append(configReloaderVolumeMounts, v1.VolumeMount{
-				Name:      name,
-				MountPath: mountPath,
-			})
-			watchedDirectories = append(watchedDirectories, mountPath)
-		}
-	}
-
-	operatorInitContainers = append(operatorInitContainers,
-		operator.CreateConfigReloader(
-			"init-config-reloader",
-			operator.ReloaderResources(c.ReloaderConfig),
-			operator.ReloaderRunOnce(),
-			operator.LogFormat(p.Spec.LogFormat),
-			operator.LogLevel(p.Spec.LogLevel),
-			operator.VolumeMounts(configReloaderVolumeMounts),
-			operator.ConfigFile(path.Join(confDir, configFilename)),
-			operator.ConfigEnvsubstFile(path.Join(confOutDir, configEnvsubstFilename)),
-			operator.WatchedDirectories(watchedDirectories),
-			operator.Shard(shard),
-		),
-	)
+	for _, name := range params.ruleConfigMapNames {
+		mountPath := rulesDir + "/" + name
+		configReloaderVolumeMounts = append(configReloaderVolumeMounts, v1.VolumeMount{
+			Name:      name,
+			MountPath: mountPath,
+		})
+		watchedDirectories = append(watchedDirectories, mountPath)
+	}
+
+	reloaderOpts := []operator.ReloaderOption{
+		operator.ReloaderResources(c.ReloaderConfig),
+		operator.LogFormat(p.Spec.LogFormat),
+		operator.LogLevel(p.Spec.LogLevel),
+		operator.VolumeMounts(configReloaderVolumeMounts),
+		operator.ConfigFile(path.Join(confDir, configFilename)),
+		operator.ConfigEnvsubstFile(path.Join(confOutDir, configEnvsubstFilename)),
+		operator.WatchedDirectories(watchedDirectories),
+	}
+	if params.shard != nil {
+		reloaderOpts = append(reloaderOpts, operator.Shard(*params.shard))
+	} else {
+		reloaderOpts = append(reloaderOpts, operator.Shard(0), operator.NodeName())
+	}
 
-	initContainers, err := k8sutil.MergePatchContainers(operatorInitContainers, p.Spec.InitContainers)
+	initContainers, err := k8sutil.MergePatchContainers(
+		[]v1.Container{
+			operator.CreateConfigReloader(
+				"init-config-reloader",
+				append(reloaderOpts, operator.ReloaderRunOnce())...,
+			),
+		},
+		p.Spec.InitContainers,
+	)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to merge init containers spec")
+		return nil, nil, errors.Wrap(err, "failed to merge init containers spec")
+	}
+
+	mainReloaderOpts := append(reloaderOpts,
+		operator.ReloaderURL(url.URL{
+			Scheme: webconfig.Scheme(webFields),
+			Host:   c.LocalHost + ":9090",
+			Path:   path.Clean(webRoutePrefix + "/-/reload"),
+		}),
+		operator.ListenLocal(p.Spec.ListenLocal),
+		operator.LocalHost(c.LocalHost),
+	)
+	if u := webconfig.BasicAuthUser(webFields); u != nil {
+		mainReloaderOpts = append(mainReloaderOpts, operator.ReloaderBasicAuth(u.Username, u.Password))
 	}
 
 	operatorContainers := append([]v1.Container{
 		{
 			Name:                     "prometheus",
-			Image:                    prometheusImagePath,
+			Image:                    params.image,
 			Ports:                    ports,
 			Args:                     promArgs,
+			Env:                      webconfig.BasicAuthEnvVars(webFields),
 			VolumeMounts:             promVolumeMounts,
 			ReadinessProbe:           readinessProbe,
 			Resources:                p.Spec.Resources,
 			TerminationMessagePolicy: v1.TerminationMessageFallbackToLogsOnError,
 		},
-		operator.CreateConfigReloader(
-			"config-reloader",
-			operator.ReloaderResources(c.ReloaderConfig),
-			operator.ReloaderURL(url.URL{
-				Scheme: "http",
-				Host:   c.LocalHost + ":9090",
-				Path:   path.Clean(webRoutePrefix + "/-/reload"),
-			}),
-			operator.ListenLocal(p.Spec.ListenLocal),
-			operator.LocalHost(c.LocalHost),
-			operator.LogFormat(p.Spec.LogFormat),
-			operator.LogLevel(p.Spec.LogLevel),
-			operator.ConfigFile(path.Join(confDir, configFilename)),
-			operator.ConfigEnvsubstFile(path.Join(confOutDir, configEnvsubstFilename)),
-			operator.WatchedDirectories(watchedDirectories), operator.VolumeMounts(configReloaderVolumeMounts),
-			operator.Shard(shard),
-		),
-	}, additionalContainers...)
+		operator.CreateConfigReloader("config-reloader", mainReloaderOpts...),
+	}, params.additionalContainers...)
 
 	containers, err := k8sutil.MergePatchContainers(operatorContainers, p.Spec.Containers)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to merge containers spec")
+		return nil, nil, errors.Wrap(err, "failed to merge containers spec")
 	}
-	// PodManagementPolicy is set to Parallel to mitigate issues in kubernetes: https://github.com/kubernetes/kubernetes/issues/60164
-	// This is also mentioned as one of limitations of StatefulSets: https://kubernetes.io/docs/concepts/workloads/controllers/statefulset/#limitations
-	return &appsv1.StatefulSetSpec{
-		ServiceName:         governingServiceName,
-		Replicas:            p.Spec.Replicas,
-		PodManagementPolicy: appsv1.ParallelPodManagement,
-		UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
-			Type: appsv1.RollingUpdateStatefulSetStrategyType,
-		},
-		Selector: &metav1.LabelSelector{
-			MatchLabels: finalSelectorLabels,
+
+	return &v1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:      c.Labels.Merge(podLabels),
+			Annotations: podAnnotations,
 		},
-		Template: v1.PodTemplateSpec{
-			ObjectMeta: metav1.ObjectMeta{
-				Labels:      finalLabels,
-				Annotations: podAnnotations,
-			},
-			Spec: v1.PodSpec{
-				Containers:                    containers,
-				InitContainers:                initContainers,
-				SecurityContext:               p.Spec.SecurityContext,
-				ServiceAccountName:            p.Spec.ServiceAccountName,
-				NodeSelector:                  p.Spec.NodeSelector,
-				PriorityClassName:             p.Spec.PriorityClassName,
-				TerminationGracePeriodSeconds: &terminationGracePeriod,
-				Volumes:                       volumes,
-				Tolerations:                   p.Spec.Tolerations,
-				Affinity:                      p.Spec.Affinity,
-				TopologySpreadConstraints:     p.Spec.TopologySpreadConstraints,
-			},
+		Spec: v1.PodSpec{
+			Containers:                    containers,
+			InitContainers:                initContainers,
+			SecurityContext:               p.Spec.SecurityContext,
+			ServiceAccountName:            p.Spec.ServiceAccountName,
+			NodeSelector:                  p.Spec.NodeSelector,
+			PriorityClassName:             p.Spec.PriorityClassName,
+			TerminationGracePeriodSeconds: &terminationGracePeriod,
+			Volumes:                       volumes,
+			Tolerations:                   p.Spec.Tolerations,
+			Affinity:                      p.Spec.Affinity,
+			TopologySpreadConstraints:     p.Spec.TopologySpreadConstraints,
+			ImagePullSecrets:              p.Spec.ImagePullSecrets,
+			HostAliases:                   p.Spec.HostAliases,
+			DNSPolicy:                     operator.DNSPolicy(p.Spec.DNSPolicy, p.Spec.HostNetwork),
+			DNSConfig:                     p.Spec.DNSConfig,
+			RuntimeClassName:              p.Spec.RuntimeClassName,
+			AutomountServiceAccountToken:  p.Spec.AutomountServiceAccountToken,
+			HostNetwork:                   p.Spec.HostNetwork,
+			SchedulerName:                 p.Spec.SchedulerName,
+			ReadinessGates:                p.Spec.ReadinessGates,
 		},
-	}, nil
+	}, c.Labels.Merge(params.selectorLabels), nil
 }
 
 func configSecretName(name string) string {
@@ -918,3 +1049,54 @@ func subPathForStorage(s *monitoringv1.StorageSpec) string {
 
 	return "prometheus-db"
 }
+
+// makeQueryTenancyContainer returns the prom-label-proxy container which
+// enforces the namespace label on the queries sent to the web port of the
+// governing service.
+func makeQueryTenancyContainer(p monitoringv1.Prometheus, c *operator.Config, webFields monitoringv1.WebConfigFileFields, webRoutePrefix string) (*v1.Container, error) {
+	// The proxy can't verify the certificate of the Prometheus web server.
+	if webFields.TLSConfig != nil {
+		return nil, errors.New("queryTenancy can't be used when the web server is configured with TLS")
+	}
+
+	// The proxy doesn't authenticate against the Prometheus web server.
+	if len(webFields.BasicAuthUsers) > 0 {
+		return nil, errors.New("queryTenancy can't be used when the web server is configured with basic authentication")
+	}
+
+	qt := p.Spec.QueryTenancy
+
+	label := qt.Label
+	if label == "" {
+		label = p.Spec.EnforcedNamespaceLabel
+	}
+	if label == "" {
+		label = defaultQueryTenancyLabel
+	}
+
+	header := qt.Header
+	if header == "" {
+		header = defaultQueryTenancyHeader
+	}
+
+	return &v1.Container{
+		Name:                     "query-proxy",
+		Image:                    operator.StringPtrValOrDefault(qt.Image, operator.DefaultPromLabelProxyImage),
+		TerminationMessagePolicy: v1.TerminationMessageFallbackToLogsOnError,
+		Args: []string{
+			fmt.Sprintf("--insecure-listen-address=:%d", queryProxyPort),
+			fmt.Sprintf("--upstream=http://%s:9090%s", c.LocalHost, path.Clean(webRoutePrefix)),
+			"--label=" + label,
+			"--header-name=" + header,
+			"--enable-label-apis",
+		},
+		Ports: []v1.ContainerPort{
+			{
+				Name:          queryProxyPortName,
+				ContainerPort: queryProxyPort,
+				Protocol:      v1.ProtocolTCP,
+			},
+		},
+		Resources: qt.Resources,
+	}, nil
+}
@@ -116,9 +116,11 @@ func TestPodLabelsAnnotations(t *testing.T) {
 	sset, err := makeStatefulSet("test", monitoringv1.Prometheus{
 		ObjectMeta: metav1.ObjectMeta{},
 		Spec: monitoringv1.PrometheusSpec{
-			PodMetadata: &monitoringv1.EmbeddedObjectMetadata{
-				Annotations: annotations,
-				Labels:      labels,
+			CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+				PodMetadata: &monitoringv1.EmbeddedObjectMetadata{
+					Annotations: annotations,
+					Labels:      labels,
+				},
 			},
 		},
 	}, defaultTestConfig, nil, "", 0)
@@ -137,8 +139,10 @@ func TestPodLabelsShouldNotBeSelectorLabels(t *testing.T) {
 	sset, err := makeStatefulSet("test", monitoringv1.Prometheus{
 		ObjectMeta: metav1.ObjectMeta{},
 		Spec: monitoringv1.PrometheusSpec{
-			PodMetadata: &monitoringv1.EmbeddedObjectMetadata{
-				Labels: labels,
+			CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+				PodMetadata: &monitoringv1.EmbeddedObjectMetadata{
+					Labels: labels,
+				},
 			},
 		},
 	}, defaultTestConfig, nil, "", 0)
@@ -176,8 +180,10 @@ func TestStatefulSetPVC(t *testing.T) {
 			Annotations: annotations,
 		},
 		Spec: monitoringv1.PrometheusSpec{
-			Storage: &monitoringv1.StorageSpec{
-				VolumeClaimTemplate: pvc,
+			CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+				Storage: &monitoringv1.StorageSpec{
+					VolumeClaimTemplate: pvc,
+				},
 			},
 		},
 	}, defaultTestConfig, nil, "", 0)
@@ -208,8 +214,10 @@ func TestStatefulSetEmptyDir(t *testing.T) {
 			Annotations: annotations,
 		},
 		Spec: monitoringv1.PrometheusSpec{
-			Storage: &monitoringv1.StorageSpec{
-				EmptyDir: &emptyDir,
+			CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+				Storage: &monitoringv1.StorageSpec{
+					EmptyDir: &emptyDir,
+				},
 			},
 		},
 	}, defaultTestConfig, nil, "", 0)
@@ -336,8 +344,10 @@ func TestStatefulSetVolumeInitial(t *testing.T) {
 			Name: "volume-init-test",
 		},
 		Spec: monitoringv1.PrometheusSpec{
-			Secrets: []string{
-				"test-secret1",
+			CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+				Secrets: []string{
+					"test-secret1",
+				},
 			},
 		},
 	}, defaultTestConfig, []string{"rules-configmap-one"}, "", 0)
@@ -358,7 +368,9 @@ func TestStatefulSetVolumeInitial(t *testing.T) {
 func TestAdditionalConfigMap(t *testing.T) {
 	sset, err := makeStatefulSet("test", monitoringv1.Prometheus{
 		Spec: monitoringv1.PrometheusSpec{
-			ConfigMaps: []string{"test-cm1"},
+			CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+				ConfigMaps: []string{"test-cm1"},
+			},
 		},
 	}, defaultTestConfig, nil, "", 0)
 	if err != nil {
@@ -389,7 +401,9 @@ func TestAdditionalConfigMap(t *testing.T) {
 func TestListenLocal(t *testing.T) {
 	sset, err := makeStatefulSet("test", monitoringv1.Prometheus{
 		Spec: monitoringv1.PrometheusSpec{
-			ListenLocal: true,
+			CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+				ListenLocal: true,
+			},
 		},
 	}, defaultTestConfig, nil, "", 0)
 	if err != nil {
@@ -434,17 +448,19 @@ func TestListenLocal(t *testing.T) {
 func TestListenTLS(t *testing.T) {
 	sset, err := makeStatefulSet("test", monitoringv1.Prometheus{
 		Spec: monitoringv1.PrometheusSpec{
-			Web: &monitoringv1.WebSpec{
-				TLSConfig: &monitoringv1.WebTLSConfig{
-					KeySecret: v1.SecretKeySelector{
-						LocalObjectReference: v1.LocalObjectReference{
-							Name: "some-secret",
-						},
-					},
-					Cert: monitoringv1.SecretOrConfigMap{
-						ConfigMap: &v1.ConfigMapKeySelector{
+			CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+				Web: &monitoringv1.WebSpec{
+					TLSConfig: &monitoringv1.WebTLSConfig{
+						KeySecret: v1.SecretKeySelector{
 							LocalObjectReference: v1.LocalObjectReference{
-								Name: "some-configmap",
+								Name: "some-secret",
+							},
+						},
+						Cert: monitoringv1.SecretOrConfigMap{
+							ConfigMap: &v1.ConfigMapKeySelector{
+								LocalObjectReference: v1.LocalObjectReference{
+									Name: "some-configmap",
+								},
 							},
 						},
 					},
@@ -474,12 +490,93 @@ func TestListenTLS(t *testing.T) {
 	}
 }
 
+func TestWebBasicAuth(t *testing.T) {
+	password := v1.SecretKeySelector{
+		LocalObjectReference: v1.LocalObjectReference{
+			Name: "web-users",
+		},
+		Key: "admin",
+	}
+	thanosVersion := "v0.24.0"
+	p := monitoringv1.Prometheus{
+		Spec: monitoringv1.PrometheusSpec{
+			CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+				Web: &monitoringv1.WebSpec{
+					BasicAuthUsers: []monitoringv1.WebBasicAuthUser{
+						{Username: "admin", Password: password},
+					},
+				},
+			},
+			Thanos: &monitoringv1.ThanosSpec{
+				Version: &thanosVersion,
+			},
+		},
+	}
+
+	sset, err := makeStatefulSet("test", p, defaultTestConfig, nil, "", 0)
+	if err != nil {
+		t.Fatalf("Unexpected error while making StatefulSet: %v", err)
+	}
+
+	var prometheus, reloader, thanos v1.Container
+	for _, c := range sset.Spec.Template.Spec.Containers {
+		switch c.Name {
+		case "prometheus":
+			prometheus = c
+		case "config-reloader":
+			reloader = c
+		case "thanos-sidecar":
+			thanos = c
+		}
+	}
+
+	if prometheus.ReadinessProbe.HTTPGet != nil || prometheus.ReadinessProbe.Exec == nil {
+		t.Fatalf("expected an exec readiness probe, got %+v", prometheus.ReadinessProbe.Handler)
+	}
+	expectedEnv := []v1.EnvVar{
+		{Name: "WEB_BASIC_AUTH_USERNAME", Value: "admin"},
+		{Name: "WEB_BASIC_AUTH_PASSWORD", ValueFrom: &v1.EnvVarSource{SecretKeyRef: &password}},
+	}
+	if !reflect.DeepEqual(prometheus.Env, expectedEnv) {
+		t.Fatalf("Unexpected environment.\n\nExpected: %+v\n\nGot: %+v", expectedEnv, prometheus.Env)
+	}
+
+	var found bool
+	for _, env := range reloader.Env {
+		if env.Name == "RELOAD_BASIC_AUTH_PASSWORD" && reflect.DeepEqual(env.ValueFrom.SecretKeyRef, &password) {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected the config-reloader to get the basic auth password, got %+v", reloader.Env)
+	}
+
+	expectedArg := `--prometheus.http-client={"basic_auth":{"password_file":"/etc/prometheus/web_basic_auth/password","username":"admin"}}`
+	found = false
+	for _, arg := range thanos.Args {
+		if arg == expectedArg {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected argument %q for the Thanos sidecar, got %v", expectedArg, thanos.Args)
+	}
+
+	oldVersion := "v0.23.0"
+	p.Spec.Thanos.Version = &oldVersion
+	if _, err := makeStatefulSet("test", p, defaultTestConfig, nil, "", 0); err == nil {
+		t.Fatal("expected an error for Thanos < 0.24.0")
+	}
+}
+
 func TestTagAndShaAndVersion(t *testing.T) {
 	{
 		sset, err := makeStatefulSet("test", monitoringv1.Prometheus{
 			Spec: monitoringv1.PrometheusSpec{
-				Tag:     "my-unrelated-tag",
-				Version: "v2.3.2",
+				CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+					Tag:     "my-unrelated-tag",
+					Version: "v2.3.2",
+				},
 			},
 		}, defaultTestConfig, nil, "", 0)
 		if err != nil {
@@ -495,9 +592,11 @@ func TestTagAndShaAndVersion(t *testing.T) {
 	{
 		sset, err := makeStatefulSet("test", monitoringv1.Prometheus{
 			Spec: monitoringv1.PrometheusSpec{
-				SHA:     "7384a79f4b4991bf8269e7452390249b7c70bcdd10509c8c1c6c6e30e32fb324",
-				Tag:     "my-unrelated-tag",
-				Version: "v2.3.2",
+				CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+					SHA:     "7384a79f4b4991bf8269e7452390249b7c70bcdd10509c8c1c6c6e30e32fb324",
+					Tag:     "my-unrelated-tag",
+					Version: "v2.3.2",
+				},
 			},
 		}, defaultTestConfig, nil, "", 0)
 		if err != nil {
@@ -515,10 +614,12 @@ func TestTagAndShaAndVersion(t *testing.T) {
 		image := "my-reg/prometheus"
 		sset, err := makeStatefulSet("test", monitoringv1.Prometheus{
 			Spec: monitoringv1.PrometheusSpec{
-				SHA:     "7384a79f4b4991bf8269e7452390249b7c70bcdd10509c8c1c6c6e30e32fb324",
-				Tag:     "my-unrelated-tag",
-				Version: "v2.3.2",
-				Image:   &image,
+				CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+					SHA:     "7384a79f4b4991bf8269e7452390249b7c70bcdd10509c8c1c6c6e30e32fb324",
+					Tag:     "my-unrelated-tag",
+					Version: "v2.3.2",
+					Image:   &image,
+				},
 			},
 		}, defaultTestConfig, nil, "", 0)
 		if err != nil {
@@ -535,10 +636,12 @@ func TestTagAndShaAndVersion(t *testing.T) {
 		image := "my-reg/prometheus:latest"
 		sset, err := makeStatefulSet("test", monitoringv1.Prometheus{
 			Spec: monitoringv1.PrometheusSpec{
-				SHA:     "7384a79f4b4991bf8269e7452390249b7c70bcdd10509c8c1c6c6e30e32fb324",
-				Tag:     "my-unrelated-tag",
-				Version: "v2.3.2",
-				Image:   &image,
+				CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+					SHA:     "7384a79f4b4991bf8269e7452390249b7c70bcdd10509c8c1c6c6e30e32fb324",
+					Tag:     "my-unrelated-tag",
+					Version: "v2.3.2",
+					Image:   &image,
+				},
 			},
 		}, defaultTestConfig, nil, "", 0)
 		if err != nil {
@@ -555,8 +658,10 @@ func TestTagAndShaAndVersion(t *testing.T) {
 		image := "my-reg/prometheus"
 		sset, err := makeStatefulSet("test", monitoringv1.Prometheus{
 			Spec: monitoringv1.PrometheusSpec{
-				Version: "v2.3.2",
-				Image:   &image,
+				CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+					Version: "v2.3.2",
+					Image:   &image,
+				},
 			},
 		}, defaultTestConfig, nil, "", 0)
 		if err != nil {
@@ -573,9 +678,11 @@ func TestTagAndShaAndVersion(t *testing.T) {
 		image := "my-reg/prometheus"
 		sset, err := makeStatefulSet("test", monitoringv1.Prometheus{
 			Spec: monitoringv1.PrometheusSpec{
-				SHA:     "7384a79f4b4991bf8269e7452390249b7c70bcdd10509c8c1c6c6e30e32fb324",
-				Version: "v2.3.2",
-				Image:   &image,
+				CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+					SHA:     "7384a79f4b4991bf8269e7452390249b7c70bcdd10509c8c1c6c6e30e32fb324",
+					Version: "v2.3.2",
+					Image:   &image,
+				},
 			},
 		}, defaultTestConfig, nil, "", 0)
 		if err != nil {
@@ -592,7 +699,9 @@ func TestTagAndShaAndVersion(t *testing.T) {
 		image := "my-reg/prometheus"
 		sset, err := makeStatefulSet("test", monitoringv1.Prometheus{
 			Spec: monitoringv1.PrometheusSpec{
-				Image: &image,
+				CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+					Image: &image,
+				},
 			},
 		}, defaultTestConfig, nil, "", 0)
 		if err != nil {
@@ -609,8 +718,10 @@ func TestTagAndShaAndVersion(t *testing.T) {
 		image := "my-reg/prometheus"
 		sset, err := makeStatefulSet("test", monitoringv1.Prometheus{
 			Spec: monitoringv1.PrometheusSpec{
-				SHA:   "7384a79f4b4991bf8269e7452390249b7c70bcdd10509c8c1c6c6e30e32fb324",
-				Image: &image,
+				CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+					SHA:   "7384a79f4b4991bf8269e7452390249b7c70bcdd10509c8c1c6c6e30e32fb324",
+					Image: &image,
+				},
 			},
 		}, defaultTestConfig, nil, "", 0)
 		if err != nil {
@@ -627,8 +738,10 @@ func TestTagAndShaAndVersion(t *testing.T) {
 		image := ""
 		sset, err := makeStatefulSet("test", monitoringv1.Prometheus{
 			Spec: monitoringv1.PrometheusSpec{
-				Tag:   "my-unrelated-tag",
-				Image: &image,
+				CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+					Tag:   "my-unrelated-tag",
+					Image: &image,
+				},
 			},
 		}, defaultTestConfig, nil, "", 0)
 		if err != nil {
@@ -645,9 +758,11 @@ func TestTagAndShaAndVersion(t *testing.T) {
 		image := "my-reg/prometheus@sha256:7384a79f4b4991bf8269e7452390249b7c70bcdd10509c8c1c6c6e30e32fb325"
 		sset, err := makeStatefulSet("test", monitoringv1.Prometheus{
 			Spec: monitoringv1.PrometheusSpec{
-				SHA:   "7384a79f4b4991bf8269e7452390249b7c70bcdd10509c8c1c6c6e30e32fb324",
-				Tag:   "my-unrelated-tag",
-				Image: &image,
+				CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+					SHA:   "7384a79f4b4991bf8269e7452390249b7c70bcdd10509c8c1c6c6e30e32fb324",
+					Tag:   "my-unrelated-tag",
+					Image: &image,
+				},
 			},
 		}, defaultTestConfig, nil, "", 0)
 		if err != nil {
@@ -1132,7 +1247,9 @@ func TestRetentionSize(t *testing.T) {
 	for _, test := range tests {
 		sset, err := makeStatefulSet("test", monitoringv1.Prometheus{
 			Spec: monitoringv1.PrometheusSpec{
-				Version:       test.version,
+				CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+					Version: test.version,
+				},
 				RetentionSize: test.specRetentionSize,
 			},
 		}, defaultTestConfig, nil, "", 0)
@@ -1174,7 +1291,9 @@ func TestRetention(t *testing.T) {
 	for _, test := range tests {
 		sset, err := makeStatefulSet("test", monitoringv1.Prometheus{
 			Spec: monitoringv1.PrometheusSpec{
-				Version:   test.version,
+				CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+					Version: test.version,
+				},
 				Retention: test.specRetention,
 			},
 		}, defaultTestConfig, nil, "", 0)
@@ -1213,8 +1332,10 @@ func TestReplicasConfigurationWithSharding(t *testing.T) {
 	shards := int32(3)
 	sset, err := makeStatefulSet("test", monitoringv1.Prometheus{
 		Spec: monitoringv1.PrometheusSpec{
-			Replicas: &replicas,
-			Shards:   &shards,
+			CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+				Replicas: &replicas,
+				Shards:   &shards,
+			},
 		},
 	}, testConfig, nil, "", 1)
 	if err != nil {
@@ -1552,9 +1673,11 @@ func TestAdditionalContainers(t *testing.T) {
 	// Add an extra container
 	addSset, err := makeStatefulSet("test", monitoringv1.Prometheus{
 		Spec: monitoringv1.PrometheusSpec{
-			Containers: []v1.Container{
-				{
-					Name: "extra-container",
+			CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+				Containers: []v1.Container{
+					{
+						Name: "extra-container",
+					},
 				},
 			},
 		},
@@ -1570,10 +1693,12 @@ func TestAdditionalContainers(t *testing.T) {
 	const containerImage = "madeUpContainerImage"
 	modSset, err := makeStatefulSet("test", monitoringv1.Prometheus{
 		Spec: monitoringv1.PrometheusSpec{
-			Containers: []v1.Container{
-				{
-					Name:  existingContainerName,
-					Image: containerImage,
+			CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+				Containers: []v1.Container{
+					{
+						Name:  existingContainerName,
+						Image: containerImage,
+					},
 				},
 			},
 		},
@@ -1617,8 +1742,10 @@ func TestWALCompression(t *testing.T) {
 	for _, test := range tests {
 		sset, err := makeStatefulSet("test", monitoringv1.Prometheus{
 			Spec: monitoringv1.PrometheusSpec{
-				Version:        test.version,
-				WALCompression: test.enabled,
+				CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+					Version:        test.version,
+					WALCompression: test.enabled,
+				},
 			},
 		}, defaultTestConfig, nil, "", 0)
 		if err != nil {
@@ -1687,7 +1814,9 @@ func TestTerminationPolicy(t *testing.T) {
 func TestEnableFeaturesWithOneFeature(t *testing.T) {
 	sset, err := makeStatefulSet("test", monitoringv1.Prometheus{
 		Spec: monitoringv1.PrometheusSpec{
-			EnableFeatures: []string{"exemplar-storage"},
+			CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+				EnableFeatures: []string{"exemplar-storage"},
+			},
 		},
 	}, defaultTestConfig, nil, "", 0)
 
@@ -1710,7 +1839,9 @@ func TestEnableFeaturesWithOneFeature(t *testing.T) {
 func TestEnableFeaturesWithMultipleFeature(t *testing.T) {
 	sset, err := makeStatefulSet("test", monitoringv1.Prometheus{
 		Spec: monitoringv1.PrometheusSpec{
-			EnableFeatures: []string{"exemplar-storage1", "exemplar-storage2"},
+			CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+				EnableFeatures: []string{"exemplar-storage1", "exemplar-storage2"},
+			},
 		},
 	}, defaultTestConfig, nil, "", 0)
 
@@ -1734,8 +1865,10 @@ func TestWebPageTitle(t *testing.T) {
 	pageTitle := "my-page-title"
 	sset, err := makeStatefulSet("test", monitoringv1.Prometheus{
 		Spec: monitoringv1.PrometheusSpec{
-			Web: &monitoringv1.WebSpec{
-				PageTitle: &pageTitle,
+			CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+				Web: &monitoringv1.WebSpec{
+					PageTitle: &pageTitle,
+				},
 			},
 		},
 	}, defaultTestConfig, nil, "", 0)
@@ -1764,8 +1897,10 @@ func TestExpectedStatefulSetShardNames(t *testing.T) {
 			Name: "test",
 		},
 		Spec: monitoringv1.PrometheusSpec{
-			Shards:   &shards,
-			Replicas: &replicas,
+			CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+				Shards:   &shards,
+				Replicas: &replicas,
+			},
 		},
 	})
 
@@ -1856,3 +1991,316 @@ func TestThanosReadyTimeout(t *testing.T) {
 		t.Fatal("Sidecar ready timeout not set when it should.")
 	}
 }
+
+func TestPodSpecFields(t *testing.T) {
+	runtimeClass := "gvisor"
+	sset, err := makeStatefulSet("test", monitoringv1.Prometheus{
+		Spec: monitoringv1.PrometheusSpec{
+			CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+				HostAliases: []v1.HostAlias{
+					{IP: "10.0.0.1", Hostnames: []string{"remote-write.example.com"}},
+				},
+				DNSConfig: &v1.PodDNSConfig{
+					Nameservers: []string{"10.0.0.2"},
+				},
+				RuntimeClassName:             &runtimeClass,
+				AutomountServiceAccountToken: new(bool),
+				HostNetwork:                  true,
+				SchedulerName:                "custom-scheduler",
+				ReadinessGates: []v1.PodReadinessGate{
+					{ConditionType: "example.com/ready"},
+				},
+			},
+		},
+	}, defaultTestConfig, nil, "", 0)
+	require.NoError(t, err)
+
+	spec := sset.Spec.Template.Spec
+	require.Equal(t, []v1.HostAlias{{IP: "10.0.0.1", Hostnames: []string{"remote-write.example.com"}}}, spec.HostAliases)
+	require.Equal(t, v1.DNSClusterFirstWithHostNet, spec.DNSPolicy)
+	require.Equal(t, []string{"10.0.0.2"}, spec.DNSConfig.Nameservers)
+	require.Equal(t, "gvisor", *spec.RuntimeClassName)
+	require.False(t, *spec.AutomountServiceAccountToken)
+	require.True(t, spec.HostNetwork)
+	require.Equal(t, "custom-scheduler", spec.SchedulerName)
+	require.Equal(t, []v1.PodReadinessGate{{ConditionType: "example.com/ready"}}, spec.ReadinessGates)
+}
+
+func TestPodTemplateOverride(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		override monitoringv1.PodTemplateOverride
+	}{
+		{
+			name: "strategic merge patch",
+			override: monitoringv1.PodTemplateOverride{
+				Patch: `
+metadata:
+  annotations:
+    example.com/override: "true"
+spec:
+  enableServiceLinks: false
+  containers:
+  - name: prometheus
+    workingDir: /prometheus
+`,
+			},
+		},
+		{
+			name: "JSON patch",
+			override: monitoringv1.PodTemplateOverride{
+				Type: monitoringv1.JSONPatchPodTemplateOverride,
+				Patch: `[
+  {"op": "add", "path": "/metadata/annotations/example.com~1override", "value": "true"},
+  {"op": "add", "path": "/spec/enableServiceLinks", "value": false},
+  {"op": "add", "path": "/spec/containers/0/workingDir", "value": "/prometheus"}
+]`,
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			override := tc.override
+			sset, err := makeStatefulSet("test", monitoringv1.Prometheus{
+				Spec: monitoringv1.PrometheusSpec{
+					CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+						PodTemplateOverride: &override,
+					},
+				},
+			}, defaultTestConfig, nil, "", 0)
+			require.NoError(t, err)
+
+			tmpl := sset.Spec.Template
+			require.Equal(t, "true", tmpl.Annotations["example.com/override"])
+			require.NotNil(t, tmpl.Spec.EnableServiceLinks)
+			require.False(t, *tmpl.Spec.EnableServiceLinks)
+			require.Equal(t, "prometheus", tmpl.Spec.Containers[0].Name)
+			require.Equal(t, "/prometheus", tmpl.Spec.Containers[0].WorkingDir)
+			// The other containers are left untouched.
+			require.Equal(t, "config-reloader", tmpl.Spec.Containers[1].Name)
+			require.Empty(t, tmpl.Spec.Containers[1].WorkingDir)
+		})
+	}
+
+	_, err := makeStatefulSet("test", monitoringv1.Prometheus{
+		Spec: monitoringv1.PrometheusSpec{
+			CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+				PodTemplateOverride: &monitoringv1.PodTemplateOverride{
+					Type:  monitoringv1.JSONPatchPodTemplateOverride,
+					Patch: `[{"op": "remove", "path": "/spec/doesNotExist"}]`,
+				},
+			},
+		},
+	}, defaultTestConfig, nil, "", 0)
+	require.Error(t, err)
+}
+
+func TestQueryTenancy(t *testing.T) {
+	p := monitoringv1.Prometheus{
+		Spec: monitoringv1.PrometheusSpec{
+			CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+				EnforcedNamespaceLabel: "tenant",
+			},
+			QueryTenancy: &monitoringv1.QueryTenancySpec{},
+			Thanos:       &monitoringv1.ThanosSpec{},
+		},
+	}
+
+	sset, err := makeStatefulSet("test", p, defaultTestConfig, nil, "", 0)
+	if err != nil {
+		t.Fatalf("Unexpected error while making StatefulSet: %v", err)
+	}
+
+	var proxy *v1.Container
+	for i, c := range sset.Spec.Template.Spec.Containers {
+		if c.Name == "query-proxy" {
+			proxy = &sset.Spec.Template.Spec.Containers[i]
+		}
+		if c.Name == "thanos-sidecar" && c.Args[1] != "--prometheus.url=http://"+defaultTestConfig.LocalHost+":9090/" {
+			t.Fatalf("expected the Thanos sidecar to query Prometheus directly, got %q", c.Args[1])
+		}
+	}
+	if proxy == nil {
+		t.Fatal("query-proxy container not found")
+	}
+
+	expectedArgs := []string{
+		"--insecure-listen-address=:9095",
+		"--upstream=http://" + defaultTestConfig.LocalHost + ":9090/",
+		"--label=tenant",
+		"--header-name=X-Namespace",
+		"--enable-label-apis",
+	}
+	if !reflect.DeepEqual(proxy.Args, expectedArgs) {
+		t.Fatalf("unexpected proxy arguments\nexpected: %v\ngot: %v", expectedArgs, proxy.Args)
+	}
+	if proxy.Image != operator.DefaultPromLabelProxyImage {
+		t.Fatalf("expected image %q, got %q", operator.DefaultPromLabelProxyImage, proxy.Image)
+	}
+
+	// The queries can't bypass the proxy: the service doesn't expose any
+	// Prometheus port and Prometheus listens on the loopback interface.
+	svc := makeStatefulSetService(&p, *defaultTestConfig)
+	expectedTargets := map[string]string{
+		"web":  queryProxyPortName,
+		"grpc": "grpc",
+	}
+	if len(svc.Spec.Ports) != len(expectedTargets) {
+		t.Fatalf("expected %d service ports, got %v", len(expectedTargets), svc.Spec.Ports)
+	}
+	for _, port := range svc.Spec.Ports {
+		if port.TargetPort.StrVal != expectedTargets[port.Name] {
+			t.Fatalf("expected port %q to target %q, got %q", port.Name, expectedTargets[port.Name], port.TargetPort.StrVal)
+		}
+	}
+
+	for _, c := range sset.Spec.Template.Spec.Containers {
+		if c.Name != "prometheus" {
+			continue
+		}
+		if len(c.Ports) != 0 {
+			t.Fatalf("expected no port on the prometheus container, got %v", c.Ports)
+		}
+		found := false
+		for _, arg := range c.Args {
+			if arg == "--web.listen-address=127.0.0.1:9090" {
+				found = true
+			}
+		}
+		if !found {
+			t.Fatalf("expected prometheus to listen on the loopback interface, got %v", c.Args)
+		}
+	}
+
+	for _, tc := range []struct {
+		name string
+		web  monitoringv1.WebSpec
+	}{
+		{
+			name: "TLS",
+			web:  monitoringv1.WebSpec{TLSConfig: &monitoringv1.WebTLSConfig{}},
+		},
+		{
+			name: "basic authentication",
+			web: monitoringv1.WebSpec{
+				BasicAuthUsers: []monitoringv1.WebBasicAuthUser{{Username: "alice"}},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p := *p.DeepCopy()
+			p.Spec.Web = &tc.web
+			if _, err := makeStatefulSet("test", p, defaultTestConfig, nil, "", 0); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
+
+func TestTSDB(t *testing.T) {
+	var (
+		queueSize int32 = 1000
+		exemplars int64 = 100000
+	)
+
+	for _, tc := range []struct {
+		name         string
+		spec         monitoringv1.PrometheusSpec
+		expectedArgs []string
+		expectedErr  bool
+	}{
+		{
+			name: "block durations and head chunks queue",
+			spec: monitoringv1.PrometheusSpec{
+				CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+					Version: "v2.30.0",
+				},
+				TSDB: &monitoringv1.TSDBSpec{
+					MinBlockDuration:         "1h",
+					MaxBlockDuration:         "6h",
+					HeadChunksWriteQueueSize: &queueSize,
+				},
+			},
+			expectedArgs: []string{
+				"--storage.tsdb.head-chunks-write-queue-size=1000",
+				"--storage.tsdb.max-block-duration=6h",
+				"--storage.tsdb.min-block-duration=1h",
+			},
+		},
+		{
+			name: "compaction disabled",
+			spec: monitoringv1.PrometheusSpec{
+				DisableCompaction: true,
+				TSDB:              &monitoringv1.TSDBSpec{},
+			},
+			expectedArgs: []string{
+				"--storage.tsdb.max-block-duration=2h",
+				"--storage.tsdb.min-block-duration=2h",
+			},
+		},
+		{
+			name: "block durations with compaction disabled",
+			spec: monitoringv1.PrometheusSpec{
+				DisableCompaction: true,
+				TSDB:              &monitoringv1.TSDBSpec{MaxBlockDuration: "6h"},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "min block duration greater than max",
+			spec: monitoringv1.PrometheusSpec{
+				TSDB: &monitoringv1.TSDBSpec{MinBlockDuration: "6h", MaxBlockDuration: "1h"},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "invalid duration",
+			spec: monitoringv1.PrometheusSpec{
+				TSDB: &monitoringv1.TSDBSpec{OutOfOrderTimeWindow: "foo"},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "out-of-order window unsupported",
+			spec: monitoringv1.PrometheusSpec{
+				CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+					Version: "v2.38.0",
+				},
+				TSDB: &monitoringv1.TSDBSpec{OutOfOrderTimeWindow: "10m"},
+			},
+			expectedErr: true,
+		},
+		{
+			name: "exemplars unsupported",
+			spec: monitoringv1.PrometheusSpec{
+				CommonPrometheusFields: monitoringv1.CommonPrometheusFields{
+					Version: "v2.29.0",
+				},
+				TSDB: &monitoringv1.TSDBSpec{MaxExemplars: &exemplars},
+			},
+			expectedErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			sset, err := makeStatefulSet("test", monitoringv1.Prometheus{Spec: tc.spec}, defaultTestConfig, nil, "", 0)
+			if tc.expectedErr {
+				if err == nil {
+					t.Fatal("expected error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error while making StatefulSet: %v", err)
+			}
+
+			var args []string
+			for _, arg := range sset.Spec.Template.Spec.Containers[0].Args {
+				if strings.HasPrefix(arg, "--storage.tsdb.head-chunks") || strings.HasPrefix(arg, "--storage.tsdb.max-block") || strings.HasPrefix(arg, "--storage.tsdb.min-block") {
+					args = append(args, arg)
+				}
+			}
+			if !reflect.DeepEqual(args, tc.expectedArgs) {
+				t.Fatalf("expected arguments %v, got %v", tc.expectedArgs, args)
+			}
+		})
+	}
+}
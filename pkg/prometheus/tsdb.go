@@ -0,0 +1,155 @@
+// Copyright 2023 The prometheus-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus
+
+import (
+	"fmt"
+
+	"github.com/blang/semver/v4"
+	"github.com/pkg/errors"
+	"github.com/prometheus/common/model"
+	"gopkg.in/yaml.v2"
+
+	v1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+)
+
+// validateTSDB checks that the TSDB settings are valid and supported by the
+// given Prometheus version.
+func validateTSDB(p *v1.Prometheus, version semver.Version) error {
+	tsdb := p.Spec.TSDB
+	if tsdb == nil {
+		return nil
+	}
+
+	for _, d := range []struct {
+		name  string
+		value string
+	}{
+		{"outOfOrderTimeWindow", tsdb.OutOfOrderTimeWindow},
+		{"minBlockDuration", tsdb.MinBlockDuration},
+		{"maxBlockDuration", tsdb.MaxBlockDuration},
+	} {
+		if d.value == "" {
+			continue
+		}
+		if _, err := model.ParseDuration(d.value); err != nil {
+			return errors.Wrapf(err, "invalid tsdb.%s", d.name)
+		}
+	}
+
+	if tsdb.MinBlockDuration != "" && tsdb.MaxBlockDuration != "" {
+		minDuration, _ := model.ParseDuration(tsdb.MinBlockDuration)
+		maxDuration, _ := model.ParseDuration(tsdb.MaxBlockDuration)
+		if minDuration > maxDuration {
+			return errors.Errorf("tsdb.minBlockDuration (%s) must not be greater than tsdb.maxBlockDuration (%s)", tsdb.MinBlockDuration, tsdb.MaxBlockDuration)
+		}
+	}
+
+	if tsdb.HeadChunksWriteQueueSize != nil && *tsdb.HeadChunksWriteQueueSize < 0 {
+		return errors.Errorf("tsdb.headChunksWriteQueueSize must not be negative, got %d", *tsdb.HeadChunksWriteQueueSize)
+	}
+
+	if tsdb.MaxExemplars != nil && *tsdb.MaxExemplars < 0 {
+		return errors.Errorf("tsdb.maxExemplars must not be negative, got %d", *tsdb.MaxExemplars)
+	}
+
+	for _, f := range []struct {
+		name       string
+		set        bool
+		minVersion string
+	}{
+		{"outOfOrderTimeWindow", tsdb.OutOfOrderTimeWindow != "", "2.39.0"},
+		{"headChunksWriteQueueSize", tsdb.HeadChunksWriteQueueSize != nil, "2.30.0"},
+		{"maxExemplars", tsdb.MaxExemplars != nil, "2.30.0"},
+	} {
+		if f.set && version.LT(semver.MustParse(f.minVersion)) {
+			return errors.Errorf("tsdb.%s requires Prometheus >= %s, got %s", f.name, f.minVersion, version)
+		}
+	}
+
+	return nil
+}
+
+// tsdbArgs returns the command-line arguments configuring the TSDB, except the
+// block durations which depend on whether the compaction is disabled.
+func tsdbArgs(p *v1.Prometheus) []string {
+	var args []string
+
+	if p.Spec.TSDB != nil && p.Spec.TSDB.HeadChunksWriteQueueSize != nil {
+		args = append(args, fmt.Sprintf("-storage.tsdb.head-chunks-write-queue-size=%d", *p.Spec.TSDB.HeadChunksWriteQueueSize))
+	}
+
+	return args
+}
+
+// tsdbBlockDurationArgs returns the command-line arguments configuring the
+// duration of the TSDB blocks.
+func tsdbBlockDurationArgs(p *v1.Prometheus, disableCompaction bool) ([]string, error) {
+	if disableCompaction {
+		if p.Spec.TSDB != nil && (p.Spec.TSDB.MinBlockDuration != "" || p.Spec.TSDB.MaxBlockDuration != "") {
+			return nil, errors.New("tsdb.minBlockDuration and tsdb.maxBlockDuration can't be set when the compaction is disabled")
+		}
+
+		return []string{
+			"--storage.tsdb.max-block-duration=2h",
+			"--storage.tsdb.min-block-duration=2h",
+		}, nil
+	}
+
+	var args []string
+	if p.Spec.TSDB == nil {
+		return args, nil
+	}
+
+	if p.Spec.TSDB.MaxBlockDuration != "" {
+		args = append(args, "--storage.tsdb.max-block-duration="+p.Spec.TSDB.MaxBlockDuration)
+	}
+	if p.Spec.TSDB.MinBlockDuration != "" {
+		args = append(args, "--storage.tsdb.min-block-duration="+p.Spec.TSDB.MinBlockDuration)
+	}
+
+	return args, nil
+}
+
+// generateStorageConfig returns the storage section of the configuration
+// file, or nil if it would be empty.
+func generateStorageConfig(p *v1.Prometheus, version semver.Version) yaml.MapSlice {
+	tsdb := p.Spec.TSDB
+	if tsdb == nil {
+		return nil
+	}
+
+	var storage yaml.MapSlice
+
+	if tsdb.OutOfOrderTimeWindow != "" && version.GTE(semver.MustParse("2.39.0")) {
+		storage = append(storage, yaml.MapItem{
+			Key: "tsdb",
+			Value: yaml.MapSlice{
+				{Key: "out_of_order_time_window", Value: tsdb.OutOfOrderTimeWindow},
+			},
+		})
+	}
+
+	if tsdb.MaxExemplars != nil && version.GTE(semver.MustParse("2.30.0")) {
+		storage = append(storage, yaml.MapItem{
+			Key: "exemplars",
+			Value: yaml.MapSlice{
+				{Key: "max_exemplars", Value: *tsdb.MaxExemplars},
+			},
+		})
+	}
+
+	return storage
+}
@@ -0,0 +1,84 @@
+// Copyright 2022 The prometheus-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/blang/semver/v4"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/prometheus-operator/prometheus-operator/pkg/k8sutil"
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	webBasicAuthDir          = "/etc/prometheus/web_basic_auth"
+	webBasicAuthPasswordFile = "password"
+)
+
+// webConfigFileFields returns the fields of the web config file which are
+// effective for the given Prometheus version.
+func webConfigFileFields(p *monitoringv1.Prometheus, version semver.Version) monitoringv1.WebConfigFileFields {
+	if p.Spec.Web == nil || version.LT(semver.MustParse("2.24.0")) {
+		return monitoringv1.WebConfigFileFields{}
+	}
+	return p.Spec.Web.ConfigFileFields()
+}
+
+// makeThanosHTTPClientArg returns the argument configuring the client used
+// by the Thanos sidecar to access the web server of Prometheus and the
+// volume and volume mount it depends on (if any).
+func makeThanosHTTPClientArg(fields monitoringv1.WebConfigFileFields) (string, []v1.Volume, []v1.VolumeMount) {
+	var (
+		cfg     = map[string]interface{}{}
+		volumes []v1.Volume
+		mounts  []v1.VolumeMount
+	)
+
+	if fields.TLSConfig != nil {
+		// The certificate isn't issued for localhost.
+		cfg["tls_config"] = map[string]interface{}{"insecure_skip_verify": true}
+	}
+
+	if u := fields.BasicAuthUsers; len(u) > 0 {
+		volumeName := k8sutil.SanitizeVolumeName("web-basic-auth-" + u[0].Password.Name)
+		cfg["basic_auth"] = map[string]interface{}{
+			"username":      u[0].Username,
+			"password_file": webBasicAuthDir + "/" + webBasicAuthPasswordFile,
+		}
+		volumes = append(volumes, v1.Volume{
+			Name: volumeName,
+			VolumeSource: v1.VolumeSource{
+				Secret: &v1.SecretVolumeSource{
+					SecretName: u[0].Password.Name,
+					Items: []v1.KeyToPath{
+						{Key: u[0].Password.Key, Path: webBasicAuthPasswordFile},
+					},
+				},
+			},
+		})
+		mounts = append(mounts, v1.VolumeMount{
+			Name:      volumeName,
+			MountPath: webBasicAuthDir,
+			ReadOnly:  true,
+		})
+	}
+
+	// Marshaling a map of strings and booleans can't fail and the keys are
+	// sorted.
+	b, _ := json.Marshal(cfg)
+	return fmt.Sprintf("--prometheus.http-client=%s", b), volumes, mounts
+}
@@ -16,8 +16,8 @@ package thanos
 
 import (
 	v1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/prometheus-operator/prometheus-operator/pkg/informers"
 	"github.com/prometheus/client_golang/prometheus"
-	"k8s.io/client-go/tools/cache"
 )
 
 var (
@@ -32,12 +32,14 @@ var (
 )
 
 type thanosRulerCollector struct {
-	stores []cache.Store
+	informers *informers.ForResource
+	selected  func(interface{}) bool
 }
 
-// newThanosRulerCollectorForStores creates a thanosRulerCollector initialized with the given cache store
-func newThanosRulerCollectorForStores(s ...cache.Store) *thanosRulerCollector {
-	return &thanosRulerCollector{stores: s}
+// newThanosRulerCollectorForInformers creates a thanosRulerCollector initialized with the given informers
+// and reporting only the objects accepted by the selected function.
+func newThanosRulerCollectorForInformers(infs *informers.ForResource, selected func(interface{}) bool) *thanosRulerCollector {
+	return &thanosRulerCollector{informers: infs, selected: selected}
 }
 
 // Describe implements the prometheus.Collector interface.
@@ -47,8 +49,11 @@ func (c *thanosRulerCollector) Describe(ch chan<- *prometheus.Desc) {
 
 // Collect implements the prometheus.Collector interface.
 func (c *thanosRulerCollector) Collect(ch chan<- prometheus.Metric) {
-	for _, s := range c.stores {
-		for _, tr := range s.List() {
+	for _, inf := range c.informers.GetInformers() {
+		for _, tr := range inf.Informer().GetStore().List() {
+			if !c.selected(tr) {
+				continue
+			}
 			c.collectThanos(ch, tr.(*v1.ThanosRuler))
 		}
 	}
@@ -19,15 +19,20 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/mitchellh/hashstructure"
+	"github.com/prometheus-operator/prometheus-operator/pkg/alertmanager"
+	"github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/prometheus-operator/prometheus-operator/pkg/assets"
 	monitoringclient "github.com/prometheus-operator/prometheus-operator/pkg/client/versioned"
 	"github.com/prometheus-operator/prometheus-operator/pkg/informers"
 	"github.com/prometheus-operator/prometheus-operator/pkg/k8sutil"
 	"github.com/prometheus-operator/prometheus-operator/pkg/listwatch"
 	"github.com/prometheus-operator/prometheus-operator/pkg/operator"
+	"github.com/prometheus-operator/prometheus-operator/pkg/webconfig"
 
 	"github.com/go-kit/log"
 	"github.com/go-kit/log/level"
@@ -42,6 +47,7 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/metadata"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
@@ -63,15 +69,22 @@ type Operator struct {
 	cmapInfs        *informers.ForResource
 	ruleInfs        *informers.ForResource
 	ssetInfs        *informers.ForResource
+	amInfs          *informers.ForResource
 
 	nsThanosRulerInf cache.SharedIndexInformer
 	nsRuleInf        cache.SharedIndexInformer
 
+	nsSelector *informers.NamespaceSelector
+
 	queue workqueue.RateLimitingInterface
 
 	metrics *operator.Metrics
 
-	config Config
+	// configMtx protects the settings which can be updated at runtime by
+	// ApplyConfig.
+	configMtx  sync.RWMutex
+	config     Config
+	trSelector labels.Selector
 }
 
 // Config defines configuration parameters for the Operator.
@@ -106,10 +119,40 @@ func New(ctx context.Context, conf operator.Config, logger log.Logger, r prometh
 		return nil, errors.Wrap(err, "instantiating monitoring client failed")
 	}
 
-	if _, err := labels.Parse(conf.ThanosRulerSelector); err != nil {
+	mdClient, err := metadata.NewForConfig(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "instantiating metadata client failed")
+	}
+
+	trSelector, err := labels.Parse(conf.ThanosRulerSelector)
+	if err != nil {
 		return nil, errors.Wrap(err, "can not parse thanos ruler selector value")
 	}
 
+	namespaceLabels, err := operator.CheckControllerPermissions(
+		ctx,
+		logger,
+		client.AuthorizationV1().SelfSubjectAccessReviews(),
+		"thanos",
+		operator.NamespacedAttributes{
+			AllowList:  conf.Namespaces.AllowList,
+			Attributes: operator.ListWatchAttributes(monitoring.GroupName, monitoringv1.PrometheusRuleName, monitoringv1.AlertmanagerName),
+		},
+		operator.NamespacedAttributes{
+			AllowList: conf.Namespaces.ThanosRulerAllowList,
+			Attributes: append(
+				append(
+					operator.ListWatchAttributes(monitoring.GroupName, monitoringv1.ThanosRulerName),
+					operator.ListWatchAttributes("", "configmaps")...,
+				),
+				operator.ListWatchAttributes("apps", "statefulsets")...,
+			),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	o := &Operator{
 		kclient: client,
 		mclient: mclient,
@@ -129,13 +172,23 @@ func New(ctx context.Context, conf operator.Config, logger log.Logger, r prometh
 			LogFormat:              conf.LogFormat,
 			ThanosRulerSelector:    conf.ThanosRulerSelector,
 		},
+		trSelector: trSelector,
+	}
+
+	if !namespaceLabels {
+		o.metrics.DisableFeature("namespace-labels")
+	}
+
+	o.nsSelector, err = informers.NewNamespaceSelector(o.config.Namespaces.AllowSelector, o.config.Namespaces.DenySelector, o.logger)
+	if err != nil {
+		return nil, err
 	}
 
 	o.cmapInfs, err = informers.NewInformersForResource(
-		informers.NewKubeInformerFactories(
-			o.config.Namespaces.ThanosRulerAllowList,
+		informers.NewMetadataInformerFactories(
+			o.nsSelector.AllowList(o.config.Namespaces.ThanosRulerAllowList),
 			o.config.Namespaces.DenyList,
-			o.kclient,
+			mdClient,
 			resyncPeriod,
 			func(options *metav1.ListOptions) {
 				options.LabelSelector = labelThanosRulerName
@@ -147,15 +200,15 @@ func New(ctx context.Context, conf operator.Config, logger log.Logger, r prometh
 		return nil, errors.Wrap(err, "error creating configmap informers")
 	}
 
+	// The instance selector is evaluated by the controller instead of the
+	// API server so that it can be updated at runtime.
 	o.thanosRulerInfs, err = informers.NewInformersForResource(
 		informers.NewMonitoringInformerFactories(
-			o.config.Namespaces.ThanosRulerAllowList,
+			o.nsSelector.AllowList(o.config.Namespaces.ThanosRulerAllowList),
 			o.config.Namespaces.DenyList,
 			mclient,
 			resyncPeriod,
-			func(options *metav1.ListOptions) {
-				options.LabelSelector = o.config.ThanosRulerSelector
-			},
+			nil,
 		),
 		monitoringv1.SchemeGroupVersion.WithResource(monitoringv1.ThanosRulerName),
 	)
@@ -163,15 +216,11 @@ func New(ctx context.Context, conf operator.Config, logger log.Logger, r prometh
 		return nil, errors.Wrap(err, "error creating thanosruler informers")
 	}
 
-	var thanosStores []cache.Store
-	for _, informer := range o.thanosRulerInfs.GetInformers() {
-		thanosStores = append(thanosStores, informer.Informer().GetStore())
-	}
-	o.metrics.MustRegister(newThanosRulerCollectorForStores(thanosStores...))
+	o.metrics.MustRegister(newThanosRulerCollectorForInformers(o.thanosRulerInfs, o.isSelected))
 
 	o.ruleInfs, err = informers.NewInformersForResource(
 		informers.NewMonitoringInformerFactories(
-			o.config.Namespaces.AllowList,
+			o.nsSelector.AllowList(o.config.Namespaces.AllowList),
 			o.config.Namespaces.DenyList,
 			mclient,
 			resyncPeriod,
@@ -183,9 +232,23 @@ func New(ctx context.Context, conf operator.Config, logger log.Logger, r prometh
 		return nil, errors.Wrap(err, "error creating prometheusrule informers")
 	}
 
+	o.amInfs, err = informers.NewInformersForResource(
+		informers.NewMonitoringInformerFactories(
+			o.nsSelector.AllowList(o.config.Namespaces.AllowList),
+			o.config.Namespaces.DenyList,
+			mclient,
+			resyncPeriod,
+			nil,
+		),
+		monitoringv1.SchemeGroupVersion.WithResource(monitoringv1.AlertmanagerName),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating alertmanager informers")
+	}
+
 	o.ssetInfs, err = informers.NewInformersForResource(
 		informers.NewKubeInformerFactories(
-			o.config.Namespaces.ThanosRulerAllowList,
+			o.nsSelector.AllowList(o.config.Namespaces.ThanosRulerAllowList),
 			o.config.Namespaces.DenyList,
 			o.kclient,
 			resyncPeriod,
@@ -225,6 +288,14 @@ func New(ctx context.Context, conf operator.Config, logger log.Logger, r prometh
 		o.nsThanosRulerInf = newNamespaceInformer(o, o.config.Namespaces.ThanosRulerAllowList)
 	}
 
+	// Informers for namespaces matching the namespace selector are created
+	// and removed at runtime.
+	o.nsSelector.Register(o.config.Namespaces.AllowList, o.ruleInfs, o.amInfs)
+	o.nsSelector.Register(o.config.Namespaces.ThanosRulerAllowList, o.thanosRulerInfs, o.cmapInfs, o.ssetInfs)
+	if o.nsSelector != nil {
+		o.nsRuleInf.AddEventHandler(o.nsSelector)
+	}
+
 	return o, nil
 }
 
@@ -238,6 +309,7 @@ func (o *Operator) waitForCacheSync(ctx context.Context) error {
 		{"ConfigMap", o.cmapInfs},
 		{"PrometheusRule", o.ruleInfs},
 		{"StatefulSet", o.ssetInfs},
+		{"Alertmanager", o.amInfs},
 	} {
 		for _, inf := range infs.informersForResource.GetInformers() {
 			if !operator.WaitForNamedCacheSync(ctx, "thanos", log.With(o.logger, "informer", infs.name), inf.Informer()) {
@@ -284,6 +356,11 @@ func (o *Operator) addHandlers() {
 		DeleteFunc: o.handleStatefulSetDelete,
 		UpdateFunc: o.handleStatefulSetUpdate,
 	})
+	o.amInfs.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    o.handleAlertmanagerAdd,
+		DeleteFunc: o.handleAlertmanagerDelete,
+		UpdateFunc: o.handleAlertmanagerUpdate,
+	})
 
 	// The controller needs to watch the namespaces in which the rules live
 	// because a label change on a namespace may trigger a configuration
@@ -330,6 +407,7 @@ func (o *Operator) Run(ctx context.Context) error {
 		go o.nsThanosRulerInf.Run(ctx.Done())
 	}
 	go o.ssetInfs.Start(ctx.Done())
+	go o.amInfs.Start(ctx.Done())
 	if err := o.waitForCacheSync(ctx); err != nil {
 		return err
 	}
@@ -340,6 +418,61 @@ func (o *Operator) Run(ctx context.Context) error {
 	return nil
 }
 
+// ApplyConfig updates the settings of the controller which can be changed at
+// runtime (instance selector, labels, config reloader and default base image)
+// and enqueues all ThanosRuler objects for reconciliation.
+func (o *Operator) ApplyConfig(conf operator.Config) error {
+	trSelector, err := labels.Parse(conf.ThanosRulerSelector)
+	if err != nil {
+		return errors.Wrap(err, "can not parse thanos ruler selector value")
+	}
+
+	o.configMtx.Lock()
+	o.config.ThanosRulerSelector = conf.ThanosRulerSelector
+	o.trSelector = trSelector
+	o.config.Labels = conf.Labels
+	o.config.ReloaderConfig = conf.ReloaderConfig
+	o.config.ThanosDefaultBaseImage = conf.ThanosDefaultBaseImage
+	o.configMtx.Unlock()
+
+	// Enqueue the keys of all the objects, the objects which don't match the
+	// new instance selector anymore are forgotten by the sync.
+	err = o.thanosRulerInfs.ListAll(labels.Everything(), func(obj interface{}) {
+		if key, ok := o.keyFunc(obj); ok {
+			o.enqueue(key)
+		}
+	})
+	if err != nil {
+		return errors.Wrap(err, "listing all ThanosRuler instances from cache failed")
+	}
+
+	return nil
+}
+
+// currentConfig returns a copy of the settings which can be updated at
+// runtime by ApplyConfig and the instance selector. The lock is only held
+// while copying so that a long sync doesn't block ApplyConfig.
+func (o *Operator) currentConfig() (Config, labels.Selector) {
+	o.configMtx.RLock()
+	defer o.configMtx.RUnlock()
+
+	return o.config, o.trSelector
+}
+
+// isSelected returns true if the object matches the instance selector. The
+// informers watch all the objects in the namespaces so that the selector can
+// be updated at runtime, the objects managed by other operators are filtered
+// out here.
+func (o *Operator) isSelected(obj interface{}) bool {
+	meta, ok := o.getObjectMeta(obj)
+	if !ok {
+		return false
+	}
+
+	_, trSelector := o.currentConfig()
+	return trSelector.Matches(labels.Set(meta.GetLabels()))
+}
+
 func (o *Operator) keyFunc(obj interface{}) (string, bool) {
 	k, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
 	if err != nil {
@@ -350,6 +483,10 @@ func (o *Operator) keyFunc(obj interface{}) (string, bool) {
 }
 
 func (o *Operator) handleThanosRulerAdd(obj interface{}) {
+	if !o.isSelected(obj) {
+		return
+	}
+
 	key, ok := o.keyFunc(obj)
 	if !ok {
 		return
@@ -361,6 +498,10 @@ func (o *Operator) handleThanosRulerAdd(obj interface{}) {
 }
 
 func (o *Operator) handleThanosRulerDelete(obj interface{}) {
+	if !o.isSelected(obj) {
+		return
+	}
+
 	key, ok := o.keyFunc(obj)
 	if !ok {
 		return
@@ -376,6 +517,12 @@ func (o *Operator) handleThanosRulerUpdate(old, cur interface{}) {
 		return
 	}
 
+	// An object which stops matching the instance selector is still enqueued
+	// to be forgotten by the sync.
+	if !o.isSelected(old) && !o.isSelected(cur) {
+		return
+	}
+
 	key, ok := o.keyFunc(cur)
 	if !ok {
 		return
@@ -408,7 +555,7 @@ func (o *Operator) handleConfigMapDelete(obj interface{}) {
 }
 
 func (o *Operator) handleConfigMapUpdate(old, cur interface{}) {
-	if old.(*v1.ConfigMap).ResourceVersion == cur.(*v1.ConfigMap).ResourceVersion {
+	if old.(*metav1.PartialObjectMetadata).ResourceVersion == cur.(*metav1.PartialObjectMetadata).ResourceVersion {
 		return
 	}
 
@@ -458,6 +605,43 @@ func (o *Operator) handleRuleDelete(obj interface{}) {
 	}
 }
 
+func (o *Operator) handleAlertmanagerAdd(obj interface{}) {
+	meta, ok := o.getObjectMeta(obj)
+	if ok {
+		level.Debug(o.logger).Log("msg", "Alertmanager added")
+		o.metrics.TriggerByCounter(monitoringv1.AlertmanagersKind, "add").Inc()
+
+		o.enqueueForRulesNamespace(meta.GetNamespace())
+	}
+}
+
+// The status of the Alertmanager resources changes frequently and doesn't
+// affect the Alertmanager URLs, only the labels and the spec are compared.
+func (o *Operator) handleAlertmanagerUpdate(old, cur interface{}) {
+	oldAm, curAm := old.(*monitoringv1.Alertmanager), cur.(*monitoringv1.Alertmanager)
+	if reflect.DeepEqual(oldAm.Labels, curAm.Labels) && reflect.DeepEqual(oldAm.Spec, curAm.Spec) {
+		return
+	}
+
+	meta, ok := o.getObjectMeta(cur)
+	if ok {
+		level.Debug(o.logger).Log("msg", "Alertmanager updated")
+		o.metrics.TriggerByCounter(monitoringv1.AlertmanagersKind, "update").Inc()
+
+		o.enqueueForRulesNamespace(meta.GetNamespace())
+	}
+}
+
+func (o *Operator) handleAlertmanagerDelete(obj interface{}) {
+	meta, ok := o.getObjectMeta(obj)
+	if ok {
+		level.Debug(o.logger).Log("msg", "Alertmanager deleted")
+		o.metrics.TriggerByCounter(monitoringv1.AlertmanagersKind, "delete").Inc()
+
+		o.enqueueForRulesNamespace(meta.GetNamespace())
+	}
+}
+
 func (o *Operator) thanosForStatefulSet(sset interface{}) *monitoringv1.ThanosRuler {
 	key, ok := o.keyFunc(sset)
 	if !ok {
@@ -540,6 +724,10 @@ func (o *Operator) enqueue(obj interface{}) {
 
 	key, ok := obj.(string)
 	if !ok {
+		if !o.isSelected(obj) {
+			return
+		}
+
 		key, ok = o.keyFunc(obj)
 		if !ok {
 			return
@@ -565,7 +753,9 @@ func (o *Operator) processNextWorkItem(ctx context.Context) bool {
 	defer o.queue.Done(key)
 
 	o.metrics.ReconcileCounter().Inc()
+	startTime := time.Now()
 	err := o.sync(ctx, key.(string))
+	o.metrics.ReconcileDurationHistogram().Observe(time.Since(startTime).Seconds())
 	o.metrics.SetSyncStatus(key.(string), err == nil)
 	if err == nil {
 		o.queue.Forget(key)
@@ -630,7 +820,17 @@ func (o *Operator) sync(ctx context.Context, key string) error {
 		return err
 	}
 
+	config, trSelector := o.currentConfig()
+
 	tr := trobj.(*monitoringv1.ThanosRuler)
+	logger := log.With(o.logger, "key", key)
+
+	if !trSelector.Matches(labels.Set(tr.Labels)) {
+		level.Debug(logger).Log("msg", "ThanosRuler not matching the instance selector, skipping")
+		o.metrics.ForgetObject(key)
+		return nil
+	}
+
 	tr = tr.DeepCopy()
 	tr.APIVersion = monitoringv1.SchemeGroupVersion.String()
 	tr.Kind = monitoringv1.ThanosRulerKind
@@ -639,17 +839,26 @@ func (o *Operator) sync(ctx context.Context, key string) error {
 		return nil
 	}
 
-	logger := log.With(o.logger, "key", key)
 	level.Info(logger).Log("msg", "sync thanos-ruler")
 
+	alertmanagersURL, err := o.selectAlertmanagers(tr)
+	if err != nil {
+		return errors.Wrap(err, "selecting Alertmanagers failed")
+	}
+	tr.Spec.AlertManagersURL = append(tr.Spec.AlertManagersURL, alertmanagersURL...)
+
 	ruleConfigMapNames, err := o.createOrUpdateRuleConfigMaps(ctx, tr)
 	if err != nil {
 		return err
 	}
 
+	if err := o.createOrUpdateWebConfigSecret(ctx, tr, config); err != nil {
+		return errors.Wrap(err, "synchronizing web config secret failed")
+	}
+
 	// Create governing service if it doesn't exist.
 	svcClient := o.kclient.CoreV1().Services(tr.Namespace)
-	if err = k8sutil.CreateOrUpdateService(ctx, svcClient, makeStatefulSetService(tr, o.config)); err != nil {
+	if err = k8sutil.CreateOrUpdateService(ctx, svcClient, makeStatefulSetService(tr, config)); err != nil {
 		return errors.Wrap(err, "synchronizing governing service failed")
 	}
 
@@ -664,7 +873,7 @@ func (o *Operator) sync(ctx context.Context, key string) error {
 	exists := !apierrors.IsNotFound(err)
 
 	if !exists {
-		sset, err := makeStatefulSet(tr, o.config, ruleConfigMapNames, "")
+		sset, err := makeStatefulSet(tr, config, ruleConfigMapNames, "")
 		if err != nil {
 			return errors.Wrap(err, "making thanos statefulset config failed")
 		}
@@ -681,12 +890,12 @@ func (o *Operator) sync(ctx context.Context, key string) error {
 		spec = ss.Spec
 	}
 
-	newSSetInputHash, err := createSSetInputHash(*tr, o.config, ruleConfigMapNames, spec)
+	newSSetInputHash, err := createSSetInputHash(*tr, config, ruleConfigMapNames, spec)
 	if err != nil {
 		return err
 	}
 
-	sset, err := makeStatefulSet(tr, o.config, ruleConfigMapNames, newSSetInputHash)
+	sset, err := makeStatefulSet(tr, config, ruleConfigMapNames, newSSetInputHash)
 	if err != nil {
 		return errors.Wrap(err, "making the statefulset, to update, failed")
 	}
@@ -739,6 +948,67 @@ func (o *Operator) listMatchingNamespaces(selector labels.Selector) ([]string, e
 	return ns, nil
 }
 
+// selectAlertmanagers returns the URLs of the pods of the Alertmanager
+// resources selected by the ThanosRuler object.
+func (o *Operator) selectAlertmanagers(tr *monitoringv1.ThanosRuler) ([]string, error) {
+	if tr.Spec.AlertmanagerSelector == nil {
+		return nil, nil
+	}
+
+	if tr.Spec.AlertManagersConfig != nil {
+		return nil, errors.New("alertmanagerSelector and alertmanagersConfig are mutually exclusive")
+	}
+
+	amSelector, err := metav1.LabelSelectorAsSelector(tr.Spec.AlertmanagerSelector)
+	if err != nil {
+		return nil, errors.Wrap(err, "convert alertmanager label selector to selector")
+	}
+
+	// If 'AlertmanagerNamespaceSelector' is nil, only check own namespace.
+	namespaces := []string{tr.Namespace}
+	if tr.Spec.AlertmanagerNamespaceSelector != nil {
+		amNSSelector, err := metav1.LabelSelectorAsSelector(tr.Spec.AlertmanagerNamespaceSelector)
+		if err != nil {
+			return nil, errors.Wrap(err, "convert alertmanager namespace label selector to selector")
+		}
+
+		namespaces, err = o.listMatchingNamespaces(amNSSelector)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	endpoints, err := alertmanager.SelectEndpoints(o.logger, o.amInfs, namespaces, amSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	var urls []string
+	for _, e := range endpoints {
+		// ThanosRuler has no TLS configuration for the selected
+		// Alertmanagers and couldn't verify their certificates.
+		if e.Scheme == "https" {
+			level.Warn(o.logger).Log(
+				"msg", "skipping alertmanager",
+				"err", "the Alertmanager API is served over HTTPS which isn't supported by ThanosRuler for selected Alertmanagers",
+				"alertmanager", e.Name,
+				"namespace", e.Namespace,
+			)
+			continue
+		}
+		urls = append(urls, e.URLs()...)
+	}
+
+	level.Debug(o.logger).Log(
+		"msg", "selected Alertmanagers",
+		"urls", strings.Join(urls, ","),
+		"namespace", tr.Namespace,
+		"thanos", tr.Name,
+	)
+
+	return urls, nil
+}
+
 func createSSetInputHash(tr monitoringv1.ThanosRuler, c Config, ruleConfigMapNames []string, ss interface{}) (string, error) {
 	hash, err := hashstructure.Hash(struct {
 		TR monitoringv1.ThanosRuler
@@ -876,6 +1146,26 @@ func (o *Operator) enqueueForNamespace(store cache.Store, nsName string) {
 			o.enqueue(tr)
 			return
 		}
+
+		// Check for ThanosRuler instances selecting Alertmanagers in the
+		// namespace.
+		if tr.Spec.AlertmanagerSelector != nil {
+			amNSSelector, err := metav1.LabelSelectorAsSelector(tr.Spec.AlertmanagerNamespaceSelector)
+			if err != nil {
+				level.Error(o.logger).Log(
+					"err", errors.Wrap(err, "failed to convert AlertmanagerNamespaceSelector"),
+					"name", tr.Name,
+					"namespace", tr.Namespace,
+					"selector", tr.Spec.AlertmanagerNamespaceSelector,
+				)
+				return
+			}
+
+			if amNSSelector.Matches(labels.Set(ns.Labels)) {
+				o.enqueue(tr)
+				return
+			}
+		}
 	})
 	if err != nil {
 		level.Error(o.logger).Log(
@@ -898,3 +1188,40 @@ func (o *Operator) getObjectMeta(obj interface{}) (metav1.Object, bool) {
 	}
 	return metaObj, true
 }
+
+func (o *Operator) createOrUpdateWebConfigSecret(ctx context.Context, tr *monitoringv1.ThanosRuler, config Config) error {
+	// The web config file is only mounted when configured because older
+	// Thanos versions don't support the --http.config flag.
+	if tr.Spec.Web == nil {
+		return nil
+	}
+
+	webConfig, err := webconfig.New(webConfigDir, webConfigSecretName(tr.Name), tr.Spec.Web.WebConfigFileFields)
+	if err != nil {
+		return err
+	}
+
+	boolTrue := true
+	ownerReference := metav1.OwnerReference{
+		APIVersion:         tr.APIVersion,
+		BlockOwnerDeletion: &boolTrue,
+		Controller:         &boolTrue,
+		Kind:               tr.Kind,
+		Name:               tr.Name,
+		UID:                tr.UID,
+	}
+
+	err = webConfig.CreateOrUpdateSecret(
+		ctx,
+		o.kclient.CoreV1().Secrets(tr.Namespace),
+		assets.NewStore(o.kclient.CoreV1(), o.kclient.CoreV1()),
+		tr.Namespace,
+		config.Labels.Merge(managedByOperatorLabels),
+		ownerReference,
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to create web config for ThanosRuler")
+	}
+
+	return nil
+}
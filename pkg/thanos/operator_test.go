@@ -16,6 +16,16 @@ package thanos
 
 import (
 	"testing"
+
+	"github.com/go-kit/log"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	monitoringfake "github.com/prometheus-operator/prometheus-operator/pkg/client/versioned/fake"
+	"github.com/prometheus-operator/prometheus-operator/pkg/informers"
 )
 
 func TestListOptions(t *testing.T) {
@@ -26,3 +36,97 @@ func TestListOptions(t *testing.T) {
 		}
 	}
 }
+
+func TestSelectAlertmanagers(t *testing.T) {
+	replicas := int32(2)
+	mclient := monitoringfake.NewSimpleClientset(
+		&monitoringv1.Alertmanager{
+			ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "monitoring", Labels: map[string]string{"team": "a"}},
+			Spec:       monitoringv1.AlertmanagerSpec{Replicas: &replicas},
+		},
+		&monitoringv1.Alertmanager{
+			ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "monitoring", Labels: map[string]string{"team": "b"}},
+		},
+		&monitoringv1.Alertmanager{
+			ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "default", Labels: map[string]string{"team": "a"}},
+		},
+		// ThanosRuler can't verify the certificate of the Alertmanager.
+		&monitoringv1.Alertmanager{
+			ObjectMeta: metav1.ObjectMeta{Name: "secure", Namespace: "monitoring", Labels: map[string]string{"team": "a"}},
+			Spec: monitoringv1.AlertmanagerSpec{
+				Web: &monitoringv1.AlertmanagerWebSpec{
+					WebConfigFileFields: monitoringv1.WebConfigFileFields{
+						TLSConfig: &monitoringv1.WebTLSConfig{},
+					},
+				},
+			},
+		},
+	)
+
+	amInfs, err := informers.NewInformersForResource(
+		informers.NewMonitoringInformerFactories(map[string]struct{}{v1.NamespaceAll: {}}, nil, mclient, 0, nil),
+		monitoringv1.SchemeGroupVersion.WithResource(monitoringv1.AlertmanagerName),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	amInfs.Start(stopCh)
+	for _, inf := range amInfs.GetInformers() {
+		if !cache.WaitForCacheSync(stopCh, inf.Informer().HasSynced) {
+			t.Fatal("failed to sync the alertmanager informer")
+		}
+	}
+
+	o := &Operator{logger: log.NewNopLogger(), amInfs: amInfs}
+
+	for _, tc := range []struct {
+		name string
+		spec monitoringv1.ThanosRulerSpec
+		urls []string
+		err  bool
+	}{
+		{
+			name: "no selector",
+		},
+		{
+			name: "selector in own namespace",
+			spec: monitoringv1.ThanosRulerSpec{
+				AlertManagersURL: []string{"http://alertmanager.example.com"},
+				AlertmanagerSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"team": "a"},
+				},
+			},
+			urls: []string{
+				"http://alertmanager-main-0.alertmanager-operated.monitoring.svc:9093/",
+				"http://alertmanager-main-1.alertmanager-operated.monitoring.svc:9093/",
+			},
+		},
+		{
+			name: "selector with alertmanagers config",
+			spec: monitoringv1.ThanosRulerSpec{
+				AlertManagersConfig: &v1.SecretKeySelector{
+					LocalObjectReference: v1.LocalObjectReference{Name: "alertmanagers"},
+					Key:                  "config.yaml",
+				},
+				AlertmanagerSelector: &metav1.LabelSelector{},
+			},
+			err: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			urls, err := o.selectAlertmanagers(&monitoringv1.ThanosRuler{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "monitoring"},
+				Spec:       tc.spec,
+			})
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.urls, urls)
+		})
+	}
+}
@@ -78,6 +78,7 @@ func (o *Operator) createOrUpdateRuleConfigMaps(ctx context.Context, t *monitori
 		for _, cm := range currentConfigMaps {
 			currentConfigMapNames = append(currentConfigMapNames, cm.Name)
 		}
+		o.updateRuleMetrics(t, currentConfigMapNames, newRules)
 		return currentConfigMapNames, nil
 	}
 
@@ -103,6 +104,7 @@ func (o *Operator) createOrUpdateRuleConfigMaps(ctx context.Context, t *monitori
 				return nil, errors.Wrapf(err, "failed to create ConfigMap '%v'", cm.Name)
 			}
 		}
+		o.updateRuleMetrics(t, newConfigMapNames, newRules)
 		return newConfigMapNames, nil
 	}
 
@@ -127,9 +129,18 @@ func (o *Operator) createOrUpdateRuleConfigMaps(ctx context.Context, t *monitori
 		}
 	}
 
+	o.updateRuleMetrics(t, newConfigMapNames, newRules)
 	return newConfigMapNames, nil
 }
 
+// updateRuleMetrics tracks the number of rule ConfigMaps and the total size
+// of the rule files generated for the given ThanosRuler object.
+func (o *Operator) updateRuleMetrics(t *monitoringv1.ThanosRuler, configMapNames []string, ruleFiles map[string]string) {
+	if key, ok := o.keyFunc(t); ok {
+		o.metrics.SetRuleConfigMaps(key, len(configMapNames), bucketSize(ruleFiles))
+	}
+}
+
 func prometheusRulesConfigMapSelector(thanosRulerName string) metav1.ListOptions {
 	return metav1.ListOptions{LabelSelector: fmt.Sprintf("%v=%v", labelThanosRulerName, thanosRulerName)}
 }
@@ -24,6 +24,7 @@ import (
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	"github.com/prometheus-operator/prometheus-operator/pkg/k8sutil"
 	"github.com/prometheus-operator/prometheus-operator/pkg/operator"
+	"github.com/prometheus-operator/prometheus-operator/pkg/webconfig"
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -34,6 +35,7 @@ import (
 const (
 	rulesDir                  = "/etc/thanos/rules"
 	storageDir                = "/thanos/data"
+	webConfigDir              = "/etc/thanos/web_config"
 	governingServiceName      = "thanos-ruler-operated"
 	defaultPortName           = "web"
 	defaultRetention          = "24h"
@@ -138,6 +140,10 @@ func makeStatefulSet(tr *monitoringv1.ThanosRuler, config Config, ruleConfigMapN
 
 	statefulset.Spec.Template.Spec.Volumes = append(statefulset.Spec.Template.Spec.Volumes, tr.Spec.Volumes...)
 
+	if err := operator.ApplyPodTemplateOverride(&statefulset.Spec.Template, tr.Spec.PodTemplateOverride); err != nil {
+		return nil, err
+	}
+
 	return statefulset, nil
 }
 
@@ -301,6 +307,11 @@ func makeStatefulSetSpec(tr *monitoringv1.ThanosRuler, config Config, ruleConfig
 		trCLIArgs = append(trCLIArgs, fmt.Sprintf("--alert.query-url=%s", tr.Spec.AlertQueryURL))
 	}
 
+	var webFields monitoringv1.WebConfigFileFields
+	if tr.Spec.Web != nil {
+		webFields = tr.Spec.Web.WebConfigFileFields
+	}
+
 	var additionalContainers []v1.Container
 	if len(ruleConfigMapNames) != 0 {
 		var (
@@ -317,24 +328,28 @@ func makeStatefulSetSpec(tr *monitoringv1.ThanosRuler, config Config, ruleConfig
 			watchedDirectories = append(watchedDirectories, mountPath)
 		}
 
+		reloaderOpts := []operator.ReloaderOption{
+			operator.ReloaderResources(config.ReloaderConfig),
+			operator.ReloaderURL(url.URL{
+				Scheme: webconfig.Scheme(webFields),
+				Host:   config.LocalHost + ":10902",
+				Path:   path.Clean(tr.Spec.RoutePrefix + "/-/reload"),
+			}),
+			operator.ListenLocal(tr.Spec.ListenLocal),
+			operator.LocalHost(config.LocalHost),
+			operator.LogFormat(tr.Spec.LogFormat),
+			operator.LogLevel(tr.Spec.LogLevel),
+			operator.WatchedDirectories(watchedDirectories),
+			operator.VolumeMounts(configReloaderVolumeMounts),
+			operator.Shard(-1),
+		}
+		if u := webconfig.BasicAuthUser(webFields); u != nil {
+			reloaderOpts = append(reloaderOpts, operator.ReloaderBasicAuth(u.Username, u.Password))
+		}
+
 		additionalContainers = append(
 			additionalContainers,
-			operator.CreateConfigReloader(
-				"config-reloader",
-				operator.ReloaderResources(config.ReloaderConfig),
-				operator.ReloaderURL(url.URL{
-					Scheme: "http",
-					Host:   config.LocalHost + ":10902",
-					Path:   path.Clean(tr.Spec.RoutePrefix + "/-/reload"),
-				}),
-				operator.ListenLocal(tr.Spec.ListenLocal),
-				operator.LocalHost(config.LocalHost),
-				operator.LogFormat(tr.Spec.LogFormat),
-				operator.LogLevel(tr.Spec.LogLevel),
-				operator.WatchedDirectories(watchedDirectories),
-				operator.VolumeMounts(configReloaderVolumeMounts),
-				operator.Shard(-1),
-			),
+			operator.CreateConfigReloader("config-reloader", reloaderOpts...),
 		)
 	}
 
@@ -393,6 +408,19 @@ func makeStatefulSetSpec(tr *monitoringv1.ThanosRuler, config Config, ruleConfig
 		})
 	}
 
+	if tr.Spec.Web != nil {
+		webConfig, err := webconfig.New(webConfigDir, webConfigSecretName(tr.Name), webFields)
+		if err != nil {
+			return nil, err
+		}
+		webConfig.SetFlagName("http.config")
+
+		confArg, configVols, configMounts := webConfig.GetMountParameters()
+		trCLIArgs = append(trCLIArgs, confArg)
+		trVolumes = append(trVolumes, configVols...)
+		trVolumeMounts = append(trVolumeMounts, configMounts...)
+	}
+
 	operatorContainers := append([]v1.Container{
 		{
 			Name:                     "thanos-ruler",
@@ -441,6 +469,14 @@ func makeStatefulSetSpec(tr *monitoringv1.ThanosRuler, config Config, ruleConfig
 				Tolerations:                   tr.Spec.Tolerations,
 				Affinity:                      tr.Spec.Affinity,
 				TopologySpreadConstraints:     tr.Spec.TopologySpreadConstraints,
+				HostAliases:                   tr.Spec.HostAliases,
+				DNSPolicy:                     operator.DNSPolicy(tr.Spec.DNSPolicy, tr.Spec.HostNetwork),
+				DNSConfig:                     tr.Spec.DNSConfig,
+				RuntimeClassName:              tr.Spec.RuntimeClassName,
+				AutomountServiceAccountToken:  tr.Spec.AutomountServiceAccountToken,
+				HostNetwork:                   tr.Spec.HostNetwork,
+				SchedulerName:                 tr.Spec.SchedulerName,
+				ReadinessGates:                tr.Spec.ReadinessGates,
 			},
 		},
 	}, nil
@@ -491,6 +527,10 @@ func makeStatefulSetService(tr *monitoringv1.ThanosRuler, config Config) *v1.Ser
 	return svc
 }
 
+func webConfigSecretName(name string) string {
+	return prefixedName(name) + "-web-config"
+}
+
 func prefixedName(name string) string {
 	return fmt.Sprintf("thanos-ruler-%s", name)
 }
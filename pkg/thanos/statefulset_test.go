@@ -247,6 +247,45 @@ func TestTracing(t *testing.T) {
 	}
 }
 
+func TestWebConfig(t *testing.T) {
+	sset, err := makeStatefulSet(&monitoringv1.ThanosRuler{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+		Spec: monitoringv1.ThanosRulerSpec{
+			QueryEndpoints: emptyQueryEndpoints,
+			Web: &monitoringv1.ThanosRulerWebSpec{
+				WebConfigFileFields: monitoringv1.WebConfigFileFields{
+					TLSConfig: &monitoringv1.WebTLSConfig{
+						KeySecret: v1.SecretKeySelector{
+							LocalObjectReference: v1.LocalObjectReference{Name: "web-tls"},
+							Key:                  "tls.key",
+						},
+						Cert: monitoringv1.SecretOrConfigMap{
+							Secret: &v1.SecretKeySelector{
+								LocalObjectReference: v1.LocalObjectReference{Name: "web-tls"},
+								Key:                  "tls.crt",
+							},
+						},
+					},
+				},
+			},
+		},
+	}, defaultTestConfig, []string{"rules-configmap-one"}, "")
+	require.NoError(t, err)
+
+	require.Contains(t, sset.Spec.Template.Spec.Containers[0].Args, "--http.config=/etc/thanos/web_config/web-config.yaml")
+
+	var found bool
+	for _, vol := range sset.Spec.Template.Spec.Volumes {
+		if vol.Secret != nil && vol.Secret.SecretName == "thanos-ruler-test-web-config" {
+			found = true
+		}
+	}
+	require.True(t, found, "Thanos ruler is missing the web config volume")
+
+	require.Equal(t, "config-reloader", sset.Spec.Template.Spec.Containers[1].Name)
+	require.Contains(t, sset.Spec.Template.Spec.Containers[1].Args, "--reload-url=https://:10902/-/reload")
+}
+
 func TestObjectStorage(t *testing.T) {
 	testKey := "thanos-objstore-config-secret"
 
@@ -0,0 +1,132 @@
+// Copyright 2022 The prometheus-operator Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webconfig
+
+import (
+	"fmt"
+	"strings"
+
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// SchemeAnnotation is the pod annotation advertising the scheme of the
+	// web server when it differs from HTTP.
+	SchemeAnnotation = "operator.prometheus.io/web-scheme"
+
+	basicAuthUsernameEnvVar = "WEB_BASIC_AUTH_USERNAME"
+	basicAuthPasswordEnvVar = "WEB_BASIC_AUTH_PASSWORD"
+)
+
+// BasicAuthUser returns the basic auth user used by the components running
+// next to the web server to access it, nil if the web server doesn't require
+// authentication.
+func BasicAuthUser(fields monitoringv1.WebConfigFileFields) *monitoringv1.WebBasicAuthUser {
+	if len(fields.BasicAuthUsers) == 0 {
+		return nil
+	}
+	return &fields.BasicAuthUsers[0]
+}
+
+// Scheme returns the scheme served by the web server.
+func Scheme(fields monitoringv1.WebConfigFileFields) string {
+	if fields.TLSConfig != nil {
+		return "https"
+	}
+	return "http"
+}
+
+// BasicAuthEnvVars returns the environment variables exposing the
+// credentials of the basic auth user to the probes of the web server
+// container.
+func BasicAuthEnvVars(fields monitoringv1.WebConfigFileFields) []v1.EnvVar {
+	u := BasicAuthUser(fields)
+	if u == nil {
+		return nil
+	}
+
+	password := u.Password
+	return []v1.EnvVar{
+		{
+			Name:  basicAuthUsernameEnvVar,
+			Value: u.Username,
+		},
+		{
+			Name: basicAuthPasswordEnvVar,
+			ValueFrom: &v1.EnvVarSource{
+				SecretKeyRef: &password,
+			},
+		},
+	}
+}
+
+// ProbeHandler returns the handler of a probe requesting the given path of
+// the web server.
+// The kubelet can't authenticate against the web server nor reach it when it
+// listens on the loopback interface so the request is executed from the
+// container in these cases.
+func ProbeHandler(fields monitoringv1.WebConfigFileFields, probePath string, port int, portName string, listenLocal bool) v1.Handler {
+	var handler v1.Handler
+
+	if listenLocal || BasicAuthUser(fields) != nil {
+		u := fmt.Sprintf("%s://localhost:%d%s", Scheme(fields), port, probePath)
+		handler.Exec = &v1.ExecAction{
+			Command: []string{"sh", "-c", localProbeCommand(u, fields.TLSConfig != nil, BasicAuthUser(fields) != nil)},
+		}
+		return handler
+	}
+
+	handler.HTTPGet = &v1.HTTPGetAction{
+		Path: probePath,
+		Port: intstr.FromString(portName),
+	}
+	if fields.TLSConfig != nil {
+		handler.HTTPGet.Scheme = v1.URISchemeHTTPS
+	}
+
+	return handler
+}
+
+// localProbeCommand returns a shell command requesting the given URL with
+// curl or wget, whichever is available in the container image.
+func localProbeCommand(u string, tls, basicAuth bool) string {
+	var curlArgs, wgetArgs []string
+
+	if tls {
+		// The certificate isn't issued for localhost.
+		curlArgs = append(curlArgs, "-k")
+		wgetArgs = append(wgetArgs, "--no-check-certificate")
+	}
+
+	if basicAuth {
+		curlArgs = append(curlArgs, "-f", fmt.Sprintf(`-u "$%s:$%s"`, basicAuthUsernameEnvVar, basicAuthPasswordEnvVar))
+		wgetArgs = append(wgetArgs, fmt.Sprintf(
+			`--header "Authorization: Basic $(printf '%%s:%%s' "$%s" "$%s" | base64 | tr -d '\n')"`,
+			basicAuthUsernameEnvVar,
+			basicAuthPasswordEnvVar,
+		))
+	}
+
+	curlArgs = append(curlArgs, u)
+	wgetArgs = append(wgetArgs, "-q", "-O", "/dev/null", u)
+
+	return fmt.Sprintf(
+		`if [ -x "$(command -v curl)" ]; then exec curl %s; elif [ -x "$(command -v wget)" ]; then exec wget %s; else exit 1; fi`,
+		strings.Join(curlArgs, " "),
+		strings.Join(wgetArgs, " "),
+	)
+}
@@ -15,12 +15,20 @@
 package webconfig
 
 import (
+	"context"
 	"fmt"
+	"path"
+
+	"github.com/pkg/errors"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/prometheus-operator/prometheus-operator/pkg/assets"
+	"github.com/prometheus-operator/prometheus-operator/pkg/k8sutil"
+	"golang.org/x/crypto/bcrypt"
 	"gopkg.in/yaml.v2"
 	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"path"
+	clientv1 "k8s.io/client-go/kubernetes/typed/core/v1"
 )
 
 var (
@@ -35,17 +43,25 @@ var (
 // necessary TLS credentials.
 type Config struct {
 	tlsConfig      *monitoringv1.WebTLSConfig
+	httpConfig     *monitoringv1.WebHTTPConfig
+	basicAuthUsers []monitoringv1.WebBasicAuthUser
 	tlsCredentials *tlsCredentials
 	mountingDir    string
 	secretName     string
+	flagName       string
+
+	// hashedPasswords maps the basic auth usernames to the bcrypt hashes
+	// of their passwords.
+	hashedPasswords map[string]string
 }
 
 // New creates a new Config.
-func New(mountingDir string, secretName string, tlsConfig *monitoringv1.WebTLSConfig) (*Config, error) {
-	if err := tlsConfig.Validate(); err != nil {
+func New(mountingDir string, secretName string, configFileFields monitoringv1.WebConfigFileFields) (*Config, error) {
+	if err := configFileFields.Validate(); err != nil {
 		return nil, err
 	}
 
+	tlsConfig := configFileFields.TLSConfig
 	var tlsCreds *tlsCredentials
 	if tlsConfig != nil {
 		tlsCreds = newTLSCredentials(mountingDir, tlsConfig.KeySecret, tlsConfig.Cert, tlsConfig.ClientCA)
@@ -53,15 +69,64 @@ func New(mountingDir string, secretName string, tlsConfig *monitoringv1.WebTLSCo
 
 	return &Config{
 		tlsConfig:      tlsConfig,
+		httpConfig:     configFileFields.HTTPConfig,
+		basicAuthUsers: configFileFields.BasicAuthUsers,
 		tlsCredentials: tlsCreds,
 		mountingDir:    mountingDir,
 		secretName:     secretName,
+		flagName:       "web.config.file",
 	}, nil
 }
 
+// SetFlagName overrides the name of the command line option pointing to the
+// config file which defaults to web.config.file.
+func (c *Config) SetFlagName(name string) {
+	c.flagName = name
+}
+
+// SetBasicAuthPasswords hashes the clear-text passwords of the basic auth
+// users, indexed by username. The hashes found in the current web config
+// secret are reused when they still match the password so that the secret
+// only changes when a password is updated.
+func (c *Config) SetBasicAuthPasswords(passwords map[string]string, current *v1.Secret) error {
+	var currentCfg struct {
+		BasicAuthUsers map[string]string `yaml:"basic_auth_users"`
+	}
+	if current != nil {
+		// An invalid configuration only means that all passwords are hashed
+		// again.
+		if err := yaml.Unmarshal(current.Data[configFile], &currentCfg); err != nil {
+			currentCfg.BasicAuthUsers = nil
+		}
+	}
+
+	c.hashedPasswords = make(map[string]string, len(c.basicAuthUsers))
+	for _, u := range c.basicAuthUsers {
+		password, ok := passwords[u.Username]
+		if !ok {
+			return errors.Errorf("missing password for basic auth user %q", u.Username)
+		}
+
+		if hash, ok := currentCfg.BasicAuthUsers[u.Username]; ok {
+			if bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil {
+				c.hashedPasswords[u.Username] = hash
+				continue
+			}
+		}
+
+		hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+		if err != nil {
+			return errors.Wrapf(err, "failed to hash the password of basic auth user %q", u.Username)
+		}
+		c.hashedPasswords[u.Username] = string(hash)
+	}
+
+	return nil
+}
+
 // GetMountParameters returns volumes and volume mounts referencing the config file
 // and the associated TLS credentials.
-// In addition, GetMountParameters returns a web.config.file command line option pointing
+// In addition, GetMountParameters returns a command line option pointing
 // to the file in the volume mount.
 func (c Config) GetMountParameters() (string, []v1.Volume, []v1.VolumeMount) {
 	destinationPath := path.Join(c.mountingDir, configFile)
@@ -85,6 +150,40 @@ func (c Config) GetMountParameters() (string, []v1.Volume, []v1.VolumeMount) {
 	return arg, volumes, mounts
 }
 
+// CreateOrUpdateSecret creates or updates the secret holding the web config
+// file. The passwords of the basic auth users are read from the store.
+func (c *Config) CreateOrUpdateSecret(ctx context.Context, client clientv1.SecretInterface, store *assets.Store, namespace string, labels map[string]string, ownerReference metav1.OwnerReference) error {
+	if len(c.basicAuthUsers) > 0 {
+		passwords := make(map[string]string, len(c.basicAuthUsers))
+		for _, u := range c.basicAuthUsers {
+			password, err := store.GetSecretKey(ctx, namespace, u.Password)
+			if err != nil {
+				return errors.Wrapf(err, "failed to get the password of basic auth user %q", u.Username)
+			}
+			passwords[u.Username] = password
+		}
+
+		current, err := client.Get(ctx, c.secretName, metav1.GetOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrap(err, "failed to get the current web config secret")
+		}
+		if apierrors.IsNotFound(err) {
+			current = nil
+		}
+
+		if err := c.SetBasicAuthPasswords(passwords, current); err != nil {
+			return err
+		}
+	}
+
+	secret, err := c.MakeConfigFileSecret(labels, ownerReference)
+	if err != nil {
+		return err
+	}
+
+	return k8sutil.CreateOrUpdateSecret(ctx, client, secret)
+}
+
 // MakeConfigFileSecret returns a Kubernetes secret with the data for the web config file.
 // The format of the web config file is available in the official prometheus documentation:
 // https://prometheus.io/docs/prometheus/latest/configuration/https/#https-and-authentication
@@ -107,11 +206,38 @@ func (c *Config) MakeConfigFileSecret(labels map[string]string, ownerReference m
 }
 
 func (c Config) generateConfigFileContents() ([]byte, error) {
-	tls := c.tlsConfig
-	if tls == nil {
+	cfg := yaml.MapSlice{}
+
+	if c.tlsConfig != nil {
+		cfg = append(cfg, yaml.MapItem{Key: "tls_server_config", Value: c.generateTLSServerConfig()})
+	}
+
+	if c.httpConfig != nil {
+		cfg = append(cfg, yaml.MapItem{Key: "http_server_config", Value: c.generateHTTPServerConfig()})
+	}
+
+	if len(c.basicAuthUsers) > 0 {
+		users := yaml.MapSlice{}
+		for _, u := range c.basicAuthUsers {
+			hash, ok := c.hashedPasswords[u.Username]
+			if !ok {
+				return nil, errors.Errorf("missing password hash for basic auth user %q", u.Username)
+			}
+			users = append(users, yaml.MapItem{Key: u.Username, Value: hash})
+		}
+		cfg = append(cfg, yaml.MapItem{Key: "basic_auth_users", Value: users})
+	}
+
+	if len(cfg) == 0 {
 		return []byte{}, nil
 	}
 
+	return yaml.Marshal(cfg)
+}
+
+func (c Config) generateTLSServerConfig() yaml.MapSlice {
+	tls := c.tlsConfig
+
 	tlsServerConfig := yaml.MapSlice{}
 	if certPath := c.tlsCredentials.getCertMountPath(); certPath != "" {
 		tlsServerConfig = append(tlsServerConfig, yaml.MapItem{Key: "cert_file", Value: certPath})
@@ -167,18 +293,46 @@ func (c Config) generateConfigFileContents() ([]byte, error) {
 		})
 	}
 
-	cfg := yaml.MapSlice{
-		{
-			Key:   "tls_server_config",
-			Value: tlsServerConfig,
-		},
+	return tlsServerConfig
+}
+
+func (c Config) generateHTTPServerConfig() yaml.MapSlice {
+	httpServerConfig := yaml.MapSlice{}
+
+	if c.httpConfig.HTTP2 != nil {
+		httpServerConfig = append(httpServerConfig, yaml.MapItem{
+			Key:   "http2",
+			Value: *c.httpConfig.HTTP2,
+		})
 	}
 
-	return yaml.Marshal(cfg)
+	if h := c.httpConfig.Headers; h != nil {
+		headers := yaml.MapSlice{}
+		for _, header := range []struct {
+			name  string
+			value string
+		}{
+			{"Content-Security-Policy", h.ContentSecurityPolicy},
+			{"X-Frame-Options", h.XFrameOptions},
+			{"X-Content-Type-Options", h.XContentTypeOptions},
+			{"X-XSS-Protection", h.XXSSProtection},
+			{"Strict-Transport-Security", h.StrictTransportSecurity},
+		} {
+			if header.value != "" {
+				headers = append(headers, yaml.MapItem{Key: header.name, Value: header.value})
+			}
+		}
+
+		if len(headers) > 0 {
+			httpServerConfig = append(httpServerConfig, yaml.MapItem{Key: "headers", Value: headers})
+		}
+	}
+
+	return httpServerConfig
 }
 
 func (c Config) makeArg(filePath string) string {
-	return fmt.Sprintf("--web.config.file=%s", filePath)
+	return fmt.Sprintf("--%s=%s", c.flagName, filePath)
 }
 
 func (c Config) makeVolume() v1.Volume {
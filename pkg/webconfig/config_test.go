@@ -15,12 +15,16 @@
 package webconfig_test
 
 import (
+	"reflect"
+	"testing"
+
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	"github.com/prometheus-operator/prometheus-operator/pkg/webconfig"
+	"golang.org/x/crypto/bcrypt"
+	"gopkg.in/yaml.v2"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"reflect"
-	"testing"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 var falseVal = false
@@ -165,7 +169,7 @@ func TestMakeSecret(t *testing.T) {
 	}
 
 	for _, tt := range tc {
-		config, err := webconfig.New("/web_certs_path_prefix", "test-secret", tt.webTLSConfig)
+		config, err := webconfig.New("/web_certs_path_prefix", "test-secret", monitoringv1.WebConfigFileFields{TLSConfig: tt.webTLSConfig})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -307,7 +311,7 @@ func TestGetMountParameters(t *testing.T) {
 	}
 
 	for _, tt := range ts {
-		tlsAssets, err := webconfig.New("/etc/prometheus/web_config", "web-config", tt.tlsConfig)
+		tlsAssets, err := webconfig.New("/etc/prometheus/web_config", "web-config", monitoringv1.WebConfigFileFields{TLSConfig: tt.tlsConfig})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -323,3 +327,210 @@ func TestGetMountParameters(t *testing.T) {
 		}
 	}
 }
+
+func TestMakeSecretHTTPConfig(t *testing.T) {
+	trueVal := true
+	config, err := webconfig.New("/web_certs_path_prefix", "test-secret", monitoringv1.WebConfigFileFields{
+		HTTPConfig: &monitoringv1.WebHTTPConfig{
+			HTTP2: &trueVal,
+			Headers: &monitoringv1.WebHTTPHeaders{
+				ContentSecurityPolicy: "default-src 'self'",
+				XFrameOptions:         "Deny",
+				XContentTypeOptions:   "NoSniff",
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	secret, err := config.MakeConfigFileSecret(nil, metav1.OwnerReference{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `http_server_config:
+  http2: true
+  headers:
+    Content-Security-Policy: default-src 'self'
+    X-Frame-Options: Deny
+    X-Content-Type-Options: NoSniff
+`
+	if expected != string(secret.Data["web-config.yaml"]) {
+		t.Fatalf("Got %s\nwant %s\n", secret.Data["web-config.yaml"], expected)
+	}
+}
+
+func TestSetBasicAuthPasswords(t *testing.T) {
+	fields := monitoringv1.WebConfigFileFields{
+		BasicAuthUsers: []monitoringv1.WebBasicAuthUser{
+			{
+				Username: "alice",
+				Password: v1.SecretKeySelector{
+					LocalObjectReference: v1.LocalObjectReference{Name: "web-users"},
+					Key:                  "alice",
+				},
+			},
+		},
+	}
+
+	config, err := webconfig.New("/etc/prometheus/web_config", "web-config", fields)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := config.SetBasicAuthPasswords(map[string]string{}, nil); err == nil {
+		t.Fatal("expected error for missing password")
+	}
+
+	if err := config.SetBasicAuthPasswords(map[string]string{"alice": "secret"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	secret, err := config.MakeConfigFileSecret(nil, metav1.OwnerReference{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var cfg struct {
+		BasicAuthUsers map[string]string `yaml:"basic_auth_users"`
+	}
+	if err := yaml.Unmarshal(secret.Data["web-config.yaml"], &cfg); err != nil {
+		t.Fatal(err)
+	}
+	hash := cfg.BasicAuthUsers["alice"]
+	if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte("secret")); err != nil {
+		t.Fatalf("invalid password hash %q: %v", hash, err)
+	}
+
+	// The hash from the current secret is kept as long as the password
+	// doesn't change.
+	config, err = webconfig.New("/etc/prometheus/web_config", "web-config", fields)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := config.SetBasicAuthPasswords(map[string]string{"alice": "secret"}, secret); err != nil {
+		t.Fatal(err)
+	}
+	updated, err := config.MakeConfigFileSecret(nil, metav1.OwnerReference{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(secret.Data, updated.Data) {
+		t.Fatalf("expected the password hash to be reused,\ngot  %s\nwant %s", updated.Data["web-config.yaml"], secret.Data["web-config.yaml"])
+	}
+
+	if err := config.SetBasicAuthPasswords(map[string]string{"alice": "changed"}, secret); err != nil {
+		t.Fatal(err)
+	}
+	updated, err = config.MakeConfigFileSecret(nil, metav1.OwnerReference{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reflect.DeepEqual(secret.Data, updated.Data) {
+		t.Fatal("expected the password hash to change")
+	}
+}
+
+func TestNewInvalidBasicAuthUsers(t *testing.T) {
+	password := v1.SecretKeySelector{
+		LocalObjectReference: v1.LocalObjectReference{Name: "web-users"},
+		Key:                  "password",
+	}
+
+	for _, users := range [][]monitoringv1.WebBasicAuthUser{
+		{{Username: "", Password: password}},
+		{{Username: "alice"}},
+		{{Username: "alice", Password: password}, {Username: "alice", Password: password}},
+	} {
+		if _, err := webconfig.New("/etc/prometheus/web_config", "web-config", monitoringv1.WebConfigFileFields{BasicAuthUsers: users}); err == nil {
+			t.Errorf("expected error for %v", users)
+		}
+	}
+}
+
+func TestProbeHandler(t *testing.T) {
+	tlsConfig := &monitoringv1.WebTLSConfig{
+		KeySecret: v1.SecretKeySelector{
+			LocalObjectReference: v1.LocalObjectReference{Name: "web-tls"},
+			Key:                  "tls.key",
+		},
+	}
+	users := []monitoringv1.WebBasicAuthUser{
+		{
+			Username: "admin",
+			Password: v1.SecretKeySelector{
+				LocalObjectReference: v1.LocalObjectReference{Name: "web-users"},
+				Key:                  "admin",
+			},
+		},
+	}
+
+	for _, tc := range []struct {
+		name        string
+		fields      monitoringv1.WebConfigFileFields
+		listenLocal bool
+		expected    v1.Handler
+	}{
+		{
+			name: "http",
+			expected: v1.Handler{
+				HTTPGet: &v1.HTTPGetAction{Path: "/-/ready", Port: intstr.FromString("web")},
+			},
+		},
+		{
+			name:   "https",
+			fields: monitoringv1.WebConfigFileFields{TLSConfig: tlsConfig},
+			expected: v1.Handler{
+				HTTPGet: &v1.HTTPGetAction{Path: "/-/ready", Port: intstr.FromString("web"), Scheme: v1.URISchemeHTTPS},
+			},
+		},
+		{
+			name:        "listen local",
+			listenLocal: true,
+			expected: v1.Handler{
+				Exec: &v1.ExecAction{
+					Command: []string{
+						"sh",
+						"-c",
+						`if [ -x "$(command -v curl)" ]; then exec curl http://localhost:9090/-/ready; elif [ -x "$(command -v wget)" ]; then exec wget -q -O /dev/null http://localhost:9090/-/ready; else exit 1; fi`,
+					},
+				},
+			},
+		},
+		{
+			name:   "basic auth with https",
+			fields: monitoringv1.WebConfigFileFields{TLSConfig: tlsConfig, BasicAuthUsers: users},
+			expected: v1.Handler{
+				Exec: &v1.ExecAction{
+					Command: []string{
+						"sh",
+						"-c",
+						`if [ -x "$(command -v curl)" ]; then exec curl -k -f -u "$WEB_BASIC_AUTH_USERNAME:$WEB_BASIC_AUTH_PASSWORD" https://localhost:9090/-/ready; ` +
+							`elif [ -x "$(command -v wget)" ]; then exec wget --no-check-certificate --header "Authorization: Basic $(printf '%s:%s' "$WEB_BASIC_AUTH_USERNAME" "$WEB_BASIC_AUTH_PASSWORD" | base64 | tr -d '\n')" -q -O /dev/null https://localhost:9090/-/ready; ` +
+							`else exit 1; fi`,
+					},
+				},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := webconfig.ProbeHandler(tc.fields, "/-/ready", 9090, "web", tc.listenLocal)
+			if !reflect.DeepEqual(handler, tc.expected) {
+				t.Fatalf("invalid probe handler,\ngot  %+v,\nwant %+v", handler, tc.expected)
+			}
+		})
+	}
+}
+
+func TestSetFlagName(t *testing.T) {
+	config, err := webconfig.New("/etc/thanos/web_config", "web-config", monitoringv1.WebConfigFileFields{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.SetFlagName("http.config")
+
+	arg, _, _ := config.GetMountParameters()
+	if arg != "--http.config=/etc/thanos/web_config/web-config.yaml" {
+		t.Fatalf("unexpected argument %q", arg)
+	}
+}
@@ -74,6 +74,9 @@ var (
 			ControllerPath:    "./pkg/apis/monitoring/v1alpha1",
 			CRDNames: []crdName{
 				{"alertmanagerconfig", "alertmanagerconfigs"},
+				{"prometheusagent", "prometheusagents"},
+				{"scrapeconfig", "scrapeconfigs"},
+				{"prober", "probers"},
 			},
 		},
 	}